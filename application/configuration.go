@@ -3,8 +3,10 @@ package application
 import "github.com/cloudfoundry/bosh-bootloader/storage"
 
 type GlobalConfiguration struct {
-	StateDir string
-	Debug    bool
+	StateDir     string
+	StateBackend storage.Backend
+	StateHistory storage.HistoryBackend
+	Debug        bool
 }
 
 type StringSlice []string
@@ -2,22 +2,27 @@ package application
 
 import (
 	"fmt"
-	"os"
-	"path/filepath"
+
+	"github.com/cloudfoundry/bosh-bootloader/storage"
 )
 
+type stateBackend interface {
+	Location() string
+	ReadState() ([]byte, error)
+}
+
 type StateValidator struct {
-	stateDir string
+	backend stateBackend
 }
 
-func NewStateValidator(stateDir string) StateValidator {
-	return StateValidator{stateDir: stateDir}
+func NewStateValidator(backend stateBackend) StateValidator {
+	return StateValidator{backend: backend}
 }
 
 func (s StateValidator) Validate() error {
-	_, err := os.Stat(filepath.Join(s.stateDir, "bbl-state.json"))
-	if os.IsNotExist(err) {
-		return fmt.Errorf("bbl-state.json not found in %q, ensure you're running this command in the proper state directory or create a new environment with bbl up", s.stateDir)
+	_, err := s.backend.ReadState()
+	if err == storage.StateNotFound {
+		return fmt.Errorf("bbl-state.json not found in %q, ensure you're running this command in the proper state directory or create a new environment with bbl up", s.backend.Location())
 	}
 	if err != nil {
 		return err
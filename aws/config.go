@@ -3,19 +3,47 @@ package aws
 import (
 	goaws "github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/session"
 )
 
+const roleSessionName = "bbl"
+
 type Config struct {
 	AccessKeyID     string
 	SecretAccessKey string
+	SessionToken    string
 	Region          string
 }
 
 func (c Config) ClientConfig() *goaws.Config {
 	awsConfig := &goaws.Config{
-		Credentials: credentials.NewStaticCredentials(c.AccessKeyID, c.SecretAccessKey, ""),
+		Credentials: credentials.NewStaticCredentials(c.AccessKeyID, c.SecretAccessKey, c.SessionToken),
 		Region:      goaws.String(c.Region),
 	}
 
 	return awsConfig
 }
+
+// AssumeRole exchanges the credentials in c for temporary credentials of the
+// role with the given ARN.
+func AssumeRole(c Config, roleARN string) (Config, error) {
+	sess, err := session.NewSession(c.ClientConfig())
+	if err != nil {
+		return Config{}, err //not tested
+	}
+
+	value, err := stscreds.NewCredentials(sess, roleARN, func(p *stscreds.AssumeRoleProvider) {
+		p.RoleSessionName = roleSessionName
+	}).Get()
+	if err != nil {
+		return Config{}, err
+	}
+
+	return Config{
+		AccessKeyID:     value.AccessKeyID,
+		SecretAccessKey: value.SecretAccessKey,
+		SessionToken:    value.SessionToken,
+		Region:          c.Region,
+	}, nil
+}
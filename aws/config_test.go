@@ -25,5 +25,18 @@ var _ = Describe("Config", func() {
 
 			Expect(config.ClientConfig()).To(Equal(awsConfig))
 		})
+
+		It("includes the session token of temporary credentials", func() {
+			config := aws.Config{
+				AccessKeyID:     "some-access-key-id",
+				SecretAccessKey: "some-secret-access-key",
+				SessionToken:    "some-session-token",
+				Region:          "some-region",
+			}
+
+			value, err := config.ClientConfig().Credentials.Get()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(value.SessionToken).To(Equal("some-session-token"))
+		})
 	})
 })
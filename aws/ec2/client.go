@@ -16,6 +16,7 @@ type EC2Client interface {
 	DescribeAvailabilityZones(*awsec2.DescribeAvailabilityZonesInput) (*awsec2.DescribeAvailabilityZonesOutput, error)
 	DescribeInstances(*awsec2.DescribeInstancesInput) (*awsec2.DescribeInstancesOutput, error)
 	DescribeVpcs(*awsec2.DescribeVpcsInput) (*awsec2.DescribeVpcsOutput, error)
+	DescribeSubnets(*awsec2.DescribeSubnetsInput) (*awsec2.DescribeSubnetsOutput, error)
 }
 
 type logger interface {
@@ -86,28 +87,80 @@ func (c Client) CheckExists(networkName string) (bool, error) {
 	return false, nil
 }
 
+// CheckSubnetExists reports whether vpcID holds a subnet tagged with
+// subnetName, as bbl tags the subnets it creates in an existing VPC.
+func (c Client) CheckSubnetExists(vpcID, subnetName string) (bool, error) {
+	subnets, err := c.ec2Client.DescribeSubnets(&awsec2.DescribeSubnetsInput{
+		Filters: []*awsec2.Filter{
+			{
+				Name:   awslib.String("vpc-id"),
+				Values: []*string{awslib.String(vpcID)},
+			},
+			{
+				Name:   awslib.String("tag:Name"),
+				Values: []*string{awslib.String(subnetName)},
+			},
+		},
+	})
+	if err != nil {
+		return false, fmt.Errorf("Failed to check subnet existence: %s", err)
+	}
+
+	return len(subnets.Subnets) > 0, nil
+}
+
 func (c Client) ValidateSafeToDelete(vpcID, envID string) error {
-	output, err := c.ec2Client.DescribeInstances(&awsec2.DescribeInstancesInput{
-		Filters: []*awsec2.Filter{{
-			Name:   awslib.String("vpc-id"),
-			Values: []*string{awslib.String(vpcID)},
-		}},
+	vms, err := c.remainingVMs(envID, &awsec2.Filter{
+		Name:   awslib.String("vpc-id"),
+		Values: []*string{awslib.String(vpcID)},
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(vms) > 0 {
+		return fmt.Errorf("vpc %s is not safe to delete; vms still exist: [%s]", vpcID, strings.Join(vms, ", "))
+	}
+
+	return nil
+}
+
+// ValidateSubnetsSafeToDelete only looks for VMs in subnetIDs, for an
+// environment deployed into a VPC that bbl does not own.
+func (c Client) ValidateSubnetsSafeToDelete(vpcID string, subnetIDs []string, envID string) error {
+	vms, err := c.remainingVMs(envID, &awsec2.Filter{
+		Name:   awslib.String("vpc-id"),
+		Values: []*string{awslib.String(vpcID)},
+	}, &awsec2.Filter{
+		Name:   awslib.String("subnet-id"),
+		Values: awslib.StringSlice(subnetIDs),
 	})
 	if err != nil {
 		return err
 	}
 
+	if len(vms) > 0 {
+		return fmt.Errorf("subnets %s of vpc %s are not safe to delete; vms still exist: [%s]", strings.Join(subnetIDs, ", "), vpcID, strings.Join(vms, ", "))
+	}
+
+	return nil
+}
+
+func (c Client) remainingVMs(envID string, filters ...*awsec2.Filter) ([]string, error) {
+	output, err := c.ec2Client.DescribeInstances(&awsec2.DescribeInstancesInput{
+		Filters: filters,
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	vms := c.flattenVMs(output.Reservations)
 	vms = c.removeOneVM(vms, fmt.Sprintf("%s-nat", envID))
 	vms = c.removeOneVM(vms, "NAT")
 	vms = c.removeOneVM(vms, "bosh/0")
 	vms = c.removeOneVM(vms, "jumpbox/0")
 
-	if len(vms) > 0 {
-		return fmt.Errorf("vpc %s is not safe to delete; vms still exist: [%s]", vpcID, strings.Join(vms, ", "))
-	}
-
-	return nil
+	return vms, nil
 }
 
 func (c Client) flattenVMs(reservations []*awsec2.Reservation) []string {
@@ -269,6 +269,103 @@ var _ = Describe("Client", func() {
 			})
 		})
 	})
+
+	Describe("ValidateSubnetsSafeToDelete", func() {
+		var (
+			client    ec2.Client
+			ec2Client *fakes.AWSEC2Client
+		)
+
+		BeforeEach(func() {
+			ec2Client = &fakes.AWSEC2Client{}
+			client = ec2.NewClientWithInjectedEC2Client(ec2Client, &fakes.Logger{})
+		})
+
+		It("only looks for VMs in the given subnets", func() {
+			ec2Client.DescribeInstancesCall.Returns.Output = &awsec2.DescribeInstancesOutput{
+				Reservations: []*awsec2.Reservation{
+					reservationContainingInstance("example-env-id-nat"),
+					reservationContainingInstance("bosh/0"),
+				},
+			}
+
+			err := client.ValidateSubnetsSafeToDelete("some-vpc-id", []string{"subnet-1", "subnet-2"}, "example-env-id")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(ec2Client.DescribeInstancesCall.Receives.Input).To(Equal(&awsec2.DescribeInstancesInput{
+				Filters: []*awsec2.Filter{{
+					Name:   awslib.String("vpc-id"),
+					Values: []*string{awslib.String("some-vpc-id")},
+				}, {
+					Name:   awslib.String("subnet-id"),
+					Values: []*string{awslib.String("subnet-1"), awslib.String("subnet-2")},
+				}},
+			}))
+		})
+
+		Context("when there are bosh-deployed VMs in the subnets", func() {
+			It("returns an error", func() {
+				ec2Client.DescribeInstancesCall.Returns.Output = &awsec2.DescribeInstancesOutput{
+					Reservations: []*awsec2.Reservation{
+						reservationContainingInstance("bosh/0"),
+						reservationContainingInstance("some-bosh-deployed-vm"),
+					},
+				}
+
+				err := client.ValidateSubnetsSafeToDelete("some-vpc-id", []string{"subnet-1", "subnet-2"}, "")
+				Expect(err).To(MatchError("subnets subnet-1, subnet-2 of vpc some-vpc-id are not safe to delete; vms still exist: [some-bosh-deployed-vm]"))
+			})
+		})
+	})
+
+	Describe("CheckSubnetExists", func() {
+		var (
+			client    ec2.Client
+			ec2Client *fakes.AWSEC2Client
+		)
+
+		BeforeEach(func() {
+			ec2Client = &fakes.AWSEC2Client{}
+			client = ec2.NewClientWithInjectedEC2Client(ec2Client, &fakes.Logger{})
+		})
+
+		It("looks for a subnet with the name in the vpc", func() {
+			ec2Client.DescribeSubnetsCall.Returns.Output = &awsec2.DescribeSubnetsOutput{
+				Subnets: []*awsec2.Subnet{{}},
+			}
+
+			exists, err := client.CheckSubnetExists("some-vpc-id", "some-env-bosh-subnet")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(exists).To(BeTrue())
+
+			Expect(ec2Client.DescribeSubnetsCall.Receives.Input).To(Equal(&awsec2.DescribeSubnetsInput{
+				Filters: []*awsec2.Filter{{
+					Name:   awslib.String("vpc-id"),
+					Values: []*string{awslib.String("some-vpc-id")},
+				}, {
+					Name:   awslib.String("tag:Name"),
+					Values: []*string{awslib.String("some-env-bosh-subnet")},
+				}},
+			}))
+		})
+
+		It("returns false when there is no such subnet", func() {
+			ec2Client.DescribeSubnetsCall.Returns.Output = &awsec2.DescribeSubnetsOutput{}
+
+			exists, err := client.CheckSubnetExists("some-vpc-id", "some-env-bosh-subnet")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(exists).To(BeFalse())
+		})
+
+		Context("when the describe subnets call fails", func() {
+			It("returns an error", func() {
+				ec2Client.DescribeSubnetsCall.Returns.Error = errors.New("failed to describe subnets")
+
+				_, err := client.CheckSubnetExists("some-vpc-id", "some-env-bosh-subnet")
+				Expect(err).To(MatchError("Failed to check subnet existence: failed to describe subnets"))
+			})
+		})
+	})
 })
 
 func reservationContainingInstance(tag string) *awsec2.Reservation {
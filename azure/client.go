@@ -0,0 +1,176 @@
+package azure
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/arm/compute"
+	"github.com/Azure/azure-sdk-for-go/arm/resources/resources"
+	"github.com/Azure/azure-sdk-for-go/arm/storage"
+	"github.com/Azure/go-autorest/autorest"
+)
+
+type Client struct {
+	accountsClient        storage.AccountsClient
+	virtualMachinesClient VirtualMachinesClient
+	groupsClient          GroupsClient
+	resourcesClient       ResourcesClient
+}
+
+type VirtualMachinesClient interface {
+	List(resourceGroupName string) (compute.VirtualMachineListResult, error)
+	ListNextResults(lastResults compute.VirtualMachineListResult) (compute.VirtualMachineListResult, error)
+}
+
+type GroupsClient interface {
+	CheckExistence(resourceGroupName string) (autorest.Response, error)
+}
+
+type ResourcesClient interface {
+	CheckExistence(resourceGroupName, resourceProviderNamespace, parentResourcePath, resourceType, resourceName string) (autorest.Response, error)
+	Get(resourceGroupName, resourceProviderNamespace, parentResourcePath, resourceType, resourceName string) (resources.GenericResource, error)
+}
+
+// listVirtualMachines returns the VMs in resourceGroupName. A resource group
+// that no longer exists holds no VMs, so it is not an error.
+func (c Client) listVirtualMachines(resourceGroupName string) ([]compute.VirtualMachine, error) {
+	result, err := c.virtualMachinesClient.List(resourceGroupName)
+	if err != nil {
+		if result.Response.Response != nil && result.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var vms []compute.VirtualMachine
+	for {
+		if result.Value != nil {
+			vms = append(vms, *result.Value...)
+		}
+
+		if result.NextLink == nil || *result.NextLink == "" {
+			return vms, nil
+		}
+
+		result, err = c.virtualMachinesClient.ListNextResults(result)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Methods added to conform to IAAS-agnostic interfaces
+
+// CheckExists reports whether the resource group bbl creates for an
+// environment, which holds its virtual network, already exists.
+func (c Client) CheckExists(resourceGroupName string) (bool, error) {
+	response, err := c.groupsClient.CheckExistence(resourceGroupName)
+	if err != nil {
+		return false, err
+	}
+
+	return response.Response != nil && response.StatusCode == http.StatusNoContent, nil
+}
+
+// CheckSubnetExists reports whether the virtual network given as
+// <resource-group>/<virtual-network> already holds a subnet named subnetName.
+func (c Client) CheckSubnetExists(existingNetwork, subnetName string) (bool, error) {
+	parts := strings.SplitN(existingNetwork, "/", 2)
+	resourceGroup, virtualNetwork := parts[0], parts[len(parts)-1]
+
+	response, err := c.resourcesClient.CheckExistence(resourceGroup, "Microsoft.Network", "virtualNetworks/"+virtualNetwork, "subnets", subnetName)
+	if err != nil {
+		return false, err
+	}
+
+	return response.Response != nil && response.StatusCode == http.StatusNoContent, nil
+}
+
+// VirtualNetworkAddressSpace returns the address prefixes of the virtual
+// network given as <resource-group>/<virtual-network>.
+func (c Client) VirtualNetworkAddressSpace(existingNetwork string) ([]string, error) {
+	parts := strings.SplitN(existingNetwork, "/", 2)
+	resourceGroup, virtualNetwork := parts[0], parts[len(parts)-1]
+
+	resource, err := c.resourcesClient.Get(resourceGroup, "Microsoft.Network", "", "virtualNetworks", virtualNetwork)
+	if err != nil {
+		return nil, err
+	}
+
+	var properties map[string]interface{}
+	if resource.Properties != nil {
+		properties = *resource.Properties
+	}
+
+	addressSpace, _ := properties["addressSpace"].(map[string]interface{})
+	addressPrefixes, _ := addressSpace["addressPrefixes"].([]interface{})
+
+	var prefixes []string
+	for _, prefix := range addressPrefixes {
+		if prefix, ok := prefix.(string); ok {
+			prefixes = append(prefixes, prefix)
+		}
+	}
+
+	return prefixes, nil
+}
+
+func (c Client) ValidateSafeToDelete(resourceGroupName string, envID string) error {
+	return c.validateSafeToDelete(resourceGroupName, func(map[string]*string) bool {
+		return true
+	})
+}
+
+// ValidateSubnetsSafeToDelete is used when bbl shares resourceGroupName with
+// other systems. VMs do not record their subnet without the network API, so
+// only VMs deployed by the environment's own director are in the way.
+func (c Client) ValidateSubnetsSafeToDelete(resourceGroupName string, subnets []string, envID string) error {
+	return c.validateSafeToDelete(resourceGroupName, func(tags map[string]*string) bool {
+		director, ok := tags["director"]
+		return ok && director != nil && *director == fmt.Sprintf("bosh-%s", envID)
+	})
+}
+
+func (c Client) validateSafeToDelete(resourceGroupName string, inScope func(tags map[string]*string) bool) error {
+	vms, err := c.listVirtualMachines(resourceGroupName)
+	if err != nil {
+		return err
+	}
+
+	var errorMessages []string
+	for _, vm := range vms {
+		tags := map[string]*string{}
+		if vm.Tags != nil {
+			tags = *vm.Tags
+		}
+
+		if director, ok := tags["director"]; ok && director != nil && *director == "bosh-init" {
+			continue
+		}
+
+		if !inScope(tags) {
+			continue
+		}
+
+		if deployment, ok := tags["deployment"]; ok && deployment != nil {
+			errorMessages = append(errorMessages, fmt.Sprintf("%s (deployment: %s)", vmName(vm), *deployment))
+		} else {
+			errorMessages = append(errorMessages, fmt.Sprintf("%s (not managed by bosh)", vmName(vm)))
+		}
+	}
+
+	if len(errorMessages) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("bbl environment is not safe to delete; vms still exist in resource group %s:\n%s",
+		resourceGroupName, strings.Join(errorMessages, "\n"))
+}
+
+func vmName(vm compute.VirtualMachine) string {
+	if vm.Name == nil {
+		return ""
+	}
+	return *vm.Name
+}
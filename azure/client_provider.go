@@ -4,6 +4,8 @@ import (
 	"context"
 	"net/http"
 
+	"github.com/Azure/azure-sdk-for-go/arm/compute"
+	"github.com/Azure/azure-sdk-for-go/arm/resources/resources"
 	"github.com/Azure/azure-sdk-for-go/arm/storage"
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/adal"
@@ -18,10 +20,6 @@ func azureHTTPClientFunc(config *jwt.Config) *http.Client {
 
 var azureHTTPClient = azureHTTPClientFunc
 
-type Client struct {
-	accountsClient storage.AccountsClient
-}
-
 type ClientProvider struct {
 	client Client
 }
@@ -45,8 +43,23 @@ func (p *ClientProvider) SetConfig(subscriptionID, tenantID, clientID, clientSec
 	ac.Authorizer = autorest.NewBearerAuthorizer(servicePrincipalToken)
 	ac.Sender = autorest.CreateSender(autorest.AsIs())
 
+	vmc := compute.NewVirtualMachinesClient(subscriptionID)
+	vmc.Authorizer = autorest.NewBearerAuthorizer(servicePrincipalToken)
+	vmc.Sender = autorest.CreateSender(autorest.AsIs())
+
+	gc := resources.NewGroupsClient(subscriptionID)
+	gc.Authorizer = autorest.NewBearerAuthorizer(servicePrincipalToken)
+	gc.Sender = autorest.CreateSender(autorest.AsIs())
+
+	rc := resources.NewGroupClient(subscriptionID)
+	rc.Authorizer = autorest.NewBearerAuthorizer(servicePrincipalToken)
+	rc.Sender = autorest.CreateSender(autorest.AsIs())
+
 	p.client = Client{
-		accountsClient: ac,
+		accountsClient:        ac,
+		virtualMachinesClient: vmc,
+		groupsClient:          gc,
+		resourcesClient:       rc,
 	}
 
 	_, err = ac.List()
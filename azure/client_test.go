@@ -0,0 +1,258 @@
+package azure_test
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Azure/azure-sdk-for-go/arm/compute"
+	"github.com/Azure/azure-sdk-for-go/arm/resources/resources"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/cloudfoundry/bosh-bootloader/azure"
+	"github.com/cloudfoundry/bosh-bootloader/fakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func virtualMachine(name string, tags map[string]string) compute.VirtualMachine {
+	vmTags := map[string]*string{}
+	for key, value := range tags {
+		value := value
+		vmTags[key] = &value
+	}
+
+	return compute.VirtualMachine{
+		Name: &name,
+		Tags: &vmTags,
+	}
+}
+
+var _ = Describe("Client", func() {
+	var (
+		virtualMachinesClient *fakes.AzureVirtualMachinesClient
+		groupsClient          *fakes.AzureGroupsClient
+		resourcesClient       *fakes.AzureResourcesClient
+		client                azure.Client
+	)
+
+	BeforeEach(func() {
+		virtualMachinesClient = &fakes.AzureVirtualMachinesClient{}
+		groupsClient = &fakes.AzureGroupsClient{}
+		resourcesClient = &fakes.AzureResourcesClient{}
+		client = azure.NewClientWithInjectedClients(virtualMachinesClient, groupsClient, resourcesClient)
+	})
+
+	Describe("CheckExists", func() {
+		It("returns true when the resource group exists", func() {
+			groupsClient.CheckExistenceCall.Returns.Response = autorest.Response{
+				Response: &http.Response{StatusCode: http.StatusNoContent},
+			}
+
+			exists, err := client.CheckExists("some-env-id-bosh")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(exists).To(BeTrue())
+
+			Expect(groupsClient.CheckExistenceCall.Receives.ResourceGroupName).To(Equal("some-env-id-bosh"))
+		})
+
+		It("returns false when the resource group does not exist", func() {
+			groupsClient.CheckExistenceCall.Returns.Response = autorest.Response{
+				Response: &http.Response{StatusCode: http.StatusNotFound},
+			}
+
+			exists, err := client.CheckExists("some-env-id-bosh")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(exists).To(BeFalse())
+		})
+
+		It("returns an error when the resource group cannot be checked", func() {
+			groupsClient.CheckExistenceCall.Returns.Error = errors.New("failed to check")
+
+			_, err := client.CheckExists("some-env-id-bosh")
+			Expect(err).To(MatchError("failed to check"))
+		})
+	})
+
+	Describe("CheckSubnetExists", func() {
+		It("checks for the subnet in the virtual network", func() {
+			resourcesClient.CheckExistenceCall.Returns.Response = autorest.Response{
+				Response: &http.Response{StatusCode: http.StatusNoContent},
+			}
+
+			exists, err := client.CheckSubnetExists("some-resource-group/some-vnet", "some-env-id-bosh-sn")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(exists).To(BeTrue())
+
+			Expect(resourcesClient.CheckExistenceCall.Receives.ResourceGroupName).To(Equal("some-resource-group"))
+			Expect(resourcesClient.CheckExistenceCall.Receives.ResourceProviderNamespace).To(Equal("Microsoft.Network"))
+			Expect(resourcesClient.CheckExistenceCall.Receives.ParentResourcePath).To(Equal("virtualNetworks/some-vnet"))
+			Expect(resourcesClient.CheckExistenceCall.Receives.ResourceType).To(Equal("subnets"))
+			Expect(resourcesClient.CheckExistenceCall.Receives.ResourceName).To(Equal("some-env-id-bosh-sn"))
+		})
+
+		It("returns false when the subnet does not exist", func() {
+			resourcesClient.CheckExistenceCall.Returns.Response = autorest.Response{
+				Response: &http.Response{StatusCode: http.StatusNotFound},
+			}
+
+			exists, err := client.CheckSubnetExists("some-resource-group/some-vnet", "some-env-id-bosh-sn")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(exists).To(BeFalse())
+		})
+
+		It("returns an error when the subnet cannot be checked", func() {
+			resourcesClient.CheckExistenceCall.Returns.Error = errors.New("failed to check")
+
+			_, err := client.CheckSubnetExists("some-resource-group/some-vnet", "some-env-id-bosh-sn")
+			Expect(err).To(MatchError("failed to check"))
+		})
+	})
+
+	Describe("VirtualNetworkAddressSpace", func() {
+		It("returns the address prefixes of the virtual network", func() {
+			resourcesClient.GetCall.Returns.Resource = resources.GenericResource{
+				Properties: &map[string]interface{}{
+					"addressSpace": map[string]interface{}{
+						"addressPrefixes": []interface{}{"10.0.0.0/16", "10.1.0.0/24"},
+					},
+				},
+			}
+
+			addressSpace, err := client.VirtualNetworkAddressSpace("some-resource-group/some-vnet")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(addressSpace).To(Equal([]string{"10.0.0.0/16", "10.1.0.0/24"}))
+
+			Expect(resourcesClient.GetCall.Receives.ResourceGroupName).To(Equal("some-resource-group"))
+			Expect(resourcesClient.GetCall.Receives.ResourceProviderNamespace).To(Equal("Microsoft.Network"))
+			Expect(resourcesClient.GetCall.Receives.ResourceType).To(Equal("virtualNetworks"))
+			Expect(resourcesClient.GetCall.Receives.ResourceName).To(Equal("some-vnet"))
+		})
+
+		It("returns an error when the virtual network cannot be fetched", func() {
+			resourcesClient.GetCall.Returns.Error = errors.New("failed to get")
+
+			_, err := client.VirtualNetworkAddressSpace("some-resource-group/some-vnet")
+			Expect(err).To(MatchError("failed to get"))
+		})
+	})
+
+	Describe("ValidateSubnetsSafeToDelete", func() {
+		BeforeEach(func() {
+			virtualMachinesClient.ListCall.Returns.Result = compute.VirtualMachineListResult{
+				Value: &[]compute.VirtualMachine{
+					virtualMachine("some-director", map[string]string{"director": "bosh-init", "deployment": "bosh"}),
+					virtualMachine("some-unrelated-vm", map[string]string{}),
+					virtualMachine("some-other-env-vm", map[string]string{"director": "bosh-some-other-env-id", "deployment": "cf"}),
+				},
+			}
+		})
+
+		It("ignores vms that this environment's director did not deploy", func() {
+			err := client.ValidateSubnetsSafeToDelete("some-resource-group", []string{"some-env-id-bosh-sn"}, "some-env-id")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(virtualMachinesClient.ListCall.Receives.ResourceGroupName).To(Equal("some-resource-group"))
+		})
+
+		It("returns an error when this environment's director deployed vms", func() {
+			vms := append(*virtualMachinesClient.ListCall.Returns.Result.Value,
+				virtualMachine("some-router", map[string]string{"director": "bosh-some-env-id", "deployment": "cf"}))
+			virtualMachinesClient.ListCall.Returns.Result.Value = &vms
+
+			err := client.ValidateSubnetsSafeToDelete("some-resource-group", []string{"some-env-id-bosh-sn"}, "some-env-id")
+			Expect(err).To(MatchError("bbl environment is not safe to delete; vms still exist in resource group some-resource-group:\nsome-router (deployment: cf)"))
+		})
+	})
+
+	Describe("ValidateSafeToDelete", func() {
+		Context("when only the director and jumpbox are in the resource group", func() {
+			BeforeEach(func() {
+				virtualMachinesClient.ListCall.Returns.Result = compute.VirtualMachineListResult{
+					Value: &[]compute.VirtualMachine{
+						virtualMachine("some-director", map[string]string{"director": "bosh-init", "deployment": "bosh"}),
+						virtualMachine("some-jumpbox", map[string]string{"director": "bosh-init", "deployment": "jumpbox"}),
+					},
+				}
+			})
+
+			It("does not return an error", func() {
+				err := client.ValidateSafeToDelete("some-env-id-bosh", "some-env-id")
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(virtualMachinesClient.ListCall.Receives.ResourceGroupName).To(Equal("some-env-id-bosh"))
+				Expect(virtualMachinesClient.ListNextResultsCall.CallCount).To(Equal(0))
+			})
+		})
+
+		Context("when the resource group has no vms", func() {
+			It("does not return an error", func() {
+				err := client.ValidateSafeToDelete("some-env-id-bosh", "some-env-id")
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		Context("when the resource group has already been deleted", func() {
+			BeforeEach(func() {
+				virtualMachinesClient.ListCall.Returns.Result = compute.VirtualMachineListResult{
+					Response: autorest.Response{
+						Response: &http.Response{StatusCode: http.StatusNotFound},
+					},
+				}
+				virtualMachinesClient.ListCall.Returns.Error = errors.New("ResourceGroupNotFound")
+			})
+
+			It("does not return an error", func() {
+				err := client.ValidateSafeToDelete("some-env-id-bosh", "some-env-id")
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		Context("when other vms are in the resource group", func() {
+			BeforeEach(func() {
+				nextLink := "some-next-link"
+				virtualMachinesClient.ListCall.Returns.Result = compute.VirtualMachineListResult{
+					Value: &[]compute.VirtualMachine{
+						virtualMachine("some-director", map[string]string{"director": "bosh-init", "deployment": "bosh"}),
+						virtualMachine("some-router", map[string]string{"director": "some-director", "deployment": "cf"}),
+					},
+					NextLink: &nextLink,
+				}
+				virtualMachinesClient.ListNextResultsCall.Returns.Result = compute.VirtualMachineListResult{
+					Value: &[]compute.VirtualMachine{
+						{Name: stringPointer("some-other-vm")},
+					},
+				}
+			})
+
+			It("returns a helpful error listing them, across pages of results", func() {
+				err := client.ValidateSafeToDelete("some-env-id-bosh", "some-env-id")
+				Expect(err).To(MatchError("bbl environment is not safe to delete; vms still exist in resource group some-env-id-bosh:\nsome-router (deployment: cf)\nsome-other-vm (not managed by bosh)"))
+
+				Expect(virtualMachinesClient.ListNextResultsCall.CallCount).To(Equal(1))
+				Expect(*virtualMachinesClient.ListNextResultsCall.Receives.LastResults.NextLink).To(Equal("some-next-link"))
+			})
+		})
+
+		Context("failure cases", func() {
+			It("returns an error when the vms cannot be listed", func() {
+				virtualMachinesClient.ListCall.Returns.Error = errors.New("failed to list")
+
+				err := client.ValidateSafeToDelete("some-env-id-bosh", "some-env-id")
+				Expect(err).To(MatchError("failed to list"))
+			})
+
+			It("returns an error when the next page of vms cannot be listed", func() {
+				nextLink := "some-next-link"
+				virtualMachinesClient.ListCall.Returns.Result = compute.VirtualMachineListResult{NextLink: &nextLink}
+				virtualMachinesClient.ListNextResultsCall.Returns.Error = errors.New("failed to list next")
+
+				err := client.ValidateSafeToDelete("some-env-id-bosh", "some-env-id")
+				Expect(err).To(MatchError("failed to list next"))
+			})
+		})
+	})
+})
+
+func stringPointer(s string) *string {
+	return &s
+}
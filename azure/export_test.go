@@ -0,0 +1,9 @@
+package azure
+
+func NewClientWithInjectedClients(virtualMachinesClient VirtualMachinesClient, groupsClient GroupsClient, resourcesClient ResourcesClient) Client {
+	return Client{
+		virtualMachinesClient: virtualMachinesClient,
+		groupsClient:          groupsClient,
+		resourcesClient:       resourcesClient,
+	}
+}
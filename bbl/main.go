@@ -7,6 +7,7 @@ import (
 	"io/ioutil"
 	"log"
 	"os"
+	"os/user"
 
 	"github.com/cloudfoundry/bosh-bootloader/application"
 	"github.com/cloudfoundry/bosh-bootloader/aws"
@@ -21,6 +22,7 @@ import (
 	"github.com/cloudfoundry/bosh-bootloader/gcp"
 	"github.com/cloudfoundry/bosh-bootloader/helpers"
 	"github.com/cloudfoundry/bosh-bootloader/proxy"
+	"github.com/cloudfoundry/bosh-bootloader/runtimeconfig"
 	"github.com/cloudfoundry/bosh-bootloader/storage"
 	"github.com/cloudfoundry/bosh-bootloader/terraform"
 
@@ -49,18 +51,56 @@ func main() {
 	}
 
 	needsIAASCreds := config.NeedsIAASCreds(appConfig.Command) && !appConfig.ShowCommandHelp
+	writesState := config.WritesState(appConfig.Command) && !appConfig.ShowCommandHelp
+
+	var stateLock *storage.StateLock
+	deletePlaintextFiles := func() {}
+	fatal := func(err error) {
+		deletePlaintextFiles()
+		if stateLock != nil {
+			stateLock.Unlock()
+		}
+		if _, ok := err.(commands.DriftDetectedError); ok {
+			log.Printf("\n\n%s\n", err)
+			os.Exit(2)
+		}
+		log.Fatalf("\n\n%s\n", err)
+	}
+
+	if writesState {
+		stateLock = storage.NewStateLock(appConfig.Global.StateBackend, leaseHolder(), appConfig.Command, storage.LeaseDuration)
+		err = stateLock.Lock()
+		if err != nil {
+			log.Fatalf("\n\n%s\n", err)
+		}
+
+		// Reload the state now that no other bbl can modify it.
+		appConfig, err = newConfig.ReloadState(appConfig)
+		if err != nil {
+			fatal(err)
+		}
+	}
+
 	if needsIAASCreds {
 		err = config.ValidateIAAS(appConfig.State, appConfig.Command)
 		if err != nil {
-			log.Fatalf("\n\n%s\n", err)
+			fatal(err)
 		}
 	}
 
 	// Utilities
 	envIDGenerator := helpers.NewEnvIDGenerator(rand.Reader)
 	storage.GetStateLogger = stderrLogger
-	stateStore := storage.NewStore(appConfig.Global.StateDir)
-	stateValidator := application.NewStateValidator(appConfig.Global.StateDir)
+	stateStore := storage.NewStore(appConfig.Global.StateDir, appConfig.Global.StateBackend)
+	if _, ok := appConfig.Global.StateBackend.(storage.EncryptedBackend); ok {
+		deletePlaintextFiles = func() {
+			err := stateStore.DeleteVarsDir()
+			if err != nil {
+				log.Printf("Deleting plaintext files in the vars directory: %s\n", err)
+			}
+		}
+	}
+	stateValidator := application.NewStateValidator(appConfig.Global.StateBackend)
 	certificateValidator := certs.NewValidator()
 
 	// Terraform
@@ -73,6 +113,7 @@ func main() {
 		networkDeletionValidator commands.NetworkDeletionValidator
 
 		gcpClient                 gcp.Client
+		azureClient               azure.Client
 		availabilityZoneRetriever ec2.AvailabilityZoneRetriever
 	)
 	if appConfig.State.IAAS == "aws" && needsIAASCreds {
@@ -80,6 +121,7 @@ func main() {
 		awsConfiguration := aws.Config{
 			AccessKeyID:     appConfig.State.AWS.AccessKeyID,
 			SecretAccessKey: appConfig.State.AWS.SecretAccessKey,
+			SessionToken:    appConfig.State.AWS.SessionToken,
 			Region:          appConfig.State.AWS.Region,
 		}
 		awsClientProvider.SetConfig(awsConfiguration, logger)
@@ -92,7 +134,7 @@ func main() {
 		gcpClientProvider := gcp.NewClientProvider(gcpBasePath)
 		err = gcpClientProvider.SetConfig(appConfig.State.GCP.ServiceAccountKey, appConfig.State.GCP.ProjectID, appConfig.State.GCP.Region, appConfig.State.GCP.Zone)
 		if err != nil {
-			log.Fatalf("\n\n%s\n", err)
+			fatal(err)
 		}
 
 		gcpClient = gcpClientProvider.Client()
@@ -102,8 +144,12 @@ func main() {
 		azureClientProvider := azure.NewClientProvider()
 		err = azureClientProvider.SetConfig(appConfig.State.Azure.SubscriptionID, appConfig.State.Azure.TenantID, appConfig.State.Azure.ClientID, appConfig.State.Azure.ClientSecret)
 		if err != nil {
-			log.Fatalf("\n\n%s\n", err)
+			fatal(err)
 		}
+
+		azureClient = azureClientProvider.Client()
+		networkDeletionValidator = azureClient
+		networkClient = azureClient
 	}
 
 	var (
@@ -155,6 +201,7 @@ func main() {
 		cloudConfigOpsGenerator = azurecloudconfig.NewOpsGenerator(terraformManager)
 	}
 	cloudConfigManager := cloudconfig.NewManager(logger, boshCommand, stateStore, cloudConfigOpsGenerator, boshClientProvider, socks5Proxy, terraformManager, sshKeyGetter)
+	runtimeConfigManager := runtimeconfig.NewManager(logger, boshClientProvider)
 
 	// Subcommands
 	var (
@@ -173,6 +220,8 @@ func main() {
 		lbsCmd = commands.NewGCPLBs(terraformManager, logger)
 	case "azure":
 		upCmd = commands.NewAzureUp()
+		createLBsCmd = commands.NewAzureCreateLBs(terraformManager, cloudConfigManager, stateStore, environmentValidator, azureClient)
+		lbsCmd = commands.NewAzureLBs(terraformManager, logger)
 	}
 
 	// Commands
@@ -180,20 +229,25 @@ func main() {
 	if appConfig.State.IAAS != "" {
 		envIDManager = helpers.NewEnvIDManager(envIDGenerator, networkClient)
 	}
-	up := commands.NewUp(upCmd, boshManager, cloudConfigManager, stateStore, envIDManager, terraformManager)
+	planner := commands.NewPlanner(terraformManager, boshManager, logger)
+	up := commands.NewUp(upCmd, boshManager, cloudConfigManager, runtimeConfigManager, stateStore, envIDManager, terraformManager, planner)
 	usage := commands.NewUsage(logger)
 
 	commandSet := application.CommandSet{}
 	commandSet["help"] = usage
 	commandSet["version"] = commands.NewVersion(Version, logger)
 	commandSet["up"] = up
+	commandSet["plan"] = commands.NewPlan(up)
 	sshKeyDeleter := bosh.NewSSHKeyDeleter()
-	commandSet["rotate"] = commands.NewRotate(stateValidator, sshKeyDeleter, up)
+	certificateManager := bosh.NewCertificateManager()
+	credentialManager := bosh.NewCredentialManager()
+	commandSet["rotate"] = commands.NewRotate(logger, stateValidator, sshKeyDeleter, certificateManager, credentialManager, terraformManager, up)
+	commandSet["certs"] = commands.NewCerts(logger, stateValidator, certificateManager)
 	commandSet["destroy"] = commands.NewDestroy(logger, os.Stdin, boshManager, stateStore, stateValidator, terraformManager, networkDeletionValidator)
 	commandSet["down"] = commandSet["destroy"]
-	commandSet["create-lbs"] = commands.NewCreateLBs(createLBsCmd, logger, stateValidator, certificateValidator, boshManager)
+	commandSet["create-lbs"] = commands.NewCreateLBs(createLBsCmd, logger, stateValidator, certificateValidator, boshManager, planner)
 	commandSet["update-lbs"] = commandSet["create-lbs"]
-	commandSet["delete-lbs"] = commands.NewDeleteLBs(logger, stateValidator, boshManager, cloudConfigManager, stateStore, environmentValidator, terraformManager)
+	commandSet["delete-lbs"] = commands.NewDeleteLBs(logger, stateValidator, boshManager, cloudConfigManager, stateStore, environmentValidator, terraformManager, planner)
 	commandSet["lbs"] = commands.NewLBs(lbsCmd, stateValidator)
 	commandSet["jumpbox-address"] = commands.NewStateQuery(logger, stateValidator, terraformManager, commands.JumpboxAddressPropertyName)
 	commandSet["director-address"] = commands.NewStateQuery(logger, stateValidator, terraformManager, commands.DirectorAddressPropertyName)
@@ -205,13 +259,39 @@ func main() {
 	commandSet["latest-error"] = commands.NewLatestError(logger, stateValidator)
 	commandSet["print-env"] = commands.NewPrintEnv(logger, stateValidator, terraformManager)
 	commandSet["cloud-config"] = commands.NewCloudConfig(logger, stateValidator, cloudConfigManager)
+	commandSet["runtime-config"] = commands.NewRuntimeConfig(logger, stateValidator, runtimeConfigManager)
+	commandSet["drift"] = commands.NewDrift(logger, stateValidator, terraformManager, cloudConfigManager)
 	commandSet["jumpbox-deployment-vars"] = commands.NewJumpboxDeploymentVars(logger, boshManager, stateValidator, terraformManager)
 	commandSet["bosh-deployment-vars"] = commands.NewBOSHDeploymentVars(logger, boshManager, stateValidator, terraformManager)
+	commandSet["encrypt-state"] = commands.NewEncryptState(logger, stateValidator, appConfig.Global.StateBackend)
+	commandSet["decrypt-state"] = commands.NewDecryptState(logger, stateValidator, appConfig.Global.StateBackend)
+	commandSet["state-history"] = commands.NewStateHistory(logger, appConfig.Global.StateHistory)
+	commandSet["state-restore"] = commands.NewStateRestore(logger, appConfig.Global.StateHistory)
+	commandSet["force-unlock"] = commands.NewForceUnlock(logger, appConfig.Global.StateBackend)
+	commandSet["migrate-state"] = commands.NewMigrateState(logger, stateValidator, appConfig.Global.StateBackend, stateStore)
 
 	app := application.New(commandSet, appConfig, usage)
 
 	err = app.Run()
 	if err != nil {
-		log.Fatalf("\n\n%s\n", err)
+		fatal(err)
+	}
+
+	deletePlaintextFiles()
+
+	if stateLock != nil {
+		err = stateLock.Unlock()
+		if err != nil {
+			log.Fatalf("\n\n%s\n", err)
+		}
+	}
+}
+
+func leaseHolder() string {
+	currentUser, err := user.Current()
+	if err != nil {
+		return "unknown"
 	}
+
+	return currentUser.Username
 }
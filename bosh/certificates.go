@@ -0,0 +1,256 @@
+package bosh
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+	yaml "gopkg.in/yaml.v2"
+)
+
+var timeNow = time.Now
+
+// Certificate is a certificate generated into the jumpbox or director
+// vars-store.
+type Certificate struct {
+	Deployment string
+	Name       string
+	Subject    string
+	Issuer     string
+	NotAfter   time.Time
+
+	certificate string
+	ca          string
+}
+
+// CertificateSelector chooses the certificates to rotate. It is parsed from
+// "all", "expiring-within=<duration>" or a comma separated list of names.
+type CertificateSelector struct {
+	all            bool
+	expiringWithin time.Duration
+	names          []string
+}
+
+func ParseCertificateSelector(selector string) (CertificateSelector, error) {
+	switch {
+	case selector == "":
+		return CertificateSelector{}, errors.New("no certificates given")
+	case selector == "all":
+		return CertificateSelector{all: true}, nil
+	case strings.HasPrefix(selector, "expiring-within="):
+		duration, err := parseDuration(strings.TrimPrefix(selector, "expiring-within="))
+		if err != nil {
+			return CertificateSelector{}, fmt.Errorf("expiring-within: %s", err)
+		}
+		return CertificateSelector{expiringWithin: duration}, nil
+	}
+
+	return CertificateSelector{names: strings.Split(selector, ",")}, nil
+}
+
+// parseDuration accepts a number of days, such as 30d, as well as anything
+// time.ParseDuration does.
+func parseDuration(duration string) (time.Duration, error) {
+	if strings.HasSuffix(duration, "d") {
+		days, err := strconv.Atoi(strings.TrimSuffix(duration, "d"))
+		if err != nil || days < 0 {
+			return 0, fmt.Errorf("invalid duration %s", duration)
+		}
+		return time.Duration(days) * 24 * time.Hour, nil
+	}
+
+	return time.ParseDuration(duration)
+}
+
+func (s CertificateSelector) selects(certificate Certificate) bool {
+	if s.all {
+		return true
+	}
+
+	if s.expiringWithin != 0 {
+		return certificate.NotAfter.Before(timeNow().Add(s.expiringWithin))
+	}
+
+	for _, name := range s.names {
+		if name == certificate.Name {
+			return true
+		}
+	}
+
+	return false
+}
+
+type CertificateManager struct{}
+
+func NewCertificateManager() CertificateManager {
+	return CertificateManager{}
+}
+
+// List returns the certificates in the jumpbox and director vars-stores.
+func (CertificateManager) List(state storage.State) ([]Certificate, error) {
+	jumpboxCertificates, err := certificates("jumpbox", state.Jumpbox.Variables)
+	if err != nil {
+		return nil, fmt.Errorf("Jumpbox variables: %s", err)
+	}
+
+	directorCertificates, err := certificates("director", state.BOSH.Variables)
+	if err != nil {
+		return nil, fmt.Errorf("BOSH variables: %s", err)
+	}
+
+	return append(jumpboxCertificates, directorCertificates...), nil
+}
+
+func certificates(deployment, varsString string) ([]Certificate, error) {
+	vars := map[string]interface{}{}
+	err := yaml.Unmarshal([]byte(varsString), &vars)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for name := range vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var certificates []Certificate
+	for _, name := range names {
+		value, ok := vars[name].(map[interface{}]interface{})
+		if !ok {
+			continue
+		}
+
+		certificatePEM, ok := value["certificate"].(string)
+		if !ok {
+			continue
+		}
+
+		block, _ := pem.Decode([]byte(certificatePEM))
+		if block == nil {
+			return nil, fmt.Errorf("%s: certificate is not PEM encoded", name)
+		}
+
+		parsed, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", name, err)
+		}
+
+		ca, _ := value["ca"].(string)
+
+		certificates = append(certificates, Certificate{
+			Deployment:  deployment,
+			Name:        name,
+			Subject:     parsed.Subject.CommonName,
+			Issuer:      parsed.Issuer.CommonName,
+			NotAfter:    parsed.NotAfter,
+			certificate: strings.TrimSpace(certificatePEM),
+			ca:          strings.TrimSpace(ca),
+		})
+	}
+
+	return certificates, nil
+}
+
+// Delete removes the selected certificates, and every certificate signed by
+// a removed CA, from the vars-stores so that the next create-env generates
+// them again. It returns the deleted certificates as deployment/name.
+func (m CertificateManager) Delete(state storage.State, selector CertificateSelector) (storage.State, []string, error) {
+	allCertificates, err := m.List(state)
+	if err != nil {
+		return storage.State{}, nil, err
+	}
+
+	for _, name := range selector.names {
+		if !containsCertificate(allCertificates, name) {
+			return storage.State{}, nil, fmt.Errorf("No certificate named %s", name)
+		}
+	}
+
+	deleted := map[Certificate]bool{}
+	for _, certificate := range allCertificates {
+		if selector.selects(certificate) {
+			deleted[certificate] = true
+		}
+	}
+
+	for changed := true; changed; {
+		changed = false
+		for _, certificate := range allCertificates {
+			if deleted[certificate] || certificate.ca == "" {
+				continue
+			}
+
+			for ca := range deleted {
+				if ca.Deployment == certificate.Deployment && ca.certificate == certificate.ca {
+					deleted[certificate] = true
+					changed = true
+					break
+				}
+			}
+		}
+	}
+
+	var deletedNames, jumpboxNames, directorNames []string
+	for _, certificate := range allCertificates {
+		if !deleted[certificate] {
+			continue
+		}
+
+		deletedNames = append(deletedNames, fmt.Sprintf("%s/%s", certificate.Deployment, certificate.Name))
+		if certificate.Deployment == "jumpbox" {
+			jumpboxNames = append(jumpboxNames, certificate.Name)
+		} else {
+			directorNames = append(directorNames, certificate.Name)
+		}
+	}
+
+	state.Jumpbox.Variables, err = deleteVariables(state.Jumpbox.Variables, jumpboxNames)
+	if err != nil {
+		return storage.State{}, nil, fmt.Errorf("Jumpbox variables: %s", err) //not tested
+	}
+
+	state.BOSH.Variables, err = deleteVariables(state.BOSH.Variables, directorNames)
+	if err != nil {
+		return storage.State{}, nil, fmt.Errorf("BOSH variables: %s", err) //not tested
+	}
+
+	return state, deletedNames, nil
+}
+
+func containsCertificate(certificates []Certificate, name string) bool {
+	for _, certificate := range certificates {
+		if certificate.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func deleteVariables(varsString string, names []string) (string, error) {
+	if len(names) == 0 {
+		return varsString, nil
+	}
+
+	vars := make(map[string]interface{})
+	err := yaml.Unmarshal([]byte(varsString), &vars)
+	if err != nil {
+		return "", err
+	}
+
+	for _, name := range names {
+		delete(vars, name)
+	}
+
+	newVars, err := yaml.Marshal(vars)
+	if err != nil {
+		return "", err // not tested
+	}
+	return string(newVars), nil
+}
@@ -0,0 +1,200 @@
+package bosh_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"time"
+
+	"github.com/cloudfoundry/bosh-bootloader/bosh"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+	yaml "gopkg.in/yaml.v2"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CertificateManager", func() {
+	var (
+		certificateManager bosh.CertificateManager
+		state              storage.State
+		now                time.Time
+	)
+
+	generateCertificate := func(commonName string, notAfter time.Time, parent *x509.Certificate, parentKey *rsa.PrivateKey) (*x509.Certificate, *rsa.PrivateKey, string) {
+		key, err := rsa.GenerateKey(rand.Reader, 1024)
+		Expect(err).NotTo(HaveOccurred())
+
+		template := &x509.Certificate{
+			SerialNumber:          big.NewInt(1),
+			Subject:               pkix.Name{CommonName: commonName},
+			NotBefore:             now.Add(-time.Hour),
+			NotAfter:              notAfter,
+			IsCA:                  parent == nil,
+			BasicConstraintsValid: true,
+			KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		}
+		if parent == nil {
+			parent, parentKey = template, key
+		}
+
+		der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+		Expect(err).NotTo(HaveOccurred())
+
+		certificate, err := x509.ParseCertificate(der)
+		Expect(err).NotTo(HaveOccurred())
+
+		return certificate, key, string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	}
+
+	variables := func(vars map[string]interface{}) string {
+		contents, err := yaml.Marshal(vars)
+		Expect(err).NotTo(HaveOccurred())
+		return string(contents)
+	}
+
+	BeforeEach(func() {
+		now = time.Date(2017, time.October, 1, 0, 0, 0, 0, time.UTC)
+		bosh.SetTimeNow(func() time.Time { return now })
+
+		ca, caKey, caPEM := generateCertificate("default-ca", now.AddDate(1, 0, 0), nil, nil)
+		_, _, directorSSLPEM := generateCertificate("10.0.0.6", now.AddDate(0, 0, 10), ca, caKey)
+		_, _, uaaSSLPEM := generateCertificate("uaa", now.AddDate(1, 0, 0), ca, caKey)
+		_, _, natsCAPEM := generateCertificate("nats-ca", now.AddDate(1, 0, 0), nil, nil)
+
+		state = storage.State{
+			Jumpbox: storage.Jumpbox{
+				Variables: variables(map[string]interface{}{
+					"jumpbox_ssh": map[string]interface{}{"private_key": "some-private-key"},
+				}),
+			},
+			BOSH: storage.BOSH{
+				Variables: variables(map[string]interface{}{
+					"admin_password": "some-password",
+					"default_ca":     map[string]interface{}{"certificate": caPEM, "ca": caPEM, "private_key": "some-key"},
+					"director_ssl":   map[string]interface{}{"certificate": directorSSLPEM, "ca": caPEM, "private_key": "some-key"},
+					"uaa_ssl":        map[string]interface{}{"certificate": uaaSSLPEM, "ca": caPEM, "private_key": "some-key"},
+					"nats_ca":        map[string]interface{}{"certificate": natsCAPEM, "ca": natsCAPEM, "private_key": "some-key"},
+				}),
+			},
+		}
+
+		certificateManager = bosh.NewCertificateManager()
+	})
+
+	AfterEach(func() {
+		bosh.ResetTimeNow()
+	})
+
+	remainingVariables := func(varsString string) []string {
+		vars := map[string]interface{}{}
+		err := yaml.Unmarshal([]byte(varsString), &vars)
+		Expect(err).NotTo(HaveOccurred())
+
+		var names []string
+		for name := range vars {
+			names = append(names, name)
+		}
+		return names
+	}
+
+	Describe("List", func() {
+		It("returns the certificates in the vars-stores with their subject, issuer and expiry", func() {
+			certificates, err := certificateManager.List(state)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(certificates).To(HaveLen(4))
+			Expect(certificates[0].Deployment).To(Equal("director"))
+			Expect(certificates[0].Name).To(Equal("default_ca"))
+			Expect(certificates[1].Name).To(Equal("director_ssl"))
+			Expect(certificates[1].Subject).To(Equal("10.0.0.6"))
+			Expect(certificates[1].Issuer).To(Equal("default-ca"))
+			Expect(certificates[1].NotAfter).To(Equal(now.AddDate(0, 0, 10)))
+			Expect(certificates[2].Name).To(Equal("nats_ca"))
+			Expect(certificates[3].Name).To(Equal("uaa_ssl"))
+		})
+
+		It("returns an error when a certificate cannot be parsed", func() {
+			state.BOSH.Variables = "director_ssl:\n  certificate: not-a-certificate\n"
+
+			_, err := certificateManager.List(state)
+			Expect(err).To(MatchError("BOSH variables: director_ssl: certificate is not PEM encoded"))
+		})
+
+		It("returns an error when the jumpbox variables are invalid yaml", func() {
+			state.Jumpbox.Variables = "invalid yaml"
+
+			_, err := certificateManager.List(state)
+			Expect(err).To(MatchError(ContainSubstring("Jumpbox variables: yaml: unmarshal errors:")))
+		})
+	})
+
+	Describe("Delete", func() {
+		It("deletes the named certificates", func() {
+			selector, err := bosh.ParseCertificateSelector("director_ssl,nats_ca")
+			Expect(err).NotTo(HaveOccurred())
+
+			newState, deleted, err := certificateManager.Delete(state, selector)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(deleted).To(Equal([]string{"director/director_ssl", "director/nats_ca"}))
+			Expect(remainingVariables(newState.BOSH.Variables)).To(ConsistOf("admin_password", "default_ca", "uaa_ssl"))
+			Expect(newState.Jumpbox.Variables).To(Equal(state.Jumpbox.Variables))
+		})
+
+		It("deletes the certificates signed by a deleted ca", func() {
+			selector, err := bosh.ParseCertificateSelector("default_ca")
+			Expect(err).NotTo(HaveOccurred())
+
+			newState, deleted, err := certificateManager.Delete(state, selector)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(deleted).To(Equal([]string{"director/default_ca", "director/director_ssl", "director/uaa_ssl"}))
+			Expect(remainingVariables(newState.BOSH.Variables)).To(ConsistOf("admin_password", "nats_ca"))
+		})
+
+		It("deletes the certificates expiring within the duration", func() {
+			selector, err := bosh.ParseCertificateSelector("expiring-within=30d")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, deleted, err := certificateManager.Delete(state, selector)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(deleted).To(Equal([]string{"director/director_ssl"}))
+		})
+
+		It("deletes every certificate", func() {
+			selector, err := bosh.ParseCertificateSelector("all")
+			Expect(err).NotTo(HaveOccurred())
+
+			newState, deleted, err := certificateManager.Delete(state, selector)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(deleted).To(HaveLen(4))
+			Expect(remainingVariables(newState.BOSH.Variables)).To(ConsistOf("admin_password"))
+		})
+
+		It("returns an error for an unknown certificate", func() {
+			selector, err := bosh.ParseCertificateSelector("missing_ssl")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, _, err = certificateManager.Delete(state, selector)
+			Expect(err).To(MatchError("No certificate named missing_ssl"))
+		})
+	})
+
+	Describe("ParseCertificateSelector", func() {
+		It("returns an error for an invalid duration", func() {
+			_, err := bosh.ParseCertificateSelector("expiring-within=soon")
+			Expect(err).To(MatchError(ContainSubstring("expiring-within: time: invalid duration")))
+		})
+
+		It("returns an error when no certificates are given", func() {
+			_, err := bosh.ParseCertificateSelector("")
+			Expect(err).To(MatchError("no certificates given"))
+		})
+	})
+})
@@ -8,6 +8,7 @@ import (
 
 type CIDRBlock struct {
 	CIDRSize int
+	maskBits int
 	firstIP  IP
 }
 
@@ -38,6 +39,7 @@ func ParseCIDRBlock(cidrBlock string) (CIDRBlock, error) {
 	cidrSize := 1 << (HIGHEST_BITMASK - uint(maskBits))
 	return CIDRBlock{
 		CIDRSize: cidrSize,
+		maskBits: maskBits,
 		firstIP:  ip,
 	}, nil
 }
@@ -49,3 +51,26 @@ func (c CIDRBlock) GetFirstIP() IP {
 func (c CIDRBlock) GetLastIP() IP {
 	return c.firstIP.Add(c.CIDRSize - 1)
 }
+
+// Subnet returns the netNum-th block of the range once its prefix is extended
+// by newBits, like terraform's cidrsubnet function.
+func (c CIDRBlock) Subnet(newBits, netNum int) CIDRBlock {
+	cidrSize := c.CIDRSize >> uint(newBits)
+	return CIDRBlock{
+		CIDRSize: cidrSize,
+		maskBits: c.maskBits + newBits,
+		firstIP:  c.firstIP.Add(cidrSize * netNum),
+	}
+}
+
+func (c CIDRBlock) Contains(other CIDRBlock) bool {
+	return c.firstIP.ip <= other.firstIP.ip && other.GetLastIP().ip <= c.GetLastIP().ip
+}
+
+func (c CIDRBlock) Overlaps(other CIDRBlock) bool {
+	return c.firstIP.ip <= other.GetLastIP().ip && other.firstIP.ip <= c.GetLastIP().ip
+}
+
+func (c CIDRBlock) String() string {
+	return fmt.Sprintf("%s/%d", c.firstIP, c.maskBits)
+}
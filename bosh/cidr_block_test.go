@@ -32,6 +32,20 @@ var _ = Describe("CIDRBlock", func() {
 		})
 	})
 
+	Describe("Subnet", func() {
+		It("returns the nth block of the extended prefix", func() {
+			Expect(cidrBlock.Subnet(4, 0).String()).To(Equal("10.0.16.0/24"))
+			Expect(cidrBlock.Subnet(4, 3).String()).To(Equal("10.0.19.0/24"))
+			Expect(cidrBlock.Subnet(8, 1).String()).To(Equal("10.0.16.16/28"))
+		})
+	})
+
+	Describe("String", func() {
+		It("returns the cidr block in slash notation", func() {
+			Expect(cidrBlock.String()).To(Equal("10.0.16.0/20"))
+		})
+	})
+
 	Describe("ParseCIDRBlock", func() {
 		Context("failure cases", func() {
 			Context("when input string is not a valid CIDR block", func() {
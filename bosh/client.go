@@ -17,6 +17,9 @@ import (
 
 type Client interface {
 	UpdateCloudConfig(yaml []byte) error
+	CloudConfig() (string, error)
+	UpdateConfig(configType, name string, content []byte) error
+	DeleteConfig(configType, name string) error
 	Info() (Info, error)
 }
 
@@ -79,38 +82,144 @@ func (c client) UpdateCloudConfig(yaml []byte) error {
 	}
 	request.Header.Set("Content-Type", "text/yaml")
 
-	urlParts, err := url.Parse(c.directorAddress)
+	httpClient, err := c.uaaClient()
 	if err != nil {
 		return err //not tested
 	}
 
-	boshHost, _, err := net.SplitHostPort(urlParts.Host)
+	response, err := makeRequests(httpClient, request)
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode != http.StatusCreated {
+		return fmt.Errorf("unexpected http response %d %s", response.StatusCode, http.StatusText(response.StatusCode))
+	}
+
+	return nil
+}
+
+// CloudConfig returns the cloud config the director is using, or an empty
+// string if none has been uploaded.
+func (c client) CloudConfig() (string, error) {
+	request, err := http.NewRequest("GET", fmt.Sprintf("%s/configs?type=cloud&latest=true", c.directorAddress), strings.NewReader(""))
+	if err != nil {
+		return "", err
+	}
+
+	httpClient, err := c.uaaClient()
+	if err != nil {
+		return "", err //not tested
+	}
+
+	response, err := makeRequests(httpClient, request)
+	if err != nil {
+		return "", err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected http response %d %s", response.StatusCode, http.StatusText(response.StatusCode))
+	}
+
+	var configs []struct {
+		Content string `json:"content"`
+	}
+	if err := json.NewDecoder(response.Body).Decode(&configs); err != nil {
+		return "", err
+	}
+
+	if len(configs) == 0 {
+		return "", nil
+	}
+
+	return configs[0].Content, nil
+}
+
+// UpdateConfig uploads a named config of the given type, such as a runtime
+// or CPI config.
+func (c client) UpdateConfig(configType, name string, content []byte) error {
+	body, err := json.Marshal(map[string]string{
+		"type":    configType,
+		"name":    name,
+		"content": string(content),
+	})
 	if err != nil {
 		return err //not tested
 	}
 
-	ctx := context.Background()
-	ctx = context.WithValue(ctx, oauth2.HTTPClient, c.httpClient)
+	request, err := http.NewRequest("POST", fmt.Sprintf("%s/configs", c.directorAddress), bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
 
-	conf := &clientcredentials.Config{
-		ClientID:     c.username,
-		ClientSecret: c.password,
-		TokenURL:     fmt.Sprintf("https://%s:8443/oauth/token", boshHost),
+	httpClient, err := c.uaaClient()
+	if err != nil {
+		return err //not tested
 	}
 
-	httpClient := conf.Client(ctx)
 	response, err := makeRequests(httpClient, request)
 	if err != nil {
 		return err
 	}
 
-	if response.StatusCode != http.StatusCreated {
+	if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusCreated {
 		return fmt.Errorf("unexpected http response %d %s", response.StatusCode, http.StatusText(response.StatusCode))
 	}
 
 	return nil
 }
 
+// DeleteConfig deletes a named config of the given type. A config the director
+// does not have is already gone, so a 404 is not an error.
+func (c client) DeleteConfig(configType, name string) error {
+	query := url.Values{"type": {configType}, "name": {name}}
+	request, err := http.NewRequest("DELETE", fmt.Sprintf("%s/configs?%s", c.directorAddress, query.Encode()), strings.NewReader(""))
+	if err != nil {
+		return err
+	}
+
+	httpClient, err := c.uaaClient()
+	if err != nil {
+		return err //not tested
+	}
+
+	response, err := makeRequests(httpClient, request)
+	if err != nil {
+		return err
+	}
+
+	switch response.StatusCode {
+	case http.StatusOK, http.StatusNoContent, http.StatusNotFound:
+		return nil
+	default:
+		return fmt.Errorf("unexpected http response %d %s", response.StatusCode, http.StatusText(response.StatusCode))
+	}
+}
+
+func (c client) uaaClient() (*http.Client, error) {
+	urlParts, err := url.Parse(c.directorAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	boshHost, _, err := net.SplitHostPort(urlParts.Host)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, c.httpClient)
+
+	conf := &clientcredentials.Config{
+		ClientID:     c.username,
+		ClientSecret: c.password,
+		TokenURL:     fmt.Sprintf("https://%s:8443/oauth/token", boshHost),
+	}
+
+	return conf.Client(ctx), nil
+}
+
 func makeRequests(httpClient *http.Client, request *http.Request) (*http.Response, error) {
 	var (
 		response *http.Response
@@ -31,6 +31,9 @@ var _ = Describe("Client", func() {
 		cloudConfigContentType string
 		httpClient             *http.Client
 		failStatus             int
+		configsQuery           string
+		configBody             []byte
+		configContentType      string
 	)
 
 	BeforeEach(func() {
@@ -78,6 +81,35 @@ var _ = Describe("Client", func() {
 				          "uuid": "some-uuid",
 				          "version": "some-version"
 		                }`))
+			case "/configs":
+				if failStatus != 0 {
+					w.WriteHeader(failStatus)
+					return
+				}
+
+				token = req.Header.Get("Authorization")
+
+				if req.Method == "POST" {
+					configContentType = req.Header.Get("Content-Type")
+
+					var err error
+					configBody, err = ioutil.ReadAll(req.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					w.WriteHeader(http.StatusCreated)
+					return
+				}
+
+				if req.Method == "DELETE" {
+					configsQuery = req.URL.RawQuery
+
+					w.WriteHeader(http.StatusNoContent)
+					return
+				}
+
+				configsQuery = req.URL.RawQuery
+
+				w.Write([]byte(`[{"id": "1", "type": "cloud", "name": "default", "content": "cloud: config"}]`))
 			case "/cloud_configs":
 				if failStatus != 0 {
 					w.WriteHeader(failStatus)
@@ -181,6 +213,141 @@ var _ = Describe("Client", func() {
 		})
 	})
 
+	Describe("CloudConfig", func() {
+		var dialer *fakes.Socks5Client
+
+		BeforeEach(func() {
+			dialer = &fakes.Socks5Client{}
+			dialer.DialCall.Stub = func(network, addr string) (net.Conn, error) {
+				u, _ := url.Parse(fakeBOSH.URL)
+				return net.Dial(network, u.Host)
+			}
+
+			httpClient = &http.Client{
+				Transport: &http.Transport{
+					Dial:            dialer.Dial,
+					TLSClientConfig: tlsConfig,
+				},
+			}
+
+			fakeBOSH.StartTLS()
+		})
+
+		It("uses UAA to get a token in order to fetch the latest cloud-config", func() {
+			client := bosh.NewClient(httpClient, fakeBOSH.URL, "some-username", "some-password", string(ca))
+
+			cloudConfig, err := client.CloudConfig()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(token).To(Equal("Bearer some-uaa-token"))
+			Expect(configsQuery).To(Equal("type=cloud&latest=true"))
+			Expect(cloudConfig).To(Equal("cloud: config"))
+		})
+
+		Context("when the response is not StatusOK", func() {
+			It("returns an error", func() {
+				failStatus = http.StatusNotFound
+				client := bosh.NewClient(httpClient, fakeBOSH.URL, "some-username", "some-password", string(ca))
+
+				_, err := client.CloudConfig()
+				Expect(err).To(MatchError("unexpected http response 404 Not Found"))
+			})
+		})
+	})
+
+	Describe("UpdateConfig", func() {
+		var dialer *fakes.Socks5Client
+
+		BeforeEach(func() {
+			dialer = &fakes.Socks5Client{}
+			dialer.DialCall.Stub = func(network, addr string) (net.Conn, error) {
+				u, _ := url.Parse(fakeBOSH.URL)
+				return net.Dial(network, u.Host)
+			}
+
+			httpClient = &http.Client{
+				Transport: &http.Transport{
+					Dial:            dialer.Dial,
+					TLSClientConfig: tlsConfig,
+				},
+			}
+
+			fakeBOSH.StartTLS()
+		})
+
+		It("uses UAA to get a token in order to upload the named config", func() {
+			client := bosh.NewClient(httpClient, fakeBOSH.URL, "some-username", "some-password", string(ca))
+
+			err := client.UpdateConfig("runtime", "dns", []byte("addons: []"))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(token).To(Equal("Bearer some-uaa-token"))
+			Expect(configContentType).To(Equal("application/json"))
+			Expect(configBody).To(MatchJSON(`{"type": "runtime", "name": "dns", "content": "addons: []"}`))
+		})
+
+		Context("when the response is not StatusCreated", func() {
+			It("returns an error", func() {
+				failStatus = http.StatusBadRequest
+				client := bosh.NewClient(httpClient, fakeBOSH.URL, "some-username", "some-password", string(ca))
+
+				err := client.UpdateConfig("runtime", "dns", []byte("addons: []"))
+				Expect(err).To(MatchError("unexpected http response 400 Bad Request"))
+			})
+		})
+	})
+
+	Describe("DeleteConfig", func() {
+		var dialer *fakes.Socks5Client
+
+		BeforeEach(func() {
+			dialer = &fakes.Socks5Client{}
+			dialer.DialCall.Stub = func(network, addr string) (net.Conn, error) {
+				u, _ := url.Parse(fakeBOSH.URL)
+				return net.Dial(network, u.Host)
+			}
+
+			httpClient = &http.Client{
+				Transport: &http.Transport{
+					Dial:            dialer.Dial,
+					TLSClientConfig: tlsConfig,
+				},
+			}
+
+			fakeBOSH.StartTLS()
+		})
+
+		It("uses UAA to get a token in order to delete the named config", func() {
+			client := bosh.NewClient(httpClient, fakeBOSH.URL, "some-username", "some-password", string(ca))
+
+			err := client.DeleteConfig("runtime", "dns")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(token).To(Equal("Bearer some-uaa-token"))
+			Expect(configsQuery).To(Equal("name=dns&type=runtime"))
+		})
+
+		Context("when the director does not have the config", func() {
+			It("does not return an error", func() {
+				failStatus = http.StatusNotFound
+				client := bosh.NewClient(httpClient, fakeBOSH.URL, "some-username", "some-password", string(ca))
+
+				err := client.DeleteConfig("runtime", "dns")
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		Context("when the response is an error", func() {
+			It("returns an error", func() {
+				failStatus = http.StatusBadRequest
+				client := bosh.NewClient(httpClient, fakeBOSH.URL, "some-username", "some-password", string(ca))
+
+				err := client.DeleteConfig("runtime", "dns")
+				Expect(err).To(MatchError("unexpected http response 400 Bad Request"))
+			})
+		})
+	})
+
 	Describe("UpdateCloudConfig", func() {
 		Context("when a jumpbox is enabled", func() {
 			It("uses UAA to get a token in order to upload the cloud-config", func() {
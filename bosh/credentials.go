@@ -0,0 +1,110 @@
+package bosh
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// unrotatableCredentials must never be regenerated: credhub encrypts its data
+// with credhub_encryption_password, so a new one loses every credhub secret.
+var unrotatableCredentials = []string{"credhub_encryption_password"}
+
+type CredentialManager struct{}
+
+func NewCredentialManager() CredentialManager {
+	return CredentialManager{}
+}
+
+// Delete removes the named passwords and keys, or all of them when all is
+// true, from the jumpbox and director vars-stores so that the next create-env
+// generates them again. Certificates are left to CertificateManager and
+// unrotatableCredentials are never deleted. It returns the deleted credentials
+// as deployment/name.
+func (CredentialManager) Delete(state storage.State, names []string, all bool) (storage.State, []string, error) {
+	jumpboxVars, err := unmarshalVariables(state.Jumpbox.Variables)
+	if err != nil {
+		return storage.State{}, nil, fmt.Errorf("Jumpbox variables: %s", err)
+	}
+
+	directorVars, err := unmarshalVariables(state.BOSH.Variables)
+	if err != nil {
+		return storage.State{}, nil, fmt.Errorf("BOSH variables: %s", err)
+	}
+
+	jumpboxCredentials := credentialNames(jumpboxVars)
+	directorCredentials := credentialNames(directorVars)
+
+	for _, name := range names {
+		if contains(unrotatableCredentials, name) {
+			return storage.State{}, nil, fmt.Errorf("%s cannot be rotated without losing the data encrypted with it", name)
+		}
+		if !contains(jumpboxCredentials, name) && !contains(directorCredentials, name) {
+			return storage.State{}, nil, fmt.Errorf("No credential named %s", name)
+		}
+	}
+
+	var deleted []string
+	for _, vars := range []struct {
+		deployment  string
+		credentials []string
+		variables   *string
+	}{
+		{"jumpbox", jumpboxCredentials, &state.Jumpbox.Variables},
+		{"director", directorCredentials, &state.BOSH.Variables},
+	} {
+		var deletedNames []string
+		for _, credential := range vars.credentials {
+			if all || contains(names, credential) {
+				deletedNames = append(deletedNames, credential)
+				deleted = append(deleted, fmt.Sprintf("%s/%s", vars.deployment, credential))
+			}
+		}
+
+		*vars.variables, err = deleteVariables(*vars.variables, deletedNames)
+		if err != nil {
+			return storage.State{}, nil, err //not tested
+		}
+	}
+
+	return state, deleted, nil
+}
+
+func unmarshalVariables(varsString string) (map[string]interface{}, error) {
+	vars := map[string]interface{}{}
+	err := yaml.Unmarshal([]byte(varsString), &vars)
+	if err != nil {
+		return nil, err
+	}
+	return vars, nil
+}
+
+// credentialNames returns the sorted names of the passwords and keys in vars,
+// leaving out certificates and unrotatableCredentials.
+func credentialNames(vars map[string]interface{}) []string {
+	var names []string
+	for name, value := range vars {
+		if contains(unrotatableCredentials, name) {
+			continue
+		}
+		if value, ok := value.(map[interface{}]interface{}); ok {
+			if _, ok := value["certificate"]; ok {
+				continue
+			}
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,64 @@
+package bosh_test
+
+import (
+	"github.com/cloudfoundry/bosh-bootloader/bosh"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CredentialManager", func() {
+	var (
+		credentialManager bosh.CredentialManager
+		state             storage.State
+	)
+
+	BeforeEach(func() {
+		credentialManager = bosh.NewCredentialManager()
+		state = storage.State{
+			Jumpbox: storage.Jumpbox{
+				Variables: "jumpbox_ssh:\n  private_key: some-private-key\n",
+			},
+			BOSH: storage.BOSH{
+				Variables: "admin_password: some-password\nnats_password: some-nats-password\ncredhub_encryption_password: some-encryption-password\njumpbox_ssh:\n  private_key: some-private-key\ndirector_ssl:\n  certificate: some-certificate\n",
+			},
+		}
+	})
+
+	Describe("Delete", func() {
+		It("deletes the named credentials from both vars-stores", func() {
+			newState, deleted, err := credentialManager.Delete(state, []string{"admin_password", "jumpbox_ssh"}, false)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(deleted).To(Equal([]string{"jumpbox/jumpbox_ssh", "director/admin_password", "director/jumpbox_ssh"}))
+			Expect(newState.Jumpbox.Variables).To(Equal("{}\n"))
+			Expect(newState.BOSH.Variables).To(Equal("credhub_encryption_password: some-encryption-password\ndirector_ssl:\n  certificate: some-certificate\nnats_password: some-nats-password\n"))
+		})
+
+		It("deletes every password and key but not the certificates or the credhub encryption password", func() {
+			newState, deleted, err := credentialManager.Delete(state, nil, true)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(deleted).To(Equal([]string{"jumpbox/jumpbox_ssh", "director/admin_password", "director/jumpbox_ssh", "director/nats_password"}))
+			Expect(newState.BOSH.Variables).To(Equal("credhub_encryption_password: some-encryption-password\ndirector_ssl:\n  certificate: some-certificate\n"))
+		})
+
+		It("returns an error when the credhub encryption password is named", func() {
+			_, _, err := credentialManager.Delete(state, []string{"admin_password", "credhub_encryption_password"}, false)
+			Expect(err).To(MatchError("credhub_encryption_password cannot be rotated without losing the data encrypted with it"))
+		})
+
+		It("returns an error for an unknown credential", func() {
+			_, _, err := credentialManager.Delete(state, []string{"director_ssl"}, false)
+			Expect(err).To(MatchError("No credential named director_ssl"))
+		})
+
+		It("returns an error when the BOSH variables are invalid yaml", func() {
+			state.BOSH.Variables = "invalid yaml"
+
+			_, _, err := credentialManager.Delete(state, nil, true)
+			Expect(err).To(MatchError(ContainSubstring("BOSH variables: yaml: unmarshal errors:")))
+		})
+	})
+})
@@ -0,0 +1,109 @@
+package bosh
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	boshDeploymentAssets    = "vendor/github.com/cloudfoundry/bosh-deployment"
+	jumpboxDeploymentAssets = "vendor/github.com/cppforlife/jumpbox-deployment"
+)
+
+// JumpboxDeploymentFiles lists the files bbl takes from jumpbox-deployment.
+func JumpboxDeploymentFiles(iaas string) []string {
+	return []string{
+		"jumpbox.yml",
+		filepath.Join(iaas, "cpi.yml"),
+	}
+}
+
+// DirectorDeploymentFiles lists the files bbl takes from bosh-deployment.
+func DirectorDeploymentFiles(iaas string) []string {
+	files := []string{
+		"bosh.yml",
+		filepath.Join(iaas, "cpi.yml"),
+		"jumpbox-user.yml",
+		"uaa.yml",
+		"credhub.yml",
+	}
+
+	if iaas == "aws" {
+		files = append(files, "aws/iam-instance-profile.yml")
+	}
+
+	return files
+}
+
+// ValidateDeploymentDir returns an error naming every file in files that is
+// missing from dir.
+func ValidateDeploymentDir(dir string, files []string) error {
+	var missing []string
+	for _, file := range files {
+		if _, err := os.Stat(filepath.Join(dir, file)); err != nil {
+			missing = append(missing, file)
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("%s is missing %s", dir, strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
+// DeploymentSource describes where the deployment manifests were taken from,
+// as dir@commit when dir is a git checkout. It is empty for the embedded
+// manifests.
+func DeploymentSource(dir string) string {
+	if dir == "" {
+		return ""
+	}
+
+	commit := gitCommit(dir)
+	if commit == "" {
+		return dir
+	}
+
+	return fmt.Sprintf("%s@%s", dir, commit)
+}
+
+func gitCommit(dir string) string {
+	gitDir := filepath.Join(dir, ".git")
+
+	head, err := ioutil.ReadFile(filepath.Join(gitDir, "HEAD"))
+	if err != nil {
+		return ""
+	}
+
+	ref := strings.TrimSpace(string(head))
+	if !strings.HasPrefix(ref, "ref: ") {
+		return ref
+	}
+	ref = strings.TrimPrefix(ref, "ref: ")
+
+	commit, err := ioutil.ReadFile(filepath.Join(gitDir, ref))
+	if err == nil {
+		return strings.TrimSpace(string(commit))
+	}
+
+	packedRefs, err := os.Open(filepath.Join(gitDir, "packed-refs"))
+	if err != nil {
+		return ""
+	}
+	defer packedRefs.Close()
+
+	scanner := bufio.NewScanner(packedRefs)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && fields[1] == ref {
+			return fields[0]
+		}
+	}
+
+	return ""
+}
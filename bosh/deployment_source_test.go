@@ -0,0 +1,90 @@
+package bosh_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/cloudfoundry/bosh-bootloader/bosh"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Deployment source", func() {
+	var dir string
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	writeFile := func(path, contents string) {
+		err := os.MkdirAll(filepath.Dir(filepath.Join(dir, path)), os.ModePerm)
+		Expect(err).NotTo(HaveOccurred())
+
+		err = ioutil.WriteFile(filepath.Join(dir, path), []byte(contents), os.ModePerm)
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	Describe("DirectorDeploymentFiles", func() {
+		It("includes the cpi ops file for the iaas", func() {
+			Expect(bosh.DirectorDeploymentFiles("gcp")).To(Equal([]string{
+				"bosh.yml", "gcp/cpi.yml", "jumpbox-user.yml", "uaa.yml", "credhub.yml",
+			}))
+		})
+
+		It("includes the iam instance profile ops file on aws", func() {
+			Expect(bosh.DirectorDeploymentFiles("aws")).To(ContainElement("aws/iam-instance-profile.yml"))
+		})
+	})
+
+	Describe("ValidateDeploymentDir", func() {
+		It("returns nil when every file exists", func() {
+			writeFile("jumpbox.yml", "")
+			writeFile("gcp/cpi.yml", "")
+
+			err := bosh.ValidateDeploymentDir(dir, bosh.JumpboxDeploymentFiles("gcp"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns an error listing the missing files", func() {
+			writeFile("bosh.yml", "")
+			writeFile("uaa.yml", "")
+
+			err := bosh.ValidateDeploymentDir(dir, bosh.DirectorDeploymentFiles("azure"))
+			Expect(err).To(MatchError(dir + " is missing azure/cpi.yml, jumpbox-user.yml, credhub.yml"))
+		})
+	})
+
+	Describe("DeploymentSource", func() {
+		It("is empty for the embedded manifests", func() {
+			Expect(bosh.DeploymentSource("")).To(Equal(""))
+		})
+
+		It("is the dir when it is not a git checkout", func() {
+			Expect(bosh.DeploymentSource(dir)).To(Equal(dir))
+		})
+
+		It("includes the commit of the checked out branch", func() {
+			writeFile(".git/HEAD", "ref: refs/heads/master\n")
+			writeFile(".git/refs/heads/master", "some-commit\n")
+
+			Expect(bosh.DeploymentSource(dir)).To(Equal(dir + "@some-commit"))
+		})
+
+		It("includes the commit of a packed branch", func() {
+			writeFile(".git/HEAD", "ref: refs/heads/master\n")
+			writeFile(".git/packed-refs", "# pack-refs with: peeled fully-peeled\nother-commit refs/heads/other\nsome-commit refs/heads/master\n")
+
+			Expect(bosh.DeploymentSource(dir)).To(Equal(dir + "@some-commit"))
+		})
+
+		It("includes the commit of a detached head", func() {
+			writeFile(".git/HEAD", "some-commit\n")
+
+			Expect(bosh.DeploymentSource(dir)).To(Equal(dir + "@some-commit"))
+		})
+	})
+})
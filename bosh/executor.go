@@ -27,7 +27,24 @@ type InterpolateInput struct {
 	DeploymentVars string
 	BOSHState      map[string]interface{}
 	Variables      string
-	OpsFile        string
+
+	// SizingOps sets the VM type and disk size chosen with bbl up. It is
+	// applied after bbl's own ops files.
+	SizingOps string
+
+	// OpsFiles, VarsFiles and Vars are given by the user and applied after
+	// bbl's own ops files. Vars are key=value pairs.
+	OpsFiles  []string
+	VarsFiles []string
+	Vars      []string
+
+	// SourceDir is a bosh-deployment or jumpbox-deployment checkout to take
+	// the manifests from instead of the ones embedded in bbl.
+	SourceDir string
+
+	// SessionToken is set when the AWS credentials are temporary. The
+	// session_token var is then given to the CPI that create-env runs.
+	SessionToken bool
 }
 
 type InterpolateOutput struct {
@@ -84,10 +101,15 @@ func (e Executor) JumpboxInterpolate(input InterpolateInput) (JumpboxInterpolate
 		contents []byte
 	}
 
+	deploymentFiles, err := e.deploymentFiles(input.SourceDir, jumpboxDeploymentAssets, JumpboxDeploymentFiles(input.IAAS))
+	if err != nil {
+		return JumpboxInterpolateOutput{}, err
+	}
+
 	setupFiles := map[string]setupFile{
 		"manifest": setupFile{
 			path:     filepath.Join(input.DeploymentDir, "jumpbox.yml"),
-			contents: MustAsset("vendor/github.com/cppforlife/jumpbox-deployment/jumpbox.yml"),
+			contents: deploymentFiles["jumpbox.yml"],
 		},
 		"vars-file": setupFile{
 			path:     filepath.Join(input.VarsDir, "jumpbox-deployment-vars.yml"),
@@ -95,7 +117,7 @@ func (e Executor) JumpboxInterpolate(input InterpolateInput) (JumpboxInterpolate
 		},
 		"cpi": setupFile{
 			path:     filepath.Join(input.DeploymentDir, "cpi.yml"),
-			contents: MustAsset(filepath.Join("vendor/github.com/cppforlife/jumpbox-deployment", input.IAAS, "cpi.yml")),
+			contents: deploymentFiles[filepath.Join(input.IAAS, "cpi.yml")],
 		},
 		"vars-store": setupFile{
 			path:     filepath.Join(input.VarsDir, "jumpbox-variables.yml"),
@@ -103,6 +125,28 @@ func (e Executor) JumpboxInterpolate(input InterpolateInput) (JumpboxInterpolate
 		},
 	}
 
+	var opsFiles []setupFile
+	if input.IAAS == "aws" && input.SessionToken {
+		opsFiles = append(opsFiles, setupFile{
+			path:     filepath.Join(input.DeploymentDir, "aws-session-token-ops.yml"),
+			contents: []byte(AWSSessionTokenOps),
+		})
+	}
+
+	if input.SizingOps != "" {
+		opsFiles = append(opsFiles, setupFile{
+			path:     filepath.Join(input.DeploymentDir, "jumpbox-sizing-ops.yml"),
+			contents: []byte(input.SizingOps),
+		})
+	}
+
+	for i, contents := range input.OpsFiles {
+		opsFiles = append(opsFiles, setupFile{
+			path:     filepath.Join(input.VarsDir, fmt.Sprintf("jumpbox-user-ops-file-%d.yml", i)),
+			contents: []byte(contents),
+		})
+	}
+
 	for _, f := range setupFiles {
 		err := e.writeFile(f.path, f.contents, os.ModePerm)
 		if err != nil {
@@ -110,6 +154,13 @@ func (e Executor) JumpboxInterpolate(input InterpolateInput) (JumpboxInterpolate
 		}
 	}
 
+	for _, f := range opsFiles {
+		err := e.writeFile(f.path, f.contents, os.ModePerm)
+		if err != nil {
+			return JumpboxInterpolateOutput{}, fmt.Errorf("write file: %s", err) //not tested
+		}
+	}
+
 	args := []string{
 		"interpolate", setupFiles["manifest"].path,
 		"--var-errs",
@@ -118,8 +169,12 @@ func (e Executor) JumpboxInterpolate(input InterpolateInput) (JumpboxInterpolate
 		"-o", setupFiles["cpi"].path,
 	}
 
+	for _, f := range opsFiles {
+		args = append(args, "-o", f.path)
+	}
+
 	buffer := bytes.NewBuffer([]byte{})
-	err := e.command.Run(buffer, input.VarsDir, args)
+	err = e.command.Run(buffer, input.VarsDir, args)
 	if err != nil {
 		return JumpboxInterpolateOutput{}, fmt.Errorf("Jumpbox interpolate: %s: %s", err, buffer)
 	}
@@ -141,10 +196,15 @@ func (e Executor) DirectorInterpolate(input InterpolateInput) (InterpolateOutput
 		contents []byte
 	}
 
+	deploymentFiles, err := e.deploymentFiles(input.SourceDir, boshDeploymentAssets, DirectorDeploymentFiles(input.IAAS))
+	if err != nil {
+		return InterpolateOutput{}, err
+	}
+
 	setupFiles := map[string]setupFile{
 		"manifest": setupFile{
 			path:     filepath.Join(input.DeploymentDir, "bosh.yml"),
-			contents: MustAsset("vendor/github.com/cloudfoundry/bosh-deployment/bosh.yml"),
+			contents: deploymentFiles["bosh.yml"],
 		},
 		"vars-file": setupFile{
 			path:     filepath.Join(input.VarsDir, "director-deployment-vars.yml"),
@@ -154,28 +214,24 @@ func (e Executor) DirectorInterpolate(input InterpolateInput) (InterpolateOutput
 			path:     filepath.Join(input.VarsDir, "director-variables.yml"),
 			contents: []byte(input.Variables),
 		},
-		"user-ops": setupFile{
-			path:     filepath.Join(input.VarsDir, "user-ops-file.yml"),
-			contents: []byte(input.OpsFile),
-		},
 	}
 
 	opsFiles := []setupFile{
 		setupFile{
 			path:     filepath.Join(input.DeploymentDir, "cpi.yml"),
-			contents: MustAsset(filepath.Join("vendor/github.com/cloudfoundry/bosh-deployment", input.IAAS, "cpi.yml")),
+			contents: deploymentFiles[filepath.Join(input.IAAS, "cpi.yml")],
 		},
 		setupFile{
 			path:     filepath.Join(input.DeploymentDir, "jumpbox-user.yml"),
-			contents: MustAsset("vendor/github.com/cloudfoundry/bosh-deployment/jumpbox-user.yml"),
+			contents: deploymentFiles["jumpbox-user.yml"],
 		},
 		setupFile{
 			path:     filepath.Join(input.DeploymentDir, "uaa.yml"),
-			contents: MustAsset("vendor/github.com/cloudfoundry/bosh-deployment/uaa.yml"),
+			contents: deploymentFiles["uaa.yml"],
 		},
 		setupFile{
 			path:     filepath.Join(input.DeploymentDir, "credhub.yml"),
-			contents: MustAsset("vendor/github.com/cloudfoundry/bosh-deployment/credhub.yml"),
+			contents: deploymentFiles["credhub.yml"],
 		},
 	}
 
@@ -193,12 +249,41 @@ func (e Executor) DirectorInterpolate(input InterpolateInput) (InterpolateOutput
 			},
 			setupFile{
 				path:     filepath.Join(input.DeploymentDir, "iam-instance-profile.yml"),
-				contents: MustAsset("vendor/github.com/cloudfoundry/bosh-deployment/aws/iam-instance-profile.yml"),
+				contents: deploymentFiles["aws/iam-instance-profile.yml"],
 			},
 			setupFile{
 				path:     filepath.Join(input.DeploymentDir, "aws-bosh-director-encrypt-disk-ops.yml"),
 				contents: []byte(AWSEncryptDiskOps),
 			})
+
+		if input.SessionToken {
+			opsFiles = append(opsFiles, setupFile{
+				path:     filepath.Join(input.DeploymentDir, "aws-session-token-ops.yml"),
+				contents: []byte(AWSSessionTokenOps),
+			})
+		}
+	}
+
+	if input.SizingOps != "" {
+		opsFiles = append(opsFiles, setupFile{
+			path:     filepath.Join(input.DeploymentDir, "bosh-director-sizing-ops.yml"),
+			contents: []byte(input.SizingOps),
+		})
+	}
+
+	for i, contents := range input.OpsFiles {
+		opsFiles = append(opsFiles, setupFile{
+			path:     filepath.Join(input.VarsDir, fmt.Sprintf("user-ops-file-%d.yml", i)),
+			contents: []byte(contents),
+		})
+	}
+
+	var varsFiles []setupFile
+	for i, contents := range input.VarsFiles {
+		varsFiles = append(varsFiles, setupFile{
+			path:     filepath.Join(input.VarsDir, fmt.Sprintf("user-vars-file-%d.yml", i)),
+			contents: []byte(contents),
+		})
 	}
 
 	for _, f := range setupFiles {
@@ -208,7 +293,7 @@ func (e Executor) DirectorInterpolate(input InterpolateInput) (InterpolateOutput
 		}
 	}
 
-	for _, f := range opsFiles {
+	for _, f := range append(opsFiles, varsFiles...) {
 		err := e.writeFile(f.path, f.contents, os.ModePerm)
 		if err != nil {
 			return InterpolateOutput{}, fmt.Errorf("write file: %s", err) //not tested
@@ -218,41 +303,35 @@ func (e Executor) DirectorInterpolate(input InterpolateInput) (InterpolateOutput
 	var args = []string{
 		"interpolate", setupFiles["manifest"].path,
 		"--var-errs",
-		"--var-errs-unused",
+	}
+
+	// User vars files often hold more variables than their ops files use, so
+	// unused variables are only an error when all of them come from bbl.
+	if len(input.VarsFiles) == 0 && len(input.Vars) == 0 {
+		args = append(args, "--var-errs-unused")
+	}
+
+	args = append(args,
 		"--vars-store", setupFiles["vars-store"].path,
 		"--vars-file", setupFiles["vars-file"].path,
-	}
+	)
 
 	for _, f := range opsFiles {
 		args = append(args, "-o", f.path)
 	}
 
-	buffer := bytes.NewBuffer([]byte{})
-	err := e.command.Run(buffer, input.VarsDir, args)
-	if err != nil {
-		return InterpolateOutput{}, err
+	for _, f := range varsFiles {
+		args = append(args, "-l", f.path)
 	}
 
-	if input.OpsFile != "" {
-		err = e.writeFile(setupFiles["manifest"].path, buffer.Bytes(), os.ModePerm)
-		if err != nil {
-			//not tested
-			return InterpolateOutput{}, err
-		}
-
-		args = []string{
-			"interpolate", setupFiles["manifest"].path,
-			"--var-errs",
-			"--vars-store", setupFiles["vars-store"].path,
-			"--vars-file", setupFiles["vars-file"].path,
-			"-o", filepath.Join(input.VarsDir, "user-ops-file.yml"),
-		}
+	for _, v := range input.Vars {
+		args = append(args, "-v", v)
+	}
 
-		buffer = bytes.NewBuffer([]byte{})
-		err = e.command.Run(buffer, input.VarsDir, args)
-		if err != nil {
-			return InterpolateOutput{}, err
-		}
+	buffer := bytes.NewBuffer([]byte{})
+	err = e.command.Run(buffer, input.VarsDir, args)
+	if err != nil {
+		return InterpolateOutput{}, err
 	}
 
 	varsStore, err := e.readFile(setupFiles["vars-store"].path)
@@ -266,6 +345,32 @@ func (e Executor) DirectorInterpolate(input InterpolateInput) (InterpolateOutput
 	}, nil
 }
 
+// deploymentFiles reads files from the checkout in sourceDir, or from the
+// embedded assets under assetsDir when no checkout was given.
+func (e Executor) deploymentFiles(sourceDir, assetsDir string, files []string) (map[string][]byte, error) {
+	contents := map[string][]byte{}
+
+	for _, file := range files {
+		var (
+			fileContents []byte
+			err          error
+		)
+
+		if sourceDir == "" {
+			fileContents, err = Asset(filepath.Join(assetsDir, file))
+		} else {
+			fileContents, err = e.readFile(filepath.Join(sourceDir, file))
+		}
+		if err != nil {
+			return nil, fmt.Errorf("Read deployment file: %s", err)
+		}
+
+		contents[file] = fileContents
+	}
+
+	return contents, nil
+}
+
 func (e Executor) CreateEnv(createEnvInput CreateEnvInput) (CreateEnvOutput, error) {
 	err := e.writePreviousFiles(createEnvInput.State, createEnvInput.Variables, createEnvInput.Manifest, createEnvInput.Directory, createEnvInput.Deployment)
 	if err != nil {
@@ -7,6 +7,7 @@ import (
 	"io"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 
 	"github.com/cloudfoundry/bosh-bootloader/bosh"
 	"github.com/cloudfoundry/bosh-bootloader/fakes"
@@ -50,7 +51,6 @@ var _ = Describe("Executor", func() {
 					"key": "value",
 				},
 				Variables: "key: value",
-				OpsFile:   "some-ops-file",
 			}
 
 			executor = bosh.NewExecutor(cmd, ioutil.ReadFile, json.Unmarshal, json.Marshal, ioutil.WriteFile)
@@ -58,7 +58,6 @@ var _ = Describe("Executor", func() {
 
 		It("interpolates the jumpbox and bosh manifests", func() {
 			interpolateInput.DeploymentVars = "internal_cidr: 10.0.0.0/24"
-			interpolateInput.OpsFile = ""
 
 			jumpboxInterpolateOutput, err := executor.JumpboxInterpolate(interpolateInput)
 			Expect(err).NotTo(HaveOccurred())
@@ -82,6 +81,121 @@ var _ = Describe("Executor", func() {
 			Expect(jumpboxInterpolateOutput.Variables).To(gomegamatchers.MatchYAML("key: value"))
 		})
 
+		Context("when user ops files are provided", func() {
+			It("applies them after the cpi ops file", func() {
+				interpolateInput.OpsFiles = []string{"some-ops-file", "some-other-ops-file"}
+
+				_, err := executor.JumpboxInterpolate(interpolateInput)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, _, args := cmd.RunArgsForCall(0)
+				Expect(args).To(Equal([]string{
+					"interpolate", fmt.Sprintf("%s/jumpbox.yml", deploymentDir),
+					"--var-errs",
+					"--vars-store", fmt.Sprintf("%s/jumpbox-variables.yml", varsDir),
+					"--vars-file", fmt.Sprintf("%s/jumpbox-deployment-vars.yml", varsDir),
+					"-o", fmt.Sprintf("%s/cpi.yml", deploymentDir),
+					"-o", fmt.Sprintf("%s/jumpbox-user-ops-file-0.yml", varsDir),
+					"-o", fmt.Sprintf("%s/jumpbox-user-ops-file-1.yml", varsDir),
+				}))
+
+				contents, err := ioutil.ReadFile(filepath.Join(varsDir, "jumpbox-user-ops-file-1.yml"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(contents)).To(Equal("some-other-ops-file"))
+			})
+		})
+
+		Context("when sizing ops are provided", func() {
+			It("applies them before the user ops files", func() {
+				interpolateInput.SizingOps = "some-sizing-ops"
+				interpolateInput.OpsFiles = []string{"some-ops-file"}
+
+				_, err := executor.JumpboxInterpolate(interpolateInput)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, _, args := cmd.RunArgsForCall(0)
+				Expect(args).To(Equal([]string{
+					"interpolate", fmt.Sprintf("%s/jumpbox.yml", deploymentDir),
+					"--var-errs",
+					"--vars-store", fmt.Sprintf("%s/jumpbox-variables.yml", varsDir),
+					"--vars-file", fmt.Sprintf("%s/jumpbox-deployment-vars.yml", varsDir),
+					"-o", fmt.Sprintf("%s/cpi.yml", deploymentDir),
+					"-o", fmt.Sprintf("%s/jumpbox-sizing-ops.yml", deploymentDir),
+					"-o", fmt.Sprintf("%s/jumpbox-user-ops-file-0.yml", varsDir),
+				}))
+
+				contents, err := ioutil.ReadFile(filepath.Join(deploymentDir, "jumpbox-sizing-ops.yml"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(contents)).To(Equal("some-sizing-ops"))
+			})
+		})
+
+		Context("when the aws credentials come with a session token", func() {
+			It("gives the session token to the cpi", func() {
+				interpolateInput.SessionToken = true
+
+				_, err := executor.JumpboxInterpolate(interpolateInput)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, _, args := cmd.RunArgsForCall(0)
+				Expect(args).To(Equal([]string{
+					"interpolate", fmt.Sprintf("%s/jumpbox.yml", deploymentDir),
+					"--var-errs",
+					"--vars-store", fmt.Sprintf("%s/jumpbox-variables.yml", varsDir),
+					"--vars-file", fmt.Sprintf("%s/jumpbox-deployment-vars.yml", varsDir),
+					"-o", fmt.Sprintf("%s/cpi.yml", deploymentDir),
+					"-o", fmt.Sprintf("%s/aws-session-token-ops.yml", deploymentDir),
+				}))
+
+				contents, err := ioutil.ReadFile(filepath.Join(deploymentDir, "aws-session-token-ops.yml"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(contents)).To(Equal(bosh.AWSSessionTokenOps))
+			})
+		})
+
+		Context("when a jumpbox-deployment checkout is given", func() {
+			var sourceDir string
+
+			BeforeEach(func() {
+				var err error
+				sourceDir, err = ioutil.TempDir("", "")
+				Expect(err).NotTo(HaveOccurred())
+
+				err = os.MkdirAll(filepath.Join(sourceDir, "aws"), os.ModePerm)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = ioutil.WriteFile(filepath.Join(sourceDir, "jumpbox.yml"), []byte("some-jumpbox-manifest"), os.ModePerm)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = ioutil.WriteFile(filepath.Join(sourceDir, "aws", "cpi.yml"), []byte("some-cpi-ops"), os.ModePerm)
+				Expect(err).NotTo(HaveOccurred())
+
+				interpolateInput.SourceDir = sourceDir
+			})
+
+			It("interpolates the manifest and ops files from the checkout", func() {
+				_, err := executor.JumpboxInterpolate(interpolateInput)
+				Expect(err).NotTo(HaveOccurred())
+
+				manifest, err := ioutil.ReadFile(filepath.Join(deploymentDir, "jumpbox.yml"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(manifest)).To(Equal("some-jumpbox-manifest"))
+
+				cpiOps, err := ioutil.ReadFile(filepath.Join(deploymentDir, "cpi.yml"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(cpiOps)).To(Equal("some-cpi-ops"))
+			})
+
+			It("returns an error when a file is missing from the checkout", func() {
+				err := os.Remove(filepath.Join(sourceDir, "aws", "cpi.yml"))
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = executor.JumpboxInterpolate(interpolateInput)
+				Expect(err).To(MatchError(ContainSubstring("Read deployment file: ")))
+				Expect(cmd.RunCallCount()).To(Equal(0))
+			})
+		})
+
 		Describe("failure cases", func() {
 			Context("when trying to run a command fails", func() {
 				BeforeEach(func() {
@@ -146,7 +260,6 @@ var _ = Describe("Executor", func() {
 					"key": "value",
 				},
 				Variables: "key: value",
-				OpsFile:   "some-ops-file",
 			}
 
 			executor = bosh.NewExecutor(cmd, ioutil.ReadFile, json.Unmarshal, json.Marshal, ioutil.WriteFile)
@@ -169,7 +282,7 @@ var _ = Describe("Executor", func() {
 				interpolateOutput, err := executor.DirectorInterpolate(azureInterpolateInput)
 				Expect(err).NotTo(HaveOccurred())
 
-				Expect(cmd.RunCallCount()).To(Equal(2))
+				Expect(cmd.RunCallCount()).To(Equal(1))
 
 				expectedArgs := append([]string{
 					"interpolate", fmt.Sprintf("%s/bosh.yml", deploymentDir),
@@ -186,17 +299,6 @@ var _ = Describe("Executor", func() {
 				_, _, args := cmd.RunArgsForCall(0)
 				Expect(args).To(Equal(expectedArgs))
 
-				expectedArgs = append([]string{
-					"interpolate", fmt.Sprintf("%s/bosh.yml", deploymentDir),
-					"--var-errs",
-					"--vars-store", fmt.Sprintf("%s/director-variables.yml", varsDir),
-					"--vars-file", fmt.Sprintf("%s/director-deployment-vars.yml", varsDir),
-					"-o", fmt.Sprintf("%s/user-ops-file.yml", varsDir),
-				})
-
-				_, _, args = cmd.RunArgsForCall(1)
-				Expect(args).To(Equal(expectedArgs))
-
 				Expect(interpolateOutput.Manifest).To(Equal("some-manifest"))
 				Expect(interpolateOutput.Variables).To(Equal("key: value"))
 			})
@@ -217,7 +319,6 @@ var _ = Describe("Executor", func() {
 
 			It("interpolates the jumpbox and bosh manifests", func() {
 				awsInterpolateInput.DeploymentVars = "internal_cidr: 10.0.0.0/24"
-				awsInterpolateInput.OpsFile = ""
 
 				interpolateOutput, err := executor.DirectorInterpolate(awsInterpolateInput)
 				Expect(err).NotTo(HaveOccurred())
@@ -244,6 +345,66 @@ var _ = Describe("Executor", func() {
 
 				Expect(interpolateOutput.Manifest).To(Equal("some-manifest"))
 			})
+
+			Context("when the aws credentials come with a session token", func() {
+				It("gives the session token to the cpi create-env runs", func() {
+					awsInterpolateInput.SessionToken = true
+
+					_, err := executor.DirectorInterpolate(awsInterpolateInput)
+					Expect(err).NotTo(HaveOccurred())
+
+					_, _, args := cmd.RunArgsForCall(0)
+					Expect(args[len(args)-2:]).To(Equal([]string{
+						"-o", fmt.Sprintf("%s/aws-session-token-ops.yml", deploymentDir),
+					}))
+
+					contents, err := ioutil.ReadFile(filepath.Join(deploymentDir, "aws-session-token-ops.yml"))
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(contents)).To(Equal(bosh.AWSSessionTokenOps))
+				})
+			})
+
+			Context("when a bosh-deployment checkout is given", func() {
+				BeforeEach(func() {
+					sourceDir, err := ioutil.TempDir("", "")
+					Expect(err).NotTo(HaveOccurred())
+
+					err = os.MkdirAll(filepath.Join(sourceDir, "aws"), os.ModePerm)
+					Expect(err).NotTo(HaveOccurred())
+
+					for _, file := range bosh.DirectorDeploymentFiles("aws") {
+						err = ioutil.WriteFile(filepath.Join(sourceDir, file), []byte("checkout "+file), os.ModePerm)
+						Expect(err).NotTo(HaveOccurred())
+					}
+
+					awsInterpolateInput.SourceDir = sourceDir
+				})
+
+				It("interpolates the manifest and ops files from the checkout", func() {
+					_, err := executor.DirectorInterpolate(awsInterpolateInput)
+					Expect(err).NotTo(HaveOccurred())
+
+					for file, expected := range map[string]string{
+						"bosh.yml":                 "checkout bosh.yml",
+						"cpi.yml":                  "checkout aws/cpi.yml",
+						"uaa.yml":                  "checkout uaa.yml",
+						"iam-instance-profile.yml": "checkout aws/iam-instance-profile.yml",
+					} {
+						contents, err := ioutil.ReadFile(filepath.Join(deploymentDir, file))
+						Expect(err).NotTo(HaveOccurred())
+						Expect(string(contents)).To(Equal(expected))
+					}
+				})
+
+				It("returns an error when a file is missing from the checkout", func() {
+					err := os.Remove(filepath.Join(awsInterpolateInput.SourceDir, "credhub.yml"))
+					Expect(err).NotTo(HaveOccurred())
+
+					_, err = executor.DirectorInterpolate(awsInterpolateInput)
+					Expect(err).To(MatchError(ContainSubstring("Read deployment file: ")))
+					Expect(cmd.RunCallCount()).To(Equal(0))
+				})
+			})
 		})
 
 		Context("gcp", func() {
@@ -261,7 +422,6 @@ var _ = Describe("Executor", func() {
 
 			It("interpolates the jumpbox and bosh manifests", func() {
 				gcpInterpolateInput.DeploymentVars = "internal_cidr: 10.0.0.0/24"
-				gcpInterpolateInput.OpsFile = ""
 
 				interpolateOutput, err := executor.DirectorInterpolate(gcpInterpolateInput)
 				Expect(err).NotTo(HaveOccurred())
@@ -287,60 +447,20 @@ var _ = Describe("Executor", func() {
 				Expect(interpolateOutput.Manifest).To(Equal("some-manifest"))
 			})
 
-			Context("when a user opsfile is provided", func() {
-				It("re-interpolates the bosh manifest", func() {
-					opsfile := `
----
-- type: replace
-path: /networks/name=default/subnets/0/cloud_properties/tags/-
-value: sabeti-bosh-isolation
-`
-					gcpInterpolateInput.OpsFile = opsfile
-
-					manifest := `
----
-networks
-- name: default
-  cloud_properties:
-    tags:
-      - some-tag
-`
-					manifestWithUserOpsFile := `
----
-networks
-- name: default
-  cloud_properties:
-    tags:
-      - some-tag
-      - sabeti-bosh-isolation
-`
-
-					writtenManifest := []byte{}
-					cmd.RunStub = func(stdout io.Writer, workingDirectory string, args []string) error {
-						for _, arg := range args {
-							if arg == fmt.Sprintf("%s/user-ops-file.yml", varsDir) {
-								var err error
-								writtenManifest, err = ioutil.ReadFile(fmt.Sprintf("%s/bosh.yml", deploymentDir))
-								if err != nil {
-									return err
-								}
-								stdout.Write([]byte(manifestWithUserOpsFile))
-								return nil
-							}
-						}
-						stdout.Write([]byte(manifest))
-						return nil
-					}
+			Context("when user ops files, vars files and vars are provided", func() {
+				It("applies them after bbl's ops files in the same interpolate", func() {
+					gcpInterpolateInput.OpsFiles = []string{"some-ops-file", "some-other-ops-file"}
+					gcpInterpolateInput.VarsFiles = []string{"some-vars-file"}
+					gcpInterpolateInput.Vars = []string{"some_var=some-value"}
 
 					interpolateOutput, err := executor.DirectorInterpolate(gcpInterpolateInput)
 					Expect(err).NotTo(HaveOccurred())
 
-					Expect(cmd.RunCallCount()).To(Equal(2))
+					Expect(cmd.RunCallCount()).To(Equal(1))
 
 					expectedArgs := append([]string{
 						"interpolate", fmt.Sprintf("%s/bosh.yml", deploymentDir),
 						"--var-errs",
-						"--var-errs-unused",
 						"--vars-store", fmt.Sprintf("%s/director-variables.yml", varsDir),
 						"--vars-file", fmt.Sprintf("%s/director-deployment-vars.yml", varsDir),
 						"-o", fmt.Sprintf("%s/cpi.yml", deploymentDir),
@@ -348,29 +468,55 @@ networks
 						"-o", fmt.Sprintf("%s/uaa.yml", deploymentDir),
 						"-o", fmt.Sprintf("%s/credhub.yml", deploymentDir),
 						"-o", fmt.Sprintf("%s/gcp-bosh-director-ephemeral-ip-ops.yml", deploymentDir),
+						"-o", fmt.Sprintf("%s/user-ops-file-0.yml", varsDir),
+						"-o", fmt.Sprintf("%s/user-ops-file-1.yml", varsDir),
+						"-l", fmt.Sprintf("%s/user-vars-file-0.yml", varsDir),
+						"-v", "some_var=some-value",
 					})
 
 					_, _, args := cmd.RunArgsForCall(0)
 					Expect(args).To(Equal(expectedArgs))
 
-					expectedArgsWithUserOpsfile := append([]string{
+					for file, expected := range map[string]string{
+						"user-ops-file-0.yml":  "some-ops-file",
+						"user-ops-file-1.yml":  "some-other-ops-file",
+						"user-vars-file-0.yml": "some-vars-file",
+					} {
+						contents, err := ioutil.ReadFile(filepath.Join(varsDir, file))
+						Expect(err).NotTo(HaveOccurred())
+						Expect(string(contents)).To(Equal(expected))
+					}
+
+					Expect(interpolateOutput.Manifest).To(Equal("some-manifest"))
+					Expect(interpolateOutput.Variables).To(Equal("key: value"))
+				})
+			})
+
+			Context("when sizing ops are provided", func() {
+				It("applies them after bbl's ops files", func() {
+					gcpInterpolateInput.SizingOps = "some-sizing-ops"
+
+					_, err := executor.DirectorInterpolate(gcpInterpolateInput)
+					Expect(err).NotTo(HaveOccurred())
+
+					_, _, args := cmd.RunArgsForCall(0)
+					Expect(args).To(Equal([]string{
 						"interpolate", fmt.Sprintf("%s/bosh.yml", deploymentDir),
 						"--var-errs",
+						"--var-errs-unused",
 						"--vars-store", fmt.Sprintf("%s/director-variables.yml", varsDir),
 						"--vars-file", fmt.Sprintf("%s/director-deployment-vars.yml", varsDir),
-						"-o", fmt.Sprintf("%s/user-ops-file.yml", varsDir),
-					})
-
-					_, _, args = cmd.RunArgsForCall(1)
-					Expect(args).To(Equal(expectedArgsWithUserOpsfile))
+						"-o", fmt.Sprintf("%s/cpi.yml", deploymentDir),
+						"-o", fmt.Sprintf("%s/jumpbox-user.yml", deploymentDir),
+						"-o", fmt.Sprintf("%s/uaa.yml", deploymentDir),
+						"-o", fmt.Sprintf("%s/credhub.yml", deploymentDir),
+						"-o", fmt.Sprintf("%s/gcp-bosh-director-ephemeral-ip-ops.yml", deploymentDir),
+						"-o", fmt.Sprintf("%s/bosh-director-sizing-ops.yml", deploymentDir),
+					}))
 
-					opsFileContents, err := ioutil.ReadFile(fmt.Sprintf("%s/user-ops-file.yml", varsDir))
+					contents, err := ioutil.ReadFile(filepath.Join(deploymentDir, "bosh-director-sizing-ops.yml"))
 					Expect(err).NotTo(HaveOccurred())
-					Expect(string(opsFileContents)).To(Equal(opsfile))
-					Expect(string(writtenManifest)).To(Equal(manifest))
-
-					Expect(interpolateOutput.Manifest).To(Equal(manifestWithUserOpsFile))
-					Expect(interpolateOutput.Variables).To(Equal("key: value"))
+					Expect(string(contents)).To(Equal("some-sizing-ops"))
 				})
 			})
 		})
@@ -392,23 +538,6 @@ networks
 				})
 			})
 
-			Context("when trying to run the command to interpolate with the user opsfile fails", func() {
-				BeforeEach(func() {
-					cmd.RunReturnsOnCall(1, errors.New("failed to run command"))
-				})
-
-				It("returns an error", func() {
-					executor = bosh.NewExecutor(cmd, ioutil.ReadFile, json.Unmarshal, json.Marshal, ioutil.WriteFile)
-					_, err := executor.DirectorInterpolate(bosh.InterpolateInput{
-						DeploymentDir: deploymentDir,
-						VarsDir:       varsDir,
-						IAAS:          "aws",
-						OpsFile:       "some-ops-file",
-					})
-					Expect(err).To(MatchError("failed to run command"))
-				})
-			})
-
 			Context("when the variables file fails to be read", func() {
 				It("returns an error", func() {
 					readFileFunc := func(path string) ([]byte, error) {
@@ -2,6 +2,7 @@ package bosh
 
 import (
 	"os"
+	"time"
 
 	"golang.org/x/net/proxy"
 )
@@ -29,3 +30,11 @@ func SetProxySOCKS5(f func(string, string, *proxy.Auth, proxy.Dialer) (proxy.Dia
 func ResetProxySOCKS5() {
 	proxySOCKS5 = proxy.SOCKS5
 }
+
+func SetTimeNow(f func() time.Time) {
+	timeNow = f
+}
+
+func ResetTimeNow() {
+	timeNow = time.Now
+}
@@ -0,0 +1,88 @@
+package bosh
+
+import (
+	"crypto/sha256"
+	"fmt"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// credentialKeys are the CPI properties and deployment vars holding IAAS
+// credentials. Rotating credentials alone does not need a create-env, so they
+// are left out of the fingerprint.
+var credentialKeys = []string{
+	"access_key_id",
+	"secret_access_key",
+	"session_token",
+	"json_key",
+	"gcp_credentials_json",
+	"client_id",
+	"client_secret",
+}
+
+// Fingerprint identifies everything create-env is given for a deployment:
+// the interpolated manifest, the vars-store and the CPI deployment vars. When
+// it is unchanged since the last create-env there is nothing to deploy.
+func Fingerprint(iaas, manifest, variables, deploymentVars string) string {
+	hash := sha256.New()
+	for _, part := range []string{iaas, manifestWithoutCredentials(manifest), variables, varsWithoutCredentials(deploymentVars)} {
+		fmt.Fprintf(hash, "%d:%s", len(part), part)
+	}
+
+	return fmt.Sprintf("%x", hash.Sum(nil))
+}
+
+func manifestWithoutCredentials(manifest string) string {
+	var contents map[string]interface{}
+	err := yaml.Unmarshal([]byte(manifest), &contents)
+	if err != nil || contents == nil {
+		return manifest
+	}
+
+	if cloudProvider, ok := contents["cloud_provider"].(map[interface{}]interface{}); ok {
+		deleteCPICredentials(cloudProvider["properties"])
+	}
+
+	if instanceGroups, ok := contents["instance_groups"].([]interface{}); ok {
+		for _, instanceGroup := range instanceGroups {
+			if group, ok := instanceGroup.(map[interface{}]interface{}); ok {
+				deleteCPICredentials(group["properties"])
+			}
+		}
+	}
+
+	return string(mustMarshal(contents))
+}
+
+func deleteCPICredentials(properties interface{}) {
+	props, ok := properties.(map[interface{}]interface{})
+	if !ok {
+		return
+	}
+
+	for _, cpi := range []string{"aws", "google", "azure"} {
+		if cpiProps, ok := props[cpi].(map[interface{}]interface{}); ok {
+			for _, key := range credentialKeys {
+				delete(cpiProps, key)
+			}
+		}
+	}
+}
+
+func varsWithoutCredentials(deploymentVars string) string {
+	var vars map[string]interface{}
+	err := yaml.Unmarshal([]byte(deploymentVars), &vars)
+	if err != nil || vars == nil {
+		return deploymentVars
+	}
+
+	for _, key := range credentialKeys {
+		delete(vars, key)
+	}
+
+	return string(mustMarshal(vars))
+}
+
+func unchanged(fingerprint, previous string, boshState map[string]interface{}) bool {
+	return previous != "" && previous == fingerprint && len(boshState) > 0
+}
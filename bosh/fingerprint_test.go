@@ -0,0 +1,58 @@
+package bosh_test
+
+import (
+	"strings"
+
+	"github.com/cloudfoundry/bosh-bootloader/bosh"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Fingerprint", func() {
+	It("is the same for the same inputs", func() {
+		Expect(bosh.Fingerprint("gcp", "some-manifest", "some-vars", "some-deployment-vars")).To(Equal(
+			bosh.Fingerprint("gcp", "some-manifest", "some-vars", "some-deployment-vars")))
+	})
+
+	It("changes when any input changes", func() {
+		fingerprint := bosh.Fingerprint("gcp", "some-manifest", "some-vars", "some-deployment-vars")
+
+		Expect(bosh.Fingerprint("aws", "some-manifest", "some-vars", "some-deployment-vars")).NotTo(Equal(fingerprint))
+		Expect(bosh.Fingerprint("gcp", "other-manifest", "some-vars", "some-deployment-vars")).NotTo(Equal(fingerprint))
+		Expect(bosh.Fingerprint("gcp", "some-manifest", "other-vars", "some-deployment-vars")).NotTo(Equal(fingerprint))
+		Expect(bosh.Fingerprint("gcp", "some-manifest", "some-vars", "other-deployment-vars")).NotTo(Equal(fingerprint))
+	})
+
+	It("is the same when only the iaas credentials change", func() {
+		manifest := `cloud_provider:
+  properties:
+    aws: {access_key_id: some-key-id, secret_access_key: some-secret, session_token: some-token, region: some-region}
+instance_groups:
+- name: bosh
+  properties:
+    google: {json_key: some-json-key, project: some-project}
+`
+		deploymentVars := "access_key_id: some-key-id\nsecret_access_key: some-secret\ngcp_credentials_json: some-json\nclient_secret: some-secret\nregion: some-region\n"
+		fingerprint := bosh.Fingerprint("aws", manifest, "some-vars", deploymentVars)
+
+		otherManifest := `cloud_provider:
+  properties:
+    aws: {access_key_id: other-key-id, secret_access_key: other-secret, region: some-region}
+instance_groups:
+- name: bosh
+  properties:
+    google: {json_key: other-json-key, project: some-project}
+`
+		otherDeploymentVars := "access_key_id: other-key-id\nsecret_access_key: other-secret\nsession_token: other-token\nclient_secret: other-secret\nregion: some-region\n"
+		Expect(bosh.Fingerprint("aws", otherManifest, "some-vars", otherDeploymentVars)).To(Equal(fingerprint))
+
+		Expect(bosh.Fingerprint("aws", manifest, "some-vars", "access_key_id: some-key-id\nregion: other-region\n")).NotTo(Equal(fingerprint))
+		Expect(bosh.Fingerprint("aws", strings.Replace(manifest, "project: some-project", "project: other-project", 1), "some-vars", deploymentVars)).NotTo(Equal(fingerprint))
+	})
+
+	It("does not confuse where one input ends and the next begins", func() {
+		Expect(bosh.Fingerprint("gcp", "some-manifest", "some-vars", "")).NotTo(Equal(
+			bosh.Fingerprint("gcp", "some-manifest", "", "some-vars")))
+	})
+})
@@ -0,0 +1,134 @@
+package bosh
+
+import "fmt"
+
+const DEFAULT_INTERNAL_CIDR = "10.0.0.0/16"
+
+var privateRanges = []string{"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16"}
+
+// InternalNetwork lays out the private addresses bbl uses inside the internal
+// CIDR. The CIDR is split into sixteen blocks: the first holds the director
+// subnet and the AWS load balancer subnets, and availability zone n uses block
+// n+1. Requiring an aligned /16 to /19 keeps these blocks from overlapping and
+// the load balancer subnets at least a /27, the smallest AWS ELBs accept.
+type InternalNetwork struct {
+	cidr CIDRBlock
+}
+
+// ParseInternalNetwork validates cidr, defaulting to DEFAULT_INTERNAL_CIDR for
+// environments created before the internal CIDR was configurable.
+func ParseInternalNetwork(cidr string) (InternalNetwork, error) {
+	if cidr == "" {
+		cidr = DEFAULT_INTERNAL_CIDR
+	}
+
+	cidrBlock, err := ParseCIDRBlock(cidr)
+	if err != nil {
+		return InternalNetwork{}, fmt.Errorf("Internal CIDR %s is not valid: %s", cidr, err)
+	}
+
+	if cidrBlock.maskBits < 16 || cidrBlock.maskBits > 19 {
+		return InternalNetwork{}, fmt.Errorf("Internal CIDR %s must be between a /16 and a /19", cidr)
+	}
+
+	if cidrBlock.firstIP.ip%cidrBlock.CIDRSize != 0 {
+		aligned := CIDRBlock{
+			CIDRSize: cidrBlock.CIDRSize,
+			maskBits: cidrBlock.maskBits,
+			firstIP:  cidrBlock.firstIP.Subtract(cidrBlock.firstIP.ip % cidrBlock.CIDRSize),
+		}
+		return InternalNetwork{}, fmt.Errorf("Internal CIDR %s is not aligned to its prefix length, did you mean %s?", cidr, aligned)
+	}
+
+	return InternalNetwork{cidr: cidrBlock}, nil
+}
+
+func (n InternalNetwork) CIDR() CIDRBlock {
+	return n.cidr
+}
+
+func (n InternalNetwork) DirectorSubnet() CIDRBlock {
+	return n.cidr.Subnet(8, 0)
+}
+
+func (n InternalNetwork) Gateway() IP {
+	return n.DirectorSubnet().GetFirstIP().Add(1)
+}
+
+func (n InternalNetwork) JumpboxIP() IP {
+	return n.DirectorSubnet().GetFirstIP().Add(5)
+}
+
+func (n InternalNetwork) DirectorIP() IP {
+	return n.DirectorSubnet().GetFirstIP().Add(6)
+}
+
+func (n InternalNetwork) AZSubnet(index int) CIDRBlock {
+	return n.cidr.Subnet(4, index+1)
+}
+
+// LBSubnet matches the AWS load balancer subnets laid out in lb_subnet.tf.
+func (n InternalNetwork) LBSubnet(index int) CIDRBlock {
+	return n.cidr.Subnet(8, index+2)
+}
+
+// ApplicationGatewaySubnet returns the /24 right after the internal CIDR, which
+// bbl adds to the Azure virtual network for the cf application gateway. An
+// internal CIDR at the end of a private range leaves no private /24 after it.
+func (n InternalNetwork) ApplicationGatewaySubnet() (CIDRBlock, error) {
+	subnet := CIDRBlock{
+		CIDRSize: 256,
+		maskBits: 24,
+		firstIP:  n.cidr.GetLastIP().Add(1),
+	}
+
+	for _, privateRange := range privateRanges {
+		block, err := ParseCIDRBlock(privateRange)
+		if err != nil {
+			return CIDRBlock{}, err //not tested
+		}
+
+		if block.Contains(subnet) {
+			return subnet, n.ValidateOutside("application gateway subnet", subnet)
+		}
+	}
+
+	return CIDRBlock{}, fmt.Errorf("The application gateway subnet %s after the internal CIDR %s is not a private range, use a lower internal CIDR", subnet, n.cidr)
+}
+
+// ValidateLayout checks that the director, load balancer and availability zone
+// subnets for azCount zones do not overlap. The load balancer subnets run into
+// the first availability zone before any subnet runs past the internal CIDR.
+func (n InternalNetwork) ValidateLayout(azCount int) error {
+	type namedRange struct {
+		name  string
+		block CIDRBlock
+	}
+
+	ranges := []namedRange{{"director subnet", n.DirectorSubnet()}}
+	for i := 0; i < azCount; i++ {
+		ranges = append(ranges,
+			namedRange{fmt.Sprintf("load balancer subnet %d", i), n.LBSubnet(i)},
+			namedRange{fmt.Sprintf("availability zone subnet %d", i), n.AZSubnet(i)},
+		)
+	}
+
+	for i, r := range ranges {
+		for _, other := range ranges[:i] {
+			if r.block.Overlaps(other.block) {
+				return fmt.Errorf("Internal CIDR %s cannot hold %d availability zones: the %s %s overlaps the %s %s", n.cidr, azCount, r.name, r.block, other.name, other.block)
+			}
+		}
+	}
+
+	return nil
+}
+
+// ValidateOutside checks that a range bbl places next to the internal CIDR,
+// such as the Azure application gateway subnet, does not overlap it.
+func (n InternalNetwork) ValidateOutside(name string, block CIDRBlock) error {
+	if n.cidr.Overlaps(block) {
+		return fmt.Errorf("The %s %s overlaps the internal CIDR %s", name, block, n.cidr)
+	}
+	return nil
+}
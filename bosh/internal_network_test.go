@@ -0,0 +1,123 @@
+package bosh_test
+
+import (
+	"github.com/cloudfoundry/bosh-bootloader/bosh"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("InternalNetwork", func() {
+	Describe("ParseInternalNetwork", func() {
+		It("defaults to 10.0.0.0/16", func() {
+			network, err := bosh.ParseInternalNetwork("")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(network.CIDR().String()).To(Equal("10.0.0.0/16"))
+			Expect(network.DirectorSubnet().String()).To(Equal("10.0.0.0/24"))
+			Expect(network.Gateway().String()).To(Equal("10.0.0.1"))
+			Expect(network.JumpboxIP().String()).To(Equal("10.0.0.5"))
+			Expect(network.DirectorIP().String()).To(Equal("10.0.0.6"))
+			Expect(network.AZSubnet(0).String()).To(Equal("10.0.16.0/20"))
+			Expect(network.AZSubnet(2).String()).To(Equal("10.0.48.0/20"))
+		})
+
+		It("derives every range from the internal cidr", func() {
+			network, err := bosh.ParseInternalNetwork("192.168.32.0/19")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(network.DirectorSubnet().String()).To(Equal("192.168.32.0/27"))
+			Expect(network.Gateway().String()).To(Equal("192.168.32.1"))
+			Expect(network.JumpboxIP().String()).To(Equal("192.168.32.5"))
+			Expect(network.DirectorIP().String()).To(Equal("192.168.32.6"))
+			Expect(network.AZSubnet(0).String()).To(Equal("192.168.34.0/23"))
+			Expect(network.LBSubnet(0).String()).To(Equal("192.168.32.64/27"))
+		})
+
+		Context("failure cases", func() {
+			It("returns an error when the cidr cannot be parsed", func() {
+				_, err := bosh.ParseInternalNetwork("not-a-cidr")
+				Expect(err).To(MatchError(ContainSubstring("Internal CIDR not-a-cidr is not valid:")))
+			})
+
+			It("returns an error when the cidr is larger than a /16 or smaller than a /19", func() {
+				_, err := bosh.ParseInternalNetwork("10.0.0.0/8")
+				Expect(err).To(MatchError("Internal CIDR 10.0.0.0/8 must be between a /16 and a /19"))
+
+				_, err = bosh.ParseInternalNetwork("10.0.0.0/24")
+				Expect(err).To(MatchError("Internal CIDR 10.0.0.0/24 must be between a /16 and a /19"))
+			})
+
+			It("returns an error when the cidr is not aligned to its prefix length", func() {
+				_, err := bosh.ParseInternalNetwork("10.0.1.0/16")
+				Expect(err).To(MatchError("Internal CIDR 10.0.1.0/16 is not aligned to its prefix length, did you mean 10.0.0.0/16?"))
+			})
+		})
+	})
+
+	Describe("ApplicationGatewaySubnet", func() {
+		It("returns the /24 after the internal cidr", func() {
+			network, err := bosh.ParseInternalNetwork("172.16.0.0/16")
+			Expect(err).NotTo(HaveOccurred())
+
+			subnet, err := network.ApplicationGatewaySubnet()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(subnet.String()).To(Equal("172.17.0.0/24"))
+		})
+
+		It("returns an error when the /24 after the internal cidr is not private", func() {
+			network, err := bosh.ParseInternalNetwork("172.31.0.0/16")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = network.ApplicationGatewaySubnet()
+			Expect(err).To(MatchError("The application gateway subnet 172.32.0.0/24 after the internal CIDR 172.31.0.0/16 is not a private range, use a lower internal CIDR"))
+		})
+	})
+
+	Describe("ValidateLayout", func() {
+		var network bosh.InternalNetwork
+
+		BeforeEach(func() {
+			var err error
+			network, err = bosh.ParseInternalNetwork("10.0.0.0/16")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("accepts as many availability zones as the internal cidr has room for", func() {
+			Expect(network.ValidateLayout(3)).To(Succeed())
+			Expect(network.ValidateLayout(14)).To(Succeed())
+		})
+
+		Context("when the load balancer subnets run into the first availability zone", func() {
+			It("returns an error", func() {
+				err := network.ValidateLayout(15)
+				Expect(err).To(MatchError("Internal CIDR 10.0.0.0/16 cannot hold 15 availability zones: the load balancer subnet 14 10.0.16.0/24 overlaps the availability zone subnet 0 10.0.16.0/20"))
+			})
+		})
+	})
+
+	Describe("ValidateOutside", func() {
+		var network bosh.InternalNetwork
+
+		BeforeEach(func() {
+			var err error
+			network, err = bosh.ParseInternalNetwork("10.0.0.0/16")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("accepts a range next to the internal cidr", func() {
+			block, err := bosh.ParseCIDRBlock("10.1.0.0/24")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(network.ValidateOutside("application gateway subnet", block)).To(Succeed())
+		})
+
+		It("returns an error when the range overlaps the internal cidr", func() {
+			block, err := bosh.ParseCIDRBlock("10.0.255.0/24")
+			Expect(err).NotTo(HaveOccurred())
+
+			err = network.ValidateOutside("application gateway subnet", block)
+			Expect(err).To(MatchError("The application gateway subnet 10.0.255.0/24 overlaps the internal CIDR 10.0.0.0/16"))
+		})
+	})
+})
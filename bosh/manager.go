@@ -15,8 +15,7 @@ var (
 )
 
 const (
-	DIRECTOR_USERNAME    = "admin"
-	DIRECTOR_INTERNAL_IP = "10.0.0.6"
+	DIRECTOR_USERNAME = "admin"
 )
 
 type Manager struct {
@@ -69,6 +68,7 @@ type AWSYAML struct {
 	SubnetID              string   `yaml:"subnet_id,omitempty"`
 	AccessKeyID           string   `yaml:"access_key_id,omitempty"`
 	SecretAccessKey       string   `yaml:"secret_access_key,omitempty"`
+	SessionToken          string   `yaml:"session_token,omitempty"`
 	IAMInstanceProfile    string   `yaml:"iam_instance_profile,omitempty"`
 	DefaultKeyName        string   `yaml:"default_key_name,omitempty"`
 	DefaultSecurityGroups []string `yaml:"default_security_groups,omitempty"`
@@ -143,23 +143,11 @@ func (m *Manager) Version() (string, error) {
 func (m *Manager) CreateJumpbox(state storage.State, terraformOutputs map[string]interface{}) (storage.State, error) {
 	m.logger.Step("creating jumpbox")
 
-	varsDir, err := m.stateStore.GetVarsDir()
-	if err != nil {
-		return storage.State{}, fmt.Errorf("Get vars dir: %s", err)
-	}
-
-	deploymentDir, err := m.stateStore.GetJumpboxDeploymentDir()
+	iaasInputs, err := m.jumpboxInterpolateInput(state, terraformOutputs)
 	if err != nil {
-		return storage.State{}, fmt.Errorf("Get deployment dir: %s", err)
-	}
-
-	iaasInputs := InterpolateInput{
-		DeploymentDir:  deploymentDir,
-		VarsDir:        varsDir,
-		IAAS:           state.IAAS,
-		DeploymentVars: m.GetJumpboxDeploymentVars(state, terraformOutputs),
-		Variables:      state.Jumpbox.Variables,
+		return storage.State{}, err
 	}
+	varsDir := iaasInputs.VarsDir
 
 	interpolateOutputs, err := m.executor.JumpboxInterpolate(iaasInputs)
 	if err != nil {
@@ -171,33 +159,44 @@ func (m *Manager) CreateJumpbox(state storage.State, terraformOutputs map[string
 		return storage.State{}, fmt.Errorf("Marshal yaml: %s", err)
 	}
 
+	fingerprint := Fingerprint(state.IAAS, interpolateOutputs.Manifest, interpolateOutputs.Variables, iaasInputs.DeploymentVars)
+
 	osUnsetenv("BOSH_ALL_PROXY")
-	createEnvOutputs, err := m.executor.CreateEnv(CreateEnvInput{
-		Deployment: "jumpbox",
-		Directory:  varsDir,
-		Manifest:   interpolateOutputs.Manifest,
-		State:      state.Jumpbox.State,
-		Variables:  string(variables),
-	})
-	switch err.(type) {
-	case CreateEnvError:
-		ceErr := err.(CreateEnvError)
-		state.Jumpbox = storage.Jumpbox{
-			Variables: interpolateOutputs.Variables,
-			State:     ceErr.BOSHState(),
-			Manifest:  interpolateOutputs.Manifest,
+	boshState := state.Jumpbox.State
+	if unchanged(fingerprint, state.Jumpbox.Fingerprint, boshState) {
+		m.logger.Step("jumpbox is unchanged, skipping create-env")
+	} else {
+		createEnvOutputs, err := m.executor.CreateEnv(CreateEnvInput{
+			Deployment: "jumpbox",
+			Directory:  varsDir,
+			Manifest:   interpolateOutputs.Manifest,
+			State:      state.Jumpbox.State,
+			Variables:  string(variables),
+		})
+		switch err.(type) {
+		case CreateEnvError:
+			ceErr := err.(CreateEnvError)
+			state.Jumpbox = storage.Jumpbox{
+				Variables: interpolateOutputs.Variables,
+				State:     ceErr.BOSHState(),
+				Manifest:  interpolateOutputs.Manifest,
+			}
+			return storage.State{}, fmt.Errorf("Create jumpbox env: %s", NewManagerCreateError(state, err))
+		case error:
+			return storage.State{}, fmt.Errorf("Create jumpbox env: %s", err)
 		}
-		return storage.State{}, fmt.Errorf("Create jumpbox env: %s", NewManagerCreateError(state, err))
-	case error:
-		return storage.State{}, fmt.Errorf("Create jumpbox env: %s", err)
+		m.logger.Step("created jumpbox")
+		boshState = createEnvOutputs.State
 	}
-	m.logger.Step("created jumpbox")
 
 	state.Jumpbox = storage.Jumpbox{
-		Variables: interpolateOutputs.Variables,
-		State:     createEnvOutputs.State,
-		Manifest:  interpolateOutputs.Manifest,
-		URL:       terraformOutputs["jumpbox_url"].(string),
+		Variables:        interpolateOutputs.Variables,
+		State:            boshState,
+		Manifest:         interpolateOutputs.Manifest,
+		URL:              terraformOutputs["jumpbox_url"].(string),
+		DeploymentSource: DeploymentSource(state.JumpboxDeploymentDir),
+		UserOpsFiles:     state.Jumpbox.UserOpsFiles,
+		Fingerprint:      fingerprint,
 	}
 
 	m.logger.Step("starting socks5 proxy to jumpbox")
@@ -220,49 +219,44 @@ func (m *Manager) CreateJumpbox(state storage.State, terraformOutputs map[string
 func (m *Manager) CreateDirector(state storage.State, terraformOutputs map[string]interface{}) (storage.State, error) {
 	m.logger.Step("creating bosh director")
 
-	varsDir, err := m.stateStore.GetVarsDir()
+	iaasInputs, err := m.directorInterpolateInput(state, terraformOutputs)
 	if err != nil {
-		return storage.State{}, fmt.Errorf("Get vars dir: %s", err)
-	}
-
-	directorDeploymentDir, err := m.stateStore.GetDirectorDeploymentDir()
-	if err != nil {
-		return storage.State{}, fmt.Errorf("Get deployment dir: %s", err)
-	}
-
-	iaasInputs := InterpolateInput{
-		DeploymentDir:  directorDeploymentDir,
-		VarsDir:        varsDir,
-		IAAS:           state.IAAS,
-		DeploymentVars: m.GetDirectorDeploymentVars(state, terraformOutputs),
-		Variables:      state.BOSH.Variables,
-		OpsFile:        state.BOSH.UserOpsFile,
+		return storage.State{}, err
 	}
+	varsDir := iaasInputs.VarsDir
 
 	interpolateOutputs, err := m.executor.DirectorInterpolate(iaasInputs)
 	if err != nil {
 		return storage.State{}, err
 	}
 
-	createEnvOutputs, err := m.executor.CreateEnv(CreateEnvInput{
-		Deployment: "director",
-		Directory:  varsDir,
-		Manifest:   interpolateOutputs.Manifest,
-		State:      state.BOSH.State,
-		Variables:  interpolateOutputs.Variables,
-	})
-
-	switch err.(type) {
-	case CreateEnvError:
-		ceErr := err.(CreateEnvError)
-		state.BOSH = storage.BOSH{
-			Variables: interpolateOutputs.Variables,
-			State:     ceErr.BOSHState(),
-			Manifest:  interpolateOutputs.Manifest,
+	fingerprint := Fingerprint(state.IAAS, interpolateOutputs.Manifest, interpolateOutputs.Variables, iaasInputs.DeploymentVars)
+
+	boshState := state.BOSH.State
+	if unchanged(fingerprint, state.BOSH.Fingerprint, boshState) {
+		m.logger.Step("bosh director is unchanged, skipping create-env")
+	} else {
+		createEnvOutputs, err := m.executor.CreateEnv(CreateEnvInput{
+			Deployment: "director",
+			Directory:  varsDir,
+			Manifest:   interpolateOutputs.Manifest,
+			State:      state.BOSH.State,
+			Variables:  interpolateOutputs.Variables,
+		})
+
+		switch err.(type) {
+		case CreateEnvError:
+			ceErr := err.(CreateEnvError)
+			state.BOSH = storage.BOSH{
+				Variables: interpolateOutputs.Variables,
+				State:     ceErr.BOSHState(),
+				Manifest:  interpolateOutputs.Manifest,
+			}
+			return storage.State{}, NewManagerCreateError(state, err)
+		case error:
+			return storage.State{}, fmt.Errorf("Create director env: %s", err)
 		}
-		return storage.State{}, NewManagerCreateError(state, err)
-	case error:
-		return storage.State{}, fmt.Errorf("Create director env: %s", err)
+		boshState = createEnvOutputs.State
 	}
 
 	directorVars, err := getDirectorVars(interpolateOutputs.Variables)
@@ -270,24 +264,125 @@ func (m *Manager) CreateDirector(state storage.State, terraformOutputs map[strin
 		return storage.State{}, fmt.Errorf("Get director vars: %s", err)
 	}
 
+	network, err := internalNetwork(state)
+	if err != nil {
+		return storage.State{}, err //not tested
+	}
+
 	state.BOSH = storage.BOSH{
 		DirectorName:           fmt.Sprintf("bosh-%s", state.EnvID),
-		DirectorAddress:        fmt.Sprintf("https://%s:25555", DIRECTOR_INTERNAL_IP),
+		DirectorAddress:        fmt.Sprintf("https://%s:25555", network.DirectorIP()),
 		DirectorUsername:       DIRECTOR_USERNAME,
 		DirectorPassword:       directorVars.directorPassword,
 		DirectorSSLCA:          directorVars.directorSSLCA,
 		DirectorSSLCertificate: directorVars.directorSSLCertificate,
 		DirectorSSLPrivateKey:  directorVars.directorSSLPrivateKey,
 		Variables:              interpolateOutputs.Variables,
-		State:                  createEnvOutputs.State,
+		State:                  boshState,
 		Manifest:               interpolateOutputs.Manifest,
-		UserOpsFile:            state.BOSH.UserOpsFile,
+		UserOpsFiles:           state.BOSH.UserOpsFiles,
+		UserVarsFiles:          state.BOSH.UserVarsFiles,
+		UserVars:               state.BOSH.UserVars,
+		DeploymentSource:       DeploymentSource(state.BOSHDeploymentDir),
+		Fingerprint:            fingerprint,
 	}
 
 	m.logger.Step("created bosh director")
 	return state, nil
 }
 
+// InterpolateJumpbox returns the jumpbox manifest that CreateJumpbox would
+// deploy, without deploying it.
+func (m *Manager) InterpolateJumpbox(state storage.State, terraformOutputs map[string]interface{}) (string, error) {
+	iaasInputs, err := m.jumpboxInterpolateInput(state, terraformOutputs)
+	if err != nil {
+		return "", err
+	}
+
+	interpolateOutputs, err := m.executor.JumpboxInterpolate(iaasInputs)
+	if err != nil {
+		return "", fmt.Errorf("Jumpbox interpolate: %s", err)
+	}
+
+	return interpolateOutputs.Manifest, nil
+}
+
+// InterpolateDirector returns the director manifest that CreateDirector would
+// deploy, without deploying it.
+func (m *Manager) InterpolateDirector(state storage.State, terraformOutputs map[string]interface{}) (string, error) {
+	iaasInputs, err := m.directorInterpolateInput(state, terraformOutputs)
+	if err != nil {
+		return "", err
+	}
+
+	interpolateOutputs, err := m.executor.DirectorInterpolate(iaasInputs)
+	if err != nil {
+		return "", fmt.Errorf("Director interpolate: %s", err)
+	}
+
+	return interpolateOutputs.Manifest, nil
+}
+
+func (m *Manager) jumpboxInterpolateInput(state storage.State, terraformOutputs map[string]interface{}) (InterpolateInput, error) {
+	varsDir, err := m.stateStore.GetVarsDir()
+	if err != nil {
+		return InterpolateInput{}, fmt.Errorf("Get vars dir: %s", err)
+	}
+
+	deploymentDir, err := m.stateStore.GetJumpboxDeploymentDir()
+	if err != nil {
+		return InterpolateInput{}, fmt.Errorf("Get deployment dir: %s", err)
+	}
+
+	deploymentVars, err := m.GetJumpboxDeploymentVars(state, terraformOutputs)
+	if err != nil {
+		return InterpolateInput{}, err
+	}
+
+	return InterpolateInput{
+		DeploymentDir:  deploymentDir,
+		VarsDir:        varsDir,
+		IAAS:           state.IAAS,
+		DeploymentVars: deploymentVars,
+		Variables:      state.Jumpbox.Variables,
+		SourceDir:      state.JumpboxDeploymentDir,
+		SizingOps:      JumpboxSizingOps(state.IAAS, state.JumpboxVMType),
+		OpsFiles:       userFileContents(state.Jumpbox.UserOpsFiles),
+		SessionToken:   state.AWS.SessionToken != "",
+	}, nil
+}
+
+func (m *Manager) directorInterpolateInput(state storage.State, terraformOutputs map[string]interface{}) (InterpolateInput, error) {
+	varsDir, err := m.stateStore.GetVarsDir()
+	if err != nil {
+		return InterpolateInput{}, fmt.Errorf("Get vars dir: %s", err)
+	}
+
+	directorDeploymentDir, err := m.stateStore.GetDirectorDeploymentDir()
+	if err != nil {
+		return InterpolateInput{}, fmt.Errorf("Get deployment dir: %s", err)
+	}
+
+	deploymentVars, err := m.GetDirectorDeploymentVars(state, terraformOutputs)
+	if err != nil {
+		return InterpolateInput{}, err
+	}
+
+	return InterpolateInput{
+		DeploymentDir:  directorDeploymentDir,
+		VarsDir:        varsDir,
+		IAAS:           state.IAAS,
+		DeploymentVars: deploymentVars,
+		Variables:      state.BOSH.Variables,
+		SizingOps:      DirectorSizingOps(state.IAAS, state.DirectorVMType, state.DirectorDiskSize),
+		OpsFiles:       userFileContents(state.BOSH.UserOpsFiles),
+		VarsFiles:      userFileContents(state.BOSH.UserVarsFiles),
+		Vars:           state.BOSH.UserVars,
+		SourceDir:      state.BOSHDeploymentDir,
+		SessionToken:   state.AWS.SessionToken != "",
+	}, nil
+}
+
 func (m *Manager) DeleteDirector(state storage.State, terraformOutputs map[string]interface{}) error {
 	varsDir, err := m.stateStore.GetVarsDir()
 	if err != nil {
@@ -305,7 +400,12 @@ func (m *Manager) DeleteDirector(state storage.State, terraformOutputs map[strin
 		IAAS:          state.IAAS,
 		BOSHState:     state.BOSH.State,
 		Variables:     state.BOSH.Variables,
-		OpsFile:       state.BOSH.UserOpsFile,
+		SizingOps:     DirectorSizingOps(state.IAAS, state.DirectorVMType, state.DirectorDiskSize),
+		OpsFiles:      userFileContents(state.BOSH.UserOpsFiles),
+		VarsFiles:     userFileContents(state.BOSH.UserVarsFiles),
+		Vars:          state.BOSH.UserVars,
+		SourceDir:     state.BOSHDeploymentDir,
+		SessionToken:  state.AWS.SessionToken != "",
 	}
 
 	jumpboxPrivateKey, err := getJumpboxPrivateKey(state.Jumpbox.Variables)
@@ -320,7 +420,10 @@ func (m *Manager) DeleteDirector(state storage.State, terraformOutputs map[strin
 
 	osSetenv("BOSH_ALL_PROXY", fmt.Sprintf("socks5://%s", m.socks5Proxy.Addr()))
 
-	iaasInputs.DeploymentVars = m.GetDirectorDeploymentVars(state, terraformOutputs)
+	iaasInputs.DeploymentVars, err = m.GetDirectorDeploymentVars(state, terraformOutputs)
+	if err != nil {
+		return err //not tested
+	}
 
 	interpolateOutputs, err := m.executor.DirectorInterpolate(iaasInputs)
 	if err != nil {
@@ -359,12 +462,21 @@ func (m *Manager) DeleteJumpbox(state storage.State, terraformOutputs map[string
 		return fmt.Errorf("Get deployment dir: %s", err)
 	}
 
+	deploymentVars, err := m.GetJumpboxDeploymentVars(state, terraformOutputs)
+	if err != nil {
+		return err //not tested
+	}
+
 	iaasInputs := InterpolateInput{
 		DeploymentDir:  deploymentDir,
 		VarsDir:        varsDir,
 		IAAS:           state.IAAS,
 		Variables:      state.Jumpbox.Variables,
-		DeploymentVars: m.GetJumpboxDeploymentVars(state, terraformOutputs),
+		DeploymentVars: deploymentVars,
+		SourceDir:      state.JumpboxDeploymentDir,
+		SizingOps:      JumpboxSizingOps(state.IAAS, state.JumpboxVMType),
+		OpsFiles:       userFileContents(state.Jumpbox.UserOpsFiles),
+		SessionToken:   state.AWS.SessionToken != "",
 	}
 
 	interpolateOutputs, err := m.executor.JumpboxInterpolate(iaasInputs)
@@ -391,11 +503,16 @@ func (m *Manager) DeleteJumpbox(state storage.State, terraformOutputs map[string
 	return nil
 }
 
-func (m *Manager) GetJumpboxDeploymentVars(state storage.State, terraformOutputs map[string]interface{}) string {
+func (m *Manager) GetJumpboxDeploymentVars(state storage.State, terraformOutputs map[string]interface{}) (string, error) {
+	network, err := internalNetwork(state)
+	if err != nil {
+		return "", err
+	}
+
 	vars := sharedDeploymentVarsYAML{
-		InternalCIDR: "10.0.0.0/24",
-		InternalGW:   "10.0.0.1",
-		InternalIP:   "10.0.0.5",
+		InternalCIDR: network.DirectorSubnet().String(),
+		InternalGW:   network.Gateway().String(),
+		InternalIP:   network.JumpboxIP().String(),
 		DirectorName: fmt.Sprintf("bosh-%s", state.EnvID),
 		ExternalIP:   getTerraformOutput("external_ip", terraformOutputs),
 	}
@@ -416,6 +533,7 @@ func (m *Manager) GetJumpboxDeploymentVars(state storage.State, terraformOutputs
 			SubnetID:              getTerraformOutput("bosh_subnet_id", terraformOutputs),
 			AccessKeyID:           state.AWS.AccessKeyID,
 			SecretAccessKey:       state.AWS.SecretAccessKey,
+			SessionToken:          state.AWS.SessionToken,
 			IAMInstanceProfile:    getTerraformOutput("bosh_iam_instance_profile", terraformOutputs),
 			DefaultKeyName:        getTerraformOutput("bosh_vms_key_name", terraformOutputs),
 			DefaultSecurityGroups: []string{getTerraformOutput("jumpbox_security_group", terraformOutputs)},
@@ -439,7 +557,7 @@ func (m *Manager) GetJumpboxDeploymentVars(state storage.State, terraformOutputs
 		vars.PrivateKey = getTerraformOutput("bosh_vms_private_key", terraformOutputs)
 	}
 
-	return string(mustMarshal(vars))
+	return string(mustMarshal(vars)), nil
 }
 
 func mustMarshal(yamlStruct interface{}) []byte {
@@ -451,6 +569,24 @@ func mustMarshal(yamlStruct interface{}) []byte {
 	return yamlBytes
 }
 
+func userFileContents(files []storage.UserFile) []string {
+	var contents []string
+	for _, file := range files {
+		contents = append(contents, file.Contents)
+	}
+	return contents
+}
+
+// internalNetwork parses the internal CIDR saved in the state. bbl up
+// validates it before saving it, but the state file can be edited by hand.
+func internalNetwork(state storage.State) (InternalNetwork, error) {
+	network, err := ParseInternalNetwork(state.InternalCIDR)
+	if err != nil {
+		return InternalNetwork{}, fmt.Errorf("Internal network: %s", err)
+	}
+	return network, nil
+}
+
 func getTerraformOutput(key string, outputs map[string]interface{}) string {
 	if value, ok := outputs[key]; ok {
 		return fmt.Sprintf("%s", value)
@@ -458,11 +594,16 @@ func getTerraformOutput(key string, outputs map[string]interface{}) string {
 	return ""
 }
 
-func (m *Manager) GetDirectorDeploymentVars(state storage.State, terraformOutputs map[string]interface{}) string {
+func (m *Manager) GetDirectorDeploymentVars(state storage.State, terraformOutputs map[string]interface{}) (string, error) {
+	network, err := internalNetwork(state)
+	if err != nil {
+		return "", err
+	}
+
 	vars := sharedDeploymentVarsYAML{
-		InternalCIDR: "10.0.0.0/24",
-		InternalGW:   "10.0.0.1",
-		InternalIP:   DIRECTOR_INTERNAL_IP,
+		InternalCIDR: network.DirectorSubnet().String(),
+		InternalGW:   network.Gateway().String(),
+		InternalIP:   network.DirectorIP().String(),
 		DirectorName: fmt.Sprintf("bosh-%s", state.EnvID),
 	}
 
@@ -482,6 +623,7 @@ func (m *Manager) GetDirectorDeploymentVars(state storage.State, terraformOutput
 			SubnetID:              getTerraformOutput("bosh_subnet_id", terraformOutputs),
 			AccessKeyID:           state.AWS.AccessKeyID,
 			SecretAccessKey:       state.AWS.SecretAccessKey,
+			SessionToken:          state.AWS.SessionToken,
 			IAMInstanceProfile:    getTerraformOutput("bosh_iam_instance_profile", terraformOutputs),
 			DefaultKeyName:        getTerraformOutput("bosh_vms_key_name", terraformOutputs),
 			DefaultSecurityGroups: []string{getTerraformOutput("bosh_security_group", terraformOutputs)},
@@ -503,7 +645,7 @@ func (m *Manager) GetDirectorDeploymentVars(state storage.State, terraformOutput
 		}
 	}
 
-	return string(mustMarshal(vars))
+	return string(mustMarshal(vars)), nil
 }
 
 func getJumpboxPrivateKey(v string) (string, error) {
@@ -102,7 +102,9 @@ director_ssl:
 					State: map[string]interface{}{
 						"some-key": "some-value",
 					},
-					UserOpsFile: "some-ops-file",
+					UserOpsFiles:  []storage.UserFile{{Name: "some-ops-file.yml", Contents: "some-ops-file"}},
+					UserVarsFiles: []storage.UserFile{{Name: "some-vars-file.yml", Contents: "some-vars-file"}},
+					UserVars:      []string{"some_var=some-value"},
 				},
 			}
 		})
@@ -148,10 +150,84 @@ gcp_credentials_json: some-credential-json
 				DirectorSSLCA:          "some-ca",
 				DirectorSSLCertificate: "some-certificate",
 				DirectorSSLPrivateKey:  "some-private-key",
-				UserOpsFile:            "some-ops-file",
+				UserOpsFiles:           []storage.UserFile{{Name: "some-ops-file.yml", Contents: "some-ops-file"}},
+				UserVarsFiles:          []storage.UserFile{{Name: "some-vars-file.yml", Contents: "some-vars-file"}},
+				UserVars:               []string{"some_var=some-value"},
+				Fingerprint:            bosh.Fingerprint("gcp", "some-manifest", boshVars, boshExecutor.DirectorInterpolateCall.Receives.InterpolateInput.DeploymentVars),
 			}))
 		})
 
+		Context("when the manifest, variables and deployment vars are unchanged since the last create-env", func() {
+			BeforeEach(func() {
+				_, err := boshManager.CreateDirector(state, terraformOutputs)
+				Expect(err).NotTo(HaveOccurred())
+
+				state.BOSH.Fingerprint = bosh.Fingerprint("gcp", "some-manifest", boshVars, boshExecutor.DirectorInterpolateCall.Receives.InterpolateInput.DeploymentVars)
+				boshExecutor.CreateEnvCall.CallCount = 0
+			})
+
+			It("skips create-env and keeps the bosh state", func() {
+				stateWithDirector, err := boshManager.CreateDirector(state, terraformOutputs)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(boshExecutor.CreateEnvCall.CallCount).To(Equal(0))
+				Expect(logger.StepCall.Messages).To(ContainElement("bosh director is unchanged, skipping create-env"))
+				Expect(stateWithDirector.BOSH.State).To(Equal(map[string]interface{}{"some-key": "some-value"}))
+				Expect(stateWithDirector.BOSH.Fingerprint).To(Equal(state.BOSH.Fingerprint))
+				Expect(stateWithDirector.BOSH.DirectorPassword).To(Equal("some-admin-password"))
+			})
+
+			It("runs create-env when there is no bosh state", func() {
+				state.BOSH.State = nil
+
+				_, err := boshManager.CreateDirector(state, terraformOutputs)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(boshExecutor.CreateEnvCall.CallCount).To(Equal(1))
+			})
+
+			It("runs create-env when the variables have changed", func() {
+				boshExecutor.DirectorInterpolateCall.Returns.Output.Variables = boshVars + "\nsome_password: some-new-password\n"
+
+				_, err := boshManager.CreateDirector(state, terraformOutputs)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(boshExecutor.CreateEnvCall.CallCount).To(Equal(1))
+			})
+
+			It("skips create-env when only the iaas credentials have changed", func() {
+				state.GCP.ServiceAccountKey = "some-other-credential-json"
+
+				_, err := boshManager.CreateDirector(state, terraformOutputs)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(boshExecutor.CreateEnvCall.CallCount).To(Equal(0))
+			})
+		})
+
+		Context("when a bosh-deployment dir is set", func() {
+			It("interpolates the manifest from it and records it as the deployment source", func() {
+				state.BOSHDeploymentDir = "/some/bosh-deployment"
+
+				stateWithDirector, err := boshManager.CreateDirector(state, terraformOutputs)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(boshExecutor.DirectorInterpolateCall.Receives.InterpolateInput.SourceDir).To(Equal("/some/bosh-deployment"))
+				Expect(stateWithDirector.BOSH.DeploymentSource).To(Equal("/some/bosh-deployment"))
+			})
+		})
+
+		Context("when the aws credentials come with a session token", func() {
+			It("interpolates the manifest with the session token", func() {
+				state.AWS.SessionToken = "some-session-token"
+
+				_, err := boshManager.CreateDirector(state, terraformOutputs)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(boshExecutor.DirectorInterpolateCall.Receives.InterpolateInput.SessionToken).To(BeTrue())
+			})
+		})
+
 		Context("when an error occurs", func() {
 			Context("when the executor's interpolate call fails", func() {
 				BeforeEach(func() {
@@ -239,6 +315,7 @@ gcp_credentials_json: some-credential-json
 					State: map[string]interface{}{
 						"some-key": "some-value",
 					},
+					UserOpsFiles: []storage.UserFile{{Name: "some-ops-file.yml", Contents: "some-ops-file"}},
 				},
 			}
 
@@ -304,6 +381,7 @@ gcp_credentials_json: some-credential-json
 				Expect(boshExecutor.JumpboxInterpolateCall.Receives.InterpolateInput.VarsDir).To(Equal("some-bbl-vars-dir"))
 				Expect(boshExecutor.JumpboxInterpolateCall.Receives.InterpolateInput.DeploymentDir).To(Equal("some-jumpbox-deployment-dir"))
 				Expect(boshExecutor.JumpboxInterpolateCall.Receives.InterpolateInput.IAAS).To(Equal("gcp"))
+				Expect(boshExecutor.JumpboxInterpolateCall.Receives.InterpolateInput.OpsFiles).To(Equal([]string{"some-ops-file"}))
 
 				Expect(boshExecutor.CreateEnvCall.Receives.Input.Deployment).To(Equal("jumpbox"))
 				Expect(boshExecutor.CreateEnvCall.Receives.Input.Directory).To(Equal("some-bbl-vars-dir"))
@@ -323,11 +401,76 @@ gcp_credentials_json: some-credential-json
 						State: map[string]interface{}{
 							"some-new-key": "some-new-value",
 						},
+						UserOpsFiles: []storage.UserFile{{Name: "some-ops-file.yml", Contents: "some-ops-file"}},
+						Fingerprint:  bosh.Fingerprint("gcp", "name: jumpbox", "jumpbox_ssh:\n  private_key: some-jumpbox-private-key", deploymentVars),
 					},
 				}))
 			})
 		})
 
+		Context("when the manifest, variables and deployment vars are unchanged since the last create-env", func() {
+			BeforeEach(func() {
+				state.Jumpbox.Fingerprint = bosh.Fingerprint("gcp", "name: jumpbox", "jumpbox_ssh:\n  private_key: some-jumpbox-private-key", deploymentVars)
+			})
+
+			It("skips create-env and still starts the proxy", func() {
+				state, err := boshManager.CreateJumpbox(state, terraformOutputs)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(boshExecutor.CreateEnvCall.CallCount).To(Equal(0))
+				Expect(socks5Proxy.StartCall.CallCount).To(Equal(1))
+				Expect(logger.StepCall.Messages).To(gomegamatchers.ContainSequence([]string{
+					"creating jumpbox",
+					"jumpbox is unchanged, skipping create-env",
+					"starting socks5 proxy to jumpbox",
+				}))
+
+				Expect(state.Jumpbox.State).To(Equal(map[string]interface{}{"some-key": "some-value"}))
+				Expect(state.Jumpbox.URL).To(Equal("some-jumpbox-url"))
+			})
+
+			It("runs create-env when the manifest has changed", func() {
+				boshExecutor.JumpboxInterpolateCall.Returns.Output.Manifest = "name: jumpbox\ninstance_groups: []"
+
+				_, err := boshManager.CreateJumpbox(state, terraformOutputs)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(boshExecutor.CreateEnvCall.CallCount).To(Equal(1))
+			})
+
+			It("skips create-env when only the iaas credentials have changed", func() {
+				state.GCP.ServiceAccountKey = "some-other-credential-json"
+
+				_, err := boshManager.CreateJumpbox(state, terraformOutputs)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(boshExecutor.CreateEnvCall.CallCount).To(Equal(0))
+			})
+		})
+
+		Context("when a jumpbox-deployment dir is set", func() {
+			It("interpolates the manifest from it and records it as the deployment source", func() {
+				state.JumpboxDeploymentDir = "/some/jumpbox-deployment"
+
+				state, err := boshManager.CreateJumpbox(state, terraformOutputs)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(boshExecutor.JumpboxInterpolateCall.Receives.InterpolateInput.SourceDir).To(Equal("/some/jumpbox-deployment"))
+				Expect(state.Jumpbox.DeploymentSource).To(Equal("/some/jumpbox-deployment"))
+			})
+		})
+
+		Context("when the aws credentials come with a session token", func() {
+			It("interpolates the manifest with the session token", func() {
+				state.AWS.SessionToken = "some-session-token"
+
+				_, err := boshManager.CreateJumpbox(state, terraformOutputs)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(boshExecutor.JumpboxInterpolateCall.Receives.InterpolateInput.SessionToken).To(BeTrue())
+			})
+		})
+
 		Context("when an error occurs", func() {
 			Context("when the jumpbox variables cannot be parsed", func() {
 				It("returns an error", func() {
@@ -385,6 +528,98 @@ gcp_credentials_json: some-credential-json
 		})
 	})
 
+	Describe("InterpolateJumpbox", func() {
+		It("returns the interpolated jumpbox manifest without creating the jumpbox", func() {
+			boshExecutor.JumpboxInterpolateCall.Returns.Output = bosh.JumpboxInterpolateOutput{
+				Manifest:  "some-jumpbox-manifest",
+				Variables: jumpboxVars,
+			}
+
+			manifest, err := boshManager.InterpolateJumpbox(storage.State{
+				IAAS:    "gcp",
+				Jumpbox: storage.Jumpbox{Variables: "some-jumpbox-vars"},
+			}, map[string]interface{}{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(manifest).To(Equal("some-jumpbox-manifest"))
+			Expect(boshExecutor.JumpboxInterpolateCall.Receives.InterpolateInput.DeploymentDir).To(Equal("some-jumpbox-deployment-dir"))
+			Expect(boshExecutor.JumpboxInterpolateCall.Receives.InterpolateInput.VarsDir).To(Equal("some-bbl-vars-dir"))
+			Expect(boshExecutor.JumpboxInterpolateCall.Receives.InterpolateInput.Variables).To(Equal("some-jumpbox-vars"))
+			Expect(boshExecutor.CreateEnvCall.CallCount).To(Equal(0))
+		})
+
+		It("passes the jumpbox VM type as an ops file", func() {
+			_, err := boshManager.InterpolateJumpbox(storage.State{
+				IAAS:          "gcp",
+				JumpboxVMType: "n1-standard-2",
+			}, map[string]interface{}{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(boshExecutor.JumpboxInterpolateCall.Receives.InterpolateInput.SizingOps).To(Equal(bosh.JumpboxSizingOps("gcp", "n1-standard-2")))
+		})
+
+		It("returns an error when interpolate fails", func() {
+			boshExecutor.JumpboxInterpolateCall.Returns.Error = errors.New("failed to interpolate")
+
+			_, err := boshManager.InterpolateJumpbox(storage.State{}, map[string]interface{}{})
+			Expect(err).To(MatchError("Jumpbox interpolate: failed to interpolate"))
+		})
+	})
+
+	Describe("InterpolateDirector", func() {
+		It("returns the interpolated director manifest without creating the director", func() {
+			boshExecutor.DirectorInterpolateCall.Returns.Output = bosh.InterpolateOutput{
+				Manifest:  "some-director-manifest",
+				Variables: boshVars,
+			}
+
+			manifest, err := boshManager.InterpolateDirector(storage.State{
+				IAAS: "gcp",
+				BOSH: storage.BOSH{
+					Variables:     "some-director-vars",
+					UserOpsFiles:  []storage.UserFile{{Name: "some-ops-file.yml", Contents: "some-ops-file"}},
+					UserVarsFiles: []storage.UserFile{{Name: "some-vars-file.yml", Contents: "some-vars-file"}},
+					UserVars:      []string{"some_var=some-value"},
+				},
+			}, map[string]interface{}{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(manifest).To(Equal("some-director-manifest"))
+			Expect(boshExecutor.DirectorInterpolateCall.Receives.InterpolateInput.DeploymentDir).To(Equal("some-director-deployment-dir"))
+			Expect(boshExecutor.DirectorInterpolateCall.Receives.InterpolateInput.Variables).To(Equal("some-director-vars"))
+			Expect(boshExecutor.DirectorInterpolateCall.Receives.InterpolateInput.OpsFiles).To(Equal([]string{"some-ops-file"}))
+			Expect(boshExecutor.DirectorInterpolateCall.Receives.InterpolateInput.VarsFiles).To(Equal([]string{"some-vars-file"}))
+			Expect(boshExecutor.DirectorInterpolateCall.Receives.InterpolateInput.Vars).To(Equal([]string{"some_var=some-value"}))
+			Expect(boshExecutor.CreateEnvCall.CallCount).To(Equal(0))
+		})
+
+		It("passes the director sizing as an ops file", func() {
+			_, err := boshManager.InterpolateDirector(storage.State{
+				IAAS:             "aws",
+				DirectorVMType:   "m4.2xlarge",
+				DirectorDiskSize: 128,
+				JumpboxVMType:    "t2.small",
+			}, map[string]interface{}{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(boshExecutor.DirectorInterpolateCall.Receives.InterpolateInput.SizingOps).To(Equal(bosh.DirectorSizingOps("aws", "m4.2xlarge", 128)))
+		})
+
+		It("returns an error when getting the vars dir fails", func() {
+			stateStore.GetVarsDirCall.Returns.Error = errors.New("failed to get vars dir")
+
+			_, err := boshManager.InterpolateDirector(storage.State{}, map[string]interface{}{})
+			Expect(err).To(MatchError("Get vars dir: failed to get vars dir"))
+		})
+
+		It("returns an error when interpolate fails", func() {
+			boshExecutor.DirectorInterpolateCall.Returns.Error = errors.New("failed to interpolate")
+
+			_, err := boshManager.InterpolateDirector(storage.State{}, map[string]interface{}{})
+			Expect(err).To(MatchError("Director interpolate: failed to interpolate"))
+		})
+	})
+
 	Describe("DeleteJumpbox", func() {
 		var (
 			vars          string
@@ -402,14 +637,16 @@ gcp_credentials_json: some-credential-json
 			}
 
 			incomingState = storage.State{
-				IAAS: "some-iaas",
+				IAAS:                 "some-iaas",
+				JumpboxDeploymentDir: "/some/jumpbox-deployment",
 				BOSH: storage.BOSH{
 					Variables: "some-bosh-vars",
 				},
 				Jumpbox: storage.Jumpbox{
-					Manifest:  "some-manifest",
-					State:     jumpboxState,
-					Variables: vars,
+					Manifest:     "some-manifest",
+					State:        jumpboxState,
+					Variables:    vars,
+					UserOpsFiles: []storage.UserFile{{Name: "some-ops-file.yml", Contents: "some-ops-file"}},
 				},
 			}
 		})
@@ -426,6 +663,8 @@ gcp_credentials_json: some-credential-json
 			Expect(boshExecutor.JumpboxInterpolateCall.Receives.InterpolateInput.IAAS).To(Equal("some-iaas"))
 			Expect(boshExecutor.JumpboxInterpolateCall.Receives.InterpolateInput.DeploymentDir).To(Equal("some-jumpbox-deployment-dir"))
 			Expect(boshExecutor.JumpboxInterpolateCall.Receives.InterpolateInput.VarsDir).To(Equal("some-bbl-vars-dir"))
+			Expect(boshExecutor.JumpboxInterpolateCall.Receives.InterpolateInput.SourceDir).To(Equal("/some/jumpbox-deployment"))
+			Expect(boshExecutor.JumpboxInterpolateCall.Receives.InterpolateInput.OpsFiles).To(Equal([]string{"some-ops-file"}))
 			Expect(boshExecutor.DeleteEnvCall.Receives.Input.Deployment).To(Equal("jumpbox"))
 			Expect(boshExecutor.DeleteEnvCall.Receives.Input.Directory).To(Equal("some-bbl-vars-dir"))
 			Expect(boshExecutor.DeleteEnvCall.Receives.Input.Manifest).To(Equal("some-manifest"))
@@ -481,6 +720,7 @@ gcp_credentials_json: some-credential-json
 			socks5Proxy.AddrCall.Returns.Addr = socks5ProxyAddr
 
 			err := boshManager.DeleteDirector(storage.State{
+				BOSHDeploymentDir: "/some/bosh-deployment",
 				Jumpbox: storage.Jumpbox{
 					Variables: "jumpbox_ssh:\n  private_key: some-jumpbox-private-key",
 					URL:       "some-jumpbox-url",
@@ -490,8 +730,10 @@ gcp_credentials_json: some-credential-json
 					State: map[string]interface{}{
 						"key": "value",
 					},
-					Variables:   boshVars,
-					UserOpsFile: "some-ops-file",
+					Variables:     boshVars,
+					UserOpsFiles:  []storage.UserFile{{Name: "some-ops-file.yml", Contents: "some-ops-file"}},
+					UserVarsFiles: []storage.UserFile{{Name: "some-vars-file.yml", Contents: "some-vars-file"}},
+					UserVars:      []string{"some_var=some-value"},
 				},
 			}, map[string]interface{}{})
 			Expect(err).NotTo(HaveOccurred())
@@ -506,10 +748,13 @@ gcp_credentials_json: some-credential-json
 			Expect(boshExecutor.DirectorInterpolateCall.Receives.InterpolateInput).To(Equal(bosh.InterpolateInput{
 				BOSHState:      map[string]interface{}{"key": "value"},
 				Variables:      boshVars,
-				OpsFile:        "some-ops-file",
+				OpsFiles:       []string{"some-ops-file"},
+				VarsFiles:      []string{"some-vars-file"},
+				Vars:           []string{"some_var=some-value"},
 				DeploymentDir:  "some-director-deployment-dir",
 				VarsDir:        "some-bbl-vars-dir",
 				DeploymentVars: "internal_cidr: 10.0.0.0/24\ninternal_gw: 10.0.0.1\ninternal_ip: 10.0.0.6\ndirector_name: bosh-\n",
+				SourceDir:      "/some/bosh-deployment",
 			}))
 			Expect(boshExecutor.DeleteEnvCall.Receives.Input).To(Equal(bosh.DeleteEnvInput{
 				Deployment: "director",
@@ -600,7 +845,7 @@ gcp_credentials_json: some-credential-json
 			})
 
 			It("returns a correct yaml string of bosh deployment variables", func() {
-				vars := boshManager.GetJumpboxDeploymentVars(incomingState, map[string]interface{}{
+				vars, err := boshManager.GetJumpboxDeploymentVars(incomingState, map[string]interface{}{
 					"network_name":                  "some-network",
 					"bosh_subnet_id":                "some-subnetwork",
 					"bosh_subnet_availability_zone": "some-zone",
@@ -610,6 +855,7 @@ gcp_credentials_json: some-credential-json
 					"jumpbox_security_group":        "some-security-group",
 					"external_ip":                   "some-external-ip",
 				})
+				Expect(err).NotTo(HaveOccurred())
 				Expect(vars).To(Equal(`internal_cidr: 10.0.0.0/24
 internal_gw: 10.0.0.1
 internal_ip: 10.0.0.5
@@ -627,6 +873,39 @@ default_security_groups:
 region: some-region
 `))
 			})
+
+			Context("when the credentials come with a session token", func() {
+				It("includes the session token", func() {
+					incomingState.AWS.SessionToken = "some-session-token"
+
+					vars, err := boshManager.GetJumpboxDeploymentVars(incomingState, map[string]interface{}{})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(vars).To(ContainSubstring(`access_key_id: some-access-key-id
+secret_access_key: some-secret-access-key
+session_token: some-session-token
+`))
+				})
+			})
+
+			It("derives the internal network from the internal cidr", func() {
+				incomingState.InternalCIDR = "172.16.0.0/19"
+
+				vars, err := boshManager.GetJumpboxDeploymentVars(incomingState, map[string]interface{}{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(vars).To(ContainSubstring(`internal_cidr: 172.16.0.0/27
+internal_gw: 172.16.0.1
+internal_ip: 172.16.0.5
+`))
+			})
+
+			Context("when the internal cidr is invalid", func() {
+				It("returns an error", func() {
+					incomingState.InternalCIDR = "172.16.0.0/24"
+
+					_, err := boshManager.GetJumpboxDeploymentVars(incomingState, map[string]interface{}{})
+					Expect(err).To(MatchError("Internal network: Internal CIDR 172.16.0.0/24 must be between a /16 and a /19"))
+				})
+			})
 		})
 
 		Context("gcp", func() {
@@ -645,13 +924,14 @@ region: some-region
 			})
 
 			It("returns a correct yaml string of bosh deployment variables", func() {
-				vars := boshManager.GetJumpboxDeploymentVars(incomingState, map[string]interface{}{
+				vars, err := boshManager.GetJumpboxDeploymentVars(incomingState, map[string]interface{}{
 					"network_name":       "some-network",
 					"subnetwork_name":    "some-subnetwork",
 					"bosh_open_tag_name": "some-jumpbox-tag",
 					"jumpbox_tag_name":   "some-jumpbox-fw-tag",
 					"external_ip":        "some-external-ip",
 				})
+				Expect(err).NotTo(HaveOccurred())
 				Expect(vars).To(Equal(`internal_cidr: 10.0.0.0/24
 internal_gw: 10.0.0.1
 internal_ip: 10.0.0.5
@@ -690,7 +970,7 @@ gcp_credentials_json: some-credential-json
 				}
 			})
 			It("returns a correct yaml string of bosh deployment variables", func() {
-				vars := boshManager.GetDirectorDeploymentVars(incomingState, map[string]interface{}{
+				vars, err := boshManager.GetDirectorDeploymentVars(incomingState, map[string]interface{}{
 					"network_name":           "some-network",
 					"subnetwork_name":        "some-subnetwork",
 					"bosh_open_tag_name":     "some-jumpbox-tag",
@@ -700,6 +980,7 @@ gcp_credentials_json: some-credential-json
 					"external_ip":            "some-external-ip",
 					"director_address":       "some-director-address",
 				})
+				Expect(err).NotTo(HaveOccurred())
 				Expect(vars).To(Equal(`internal_cidr: 10.0.0.0/24
 internal_gw: 10.0.0.1
 internal_ip: 10.0.0.6
@@ -716,7 +997,8 @@ gcp_credentials_json: some-credential-json
 
 			Context("when terraform outputs are missing", func() {
 				It("returns valid yaml", func() {
-					vars := boshManager.GetDirectorDeploymentVars(incomingState, map[string]interface{}{})
+					vars, err := boshManager.GetDirectorDeploymentVars(incomingState, map[string]interface{}{})
+					Expect(err).NotTo(HaveOccurred())
 					Expect(vars).To(Equal(`internal_cidr: 10.0.0.0/24
 internal_gw: 10.0.0.1
 internal_ip: 10.0.0.6
@@ -751,7 +1033,7 @@ gcp_credentials_json: some-credential-json
 
 			Context("when terraform was used to standup infrastructure", func() {
 				It("returns a correct yaml string of bosh deployment variables", func() {
-					vars := boshManager.GetDirectorDeploymentVars(incomingState, map[string]interface{}{
+					vars, err := boshManager.GetDirectorDeploymentVars(incomingState, map[string]interface{}{
 						"bosh_iam_instance_profile":     "some-bosh-iam-instance-profile",
 						"bosh_subnet_availability_zone": "some-bosh-subnet-az",
 						"bosh_security_group":           "some-bosh-security-group",
@@ -762,6 +1044,7 @@ gcp_credentials_json: some-credential-json
 						"director_address":              "some-director-address",
 						"kms_key_arn":                   "some-kms-arn",
 					})
+					Expect(err).NotTo(HaveOccurred())
 					Expect(vars).To(Equal(`internal_cidr: 10.0.0.0/24
 internal_gw: 10.0.0.1
 internal_ip: 10.0.0.6
@@ -781,9 +1064,23 @@ kms_key_arn: some-kms-arn
 				})
 			})
 
+			Context("when the credentials come with a session token", func() {
+				It("includes the session token", func() {
+					incomingState.AWS.SessionToken = "some-session-token"
+
+					vars, err := boshManager.GetDirectorDeploymentVars(incomingState, map[string]interface{}{})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(vars).To(ContainSubstring(`access_key_id: some-access-key-id
+secret_access_key: some-secret-access-key
+session_token: some-session-token
+`))
+				})
+			})
+
 			Context("when terraform outputs are missing", func() {
 				It("returns valid yaml", func() {
-					vars := boshManager.GetDirectorDeploymentVars(incomingState, map[string]interface{}{})
+					vars, err := boshManager.GetDirectorDeploymentVars(incomingState, map[string]interface{}{})
+					Expect(err).NotTo(HaveOccurred())
 					Expect(vars).To(Equal(`internal_cidr: 10.0.0.0/24
 internal_gw: 10.0.0.1
 internal_ip: 10.0.0.6
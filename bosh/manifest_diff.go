@@ -0,0 +1,213 @@
+package bosh
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+const redacted = "((redacted))"
+
+var sensitiveKey = regexp.MustCompile(`(?i)password|secret|private_key|token|credentials|certificate|(^|_)key$|(^|_)ca$`)
+
+// ManifestChange is a single difference between two manifests. Path uses the
+// same syntax as ops files, e.g. /instance_groups/name=bosh/properties/director/name.
+type ManifestChange struct {
+	Type string `json:"type"`
+	Path string `json:"path"`
+	Old  string `json:"old,omitempty"`
+	New  string `json:"new,omitempty"`
+}
+
+func (c ManifestChange) String() string {
+	switch c.Type {
+	case "added":
+		return strings.TrimSuffix(fmt.Sprintf("+ %s: %s", c.Path, c.New), ": ")
+	case "removed":
+		return strings.TrimSuffix(fmt.Sprintf("- %s: %s", c.Path, c.Old), ": ")
+	default:
+		return fmt.Sprintf("~ %s: %s -> %s", c.Path, c.Old, c.New)
+	}
+}
+
+// DiffManifests compares two YAML manifests structurally. Values below keys
+// that look like credentials are redacted.
+func DiffManifests(oldManifest, newManifest string) ([]ManifestChange, error) {
+	var oldNode, newNode interface{}
+
+	err := yaml.Unmarshal([]byte(oldManifest), &oldNode)
+	if err != nil {
+		return nil, fmt.Errorf("Parse previous manifest: %s", err)
+	}
+
+	err = yaml.Unmarshal([]byte(newManifest), &newNode)
+	if err != nil {
+		return nil, fmt.Errorf("Parse new manifest: %s", err)
+	}
+
+	changes := []ManifestChange{}
+	diffNode("", oldNode, newNode, false, &changes)
+
+	return changes, nil
+}
+
+func diffNode(path string, oldNode, newNode interface{}, sensitive bool, changes *[]ManifestChange) {
+	switch oldValue := oldNode.(type) {
+	case map[interface{}]interface{}:
+		newValue, ok := newNode.(map[interface{}]interface{})
+		if !ok {
+			break
+		}
+
+		for _, key := range sortedKeys(oldValue, newValue) {
+			childPath := fmt.Sprintf("%s/%v", path, key)
+			childSensitive := sensitive || sensitiveKey.MatchString(fmt.Sprint(key))
+
+			oldChild, inOld := oldValue[key]
+			newChild, inNew := newValue[key]
+			switch {
+			case !inNew:
+				*changes = append(*changes, ManifestChange{Type: "removed", Path: childPath, Old: formatValue(oldChild, childSensitive)})
+			case !inOld:
+				*changes = append(*changes, ManifestChange{Type: "added", Path: childPath, New: formatValue(newChild, childSensitive)})
+			default:
+				diffNode(childPath, oldChild, newChild, childSensitive, changes)
+			}
+		}
+		return
+	case []interface{}:
+		newValue, ok := newNode.([]interface{})
+		if !ok {
+			break
+		}
+
+		if named(oldValue) && named(newValue) {
+			diffNamedList(path, oldValue, newValue, sensitive, changes)
+			return
+		}
+
+		for i := 0; i < len(oldValue) || i < len(newValue); i++ {
+			childPath := fmt.Sprintf("%s/%d", path, i)
+			switch {
+			case i >= len(newValue):
+				*changes = append(*changes, ManifestChange{Type: "removed", Path: childPath, Old: formatValue(oldValue[i], sensitive)})
+			case i >= len(oldValue):
+				*changes = append(*changes, ManifestChange{Type: "added", Path: childPath, New: formatValue(newValue[i], sensitive)})
+			default:
+				diffNode(childPath, oldValue[i], newValue[i], sensitive, changes)
+			}
+		}
+		return
+	}
+
+	if !reflect.DeepEqual(oldNode, newNode) {
+		if path == "" {
+			path = "/"
+		}
+		*changes = append(*changes, ManifestChange{
+			Type: "changed",
+			Path: path,
+			Old:  formatValue(oldNode, sensitive),
+			New:  formatValue(newNode, sensitive),
+		})
+	}
+}
+
+func diffNamedList(path string, oldValue, newValue []interface{}, sensitive bool, changes *[]ManifestChange) {
+	newByName := map[string]interface{}{}
+	for _, element := range newValue {
+		newByName[elementName(element)] = element
+	}
+
+	oldByName := map[string]interface{}{}
+	for _, element := range oldValue {
+		name := elementName(element)
+		oldByName[name] = element
+
+		childPath := fmt.Sprintf("%s/name=%s", path, name)
+		newElement, ok := newByName[name]
+		if !ok {
+			*changes = append(*changes, ManifestChange{Type: "removed", Path: childPath})
+			continue
+		}
+		diffNode(childPath, element, newElement, sensitive, changes)
+	}
+
+	for _, element := range newValue {
+		name := elementName(element)
+		if _, ok := oldByName[name]; !ok {
+			*changes = append(*changes, ManifestChange{Type: "added", Path: fmt.Sprintf("%s/name=%s", path, name)})
+		}
+	}
+}
+
+func named(list []interface{}) bool {
+	if len(list) == 0 {
+		return false
+	}
+
+	names := map[string]bool{}
+	for _, element := range list {
+		name := elementName(element)
+		if name == "" || names[name] {
+			return false
+		}
+		names[name] = true
+	}
+
+	return true
+}
+
+func elementName(element interface{}) string {
+	m, ok := element.(map[interface{}]interface{})
+	if !ok {
+		return ""
+	}
+
+	name, _ := m["name"].(string)
+	return name
+}
+
+func sortedKeys(maps ...map[interface{}]interface{}) []interface{} {
+	seen := map[string]interface{}{}
+	for _, m := range maps {
+		for key := range m {
+			seen[fmt.Sprint(key)] = key
+		}
+	}
+
+	names := []string{}
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	keys := []interface{}{}
+	for _, name := range names {
+		keys = append(keys, seen[name])
+	}
+
+	return keys
+}
+
+func formatValue(value interface{}, sensitive bool) string {
+	switch value.(type) {
+	case map[interface{}]interface{}, []interface{}:
+		return ""
+	}
+
+	if sensitive {
+		return redacted
+	}
+
+	formatted := fmt.Sprint(value)
+	if strings.Contains(formatted, "\n") {
+		return strings.SplitN(formatted, "\n", 2)[0] + "..."
+	}
+
+	return formatted
+}
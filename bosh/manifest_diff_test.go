@@ -0,0 +1,90 @@
+package bosh_test
+
+import (
+	"github.com/cloudfoundry/bosh-bootloader/bosh"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DiffManifests", func() {
+	It("returns no changes for identical manifests", func() {
+		changes, err := bosh.DiffManifests("name: bosh\n", "name: bosh\n")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(changes).To(BeEmpty())
+	})
+
+	It("reports added, removed and changed values by path", func() {
+		changes, err := bosh.DiffManifests(`
+name: bosh
+instance_groups:
+- name: bosh
+  instances: 1
+  jobs:
+  - name: uaa
+  - name: credhub
+  properties:
+    director:
+      name: old-name
+tags: [a, b]
+`, `
+name: bosh
+instance_groups:
+- name: bosh
+  instances: 1
+  jobs:
+  - name: uaa
+  - name: syslog
+  properties:
+    director:
+      name: new-name
+      workers: 4
+tags: [a]
+`)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(changes).To(Equal([]bosh.ManifestChange{
+			{Type: "removed", Path: "/instance_groups/name=bosh/jobs/name=credhub"},
+			{Type: "added", Path: "/instance_groups/name=bosh/jobs/name=syslog"},
+			{Type: "changed", Path: "/instance_groups/name=bosh/properties/director/name", Old: "old-name", New: "new-name"},
+			{Type: "added", Path: "/instance_groups/name=bosh/properties/director/workers", New: "4"},
+			{Type: "removed", Path: "/tags/1", Old: "b"},
+		}))
+	})
+
+	It("redacts values below credential-like keys", func() {
+		changes, err := bosh.DiffManifests(`
+properties:
+  director_ssl:
+    private_key: old-key
+  admin_password: old-password
+  uaa_url: https://old
+`, `
+properties:
+  director_ssl:
+    private_key: new-key
+  admin_password: new-password
+  uaa_url: https://new
+`)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(changes).To(Equal([]bosh.ManifestChange{
+			{Type: "changed", Path: "/properties/admin_password", Old: "((redacted))", New: "((redacted))"},
+			{Type: "changed", Path: "/properties/director_ssl/private_key", Old: "((redacted))", New: "((redacted))"},
+			{Type: "changed", Path: "/properties/uaa_url", Old: "https://old", New: "https://new"},
+		}))
+	})
+
+	Describe("ManifestChange", func() {
+		It("formats changes for humans", func() {
+			Expect(bosh.ManifestChange{Type: "added", Path: "/a", New: "1"}.String()).To(Equal("+ /a: 1"))
+			Expect(bosh.ManifestChange{Type: "removed", Path: "/b"}.String()).To(Equal("- /b"))
+			Expect(bosh.ManifestChange{Type: "changed", Path: "/c", Old: "1", New: "2"}.String()).To(Equal("~ /c: 1 -> 2"))
+		})
+	})
+
+	It("returns an error for invalid yaml", func() {
+		_, err := bosh.DiffManifests("name: [", "name: bosh")
+		Expect(err).To(MatchError(ContainSubstring("Parse previous manifest:")))
+	})
+})
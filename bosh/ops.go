@@ -20,3 +20,12 @@ const AWSEncryptDiskOps = `---
     encrypted: true
     kms_key_arn: ((kms_key_arn))
 `
+
+// AWSSessionTokenOps gives create-env the token that comes with temporary
+// credentials. The director itself uses its IAM instance profile, so the
+// token never reaches its own CPI.
+const AWSSessionTokenOps = `---
+- type: replace
+  path: /cloud_provider/properties/aws/session_token?
+  value: ((session_token))
+`
@@ -0,0 +1,81 @@
+package bosh
+
+import (
+	"fmt"
+	"regexp"
+)
+
+var vmTypePatterns = map[string]*regexp.Regexp{
+	"aws":   regexp.MustCompile(`^[a-z][a-z0-9-]*\.[a-z0-9]+$`),
+	"gcp":   regexp.MustCompile(`^[a-z][a-z0-9]*(-[a-z0-9]+)+$`),
+	"azure": regexp.MustCompile(`^(Standard|Basic)_[A-Za-z0-9_]+$`),
+}
+
+// vmTypeProperty is the cloud property of the vms resource pool that holds
+// the VM type on each IAAS.
+var vmTypeProperty = map[string]string{
+	"aws":   "instance_type",
+	"gcp":   "machine_type",
+	"azure": "instance_type",
+}
+
+type op struct {
+	Type  string      `yaml:"type"`
+	Path  string      `yaml:"path"`
+	Value interface{} `yaml:"value"`
+}
+
+// ValidateVMType returns an error when vmType is not shaped like a VM type of
+// iaas, such as m4.xlarge, n1-standard-2 or Standard_D2_v2. An empty vmType
+// keeps the default and is valid.
+func ValidateVMType(iaas, vmType string) error {
+	pattern, ok := vmTypePatterns[iaas]
+	if vmType == "" || !ok || pattern.MatchString(vmType) {
+		return nil
+	}
+
+	return fmt.Errorf("%s is not a valid %s VM type", vmType, iaas)
+}
+
+// DirectorSizingOps returns an ops file that replaces the VM type and
+// persistent disk size, in GB, set by the CPI ops file. It is empty when
+// neither is given.
+func DirectorSizingOps(iaas, vmType string, diskSize int) string {
+	ops := vmTypeOps(iaas, vmType)
+
+	if diskSize > 0 {
+		ops = append(ops, op{
+			Type:  "replace",
+			Path:  "/disk_pools/name=disks/disk_size",
+			Value: diskSize * 1024,
+		})
+	}
+
+	return marshalOps(ops)
+}
+
+// JumpboxSizingOps returns an ops file that replaces the VM type set by the
+// CPI ops file. It is empty when no VM type is given.
+func JumpboxSizingOps(iaas, vmType string) string {
+	return marshalOps(vmTypeOps(iaas, vmType))
+}
+
+func vmTypeOps(iaas, vmType string) []op {
+	if vmType == "" {
+		return nil
+	}
+
+	return []op{{
+		Type:  "replace",
+		Path:  fmt.Sprintf("/resource_pools/name=vms/cloud_properties/%s", vmTypeProperty[iaas]),
+		Value: vmType,
+	}}
+}
+
+func marshalOps(ops []op) string {
+	if len(ops) == 0 {
+		return ""
+	}
+
+	return string(mustMarshal(ops))
+}
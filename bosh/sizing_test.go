@@ -0,0 +1,71 @@
+package bosh_test
+
+import (
+	"github.com/cloudfoundry/bosh-bootloader/bosh"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Sizing", func() {
+	Describe("ValidateVMType", func() {
+		DescribeTable("accepts the VM types of each iaas",
+			func(iaas, vmType string) {
+				Expect(bosh.ValidateVMType(iaas, vmType)).To(Succeed())
+			},
+			Entry("aws", "aws", "m4.2xlarge"),
+			Entry("gcp", "gcp", "n1-standard-4"),
+			Entry("gcp custom", "gcp", "custom-4-16384"),
+			Entry("azure", "azure", "Standard_D2_v2"),
+		)
+
+		DescribeTable("rejects VM types of other iaases",
+			func(iaas, vmType, message string) {
+				Expect(bosh.ValidateVMType(iaas, vmType)).To(MatchError(message))
+			},
+			Entry("aws", "aws", "n1-standard-2", "n1-standard-2 is not a valid aws VM type"),
+			Entry("gcp", "gcp", "m4.xlarge", "m4.xlarge is not a valid gcp VM type"),
+			Entry("azure", "azure", "t2.small", "t2.small is not a valid azure VM type"),
+		)
+	})
+
+	Describe("DirectorSizingOps", func() {
+		It("replaces the instance type and the disk size in MB", func() {
+			Expect(bosh.DirectorSizingOps("aws", "m4.2xlarge", 128)).To(MatchYAML(`
+- type: replace
+  path: /resource_pools/name=vms/cloud_properties/instance_type
+  value: m4.2xlarge
+- type: replace
+  path: /disk_pools/name=disks/disk_size
+  value: 131072
+`))
+		})
+
+		It("replaces the machine type on gcp", func() {
+			Expect(bosh.DirectorSizingOps("gcp", "n1-standard-4", 0)).To(MatchYAML(`
+- type: replace
+  path: /resource_pools/name=vms/cloud_properties/machine_type
+  value: n1-standard-4
+`))
+		})
+
+		It("is empty when no sizing is given", func() {
+			Expect(bosh.DirectorSizingOps("aws", "", 0)).To(Equal(""))
+		})
+	})
+
+	Describe("JumpboxSizingOps", func() {
+		It("replaces the instance type", func() {
+			Expect(bosh.JumpboxSizingOps("azure", "Standard_B1s")).To(MatchYAML(`
+- type: replace
+  path: /resource_pools/name=vms/cloud_properties/instance_type
+  value: Standard_B1s
+`))
+		})
+
+		It("is empty when no VM type is given", func() {
+			Expect(bosh.JumpboxSizingOps("gcp", "")).To(Equal(""))
+		})
+	})
+})
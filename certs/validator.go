@@ -10,8 +10,10 @@ import (
 	"io"
 	"io/ioutil"
 	"os"
+	"strings"
 
 	"github.com/cloudfoundry/multierror"
+	"golang.org/x/crypto/pkcs12"
 )
 
 var readAll func(r io.Reader) ([]byte, error) = ioutil.ReadAll
@@ -91,7 +93,72 @@ func (v Validator) Validate(command, certPath, keyPath, chainPath string) error
 	return nil
 }
 
+// ValidatePKCS12 checks that certPath is a PKCS#12 (PFX) file holding a
+// certificate and private key that the password in passwordPath decrypts.
+// Azure application gateways take their certificate in this format.
+func (v Validator) ValidatePKCS12(command, certPath, passwordPath string) error {
+	validateErrors := multierror.NewMultiError(command)
+
+	pfxData, err := validateFile("certificate", "--cert", certPath)
+	if err != nil {
+		validateErrors.Add(err)
+	}
+
+	password, err := validateFile("password", "--key", passwordPath)
+	if err != nil {
+		validateErrors.Add(err)
+	}
+
+	if validateErrors.Length() > 0 {
+		return validateErrors
+	}
+
+	blocks, err := pkcs12.ToPEM(pfxData, strings.TrimSpace(string(password)))
+	if err != nil {
+		validateErrors.Add(fmt.Errorf("failed to decode PFX certificate: %s", err))
+		return validateErrors
+	}
+
+	var hasCertificate, hasKey bool
+	for _, block := range blocks {
+		switch block.Type {
+		case "CERTIFICATE":
+			hasCertificate = true
+		case "PRIVATE KEY":
+			hasKey = true
+		}
+	}
+
+	if !hasCertificate {
+		validateErrors.Add(fmt.Errorf("PFX certificate does not contain a certificate: %q", certPath))
+	}
+
+	if !hasKey {
+		validateErrors.Add(fmt.Errorf("PFX certificate does not contain a private key: %q", certPath))
+	}
+
+	if validateErrors.Length() > 0 {
+		return validateErrors
+	}
+
+	return nil
+}
+
 func validateFileAndFormat(propertyName string, flagName string, filePath string) ([]byte, error) {
+	fileData, err := validateFile(propertyName, flagName, filePath)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	p, _ := pem.Decode(fileData)
+	if p == nil {
+		return []byte{}, fmt.Errorf("%s is not PEM encoded: %q", propertyName, filePath)
+	}
+
+	return fileData, nil
+}
+
+func validateFile(propertyName string, flagName string, filePath string) ([]byte, error) {
 	if filePath == "" {
 		return []byte{}, fmt.Errorf("%s is required", flagName)
 	}
@@ -117,11 +184,6 @@ func validateFileAndFormat(propertyName string, flagName string, filePath string
 		return []byte{}, fmt.Errorf("%s: %s", err, filePath)
 	}
 
-	p, _ := pem.Decode(fileData)
-	if p == nil {
-		return []byte{}, fmt.Errorf("%s is not PEM encoded: %q", propertyName, filePath)
-	}
-
 	return fileData, nil
 }
 
@@ -341,4 +341,48 @@ var _ = Describe("CertificateValidator", func() {
 			})
 		})
 	})
+
+	Describe("ValidatePKCS12", func() {
+		var certificateValidator certs.Validator
+
+		BeforeEach(func() {
+			certificateValidator = certs.NewValidator()
+		})
+
+		It("does not return an error when the password decrypts the certificate and key", func() {
+			err := certificateValidator.ValidatePKCS12("some-command-name", "fixtures/bbl.pfx", "fixtures/pfx-password.txt")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the cert and password are not provided", func() {
+			It("returns an error", func() {
+				err := certificateValidator.ValidatePKCS12("some-command-name", "", "")
+				expectedErr := multierror.NewMultiError("some-command-name")
+				expectedErr.Add(errors.New("--cert is required"))
+				expectedErr.Add(errors.New("--key is required"))
+
+				Expect(err).To(Equal(expectedErr))
+			})
+		})
+
+		Context("when the password is wrong", func() {
+			It("returns an error", func() {
+				passwordPath, err := testhelpers.WriteContentsToTempFile("some-other-password")
+				Expect(err).NotTo(HaveOccurred())
+
+				err = certificateValidator.ValidatePKCS12("some-command-name", "fixtures/bbl.pfx", passwordPath)
+				expectedErr := multierror.NewMultiError("some-command-name")
+				expectedErr.Add(errors.New("failed to decode PFX certificate: pkcs12: decryption password incorrect"))
+
+				Expect(err).To(Equal(expectedErr))
+			})
+		})
+
+		Context("when the cert is not a PFX file", func() {
+			It("returns an error", func() {
+				err := certificateValidator.ValidatePKCS12("some-command-name", "fixtures/pkcs8.crt", "fixtures/pfx-password.txt")
+				Expect(err).To(MatchError(ContainSubstring("failed to decode PFX certificate")))
+			})
+		})
+	})
 })
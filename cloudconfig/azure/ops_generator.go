@@ -45,6 +45,17 @@ type subnetCloudProperties struct {
 	SecurityGroup      string `yaml:"security_group,omitempty"`
 }
 
+type lb struct {
+	Name            string
+	CloudProperties lbCloudProperties `yaml:"cloud_properties"`
+}
+
+type lbCloudProperties struct {
+	ApplicationGateway string `yaml:"application_gateway,omitempty"`
+	LoadBalancer       string `yaml:"load_balancer,omitempty"`
+	SecurityGroup      string `yaml:"security_group,omitempty"`
+}
+
 var marshal func(interface{}) ([]byte, error) = yaml.Marshal
 
 func NewOpsGenerator(terraformManager terraformManager) OpsGenerator {
@@ -61,17 +72,25 @@ func (o OpsGenerator) Generate(state storage.State) (string, error) {
 
 	zones := []string{"z1", "z2", "z3"}
 	var subnets []networkSubnet
+	internalNetwork, err := bosh.ParseInternalNetwork(state.InternalCIDR)
+	if err != nil {
+		return "", err
+	}
+
+	outputs, err := terraformOutputStrings(terraformOutputs, "bosh_network_name", "bosh_subnet_name", "bosh_default_security_group")
+	if err != nil {
+		return "", err
+	}
+
 	for i, _ := range zones {
-		cidr := fmt.Sprintf("10.0.%d.0/20", 16*(i+1))
 		subnet, err := generateNetworkSubnet(
 			fmt.Sprintf("z%d", i+1),
-			cidr,
-			terraformOutputs["bosh_network_name"].(string),
-			terraformOutputs["bosh_subnet_name"].(string),
-			terraformOutputs["bosh_default_security_group"].(string),
+			internalNetwork.AZSubnet(i).String(),
+			outputs["bosh_network_name"],
+			outputs["bosh_subnet_name"],
+			outputs["bosh_default_security_group"],
 		)
 		if err != nil {
-			panic(err)
 			return "", err
 		}
 
@@ -99,6 +118,70 @@ func (o OpsGenerator) Generate(state storage.State) (string, error) {
 		},
 	}
 
+	if state.LB.Type == "concourse" {
+		lbOutputs, err := terraformOutputStrings(terraformOutputs, "concourse_lb_name", "concourse_security_group")
+		if err != nil {
+			return "", err
+		}
+
+		cloudConfigOps = append(cloudConfigOps, op{
+			Type: "replace",
+			Path: "/vm_extensions/-",
+			Value: lb{
+				Name: "lb",
+				CloudProperties: lbCloudProperties{
+					LoadBalancer:  lbOutputs["concourse_lb_name"],
+					SecurityGroup: lbOutputs["concourse_security_group"],
+				},
+			},
+		})
+	}
+
+	if state.LB.Type == "cf" {
+		lbOutputs, err := terraformOutputStrings(terraformOutputs, "cf_security_group", "cf_app_gateway_name", "cf_ssh_proxy_lb_name", "cf_tcp_router_lb_name")
+		if err != nil {
+			return "", err
+		}
+
+		cfSecurityGroup := lbOutputs["cf_security_group"]
+
+		cloudConfigOps = append(cloudConfigOps,
+			op{
+				Type: "replace",
+				Path: "/vm_extensions/-",
+				Value: lb{
+					Name: "cf-router-network-properties",
+					CloudProperties: lbCloudProperties{
+						ApplicationGateway: lbOutputs["cf_app_gateway_name"],
+						SecurityGroup:      cfSecurityGroup,
+					},
+				},
+			},
+			op{
+				Type: "replace",
+				Path: "/vm_extensions/-",
+				Value: lb{
+					Name: "diego-ssh-proxy-network-properties",
+					CloudProperties: lbCloudProperties{
+						LoadBalancer:  lbOutputs["cf_ssh_proxy_lb_name"],
+						SecurityGroup: cfSecurityGroup,
+					},
+				},
+			},
+			op{
+				Type: "replace",
+				Path: "/vm_extensions/-",
+				Value: lb{
+					Name: "cf-tcp-router-network-properties",
+					CloudProperties: lbCloudProperties{
+						LoadBalancer:  lbOutputs["cf_tcp_router_lb_name"],
+						SecurityGroup: cfSecurityGroup,
+					},
+				},
+			},
+		)
+	}
+
 	cloudConfigOpsYAML, err := marshal(cloudConfigOps)
 	if err != nil {
 		return "", err
@@ -113,6 +196,21 @@ func (o OpsGenerator) Generate(state storage.State) (string, error) {
 	), nil
 }
 
+// terraformOutputStrings looks up the named terraform outputs. An older
+// terraform state can be missing outputs added since it was applied.
+func terraformOutputStrings(terraformOutputs map[string]interface{}, names ...string) (map[string]string, error) {
+	outputs := map[string]string{}
+	for _, name := range names {
+		value, ok := terraformOutputs[name].(string)
+		if !ok {
+			return nil, fmt.Errorf("missing %s terraform output", name)
+		}
+		outputs[name] = value
+	}
+
+	return outputs, nil
+}
+
 func generateNetworkSubnet(az, cidr, networkName, subnetName, securityGroup string) (networkSubnet, error) {
 	parsedCidr, err := bosh.ParseCIDRBlock(cidr)
 	if err != nil {
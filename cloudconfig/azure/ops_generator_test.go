@@ -2,13 +2,16 @@ package azure_test
 
 import (
 	"errors"
+	"fmt"
 	"io/ioutil"
 	"path/filepath"
+	"strings"
 
 	"github.com/cloudfoundry/bosh-bootloader/cloudconfig/azure"
 	"github.com/cloudfoundry/bosh-bootloader/fakes"
 	"github.com/cloudfoundry/bosh-bootloader/storage"
 	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 	"github.com/pivotal-cf-experimental/gomegamatchers"
 )
@@ -52,6 +55,40 @@ var _ = Describe("AzureOpsGenerator", func() {
 			Expect(opsYAML).To(gomegamatchers.MatchYAML(expectedOpsFile))
 		})
 
+		DescribeTable("returns an ops file with additional vm extensions to support lb",
+			func(lbType string, lbOutputs map[string]interface{}) {
+				incomingState.LB.Type = lbType
+
+				expectedLBOpsFile, err := ioutil.ReadFile(filepath.Join("fixtures", fmt.Sprintf("azure-%s-lb-ops.yml", lbType)))
+				Expect(err).NotTo(HaveOccurred())
+
+				expectedOps := strings.Join([]string{string(expectedOpsFile), string(expectedLBOpsFile)}, "\n")
+
+				for name, value := range lbOutputs {
+					terraformManager.GetOutputsCall.Returns.Outputs[name] = value
+				}
+
+				opsYAML, err := opsGenerator.Generate(incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(terraformManager.GetOutputsCall.Receives.BBLState).To(Equal(incomingState))
+
+				Expect(opsYAML).To(gomegamatchers.MatchYAML(expectedOps))
+			},
+			Entry("cf load balancer exists", "cf",
+				map[string]interface{}{
+					"cf_security_group":     "some-cf-security-group",
+					"cf_app_gateway_name":   "some-app-gateway",
+					"cf_ssh_proxy_lb_name":  "some-ssh-proxy-lb",
+					"cf_tcp_router_lb_name": "some-tcp-router-lb",
+				}),
+			Entry("concourse load balancer exists", "concourse",
+				map[string]interface{}{
+					"concourse_lb_name":        "some-concourse-lb",
+					"concourse_security_group": "some-concourse-security-group",
+				}),
+		)
+
 		Context("failure cases", func() {
 			Context("when terraform output provider fails to retrieve", func() {
 				BeforeEach(func() {
@@ -64,6 +101,36 @@ var _ = Describe("AzureOpsGenerator", func() {
 				})
 			})
 
+			Context("when a network terraform output is missing", func() {
+				It("returns an error", func() {
+					delete(terraformManager.GetOutputsCall.Returns.Outputs, "bosh_subnet_name")
+
+					_, err := opsGenerator.Generate(incomingState)
+					Expect(err).To(MatchError("missing bosh_subnet_name terraform output"))
+				})
+			})
+
+			Context("when a concourse lb terraform output is missing", func() {
+				It("returns an error", func() {
+					incomingState.LB.Type = "concourse"
+					terraformManager.GetOutputsCall.Returns.Outputs["concourse_lb_name"] = "some-concourse-lb"
+
+					_, err := opsGenerator.Generate(incomingState)
+					Expect(err).To(MatchError("missing concourse_security_group terraform output"))
+				})
+			})
+
+			Context("when the terraform state predates the cf lb outputs", func() {
+				It("returns an error", func() {
+					incomingState.LB.Type = "cf"
+					terraformManager.GetOutputsCall.Returns.Outputs["cf_security_group"] = "some-cf-security-group"
+					terraformManager.GetOutputsCall.Returns.Outputs["cf_app_gateway_name"] = "some-app-gateway"
+
+					_, err := opsGenerator.Generate(incomingState)
+					Expect(err).To(MatchError("missing cf_ssh_proxy_lb_name terraform output"))
+				})
+			})
+
 			Context("when ops fail to marshal", func() {
 				BeforeEach(func() {
 					azure.SetMarshal(func(interface{}) ([]byte, error) {
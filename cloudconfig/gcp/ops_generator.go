@@ -119,11 +119,15 @@ func (o *OpsGenerator) generateGCPOps(state storage.State) ([]op, error) {
 	}
 
 	var subnets []networkSubnet
+	internalNetwork, err := bosh.ParseInternalNetwork(state.InternalCIDR)
+	if err != nil {
+		return []op{}, err
+	}
+
 	for i, _ := range state.GCP.Zones {
-		cidr := fmt.Sprintf("10.0.%d.0/20", 16*(i+1))
 		subnet, err := generateNetworkSubnet(
 			fmt.Sprintf("z%d", i+1),
-			cidr,
+			internalNetwork.AZSubnet(i).String(),
 			terraformOutputs["network_name"].(string),
 			terraformOutputs["subnetwork_name"].(string),
 			terraformOutputs["internal_tag_name"].(string),
@@ -62,6 +62,17 @@ var _ = Describe("GCPOpsGenerator", func() {
 			Expect(opsYAML).To(gomegamatchers.MatchYAML(expectedOpsFile))
 		})
 
+		It("carves the zone subnets out of the internal cidr", func() {
+			incomingState.InternalCIDR = "172.16.0.0/16"
+
+			opsYAML, err := opsGenerator.Generate(incomingState)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(opsYAML).To(ContainSubstring("range: 172.16.16.0/20"))
+			Expect(opsYAML).To(ContainSubstring("gateway: 172.16.32.1"))
+			Expect(opsYAML).To(ContainSubstring("- 172.16.63.190-172.16.63.254"))
+		})
+
 		DescribeTable("returns an ops file with additional vm extensions to support lb",
 			func(lbType string, lbOutputs map[string]interface{}) {
 				incomingState.LB.Type = lbType
@@ -2,10 +2,12 @@ package cloudconfig
 
 import (
 	"bytes"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"golang.org/x/net/proxy"
 
@@ -32,6 +34,7 @@ type Manager struct {
 
 type logger interface {
 	Step(string, ...interface{})
+	Println(string)
 }
 
 type command interface {
@@ -103,6 +106,16 @@ func (m Manager) Generate(state storage.State) (string, error) {
 		"-o", filepath.Join(cloudConfigDir, "ops.yml"),
 	}
 
+	for i, opsFile := range state.CloudConfigOpsFiles {
+		path := filepath.Join(cloudConfigDir, fmt.Sprintf("user-ops-file-%d.yml", i))
+		err = writeFile(path, []byte(opsFile.Contents), os.ModePerm)
+		if err != nil {
+			return "", err
+		}
+
+		args = append(args, "-o", path)
+	}
+
 	buf := bytes.NewBuffer([]byte{})
 	err = m.command.Run(buf, cloudConfigDir, args)
 	if err != nil {
@@ -112,18 +125,25 @@ func (m Manager) Generate(state storage.State) (string, error) {
 	return buf.String(), nil
 }
 
+// Update applies the cloud config Generate returns to the director, after
+// logging how it differs from the one the director is using. Nothing is
+// applied when they are the same.
 func (m Manager) Update(state storage.State) error {
 	boshClient, err := m.boshClientProvider.Client(state.Jumpbox, state.BOSH.DirectorAddress, state.BOSH.DirectorUsername, state.BOSH.DirectorPassword, state.BOSH.DirectorSSLCA)
 	if err != nil {
 		return err // not tested
 	}
 
-	m.logger.Step("generating cloud config")
-	cloudConfig, err := m.Generate(state)
+	cloudConfig, changes, err := m.diff(boshClient, state)
 	if err != nil {
 		return err
 	}
 
+	if len(changes) == 0 {
+		m.logger.Step("cloud config is up to date")
+		return nil
+	}
+
 	m.logger.Step("applying cloud config")
 	err = boshClient.UpdateCloudConfig([]byte(cloudConfig))
 	if err != nil {
@@ -132,3 +152,74 @@ func (m Manager) Update(state storage.State) error {
 
 	return nil
 }
+
+// Diff logs how the cloud config Generate returns differs from the one the
+// director is using, without applying it.
+func (m Manager) Diff(state storage.State) error {
+	boshClient, err := m.boshClientProvider.Client(state.Jumpbox, state.BOSH.DirectorAddress, state.BOSH.DirectorUsername, state.BOSH.DirectorPassword, state.BOSH.DirectorSSLCA)
+	if err != nil {
+		return err // not tested
+	}
+
+	_, changes, err := m.diff(boshClient, state)
+	if err != nil {
+		return err
+	}
+
+	if len(changes) == 0 {
+		m.logger.Step("cloud config is up to date")
+		return nil
+	}
+
+	m.logger.Step("not applying cloud config changes")
+	return nil
+}
+
+func (m Manager) diff(boshClient bosh.Client, state storage.State) (string, []bosh.ManifestChange, error) {
+	m.logger.Step("generating cloud config")
+	cloudConfig, err := m.Generate(state)
+	if err != nil {
+		return "", nil, err
+	}
+
+	m.logger.Step("fetching cloud config")
+	currentCloudConfig, err := boshClient.CloudConfig()
+	if err != nil {
+		return "", nil, err
+	}
+
+	if strings.TrimSpace(currentCloudConfig) == "" {
+		currentCloudConfig = "{}"
+	}
+
+	changes, err := bosh.DiffManifests(currentCloudConfig, cloudConfig)
+	if err != nil {
+		return "", nil, fmt.Errorf("Diff cloud config: %s", err)
+	}
+
+	if len(changes) > 0 {
+		m.logger.Println("cloud config changes:")
+		for _, change := range changes {
+			m.logger.Println(fmt.Sprintf("  %s", change))
+		}
+	}
+
+	return cloudConfig, changes, nil
+}
+
+// Current returns the cloud config the director is using, which differs from
+// the one Generate returns if it was changed outside of bbl.
+func (m Manager) Current(state storage.State) (string, error) {
+	boshClient, err := m.boshClientProvider.Client(state.Jumpbox, state.BOSH.DirectorAddress, state.BOSH.DirectorUsername, state.BOSH.DirectorPassword, state.BOSH.DirectorSSLCA)
+	if err != nil {
+		return "", err // not tested
+	}
+
+	m.logger.Step("fetching cloud config")
+	cloudConfig, err := boshClient.CloudConfig()
+	if err != nil {
+		return "", err
+	}
+
+	return cloudConfig, nil
+}
@@ -104,6 +104,52 @@ var _ = Describe("Manager", func() {
 			Expect(cloudConfigYAML).To(Equal("some-cloud-config"))
 		})
 
+		Context("when cloud config ops files are in the state", func() {
+			BeforeEach(func() {
+				incomingState.CloudConfigOpsFiles = []storage.UserFile{
+					{Name: "vm-types.yml", Contents: "some-vm-types-ops"},
+					{Name: "vm-extensions.yml", Contents: "some-vm-extensions-ops"},
+				}
+			})
+
+			It("applies them after bbl's ops", func() {
+				_, err := manager.Generate(incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, _, args := cmd.RunArgsForCall(0)
+				Expect(args).To(Equal([]string{
+					"interpolate", fmt.Sprintf("%s/cloud-config.yml", tempDir),
+					"-o", fmt.Sprintf("%s/ops.yml", tempDir),
+					"-o", fmt.Sprintf("%s/user-ops-file-0.yml", tempDir),
+					"-o", fmt.Sprintf("%s/user-ops-file-1.yml", tempDir),
+				}))
+
+				ops, err := ioutil.ReadFile(fmt.Sprintf("%s/user-ops-file-1.yml", tempDir))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(ops)).To(Equal("some-vm-extensions-ops"))
+			})
+
+			Context("when write file fails to write an ops file", func() {
+				BeforeEach(func() {
+					cloudconfig.SetWriteFile(func(filename string, body []byte, mode os.FileMode) error {
+						if strings.Contains(filename, "user-ops-file-0.yml") {
+							return errors.New("failed to write file")
+						}
+						return nil
+					})
+				})
+
+				AfterEach(func() {
+					cloudconfig.ResetWriteFile()
+				})
+
+				It("returns an error", func() {
+					_, err := manager.Generate(incomingState)
+					Expect(err).To(MatchError("failed to write file"))
+				})
+			})
+		})
+
 		Context("failure cases", func() {
 			Context("when getting cloud config dir fails", func() {
 				BeforeEach(func() {
@@ -186,6 +232,7 @@ var _ = Describe("Manager", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(logger.StepCall.Messages).To(Equal([]string{
 				"generating cloud config",
+				"fetching cloud config",
 				"applying cloud config",
 			}))
 		})
@@ -201,6 +248,50 @@ var _ = Describe("Manager", func() {
 			Expect(boshClient.UpdateCloudConfigCall.Receives.Yaml).To(Equal([]byte("some-cloud-config")))
 		})
 
+		Context("when the director already has a cloud config", func() {
+			BeforeEach(func() {
+				boshClient.CloudConfigCall.Returns.CloudConfig = `vm_types:
+- name: default
+  cloud_properties: {machine_type: n1-standard-1}
+`
+				cmd.RunStub = func(stdout io.Writer, workingDirectory string, args []string) error {
+					stdout.Write([]byte(`vm_types:
+- name: default
+  cloud_properties: {machine_type: n1-standard-2}
+- name: large
+`))
+					return nil
+				}
+			})
+
+			It("logs the changes before applying them", func() {
+				err := manager.Update(incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(logger.PrintlnCall.Messages).To(Equal([]string{
+					"cloud config changes:",
+					"  ~ /vm_types/name=default/cloud_properties/machine_type: n1-standard-1 -> n1-standard-2",
+					"  + /vm_types/name=large",
+				}))
+				Expect(boshClient.UpdateCloudConfigCall.CallCount).To(Equal(1))
+			})
+
+			It("does not apply a cloud config that is unchanged", func() {
+				boshClient.CloudConfigCall.Returns.CloudConfig = `vm_types:
+- name: default
+  cloud_properties: {machine_type: n1-standard-2}
+- name: large
+`
+
+				err := manager.Update(incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(boshClient.UpdateCloudConfigCall.CallCount).To(Equal(0))
+				Expect(logger.StepCall.Messages).To(ContainElement("cloud config is up to date"))
+				Expect(logger.PrintlnCall.Messages).To(BeEmpty())
+			})
+		})
+
 		Context("failure cases", func() {
 			Context("when manager generate's command fails to run", func() {
 				BeforeEach(func() {
@@ -213,6 +304,31 @@ var _ = Describe("Manager", func() {
 				})
 			})
 
+			Context("when bosh client fails to get the current cloud config", func() {
+				BeforeEach(func() {
+					boshClient.CloudConfigCall.Returns.Error = errors.New("failed to get")
+				})
+
+				It("returns an error", func() {
+					err := manager.Update(storage.State{})
+					Expect(err).To(MatchError("failed to get"))
+				})
+			})
+
+			Context("when the generated cloud config is not valid yaml", func() {
+				BeforeEach(func() {
+					cmd.RunStub = func(stdout io.Writer, workingDirectory string, args []string) error {
+						stdout.Write([]byte("%%%"))
+						return nil
+					}
+				})
+
+				It("returns an error", func() {
+					err := manager.Update(storage.State{})
+					Expect(err).To(MatchError(ContainSubstring("Diff cloud config: Parse new manifest: ")))
+				})
+			})
+
 			Context("when bosh client fails to update cloud config", func() {
 				BeforeEach(func() {
 					boshClient.UpdateCloudConfigCall.Returns.Error = errors.New("failed to update")
@@ -225,4 +341,48 @@ var _ = Describe("Manager", func() {
 			})
 		})
 	})
+
+	Describe("Diff", func() {
+		It("logs the changes without applying them", func() {
+			err := manager.Diff(incomingState)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(logger.StepCall.Messages).To(Equal([]string{
+				"generating cloud config",
+				"fetching cloud config",
+				"not applying cloud config changes",
+			}))
+			Expect(logger.PrintlnCall.Messages).To(ContainElement("cloud config changes:"))
+			Expect(boshClient.UpdateCloudConfigCall.CallCount).To(Equal(0))
+		})
+
+		It("returns an error when the cloud config cannot be generated", func() {
+			cmd.RunReturns(errors.New("failed to run"))
+
+			err := manager.Diff(incomingState)
+			Expect(err).To(MatchError("failed to run"))
+		})
+	})
+
+	Describe("Current", func() {
+		It("returns the cloud config from the bosh director", func() {
+			boshClient.CloudConfigCall.Returns.CloudConfig = "some-current-cloud-config"
+
+			cloudConfig, err := manager.Current(incomingState)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(boshClientProvider.ClientCall.Receives.DirectorAddress).To(Equal("some-director-address"))
+			Expect(cloudConfig).To(Equal("some-current-cloud-config"))
+			Expect(logger.StepCall.Messages).To(Equal([]string{"fetching cloud config"}))
+		})
+
+		Context("when bosh client fails to get the cloud config", func() {
+			It("returns an error", func() {
+				boshClient.CloudConfigCall.Returns.Error = errors.New("failed to get")
+
+				_, err := manager.Current(incomingState)
+				Expect(err).To(MatchError("failed to get"))
+			})
+		})
+	})
 })
@@ -95,7 +95,7 @@ func (c AWSCreateLBs) Execute(config CreateLBsConfig, state storage.State) error
 	}
 
 	if !state.NoDirector {
-		err = c.cloudConfigManager.Update(state)
+		err = updateCloudConfig(c.cloudConfigManager, state, config.CloudConfigMode)
 		if err != nil {
 			return err
 		}
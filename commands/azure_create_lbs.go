@@ -0,0 +1,145 @@
+package commands
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/cloudfoundry/bosh-bootloader/bosh"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+)
+
+type AzureCreateLBs struct {
+	terraformManager     terraformApplier
+	cloudConfigManager   cloudConfigManager
+	stateStore           stateStore
+	environmentValidator EnvironmentValidator
+	virtualNetworkClient virtualNetworkClient
+}
+
+type virtualNetworkClient interface {
+	VirtualNetworkAddressSpace(existingNetwork string) ([]string, error)
+}
+
+type AzureCreateLBsConfig struct {
+	LBType   string
+	CertPath string
+	KeyPath  string
+	Domain   string
+}
+
+func NewAzureCreateLBs(terraformManager terraformApplier, cloudConfigManager cloudConfigManager,
+	stateStore stateStore, environmentValidator EnvironmentValidator, virtualNetworkClient virtualNetworkClient) AzureCreateLBs {
+	return AzureCreateLBs{
+		terraformManager:     terraformManager,
+		cloudConfigManager:   cloudConfigManager,
+		stateStore:           stateStore,
+		environmentValidator: environmentValidator,
+		virtualNetworkClient: virtualNetworkClient,
+	}
+}
+
+func (c AzureCreateLBs) Execute(config CreateLBsConfig, state storage.State) error {
+	if state.LB.Type != "" {
+		if config.Azure.Domain == "" {
+			config.Azure.Domain = state.LB.Domain
+		}
+	}
+
+	err := c.terraformManager.ValidateVersion()
+	if err != nil {
+		return err
+	}
+
+	if err := c.environmentValidator.Validate(state); err != nil {
+		return err
+	}
+
+	state.LB.Type = config.Azure.LBType
+
+	if config.Azure.LBType == "cf" {
+		err = c.validateApplicationGatewaySubnet(state)
+		if err != nil {
+			return err
+		}
+
+		state.LB.Domain = config.Azure.Domain
+
+		state.LB.Cert, state.LB.Key, err = readPFX(config.Azure.CertPath, config.Azure.KeyPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	state, err = c.terraformManager.Apply(state)
+	if err != nil {
+		return handleTerraformError(err, c.stateStore)
+	}
+
+	if err := c.stateStore.Set(state); err != nil {
+		return err
+	}
+
+	if !state.NoDirector {
+		err = updateCloudConfig(c.cloudConfigManager, state, config.CloudConfigMode)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateApplicationGatewaySubnet checks that the subnet the application
+// gateway needs is private and, since bbl cannot add it to an existing virtual
+// network, that the existing network's address space already includes it.
+func (c AzureCreateLBs) validateApplicationGatewaySubnet(state storage.State) error {
+	internalNetwork, err := bosh.ParseInternalNetwork(state.InternalCIDR)
+	if err != nil {
+		return err //not tested
+	}
+
+	subnet, err := internalNetwork.ApplicationGatewaySubnet()
+	if err != nil {
+		return err
+	}
+
+	if state.ExistingNetwork == "" {
+		return nil
+	}
+
+	addressSpace, err := c.virtualNetworkClient.VirtualNetworkAddressSpace(state.ExistingNetwork)
+	if err != nil {
+		return fmt.Errorf("Get address space of %s: %s", state.ExistingNetwork, err)
+	}
+
+	for _, prefix := range addressSpace {
+		block, err := bosh.ParseCIDRBlock(prefix)
+		if err != nil {
+			continue
+		}
+
+		if block.Contains(subnet) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("The address space of %s does not include %s, which the application gateway needs. Add it to the virtual network and try again.", state.ExistingNetwork, subnet)
+}
+
+// readPFX returns the PFX certificate at certPath base64 encoded, which is how
+// the application gateway takes it, and the password stored in passwordPath.
+func readPFX(certPath, passwordPath string) (string, string, error) {
+	pfx, err := ioutil.ReadFile(certPath)
+	if err != nil {
+		return "", "", err
+	}
+
+	password, err := ioutil.ReadFile(passwordPath)
+	if err != nil {
+		return "", "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(pfx), strings.TrimSpace(string(password)), nil
+}
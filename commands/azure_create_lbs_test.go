@@ -0,0 +1,264 @@
+package commands_test
+
+import (
+	"encoding/base64"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/cloudfoundry/bosh-bootloader/application"
+	"github.com/cloudfoundry/bosh-bootloader/commands"
+	"github.com/cloudfoundry/bosh-bootloader/fakes"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+	"github.com/cloudfoundry/bosh-bootloader/terraform"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AzureCreateLBs", func() {
+	var (
+		terraformManager       *fakes.TerraformManager
+		cloudConfigManager     *fakes.CloudConfigManager
+		stateStore             *fakes.StateStore
+		environmentValidator   *fakes.EnvironmentValidator
+		virtualNetworkClient   *fakes.VirtualNetworkClient
+		terraformExecutorError *fakes.TerraformExecutorError
+
+		bblState     storage.State
+		command      commands.AzureCreateLBs
+		certPath     string
+		passwordPath string
+	)
+
+	BeforeEach(func() {
+		terraformManager = &fakes.TerraformManager{}
+		cloudConfigManager = &fakes.CloudConfigManager{}
+		stateStore = &fakes.StateStore{}
+		environmentValidator = &fakes.EnvironmentValidator{}
+		virtualNetworkClient = &fakes.VirtualNetworkClient{}
+		terraformExecutorError = &fakes.TerraformExecutorError{}
+
+		command = commands.NewAzureCreateLBs(terraformManager, cloudConfigManager, stateStore, environmentValidator, virtualNetworkClient)
+
+		tempDir, err := ioutil.TempDir("", "")
+		Expect(err).NotTo(HaveOccurred())
+
+		certPath = filepath.Join(tempDir, "cert.pfx")
+		err = ioutil.WriteFile(certPath, []byte("some-pfx-cert"), os.ModePerm)
+		Expect(err).NotTo(HaveOccurred())
+
+		passwordPath = filepath.Join(tempDir, "password")
+		err = ioutil.WriteFile(passwordPath, []byte("some-password\n"), os.ModePerm)
+		Expect(err).NotTo(HaveOccurred())
+
+		bblState = storage.State{
+			IAAS:    "azure",
+			TFState: "some-tfstate",
+		}
+	})
+
+	Describe("Execute", func() {
+		It("applies terraform with the base64 encoded PFX certificate and its password", func() {
+			err := command.Execute(commands.CreateLBsConfig{Azure: commands.AzureCreateLBsConfig{
+				LBType:   "cf",
+				CertPath: certPath,
+				KeyPath:  passwordPath,
+				Domain:   "some-domain",
+			}}, bblState)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(terraformManager.ValidateVersionCall.CallCount).To(Equal(1))
+			Expect(environmentValidator.ValidateCall.Receives.State).To(Equal(bblState))
+			Expect(terraformManager.ApplyCall.Receives.BBLState).To(Equal(storage.State{
+				IAAS:    "azure",
+				TFState: "some-tfstate",
+				LB: storage.LB{
+					Type:   "cf",
+					Cert:   base64.StdEncoding.EncodeToString([]byte("some-pfx-cert")),
+					Key:    "some-password",
+					Domain: "some-domain",
+				},
+			}))
+		})
+
+		Context("when the environment uses an existing virtual network", func() {
+			BeforeEach(func() {
+				bblState.ExistingNetwork = "some-resource-group/some-vnet"
+				virtualNetworkClient.VirtualNetworkAddressSpaceCall.Returns.AddressSpace = []string{"10.0.0.0/16", "10.1.0.0/16"}
+			})
+
+			It("checks that the address space includes the application gateway subnet", func() {
+				err := command.Execute(commands.CreateLBsConfig{Azure: commands.AzureCreateLBsConfig{
+					LBType:   "cf",
+					CertPath: certPath,
+					KeyPath:  passwordPath,
+				}}, bblState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(virtualNetworkClient.VirtualNetworkAddressSpaceCall.Receives.ExistingNetwork).To(Equal("some-resource-group/some-vnet"))
+				Expect(terraformManager.ApplyCall.CallCount).To(Equal(1))
+			})
+
+			It("returns an error when the address space lacks the application gateway subnet", func() {
+				virtualNetworkClient.VirtualNetworkAddressSpaceCall.Returns.AddressSpace = []string{"10.0.0.0/16"}
+
+				err := command.Execute(commands.CreateLBsConfig{Azure: commands.AzureCreateLBsConfig{
+					LBType:   "cf",
+					CertPath: certPath,
+					KeyPath:  passwordPath,
+				}}, bblState)
+				Expect(err).To(MatchError("The address space of some-resource-group/some-vnet does not include 10.1.0.0/24, which the application gateway needs. Add it to the virtual network and try again."))
+				Expect(terraformManager.ApplyCall.CallCount).To(Equal(0))
+			})
+
+			It("returns an error when the address space cannot be fetched", func() {
+				virtualNetworkClient.VirtualNetworkAddressSpaceCall.Returns.Error = errors.New("failed to get")
+
+				err := command.Execute(commands.CreateLBsConfig{Azure: commands.AzureCreateLBsConfig{
+					LBType:   "cf",
+					CertPath: certPath,
+					KeyPath:  passwordPath,
+				}}, bblState)
+				Expect(err).To(MatchError("Get address space of some-resource-group/some-vnet: failed to get"))
+			})
+
+			It("does not check the address space for concourse", func() {
+				err := command.Execute(commands.CreateLBsConfig{Azure: commands.AzureCreateLBsConfig{
+					LBType: "concourse",
+				}}, bblState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(virtualNetworkClient.VirtualNetworkAddressSpaceCall.CallCount).To(Equal(0))
+			})
+		})
+
+		It("returns an error when the application gateway subnet is not private", func() {
+			bblState.InternalCIDR = "192.168.0.0/16"
+
+			err := command.Execute(commands.CreateLBsConfig{Azure: commands.AzureCreateLBsConfig{
+				LBType:   "cf",
+				CertPath: certPath,
+				KeyPath:  passwordPath,
+			}}, bblState)
+			Expect(err).To(MatchError("The application gateway subnet 192.169.0.0/24 after the internal CIDR 192.168.0.0/16 is not a private range, use a lower internal CIDR"))
+		})
+
+		It("keeps the existing domain when none is given", func() {
+			bblState.LB = storage.LB{Type: "cf", Domain: "some-old-domain"}
+
+			err := command.Execute(commands.CreateLBsConfig{Azure: commands.AzureCreateLBsConfig{
+				LBType:   "cf",
+				CertPath: certPath,
+				KeyPath:  passwordPath,
+			}}, bblState)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(terraformManager.ApplyCall.Receives.BBLState.LB.Domain).To(Equal("some-old-domain"))
+		})
+
+		It("does not need a certificate for concourse", func() {
+			err := command.Execute(commands.CreateLBsConfig{Azure: commands.AzureCreateLBsConfig{
+				LBType: "concourse",
+			}}, bblState)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(terraformManager.ApplyCall.Receives.BBLState.LB).To(Equal(storage.LB{Type: "concourse"}))
+		})
+
+		It("saves the state and uploads a new cloud config", func() {
+			terraformManager.ApplyCall.Returns.BBLState = storage.State{
+				LB:      storage.LB{Type: "concourse"},
+				TFState: "some-new-tfstate",
+			}
+
+			err := command.Execute(commands.CreateLBsConfig{Azure: commands.AzureCreateLBsConfig{
+				LBType: "concourse",
+			}}, bblState)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(stateStore.SetCall.CallCount).To(Equal(1))
+			Expect(stateStore.SetCall.Receives[0].State).To(Equal(terraformManager.ApplyCall.Returns.BBLState))
+			Expect(cloudConfigManager.UpdateCall.CallCount).To(Equal(1))
+			Expect(cloudConfigManager.UpdateCall.Receives.State).To(Equal(terraformManager.ApplyCall.Returns.BBLState))
+		})
+
+		Context("when there is no BOSH director", func() {
+			It("does not update the cloud config", func() {
+				terraformManager.ApplyCall.Returns.BBLState.NoDirector = true
+
+				err := command.Execute(commands.CreateLBsConfig{Azure: commands.AzureCreateLBsConfig{
+					LBType: "concourse",
+				}}, storage.State{NoDirector: true})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cloudConfigManager.UpdateCall.CallCount).To(Equal(0))
+			})
+		})
+
+		Context("failure cases", func() {
+			It("returns an error when the terraform version is not valid", func() {
+				terraformManager.ValidateVersionCall.Returns.Error = errors.New("cannot validate version")
+
+				err := command.Execute(commands.CreateLBsConfig{}, bblState)
+				Expect(err).To(MatchError("cannot validate version"))
+			})
+
+			It("returns an error when the environment is not valid", func() {
+				environmentValidator.ValidateCall.Returns.Error = application.DirectorNotReachable
+
+				err := command.Execute(commands.CreateLBsConfig{}, bblState)
+				Expect(err).To(MatchError(application.DirectorNotReachable))
+			})
+
+			It("returns an error when the certificate cannot be read", func() {
+				err := command.Execute(commands.CreateLBsConfig{Azure: commands.AzureCreateLBsConfig{
+					LBType:   "cf",
+					CertPath: "/some/missing/cert.pfx",
+					KeyPath:  passwordPath,
+				}}, bblState)
+				Expect(err).To(MatchError("open /some/missing/cert.pfx: no such file or directory"))
+			})
+
+			It("returns an error when the password cannot be read", func() {
+				err := command.Execute(commands.CreateLBsConfig{Azure: commands.AzureCreateLBsConfig{
+					LBType:   "cf",
+					CertPath: certPath,
+					KeyPath:  "/some/missing/password",
+				}}, bblState)
+				Expect(err).To(MatchError("open /some/missing/password: no such file or directory"))
+			})
+
+			It("saves the tf state when terraform apply fails", func() {
+				terraformExecutorError.TFStateCall.Returns.TFState = "some-updated-tf-state"
+				terraformExecutorError.ErrorCall.Returns = "failed to apply"
+				terraformManager.ApplyCall.Returns.Error = terraform.NewManagerError(bblState, terraformExecutorError)
+
+				err := command.Execute(commands.CreateLBsConfig{Azure: commands.AzureCreateLBsConfig{
+					LBType: "concourse",
+				}}, bblState)
+				Expect(err).To(MatchError("failed to apply"))
+				Expect(stateStore.SetCall.CallCount).To(Equal(1))
+				Expect(stateStore.SetCall.Receives[0].State.TFState).To(Equal("some-updated-tf-state"))
+			})
+
+			It("returns an error when the state cannot be saved", func() {
+				stateStore.SetCall.Returns = []fakes.SetCallReturn{{Error: errors.New("failed to save state")}}
+
+				err := command.Execute(commands.CreateLBsConfig{Azure: commands.AzureCreateLBsConfig{
+					LBType: "concourse",
+				}}, bblState)
+				Expect(err).To(MatchError("failed to save state"))
+			})
+
+			It("returns an error when the cloud config cannot be updated", func() {
+				cloudConfigManager.UpdateCall.Returns.Error = errors.New("failed to update cloud config")
+
+				err := command.Execute(commands.CreateLBsConfig{Azure: commands.AzureCreateLBsConfig{
+					LBType: "concourse",
+				}}, bblState)
+				Expect(err).To(MatchError("failed to update cloud config"))
+			})
+		})
+	})
+})
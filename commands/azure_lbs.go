@@ -0,0 +1,93 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+)
+
+type AzureLBs struct {
+	terraformManager terraformOutputter
+	logger           logger
+}
+
+func NewAzureLBs(terraformManager terraformOutputter, logger logger) AzureLBs {
+	return AzureLBs{
+		terraformManager: terraformManager,
+		logger:           logger,
+	}
+}
+
+func (l AzureLBs) Execute(subcommandFlags []string, state storage.State) error {
+	terraformOutputs, err := l.terraformManager.GetOutputs(state)
+	if err != nil {
+		return err
+	}
+
+	switch state.LB.Type {
+	case "cf":
+		lbIPs, err := lbOutputs(terraformOutputs, "router_lb_ip", "ssh_proxy_lb_ip", "tcp_router_lb_ip")
+		if err != nil {
+			return err
+		}
+
+		dnsServers, _ := terraformOutputs["env_dns_zone_name_servers"].([]string)
+
+		if len(subcommandFlags) > 0 && subcommandFlags[0] == "--json" {
+			lbOutput, err := json.Marshal(struct {
+				RouterLBIP             string   `json:"cf_router_lb,omitempty"`
+				SSHProxyLBIP           string   `json:"cf_ssh_proxy_lb,omitempty"`
+				TCPRouterLBIP          string   `json:"cf_tcp_router_lb,omitempty"`
+				SystemDomainDNSServers []string `json:"cf_system_domain_dns_servers,omitempty"`
+			}{
+				RouterLBIP:             lbIPs["router_lb_ip"],
+				SSHProxyLBIP:           lbIPs["ssh_proxy_lb_ip"],
+				TCPRouterLBIP:          lbIPs["tcp_router_lb_ip"],
+				SystemDomainDNSServers: dnsServers,
+			})
+			if err != nil {
+				// not tested
+				return err
+			}
+
+			l.logger.Println(string(lbOutput))
+		} else {
+			l.logger.Printf("CF Router LB: %s\n", lbIPs["router_lb_ip"])
+			l.logger.Printf("CF SSH Proxy LB: %s\n", lbIPs["ssh_proxy_lb_ip"])
+			l.logger.Printf("CF TCP Router LB: %s\n", lbIPs["tcp_router_lb_ip"])
+
+			if len(dnsServers) > 0 {
+				l.logger.Printf("CF System Domain DNS servers: %s\n", strings.Join(dnsServers, " "))
+			}
+		}
+	case "concourse":
+		lbIPs, err := lbOutputs(terraformOutputs, "concourse_lb_ip")
+		if err != nil {
+			return err
+		}
+
+		l.logger.Printf("Concourse LB: %s\n", lbIPs["concourse_lb_ip"])
+	default:
+		return errors.New("no lbs found")
+	}
+
+	return nil
+}
+
+// lbOutputs looks up the named terraform outputs. A terraform state applied
+// by an older bbl can be missing the outputs of load balancers added since.
+func lbOutputs(terraformOutputs map[string]interface{}, names ...string) (map[string]string, error) {
+	outputs := map[string]string{}
+	for _, name := range names {
+		value, ok := terraformOutputs[name].(string)
+		if !ok {
+			return nil, fmt.Errorf("missing %s terraform output", name)
+		}
+		outputs[name] = value
+	}
+
+	return outputs, nil
+}
@@ -0,0 +1,142 @@
+package commands_test
+
+import (
+	"errors"
+
+	"github.com/cloudfoundry/bosh-bootloader/commands"
+	"github.com/cloudfoundry/bosh-bootloader/fakes"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AzureLBs", func() {
+	var (
+		command commands.AzureLBs
+
+		terraformManager *fakes.TerraformManager
+		logger           *fakes.Logger
+
+		incomingState storage.State
+	)
+
+	BeforeEach(func() {
+		terraformManager = &fakes.TerraformManager{}
+		terraformManager.GetOutputsCall.Returns.Outputs = map[string]interface{}{
+			"router_lb_ip":     "some-router-lb-ip",
+			"ssh_proxy_lb_ip":  "some-ssh-proxy-lb-ip",
+			"tcp_router_lb_ip": "some-tcp-router-lb-ip",
+			"concourse_lb_ip":  "some-concourse-lb-ip",
+		}
+		logger = &fakes.Logger{}
+
+		incomingState = storage.State{}
+
+		command = commands.NewAzureLBs(terraformManager, logger)
+	})
+
+	Describe("Execute", func() {
+		It("prints LB ips for lb type cf", func() {
+			incomingState.LB = storage.LB{
+				Type: "cf",
+			}
+			err := command.Execute([]string{}, incomingState)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(terraformManager.GetOutputsCall.Receives.BBLState).To(Equal(incomingState))
+			Expect(logger.PrintfCall.Messages).To(ConsistOf([]string{
+				"CF Router LB: some-router-lb-ip\n",
+				"CF SSH Proxy LB: some-ssh-proxy-lb-ip\n",
+				"CF TCP Router LB: some-tcp-router-lb-ip\n",
+			}))
+		})
+
+		Context("when the domain is specified", func() {
+			BeforeEach(func() {
+				terraformManager.GetOutputsCall.Returns.Outputs["env_dns_zone_name_servers"] = []string{"name-server-1.", "name-server-2."}
+				incomingState.LB = storage.LB{
+					Type:   "cf",
+					Domain: "some-domain",
+				}
+			})
+
+			It("prints the name servers of the dns zone", func() {
+				err := command.Execute([]string{}, incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(logger.PrintfCall.Messages).To(ConsistOf([]string{
+					"CF Router LB: some-router-lb-ip\n",
+					"CF SSH Proxy LB: some-ssh-proxy-lb-ip\n",
+					"CF TCP Router LB: some-tcp-router-lb-ip\n",
+					"CF System Domain DNS servers: name-server-1. name-server-2.\n",
+				}))
+			})
+
+			Context("when the json flag is provided", func() {
+				It("prints LB ips for lb type cf in json format", func() {
+					err := command.Execute([]string{"--json"}, incomingState)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(logger.PrintlnCall.Receives.Message).To(MatchJSON(`{
+						"cf_router_lb": "some-router-lb-ip",
+						"cf_ssh_proxy_lb": "some-ssh-proxy-lb-ip",
+						"cf_tcp_router_lb": "some-tcp-router-lb-ip",
+						"cf_system_domain_dns_servers": [
+							"name-server-1.",
+							"name-server-2."
+						]
+					}`))
+				})
+			})
+		})
+
+		It("prints LB ips for lb type concourse", func() {
+			incomingState.LB = storage.LB{
+				Type: "concourse",
+			}
+			err := command.Execute([]string{}, incomingState)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(logger.PrintfCall.Messages).To(ConsistOf([]string{
+				"Concourse LB: some-concourse-lb-ip\n",
+			}))
+		})
+
+		Context("failure cases", func() {
+			It("returns an error when terraform output provider fails", func() {
+				terraformManager.GetOutputsCall.Returns.Error = errors.New("failed to return terraform output")
+
+				err := command.Execute([]string{}, incomingState)
+				Expect(err).To(MatchError("failed to return terraform output"))
+			})
+
+			Context("when the terraform state is missing the lb outputs", func() {
+				BeforeEach(func() {
+					terraformManager.GetOutputsCall.Returns.Outputs = map[string]interface{}{
+						"router_lb_ip": "some-router-lb-ip",
+					}
+				})
+
+				It("returns an error for the cf lbs", func() {
+					incomingState.LB = storage.LB{Type: "cf"}
+
+					err := command.Execute([]string{}, incomingState)
+					Expect(err).To(MatchError("missing ssh_proxy_lb_ip terraform output"))
+				})
+
+				It("returns an error for the concourse lb", func() {
+					incomingState.LB = storage.LB{Type: "concourse"}
+
+					err := command.Execute([]string{"--json"}, incomingState)
+					Expect(err).To(MatchError("missing concourse_lb_ip terraform output"))
+				})
+			})
+
+			It("returns a nice error message when no lb type is found", func() {
+				err := command.Execute([]string{}, incomingState)
+				Expect(err).To(MatchError("no lbs found"))
+			})
+		})
+	})
+})
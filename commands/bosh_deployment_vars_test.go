@@ -90,6 +90,17 @@ var _ = Describe("BOSHDeploymentVars", func() {
 					Expect(err).To(MatchError("get terraform outputs: coconut"))
 				})
 			})
+
+			Context("when the bosh manager fails to get deployment vars", func() {
+				BeforeEach(func() {
+					boshManager.GetDirectorDeploymentVarsCall.Returns.Error = errors.New("pineapple")
+				})
+
+				It("returns an error", func() {
+					err := boshDeploymentVars.Execute([]string{}, storage.State{})
+					Expect(err).To(MatchError("pineapple"))
+				})
+			})
 		})
 	})
 })
@@ -0,0 +1,56 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/cloudfoundry/bosh-bootloader/bosh"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+)
+
+const (
+	CertsCommand = "certs"
+)
+
+type certificateManager interface {
+	List(storage.State) ([]bosh.Certificate, error)
+	Delete(storage.State, bosh.CertificateSelector) (storage.State, []string, error)
+}
+
+// Certs prints the certificates in the jumpbox and director vars-stores.
+type Certs struct {
+	logger             logger
+	stateValidator     stateValidator
+	certificateManager certificateManager
+}
+
+func NewCerts(logger logger, stateValidator stateValidator, certificateManager certificateManager) Certs {
+	return Certs{
+		logger:             logger,
+		stateValidator:     stateValidator,
+		certificateManager: certificateManager,
+	}
+}
+
+func (c Certs) CheckFastFails(subcommandFlags []string, state storage.State) error {
+	return c.stateValidator.Validate()
+}
+
+func (c Certs) Execute(subcommandFlags []string, state storage.State) error {
+	certificates, err := c.certificateManager.List(state)
+	if err != nil {
+		return err
+	}
+
+	if len(certificates) == 0 {
+		c.logger.Println("No certificates found.")
+		return nil
+	}
+
+	c.logger.Printf("%-10s %-24s %-28s %-28s %s\n", "DEPLOYMENT", "NAME", "SUBJECT", "ISSUER", "EXPIRES")
+	for _, certificate := range certificates {
+		c.logger.Printf("%-10s %-24s %-28s %-28s %s\n", certificate.Deployment, certificate.Name,
+			certificate.Subject, certificate.Issuer, certificate.NotAfter.UTC().Format(time.RFC3339))
+	}
+
+	return nil
+}
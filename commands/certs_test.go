@@ -0,0 +1,77 @@
+package commands_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/cloudfoundry/bosh-bootloader/bosh"
+	"github.com/cloudfoundry/bosh-bootloader/commands"
+	"github.com/cloudfoundry/bosh-bootloader/fakes"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Certs", func() {
+	var (
+		logger             *fakes.Logger
+		stateValidator     *fakes.StateValidator
+		certificateManager *fakes.CertificateManager
+
+		certs commands.Certs
+	)
+
+	BeforeEach(func() {
+		logger = &fakes.Logger{}
+		stateValidator = &fakes.StateValidator{}
+		certificateManager = &fakes.CertificateManager{}
+
+		certs = commands.NewCerts(logger, stateValidator, certificateManager)
+	})
+
+	Describe("CheckFastFails", func() {
+		It("returns an error when the state is not valid", func() {
+			stateValidator.ValidateCall.Returns.Error = errors.New("no state")
+
+			err := certs.CheckFastFails([]string{}, storage.State{})
+			Expect(err).To(MatchError("no state"))
+		})
+	})
+
+	Describe("Execute", func() {
+		It("prints the certificates", func() {
+			state := storage.State{EnvID: "some-env-id"}
+			certificateManager.ListCall.Returns.Certificates = []bosh.Certificate{{
+				Deployment: "director",
+				Name:       "director_ssl",
+				Subject:    "10.0.0.6",
+				Issuer:     "default-ca",
+				NotAfter:   time.Date(2018, time.October, 1, 0, 0, 0, 0, time.UTC),
+			}}
+
+			err := certs.Execute([]string{}, state)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(certificateManager.ListCall.Receives.State).To(Equal(state))
+			Expect(logger.PrintfCall.Messages).To(Equal([]string{
+				"DEPLOYMENT NAME                     SUBJECT                      ISSUER                       EXPIRES\n",
+				"director   director_ssl             10.0.0.6                     default-ca                   2018-10-01T00:00:00Z\n",
+			}))
+		})
+
+		It("says when there are no certificates", func() {
+			err := certs.Execute([]string{}, storage.State{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(logger.PrintlnCall.Messages).To(Equal([]string{"No certificates found."}))
+		})
+
+		It("returns an error when the certificates cannot be listed", func() {
+			certificateManager.ListCall.Returns.Error = errors.New("failed to list")
+
+			err := certs.Execute([]string{}, storage.State{})
+			Expect(err).To(MatchError("failed to list"))
+		})
+	})
+})
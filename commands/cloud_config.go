@@ -1,11 +1,22 @@
 package commands
 
-import "github.com/cloudfoundry/bosh-bootloader/storage"
+import (
+	"errors"
+
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+)
 
 const (
 	CloudConfigCommand = "cloud-config"
 )
 
+// --cloud-config-mode values. apply is the default.
+const (
+	cloudConfigModeApply = "apply"
+	cloudConfigModeDiff  = "diff"
+	cloudConfigModeSkip  = "skip"
+)
+
 type CloudConfig struct {
 	logger             logger
 	stateValidator     stateValidator
@@ -29,6 +40,28 @@ func (c CloudConfig) CheckFastFails(subcommandFlags []string, state storage.Stat
 	return nil
 }
 
+func validateCloudConfigMode(mode string) error {
+	switch mode {
+	case cloudConfigModeApply, cloudConfigModeDiff, cloudConfigModeSkip:
+		return nil
+	}
+
+	return errors.New("--cloud-config-mode must be apply, diff or skip")
+}
+
+// updateCloudConfig applies the cloud config, only prints the changes, or
+// leaves the director's cloud config alone depending on mode.
+func updateCloudConfig(cloudConfigManager cloudConfigManager, state storage.State, mode string) error {
+	switch mode {
+	case cloudConfigModeSkip:
+		return nil
+	case cloudConfigModeDiff:
+		return cloudConfigManager.Diff(state)
+	}
+
+	return cloudConfigManager.Update(state)
+}
+
 func (c CloudConfig) Execute(args []string, state storage.State) error {
 	contents, err := c.cloudConfigManager.Generate(state)
 	if err != nil {
@@ -3,25 +3,82 @@ package commands
 const (
 	UpCommandUsage = `Deploys BOSH director on an IAAS
 
-  --iaas                     IAAS to deploy your BOSH director onto. Valid options: "aws", "azure", "gcp" (Defaults to environment variable BBL_IAAS)
-  [--name]                   Name to assign to your BOSH director (optional, will be randomly generated)
-  [--ops-file]               Path to BOSH ops file (optional)
-  [--no-director]            Skips creating BOSH environment
-
-  --aws-access-key-id        AWS Access Key ID to use (Defaults to environment variable BBL_AWS_ACCESS_KEY_ID)
-  --aws-secret-access-key    AWS Secret Access Key to use (Defaults to environment variable BBL_AWS_SECRET_ACCESS_KEY)
-  --aws-region               AWS Region to use (Defaults to environment variable BBL_AWS_REGION)
-  [--aws-bosh-az]            AWS Availability Zone to use for BOSH director (Defaults to environment variable BBL_AWS_BOSH_AZ)
-
-  --gcp-service-account-key  GCP Service Access Key to use (Defaults to environment variable BBL_GCP_SERVICE_ACCOUNT_KEY)
-  --gcp-zone                 GCP Zone to use for BOSH director (Defaults to environment variable BBL_GCP_ZONE)
-  --gcp-region               GCP Region to use (Defaults to environment variable BBL_GCP_REGION)
-
-  --azure-subscription-id    Azure Subscription ID to use (Defaults to environment variable BBL_AZURE_SUBSCRIPTION_ID)
-  --azure-tenant-id          Azure Tenant ID to use (Defaults to environment variable BBL_AZURE_TENANT_ID)
-  --azure-client-id          Azure Client ID to use (Defaults to environment variable BBL_AZURE_CLIENT_ID)
-  --azure-client-secret      Azure Client Secret to use (Defaults to environment variable BBL_AZURE_CLIENT_SECRET)
-  --azure-location           Azure Location to use (Defaults to environment variable BBL_AZURE_LOCATION)`
+  --iaas                           IAAS to deploy your BOSH director onto. Valid options: "aws", "azure", "gcp" (Defaults to environment variable BBL_IAAS)
+  [--name]                         Name to assign to your BOSH director (optional, will be randomly generated)
+  [--ops-file]                     Path to a BOSH ops file to apply to the director, can be repeated (optional)
+  [--remove-ops-file]              Name of a previously applied ops file to stop applying, can be repeated (optional)
+  [--vars-file]                    Path to a YAML file of variables for the ops files, can be repeated (optional)
+  [--remove-vars-file]             Name of a previously applied vars file to stop applying, can be repeated (optional)
+  [--var]                          Variable for the ops files as key=value, can be repeated (optional)
+  [--remove-var]                   Key of a previously given variable to stop applying, can be repeated (optional)
+  [--jumpbox-ops-file]             Path to an ops file to apply to the jumpbox, can be repeated (optional)
+  [--remove-jumpbox-ops-file]      Name of a previously applied jumpbox ops file to stop applying, can be repeated (optional)
+  [--cloud-config-ops-file]        Path to an ops file to apply to the cloud config bbl generates, can be repeated (optional)
+  [--remove-cloud-config-ops-file] Name of a previously applied cloud config ops file to stop applying, can be repeated (optional)
+  [--runtime-config]               Path to a runtime config to upload to the director, named after the file, can be repeated (optional)
+  [--remove-runtime-config]        Name of a previously uploaded runtime config file to delete from the director, can be repeated (optional)
+  [--cpi-config]                   Path to a CPI config to upload to the director (optional)
+  [--remove-cpi-config]            Deletes the previously given CPI config from the director (optional)
+  [--no-director]                  Skips creating BOSH environment
+  [--terraform-overrides]          Directory of .tf files to add to the generated terraform template on every apply (optional)
+  [--existing-network]             Existing AWS VPC ID, GCP network name or Azure <resource-group>/<virtual-network> to deploy into (optional)
+  [--internal-cidr]                /16 to /19 range for the director, jumpbox and deployment subnets (Defaults to 10.0.0.0/16)
+  [--bosh-deployment-dir]          Path to a bosh-deployment checkout to use instead of the one included in bbl (optional)
+  [--jumpbox-deployment-dir]       Path to a jumpbox-deployment checkout to use instead of the one included in bbl (optional)
+  [--director-vm-type]             VM type for the director, e.g. m4.xlarge, n1-standard-4 or Standard_D2_v2 (optional)
+  [--director-disk-size]           Size in GB of the director's persistent disk (optional)
+  [--jumpbox-vm-type]              VM type for the jumpbox (optional)
+  [--recreate]                     Runs create-env for the jumpbox and director even when nothing has changed (optional)
+  [--cloud-config-mode]            "apply", "diff" to only print the cloud config changes, or "skip" to leave the cloud config alone (Defaults to apply)
+  [--dry-run]                      Prints the terraform and manifest changes up would make without applying them (optional)
+  [--json-report]                  Path to write the --dry-run report to as JSON (optional)
+
+  --aws-access-key-id              AWS Access Key ID to use (Defaults to environment variable BBL_AWS_ACCESS_KEY_ID)
+  --aws-secret-access-key          AWS Secret Access Key to use (Defaults to environment variable BBL_AWS_SECRET_ACCESS_KEY)
+  --aws-region                     AWS Region to use (Defaults to environment variable BBL_AWS_REGION)
+  [--aws-profile]                  AWS shared credentials profile to use instead of access keys (Defaults to environment variable BBL_AWS_PROFILE)
+  [--aws-shared-credentials-file]  Path to the AWS shared credentials file (Defaults to environment variable BBL_AWS_SHARED_CREDENTIALS_FILE)
+  [--aws-assume-role]              ARN of an IAM role to assume with the AWS credentials (Defaults to environment variable BBL_AWS_ASSUME_ROLE)
+  [--aws-bosh-az]                  AWS Availability Zone to use for BOSH director (Defaults to environment variable BBL_AWS_BOSH_AZ)
+
+  --gcp-service-account-key        GCP Service Access Key to use (Defaults to environment variable BBL_GCP_SERVICE_ACCOUNT_KEY, then the application default credentials)
+  --gcp-zone                       GCP Zone to use for BOSH director (Defaults to environment variable BBL_GCP_ZONE)
+  --gcp-region                     GCP Region to use (Defaults to environment variable BBL_GCP_REGION)
+
+  --azure-subscription-id          Azure Subscription ID to use (Defaults to environment variable BBL_AZURE_SUBSCRIPTION_ID)
+  --azure-tenant-id                Azure Tenant ID to use (Defaults to environment variable BBL_AZURE_TENANT_ID)
+  --azure-client-id                Azure Client ID to use (Defaults to environment variable BBL_AZURE_CLIENT_ID)
+  --azure-client-secret            Azure Client Secret to use (Defaults to environment variable BBL_AZURE_CLIENT_SECRET)
+  --azure-location                 Azure Location to use (Defaults to environment variable BBL_AZURE_LOCATION)
+  [--azure-auth-file]              Path to an Azure SDK auth file with the subscription, tenant, client ID and secret (Defaults to environment variable BBL_AZURE_AUTH_FILE)`
+
+	PlanCommandUsage = `Prints the terraform and manifest changes "bbl up" would make without applying them
+
+  [--name]                         Name to assign to your BOSH director (optional, will be randomly generated)
+  [--ops-file]                     Path to a BOSH ops file to apply to the director, can be repeated (optional)
+  [--remove-ops-file]              Name of a previously applied ops file to stop applying, can be repeated (optional)
+  [--vars-file]                    Path to a YAML file of variables for the ops files, can be repeated (optional)
+  [--remove-vars-file]             Name of a previously applied vars file to stop applying, can be repeated (optional)
+  [--var]                          Variable for the ops files as key=value, can be repeated (optional)
+  [--remove-var]                   Key of a previously given variable to stop applying, can be repeated (optional)
+  [--jumpbox-ops-file]             Path to an ops file to apply to the jumpbox, can be repeated (optional)
+  [--remove-jumpbox-ops-file]      Name of a previously applied jumpbox ops file to stop applying, can be repeated (optional)
+  [--cloud-config-ops-file]        Path to an ops file to apply to the cloud config bbl generates, can be repeated (optional)
+  [--remove-cloud-config-ops-file] Name of a previously applied cloud config ops file to stop applying, can be repeated (optional)
+  [--runtime-config]               Path to a runtime config to upload to the director, named after the file, can be repeated (optional)
+  [--remove-runtime-config]        Name of a previously uploaded runtime config file to delete from the director, can be repeated (optional)
+  [--cpi-config]                   Path to a CPI config to upload to the director (optional)
+  [--remove-cpi-config]            Deletes the previously given CPI config from the director (optional)
+  [--no-director]                  Skips planning the BOSH environment
+  [--terraform-overrides]          Directory of .tf files to add to the generated terraform template (optional)
+  [--existing-network]             Existing AWS VPC ID, GCP network name or Azure <resource-group>/<virtual-network> to deploy into (optional)
+  [--internal-cidr]                /16 to /19 range for the director, jumpbox and deployment subnets (Defaults to 10.0.0.0/16)
+  [--bosh-deployment-dir]          Path to a bosh-deployment checkout to use instead of the one included in bbl (optional)
+  [--jumpbox-deployment-dir]       Path to a jumpbox-deployment checkout to use instead of the one included in bbl (optional)
+  [--director-vm-type]             VM type for the director, e.g. m4.xlarge, n1-standard-4 or Standard_D2_v2 (optional)
+  [--director-disk-size]           Size in GB of the director's persistent disk (optional)
+  [--jumpbox-vm-type]              VM type for the jumpbox (optional)
+  [--json-report]                  Path to write the report to as JSON (optional)`
 
 	DestroyCommandUsage = `Tears down BOSH director infrastructure
 
@@ -30,24 +87,32 @@ const (
 
 	CreateLBsCommandUsage = `Attaches load balancer(s) with a certificate, key, and optional chain
 
-  --type              Load balancer(s) type. Valid options: "concourse" or "cf"
-  [--cert]            Path to SSL certificate (conditionally required; refer to table below)
-  [--key]             Path to SSL certificate key (conditionally required; refer to table below)
-  [--chain]           Path to SSL certificate chain (optional; only supported on aws)
-  [--domain]          Creates a DNS zone and records for the given domain (supported when type="cf")
+  --type                 Load balancer(s) type. Valid options: "concourse" or "cf"
+  [--cert]               Path to SSL certificate, or to a PFX certificate on azure (conditionally required; refer to table below)
+  [--key]                Path to SSL certificate key, or to a file with the PFX password on azure (conditionally required; refer to table below)
+  [--chain]              Path to SSL certificate chain (optional; only supported on aws)
+  [--domain]             Creates a DNS zone and records for the given domain (supported when type="cf")
+  [--cloud-config-mode]  "apply", "diff" to only print the cloud config changes, or "skip" to leave the cloud config alone (Defaults to apply)
+  [--dry-run]            Prints the changes without applying them (optional)
+  [--json-report]        Path to write the --dry-run report to as JSON (optional)
 
   --cert/--key requirements:
-  ------------------------------
-  |     | cf       | concourse |
-  ------------------------------
-  | aws | required | required  |
-  ------------------------------
-  | gcp | required | n/a       |
-  ------------------------------`
+  --------------------------------
+  |       | cf       | concourse |
+  --------------------------------
+  | aws   | required | required  |
+  --------------------------------
+  | gcp   | required | n/a       |
+  --------------------------------
+  | azure | required | n/a       |
+  --------------------------------`
 
 	DeleteLBsCommandUsage = `Deletes load balancer(s)
 
-  [--skip-if-missing]  Skips deleting load balancer(s) if it is not attached (optional)`
+  [--skip-if-missing]    Skips deleting load balancer(s) if it is not attached (optional)
+  [--cloud-config-mode]  "apply", "diff" to only print the cloud config changes, or "skip" to leave the cloud config alone (Defaults to apply)
+  [--dry-run]            Prints the changes without applying them (optional)
+  [--json-report]        Path to write the --dry-run report to as JSON (optional)`
 
 	LBsCommandUsage = "Prints attached load balancer(s)"
 
@@ -59,7 +124,13 @@ const (
 
 	SSHKeyCommandUsage = "Prints SSH private key for the jumpbox user. This can be used to ssh to the director/use the director as a gateway host."
 
-	RotateCommandUsage = "Rotates SSH key for the jumpbox user."
+	RotateCommandUsage = `Rotates SSH key for the jumpbox user, or the given certificates and credentials
+
+  [--certs]        Certificates to rotate instead of the SSH key, along with the certificates they sign: a comma separated list of names, "all" or "expiring-within=<duration>", e.g. expiring-within=30d (optional)
+  [--credentials]  Comma separated names of passwords and keys to rotate instead of the SSH key, e.g. admin_password,nats_password. bosh_vms_private_key replaces the key pair terraform creates for BOSH VMs (optional)
+  [--all]          Rotates every password and key, including bosh_vms_private_key but not credhub_encryption_password (optional)`
+
+	CertsCommandUsage = "Prints the certificates in the jumpbox and director vars-stores with their subject, issuer and expiry"
 
 	JumpboxAddressCommandUsage = "Prints BOSH jumpbox address"
 
@@ -80,10 +151,34 @@ const (
 	JumpboxDeploymentVarsCommandUsage = "Prints required variables for jumpbox deployment"
 
 	CloudConfigUsage = "Prints suggested cloud configuration for BOSH environment"
+
+	RuntimeConfigUsage = "Prints the runtime configs and CPI config bbl up uploads to the director"
+
+	DriftCommandUsage = `Reports changes made outside of bbl to the infrastructure and the director's cloud config, exiting with status 2 if there are any
+
+  [--json-report]  Path to write the report to as JSON (optional)`
+
+	EncryptStateCommandUsage = "Encrypts bbl-state.json with the key given by --state-encryption-key or --state-encryption-key-file"
+
+	DecryptStateCommandUsage = "Decrypts bbl-state.json with the key given by --state-encryption-key or --state-encryption-key-file"
+
+	StateHistoryCommandUsage = "Lists the snapshots of bbl-state.json kept in the state dir (see --state-history-size)"
+
+	MigrateStateCommandUsage = `Migrates bbl-state.json to the current schema version, keeping a backup of the old file
+
+  [--dry-run]  Prints the migration steps without changing bbl-state.json (optional)`
+
+	ForceUnlockCommandUsage = "Removes the lock taken on the state by a bbl that is no longer running"
+
+	StateRestoreCommandUsage = `Restores bbl-state.json from a snapshot
+
+  <snapshot-id>  ID of the snapshot to restore, as printed by "bbl state-history"`
 )
 
 func (Up) Usage() string { return UpCommandUsage }
 
+func (Plan) Usage() string { return PlanCommandUsage }
+
 func (Destroy) Usage() string { return DestroyCommandUsage }
 
 func (CreateLBs) Usage() string { return CreateLBsCommandUsage }
@@ -102,6 +197,10 @@ func (LatestError) Usage() string { return LatestErrorCommandUsage }
 
 func (CloudConfig) Usage() string { return CloudConfigUsage }
 
+func (RuntimeConfig) Usage() string { return RuntimeConfigUsage }
+
+func (Drift) Usage() string { return DriftCommandUsage }
+
 func (BOSHDeploymentVars) Usage() string { return BOSHDeploymentVarsCommandUsage }
 
 func (JumpboxDeploymentVars) Usage() string { return JumpboxDeploymentVarsCommandUsage }
@@ -110,6 +209,20 @@ func (SSHKey) Usage() string { return SSHKeyCommandUsage }
 
 func (Rotate) Usage() string { return RotateCommandUsage }
 
+func (Certs) Usage() string { return CertsCommandUsage }
+
+func (EncryptState) Usage() string { return EncryptStateCommandUsage }
+
+func (DecryptState) Usage() string { return DecryptStateCommandUsage }
+
+func (StateHistory) Usage() string { return StateHistoryCommandUsage }
+
+func (StateRestore) Usage() string { return StateRestoreCommandUsage }
+
+func (ForceUnlock) Usage() string { return ForceUnlockCommandUsage }
+
+func (MigrateState) Usage() string { return MigrateStateCommandUsage }
+
 func (s StateQuery) Usage() string {
 	switch s.propertyName {
 	case EnvIDPropertyName:
@@ -16,25 +16,54 @@ var _ = Describe("Commands Usage", func() {
 				usageText := upCmd.Usage()
 				Expect(usageText).To(Equal(`Deploys BOSH director on an IAAS
 
-  --iaas                     IAAS to deploy your BOSH director onto. Valid options: "aws", "azure", "gcp" (Defaults to environment variable BBL_IAAS)
-  [--name]                   Name to assign to your BOSH director (optional, will be randomly generated)
-  [--ops-file]               Path to BOSH ops file (optional)
-  [--no-director]            Skips creating BOSH environment
-
-  --aws-access-key-id        AWS Access Key ID to use (Defaults to environment variable BBL_AWS_ACCESS_KEY_ID)
-  --aws-secret-access-key    AWS Secret Access Key to use (Defaults to environment variable BBL_AWS_SECRET_ACCESS_KEY)
-  --aws-region               AWS Region to use (Defaults to environment variable BBL_AWS_REGION)
-  [--aws-bosh-az]            AWS Availability Zone to use for BOSH director (Defaults to environment variable BBL_AWS_BOSH_AZ)
-
-  --gcp-service-account-key  GCP Service Access Key to use (Defaults to environment variable BBL_GCP_SERVICE_ACCOUNT_KEY)
-  --gcp-zone                 GCP Zone to use for BOSH director (Defaults to environment variable BBL_GCP_ZONE)
-  --gcp-region               GCP Region to use (Defaults to environment variable BBL_GCP_REGION)
-
-  --azure-subscription-id    Azure Subscription ID to use (Defaults to environment variable BBL_AZURE_SUBSCRIPTION_ID)
-  --azure-tenant-id          Azure Tenant ID to use (Defaults to environment variable BBL_AZURE_TENANT_ID)
-  --azure-client-id          Azure Client ID to use (Defaults to environment variable BBL_AZURE_CLIENT_ID)
-  --azure-client-secret      Azure Client Secret to use (Defaults to environment variable BBL_AZURE_CLIENT_SECRET)
-  --azure-location           Azure Location to use (Defaults to environment variable BBL_AZURE_LOCATION)`))
+  --iaas                           IAAS to deploy your BOSH director onto. Valid options: "aws", "azure", "gcp" (Defaults to environment variable BBL_IAAS)
+  [--name]                         Name to assign to your BOSH director (optional, will be randomly generated)
+  [--ops-file]                     Path to a BOSH ops file to apply to the director, can be repeated (optional)
+  [--remove-ops-file]              Name of a previously applied ops file to stop applying, can be repeated (optional)
+  [--vars-file]                    Path to a YAML file of variables for the ops files, can be repeated (optional)
+  [--remove-vars-file]             Name of a previously applied vars file to stop applying, can be repeated (optional)
+  [--var]                          Variable for the ops files as key=value, can be repeated (optional)
+  [--remove-var]                   Key of a previously given variable to stop applying, can be repeated (optional)
+  [--jumpbox-ops-file]             Path to an ops file to apply to the jumpbox, can be repeated (optional)
+  [--remove-jumpbox-ops-file]      Name of a previously applied jumpbox ops file to stop applying, can be repeated (optional)
+  [--cloud-config-ops-file]        Path to an ops file to apply to the cloud config bbl generates, can be repeated (optional)
+  [--remove-cloud-config-ops-file] Name of a previously applied cloud config ops file to stop applying, can be repeated (optional)
+  [--runtime-config]               Path to a runtime config to upload to the director, named after the file, can be repeated (optional)
+  [--remove-runtime-config]        Name of a previously uploaded runtime config file to delete from the director, can be repeated (optional)
+  [--cpi-config]                   Path to a CPI config to upload to the director (optional)
+  [--remove-cpi-config]            Deletes the previously given CPI config from the director (optional)
+  [--no-director]                  Skips creating BOSH environment
+  [--terraform-overrides]          Directory of .tf files to add to the generated terraform template on every apply (optional)
+  [--existing-network]             Existing AWS VPC ID, GCP network name or Azure <resource-group>/<virtual-network> to deploy into (optional)
+  [--internal-cidr]                /16 to /19 range for the director, jumpbox and deployment subnets (Defaults to 10.0.0.0/16)
+  [--bosh-deployment-dir]          Path to a bosh-deployment checkout to use instead of the one included in bbl (optional)
+  [--jumpbox-deployment-dir]       Path to a jumpbox-deployment checkout to use instead of the one included in bbl (optional)
+  [--director-vm-type]             VM type for the director, e.g. m4.xlarge, n1-standard-4 or Standard_D2_v2 (optional)
+  [--director-disk-size]           Size in GB of the director's persistent disk (optional)
+  [--jumpbox-vm-type]              VM type for the jumpbox (optional)
+  [--recreate]                     Runs create-env for the jumpbox and director even when nothing has changed (optional)
+  [--cloud-config-mode]            "apply", "diff" to only print the cloud config changes, or "skip" to leave the cloud config alone (Defaults to apply)
+  [--dry-run]                      Prints the terraform and manifest changes up would make without applying them (optional)
+  [--json-report]                  Path to write the --dry-run report to as JSON (optional)
+
+  --aws-access-key-id              AWS Access Key ID to use (Defaults to environment variable BBL_AWS_ACCESS_KEY_ID)
+  --aws-secret-access-key          AWS Secret Access Key to use (Defaults to environment variable BBL_AWS_SECRET_ACCESS_KEY)
+  --aws-region                     AWS Region to use (Defaults to environment variable BBL_AWS_REGION)
+  [--aws-profile]                  AWS shared credentials profile to use instead of access keys (Defaults to environment variable BBL_AWS_PROFILE)
+  [--aws-shared-credentials-file]  Path to the AWS shared credentials file (Defaults to environment variable BBL_AWS_SHARED_CREDENTIALS_FILE)
+  [--aws-assume-role]              ARN of an IAM role to assume with the AWS credentials (Defaults to environment variable BBL_AWS_ASSUME_ROLE)
+  [--aws-bosh-az]                  AWS Availability Zone to use for BOSH director (Defaults to environment variable BBL_AWS_BOSH_AZ)
+
+  --gcp-service-account-key        GCP Service Access Key to use (Defaults to environment variable BBL_GCP_SERVICE_ACCOUNT_KEY, then the application default credentials)
+  --gcp-zone                       GCP Zone to use for BOSH director (Defaults to environment variable BBL_GCP_ZONE)
+  --gcp-region                     GCP Region to use (Defaults to environment variable BBL_GCP_REGION)
+
+  --azure-subscription-id          Azure Subscription ID to use (Defaults to environment variable BBL_AZURE_SUBSCRIPTION_ID)
+  --azure-tenant-id                Azure Tenant ID to use (Defaults to environment variable BBL_AZURE_TENANT_ID)
+  --azure-client-id                Azure Client ID to use (Defaults to environment variable BBL_AZURE_CLIENT_ID)
+  --azure-client-secret            Azure Client Secret to use (Defaults to environment variable BBL_AZURE_CLIENT_SECRET)
+  --azure-location                 Azure Location to use (Defaults to environment variable BBL_AZURE_LOCATION)
+  [--azure-auth-file]              Path to an Azure SDK auth file with the subscription, tenant, client ID and secret (Defaults to environment variable BBL_AZURE_AUTH_FILE)`))
 			})
 		})
 	})
@@ -46,20 +75,25 @@ var _ = Describe("Commands Usage", func() {
 				usageText := command.Usage()
 				Expect(usageText).To(Equal(`Attaches load balancer(s) with a certificate, key, and optional chain
 
-  --type              Load balancer(s) type. Valid options: "concourse" or "cf"
-  [--cert]            Path to SSL certificate (conditionally required; refer to table below)
-  [--key]             Path to SSL certificate key (conditionally required; refer to table below)
-  [--chain]           Path to SSL certificate chain (optional; only supported on aws)
-  [--domain]          Creates a DNS zone and records for the given domain (supported when type="cf")
+  --type                 Load balancer(s) type. Valid options: "concourse" or "cf"
+  [--cert]               Path to SSL certificate, or to a PFX certificate on azure (conditionally required; refer to table below)
+  [--key]                Path to SSL certificate key, or to a file with the PFX password on azure (conditionally required; refer to table below)
+  [--chain]              Path to SSL certificate chain (optional; only supported on aws)
+  [--domain]             Creates a DNS zone and records for the given domain (supported when type="cf")
+  [--cloud-config-mode]  "apply", "diff" to only print the cloud config changes, or "skip" to leave the cloud config alone (Defaults to apply)
+  [--dry-run]            Prints the changes without applying them (optional)
+  [--json-report]        Path to write the --dry-run report to as JSON (optional)
 
   --cert/--key requirements:
-  ------------------------------
-  |     | cf       | concourse |
-  ------------------------------
-  | aws | required | required  |
-  ------------------------------
-  | gcp | required | n/a       |
-  ------------------------------`))
+  --------------------------------
+  |       | cf       | concourse |
+  --------------------------------
+  | aws   | required | required  |
+  --------------------------------
+  | gcp   | required | n/a       |
+  --------------------------------
+  | azure | required | n/a       |
+  --------------------------------`))
 			})
 		})
 	})
@@ -71,7 +105,58 @@ var _ = Describe("Commands Usage", func() {
 				usageText := command.Usage()
 				Expect(usageText).To(Equal(`Deletes load balancer(s)
 
-  [--skip-if-missing]  Skips deleting load balancer(s) if it is not attached (optional)`))
+  [--skip-if-missing]    Skips deleting load balancer(s) if it is not attached (optional)
+  [--cloud-config-mode]  "apply", "diff" to only print the cloud config changes, or "skip" to leave the cloud config alone (Defaults to apply)
+  [--dry-run]            Prints the changes without applying them (optional)
+  [--json-report]        Path to write the --dry-run report to as JSON (optional)`))
+			})
+		})
+	})
+
+	Describe("Plan", func() {
+		Describe("Usage", func() {
+			It("returns string describing usage", func() {
+				command := commands.Plan{}
+				usageText := command.Usage()
+				Expect(usageText).To(Equal(`Prints the terraform and manifest changes "bbl up" would make without applying them
+
+  [--name]                         Name to assign to your BOSH director (optional, will be randomly generated)
+  [--ops-file]                     Path to a BOSH ops file to apply to the director, can be repeated (optional)
+  [--remove-ops-file]              Name of a previously applied ops file to stop applying, can be repeated (optional)
+  [--vars-file]                    Path to a YAML file of variables for the ops files, can be repeated (optional)
+  [--remove-vars-file]             Name of a previously applied vars file to stop applying, can be repeated (optional)
+  [--var]                          Variable for the ops files as key=value, can be repeated (optional)
+  [--remove-var]                   Key of a previously given variable to stop applying, can be repeated (optional)
+  [--jumpbox-ops-file]             Path to an ops file to apply to the jumpbox, can be repeated (optional)
+  [--remove-jumpbox-ops-file]      Name of a previously applied jumpbox ops file to stop applying, can be repeated (optional)
+  [--cloud-config-ops-file]        Path to an ops file to apply to the cloud config bbl generates, can be repeated (optional)
+  [--remove-cloud-config-ops-file] Name of a previously applied cloud config ops file to stop applying, can be repeated (optional)
+  [--runtime-config]               Path to a runtime config to upload to the director, named after the file, can be repeated (optional)
+  [--remove-runtime-config]        Name of a previously uploaded runtime config file to delete from the director, can be repeated (optional)
+  [--cpi-config]                   Path to a CPI config to upload to the director (optional)
+  [--remove-cpi-config]            Deletes the previously given CPI config from the director (optional)
+  [--no-director]                  Skips planning the BOSH environment
+  [--terraform-overrides]          Directory of .tf files to add to the generated terraform template (optional)
+  [--existing-network]             Existing AWS VPC ID, GCP network name or Azure <resource-group>/<virtual-network> to deploy into (optional)
+  [--internal-cidr]                /16 to /19 range for the director, jumpbox and deployment subnets (Defaults to 10.0.0.0/16)
+  [--bosh-deployment-dir]          Path to a bosh-deployment checkout to use instead of the one included in bbl (optional)
+  [--jumpbox-deployment-dir]       Path to a jumpbox-deployment checkout to use instead of the one included in bbl (optional)
+  [--director-vm-type]             VM type for the director, e.g. m4.xlarge, n1-standard-4 or Standard_D2_v2 (optional)
+  [--director-disk-size]           Size in GB of the director's persistent disk (optional)
+  [--jumpbox-vm-type]              VM type for the jumpbox (optional)
+  [--json-report]                  Path to write the report to as JSON (optional)`))
+			})
+		})
+	})
+
+	Describe("Drift", func() {
+		Describe("Usage", func() {
+			It("returns string describing usage", func() {
+				command := commands.Drift{}
+				usageText := command.Usage()
+				Expect(usageText).To(Equal(`Reports changes made outside of bbl to the infrastructure and the director's cloud config, exiting with status 2 if there are any
+
+  [--json-report]  Path to write the report to as JSON (optional)`))
 			})
 		})
 	})
@@ -111,13 +196,19 @@ var _ = Describe("Commands Usage", func() {
 		Entry("director-ca-cert", newStateQuery("director ca cert"), "Prints BOSH director CA certificate"),
 		Entry("env-id", newStateQuery("environment id"), "Prints environment ID"),
 		Entry("ssh-key", commands.SSHKey{}, "Prints SSH private key for the jumpbox user. This can be used to ssh to the director/use the director as a gateway host."),
-		Entry("rotate", commands.Rotate{}, "Rotates SSH key for the jumpbox user."),
+		Entry("rotate", commands.Rotate{}, `Rotates SSH key for the jumpbox user, or the given certificates and credentials
+
+  [--certs]        Certificates to rotate instead of the SSH key, along with the certificates they sign: a comma separated list of names, "all" or "expiring-within=<duration>", e.g. expiring-within=30d (optional)
+  [--credentials]  Comma separated names of passwords and keys to rotate instead of the SSH key, e.g. admin_password,nats_password. bosh_vms_private_key replaces the key pair terraform creates for BOSH VMs (optional)
+  [--all]          Rotates every password and key, including bosh_vms_private_key but not credhub_encryption_password (optional)`),
+		Entry("certs", commands.Certs{}, "Prints the certificates in the jumpbox and director vars-stores with their subject, issuer and expiry"),
 		Entry("print-env", commands.PrintEnv{}, "Prints required BOSH environment variables"),
 		Entry("latest-error", commands.LatestError{}, "Prints the output from the latest call to terraform"),
 		Entry("bosh-deployment-vars", commands.BOSHDeploymentVars{}, "Prints required variables for BOSH deployment"),
 		Entry("jumpbox-deployment-vars", commands.JumpboxDeploymentVars{}, "Prints required variables for jumpbox deployment"),
 		Entry("version", commands.Version{}, "Prints version"),
 		Entry("cloud-config", commands.CloudConfig{}, "Prints suggested cloud configuration for BOSH environment"),
+		Entry("runtime-config", commands.RuntimeConfig{}, "Prints the runtime configs and CPI config bbl up uploads to the director"),
 	)
 })
 
@@ -3,6 +3,7 @@ package commands
 import (
 	"errors"
 	"fmt"
+	"io/ioutil"
 
 	"github.com/cloudfoundry/bosh-bootloader/flags"
 	"github.com/cloudfoundry/bosh-bootloader/storage"
@@ -14,6 +15,7 @@ type CreateLBs struct {
 	certificateValidator certificateValidator
 	logger               logger
 	stateValidator       stateValidator
+	planner              planner
 }
 
 type CreateLBsCmd interface {
@@ -21,19 +23,25 @@ type CreateLBsCmd interface {
 }
 
 type CreateLBsConfig struct {
-	AWS AWSCreateLBsConfig
-	GCP GCPCreateLBsConfig
+	AWS        AWSCreateLBsConfig
+	GCP        GCPCreateLBsConfig
+	Azure      AzureCreateLBsConfig
+	DryRun     bool
+	JSONReport string
+
+	CloudConfigMode string
 }
 
 var LBNotFound error = errors.New("no load balancer has been found for this bbl environment")
 
-func NewCreateLBs(createLBsCmd CreateLBsCmd, logger logger, stateValidator stateValidator, certificateValidator certificateValidator, boshManager boshManager) CreateLBs {
+func NewCreateLBs(createLBsCmd CreateLBsCmd, logger logger, stateValidator stateValidator, certificateValidator certificateValidator, boshManager boshManager, planner planner) CreateLBs {
 	return CreateLBs{
 		createLBsCmd:         createLBsCmd,
 		boshManager:          boshManager,
 		logger:               logger,
 		stateValidator:       stateValidator,
 		certificateValidator: certificateValidator,
+		planner:              planner,
 	}
 }
 
@@ -51,13 +59,25 @@ func (c CreateLBs) CheckFastFails(subcommandFlags []string, state storage.State)
 		return errors.New("--type is required")
 	}
 
-	if !(state.IAAS == "gcp" && getLBType(config) == "concourse") {
+	switch {
+	case state.IAAS == "azure" && getLBType(config) == "cf":
+		err = c.certificateValidator.ValidatePKCS12("create-lbs", getCertPath(config), getKeyPath(config))
+		if err != nil {
+			return fmt.Errorf("Validate certificate: %s", err)
+		}
+	case state.IAAS == "azure", state.IAAS == "gcp" && getLBType(config) == "concourse":
+	default:
 		err = c.certificateValidator.Validate("create-lbs", getCertPath(config), getKeyPath(config), getChainPath(config))
 		if err != nil {
 			return fmt.Errorf("Validate certificate: %s", err)
 		}
 	}
 
+	err = validateCloudConfigMode(config.CloudConfigMode)
+	if err != nil {
+		return err
+	}
+
 	if getLBType(config) == "concourse" && getDomain(config) != "" {
 		return errors.New("--domain is not implemented for concourse load balancers. Remove the --domain flag and try again.")
 	}
@@ -78,6 +98,15 @@ func (c CreateLBs) Execute(args []string, state storage.State) error {
 		return err
 	}
 
+	if config.DryRun {
+		state, err = desiredLBState(config, state)
+		if err != nil {
+			return err
+		}
+
+		return c.planner.Plan(state, config.JSONReport)
+	}
+
 	err = c.createLBsCmd.Execute(config, state)
 	if err != nil {
 		return err
@@ -86,6 +115,54 @@ func (c CreateLBs) Execute(args []string, state storage.State) error {
 	return nil
 }
 
+// desiredLBState returns state with the load balancer described by config,
+// the same way the IAAS specific create-lbs commands would before applying it.
+func desiredLBState(config CreateLBsConfig, state storage.State) (storage.State, error) {
+	lbType := getLBType(config)
+	domain := getDomain(config)
+	if state.LB.Type != "" && domain == "" {
+		domain = state.LB.Domain
+	}
+
+	state.LB.Type = lbType
+	state.LB.Domain = domain
+
+	if (state.IAAS == "gcp" || state.IAAS == "azure") && lbType != "cf" {
+		return state, nil
+	}
+
+	if state.IAAS == "azure" {
+		var err error
+		state.LB.Cert, state.LB.Key, err = readPFX(getCertPath(config), getKeyPath(config))
+		if err != nil {
+			return storage.State{}, err
+		}
+
+		return state, nil
+	}
+
+	for _, file := range []struct {
+		path     string
+		contents *string
+	}{
+		{getCertPath(config), &state.LB.Cert},
+		{getKeyPath(config), &state.LB.Key},
+		{getChainPath(config), &state.LB.Chain},
+	} {
+		if file.path == "" {
+			continue
+		}
+
+		contents, err := ioutil.ReadFile(file.path)
+		if err != nil {
+			return storage.State{}, err
+		}
+		*file.contents = string(contents)
+	}
+
+	return state, nil
+}
+
 func parseFlags(subcommandFlags []string, iaas string, existingLBType string) (CreateLBsConfig, error) {
 	lbFlags := flags.New("create-lbs")
 
@@ -102,7 +179,15 @@ func parseFlags(subcommandFlags []string, iaas string, existingLBType string) (C
 		lbFlags.String(&config.GCP.CertPath, "cert", "")
 		lbFlags.String(&config.GCP.KeyPath, "key", "")
 		lbFlags.String(&config.GCP.Domain, "domain", "")
+	case "azure":
+		lbFlags.String(&config.Azure.LBType, "type", existingLBType)
+		lbFlags.String(&config.Azure.CertPath, "cert", "")
+		lbFlags.String(&config.Azure.KeyPath, "key", "")
+		lbFlags.String(&config.Azure.Domain, "domain", "")
 	}
+	lbFlags.Bool(&config.DryRun, "", "dry-run", false)
+	lbFlags.String(&config.JSONReport, "json-report", "")
+	lbFlags.String(&config.CloudConfigMode, "cloud-config-mode", cloudConfigModeApply)
 
 	if err := lbFlags.Parse(subcommandFlags); err != nil {
 		return config, err
@@ -118,6 +203,9 @@ func getLBType(config CreateLBsConfig) string {
 	if config.GCP.LBType != "" {
 		return config.GCP.LBType
 	}
+	if config.Azure.LBType != "" {
+		return config.Azure.LBType
+	}
 	return ""
 }
 
@@ -128,6 +216,9 @@ func getCertPath(config CreateLBsConfig) string {
 	if config.GCP.CertPath != "" {
 		return config.GCP.CertPath
 	}
+	if config.Azure.CertPath != "" {
+		return config.Azure.CertPath
+	}
 	return ""
 }
 
@@ -138,6 +229,9 @@ func getKeyPath(config CreateLBsConfig) string {
 	if config.GCP.KeyPath != "" {
 		return config.GCP.KeyPath
 	}
+	if config.Azure.KeyPath != "" {
+		return config.Azure.KeyPath
+	}
 	return ""
 }
 
@@ -155,5 +249,8 @@ func getDomain(config CreateLBsConfig) string {
 	if config.GCP.Domain != "" {
 		return config.GCP.Domain
 	}
+	if config.Azure.Domain != "" {
+		return config.Azure.Domain
+	}
 	return ""
 }
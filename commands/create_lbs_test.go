@@ -1,7 +1,11 @@
 package commands_test
 
 import (
+	"encoding/base64"
 	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 
 	"github.com/cloudfoundry/bosh-bootloader/commands"
 	"github.com/cloudfoundry/bosh-bootloader/fakes"
@@ -19,6 +23,7 @@ var _ = Describe("create-lbs", func() {
 		certificateValidator *fakes.CertificateValidator
 		logger               *fakes.Logger
 		stateValidator       *fakes.StateValidator
+		planner              *fakes.Planner
 	)
 
 	BeforeEach(func() {
@@ -28,8 +33,9 @@ var _ = Describe("create-lbs", func() {
 		certificateValidator = &fakes.CertificateValidator{}
 		logger = &fakes.Logger{}
 		stateValidator = &fakes.StateValidator{}
+		planner = &fakes.Planner{}
 
-		command = commands.NewCreateLBs(createLBsCmd, logger, stateValidator, certificateValidator, boshManager)
+		command = commands.NewCreateLBs(createLBsCmd, logger, stateValidator, certificateValidator, boshManager, planner)
 	})
 
 	Describe("CheckFastFails", func() {
@@ -125,6 +131,39 @@ var _ = Describe("create-lbs", func() {
 			})
 		})
 
+		Context("when iaas is azure and lb type is cf", func() {
+			It("validates the PFX certificate and its password", func() {
+				certificateValidator.ValidatePKCS12Call.Returns.Error = errors.New("failed to validate")
+				err := command.CheckFastFails([]string{
+					"--type", "cf",
+					"--cert", "/path/to/cert.pfx",
+					"--key", "/path/to/password",
+				}, storage.State{
+					IAAS: "azure",
+				})
+
+				Expect(err).To(MatchError("Validate certificate: failed to validate"))
+				Expect(certificateValidator.ValidatePKCS12Call.Receives.Command).To(Equal("create-lbs"))
+				Expect(certificateValidator.ValidatePKCS12Call.Receives.CertificatePath).To(Equal("/path/to/cert.pfx"))
+				Expect(certificateValidator.ValidatePKCS12Call.Receives.PasswordPath).To(Equal("/path/to/password"))
+				Expect(certificateValidator.ValidateCall.CallCount).To(Equal(0))
+			})
+		})
+
+		Context("when iaas is azure and lb type is concourse", func() {
+			It("does not call certificateValidator", func() {
+				err := command.CheckFastFails([]string{
+					"--type", "concourse",
+				}, storage.State{
+					IAAS: "azure",
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(certificateValidator.ValidateCall.CallCount).To(Equal(0))
+				Expect(certificateValidator.ValidatePKCS12Call.CallCount).To(Equal(0))
+			})
+		})
+
 		Context("when lb type is concourse and domain flag is supplied", func() {
 			It("returns an error", func() {
 				err := command.CheckFastFails(
@@ -138,6 +177,18 @@ var _ = Describe("create-lbs", func() {
 				Expect(err).To(MatchError("--domain is not implemented for concourse load balancers. Remove the --domain flag and try again."))
 			})
 		})
+
+		Context("when the cloud config mode is not known", func() {
+			It("returns an error", func() {
+				err := command.CheckFastFails([]string{
+					"--type", "concourse",
+					"--cloud-config-mode", "preview",
+				}, storage.State{
+					IAAS: "gcp",
+				})
+				Expect(err).To(MatchError("--cloud-config-mode must be apply, diff or skip"))
+			})
+		})
 	})
 
 	Describe("Execute", func() {
@@ -151,7 +202,7 @@ var _ = Describe("create-lbs", func() {
 				Expect(err).NotTo(HaveOccurred())
 				Expect(createLBsCmd.ExecuteCall.Receives.Config).Should(Equal(commands.CreateLBsConfig{GCP: commands.GCPCreateLBsConfig{
 					LBType: "concourse",
-				}}))
+				}, CloudConfigMode: "apply"}))
 			})
 		})
 
@@ -171,7 +222,7 @@ var _ = Describe("create-lbs", func() {
 					CertPath: "my-cert",
 					KeyPath:  "my-key",
 					Domain:   "some-domain",
-				}}))
+				}, CloudConfigMode: "apply"}))
 			})
 		})
 
@@ -197,6 +248,33 @@ var _ = Describe("create-lbs", func() {
 							ChainPath: "my-chain",
 							Domain:    "some-domain",
 						},
+						CloudConfigMode: "apply",
+					},
+				))
+			})
+		})
+
+		Context("if the iaas is Azure", func() {
+			It("creates an Azure lb type", func() {
+				err := command.Execute([]string{
+					"--type", "cf",
+					"--cert", "my-cert.pfx",
+					"--key", "my-password",
+					"--domain", "some-domain",
+				}, storage.State{
+					IAAS: "azure",
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(createLBsCmd.ExecuteCall.Receives.Config).Should(Equal(
+					commands.CreateLBsConfig{
+						Azure: commands.AzureCreateLBsConfig{
+							LBType:   "cf",
+							CertPath: "my-cert.pfx",
+							KeyPath:  "my-password",
+							Domain:   "some-domain",
+						},
+						CloudConfigMode: "apply",
 					},
 				))
 			})
@@ -223,6 +301,7 @@ var _ = Describe("create-lbs", func() {
 								CertPath: "some-new-cert",
 								KeyPath:  "some-new-key",
 							},
+							CloudConfigMode: "apply",
 						},
 					))
 				})
@@ -248,12 +327,98 @@ var _ = Describe("create-lbs", func() {
 								CertPath: "some-new-cert",
 								KeyPath:  "some-new-key",
 							},
+							CloudConfigMode: "apply",
 						},
 					))
 				})
 			})
 		})
 
+		Context("when --dry-run is passed", func() {
+			var tempDir string
+
+			BeforeEach(func() {
+				var err error
+				tempDir, err = ioutil.TempDir("", "")
+				Expect(err).NotTo(HaveOccurred())
+
+				for _, name := range []string{"cert", "key", "chain"} {
+					err = ioutil.WriteFile(filepath.Join(tempDir, name), []byte("some-"+name), os.ModePerm)
+					Expect(err).NotTo(HaveOccurred())
+				}
+			})
+
+			It("plans the state with the load balancer instead of creating it", func() {
+				err := command.Execute([]string{
+					"--type", "cf",
+					"--cert", filepath.Join(tempDir, "cert"),
+					"--key", filepath.Join(tempDir, "key"),
+					"--chain", filepath.Join(tempDir, "chain"),
+					"--domain", "some-domain",
+					"--dry-run",
+					"--json-report", "some-report.json",
+				}, storage.State{
+					IAAS:    "aws",
+					TFState: "some-tf-state",
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(createLBsCmd.ExecuteCall.CallCount).To(Equal(0))
+				Expect(planner.PlanCall.CallCount).To(Equal(1))
+				Expect(planner.PlanCall.Receives.JSONReportPath).To(Equal("some-report.json"))
+				Expect(planner.PlanCall.Receives.State).To(Equal(storage.State{
+					IAAS:    "aws",
+					TFState: "some-tf-state",
+					LB: storage.LB{
+						Type:   "cf",
+						Cert:   "some-cert",
+						Key:    "some-key",
+						Chain:  "some-chain",
+						Domain: "some-domain",
+					},
+				}))
+			})
+
+			It("keeps the existing domain and skips certificates for gcp concourse load balancers", func() {
+				err := command.Execute([]string{"--dry-run"}, storage.State{
+					IAAS: "gcp",
+					LB:   storage.LB{Type: "concourse", Domain: "some-domain"},
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(planner.PlanCall.Receives.State.LB).To(Equal(storage.LB{
+					Type:   "concourse",
+					Domain: "some-domain",
+				}))
+			})
+
+			It("plans azure cf load balancers with the base64 encoded PFX certificate", func() {
+				err := command.Execute([]string{
+					"--type", "cf",
+					"--cert", filepath.Join(tempDir, "cert"),
+					"--key", filepath.Join(tempDir, "key"),
+					"--dry-run",
+				}, storage.State{IAAS: "azure"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(planner.PlanCall.Receives.State.LB).To(Equal(storage.LB{
+					Type: "cf",
+					Cert: base64.StdEncoding.EncodeToString([]byte("some-cert")),
+					Key:  "some-key",
+				}))
+			})
+
+			It("returns an error when a certificate cannot be read", func() {
+				err := command.Execute([]string{
+					"--type", "concourse",
+					"--cert", "/some/missing/cert",
+					"--dry-run",
+				}, storage.State{IAAS: "aws"})
+				Expect(err).To(MatchError("open /some/missing/cert: no such file or directory"))
+				Expect(planner.PlanCall.CallCount).To(Equal(0))
+			})
+		})
+
 		Context("failure cases", func() {
 			Context("when an invalid command line flag is supplied", func() {
 				It("returns an error", func() {
@@ -0,0 +1,47 @@
+package commands
+
+import "github.com/cloudfoundry/bosh-bootloader/storage"
+
+type DecryptState struct {
+	logger         logger
+	stateValidator stateValidator
+	stateBackend   storage.Backend
+}
+
+func NewDecryptState(logger logger, stateValidator stateValidator, stateBackend storage.Backend) DecryptState {
+	return DecryptState{
+		logger:         logger,
+		stateValidator: stateValidator,
+		stateBackend:   stateBackend,
+	}
+}
+
+func (d DecryptState) CheckFastFails(subcommandFlags []string, state storage.State) error {
+	if _, ok := d.stateBackend.(stateEncrypter); !ok {
+		return errStateEncryptionKeyRequired
+	}
+
+	err := d.stateValidator.Validate()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (d DecryptState) Execute(subcommandFlags []string, state storage.State) error {
+	decrypter, ok := d.stateBackend.(stateEncrypter)
+	if !ok {
+		return errStateEncryptionKeyRequired
+	}
+
+	d.logger.Step("decrypting bbl-state.json")
+	err := decrypter.DecryptState()
+	if err != nil {
+		return err
+	}
+
+	d.logger.Printf("bbl-state.json in %s is no longer encrypted\n", d.stateBackend.Location())
+
+	return nil
+}
@@ -15,15 +15,19 @@ type DeleteLBs struct {
 	stateStore           stateStore
 	environmentValidator environmentValidator
 	terraformManager     terraformApplier
+	planner              planner
 }
 
 type config struct {
-	skipIfMissing bool
+	skipIfMissing   bool
+	dryRun          bool
+	jsonReport      string
+	cloudConfigMode string
 }
 
 func NewDeleteLBs(logger logger, stateValidator stateValidator, boshManager boshManager,
 	cloudConfigManager cloudConfigManager, stateStore stateStore,
-	environmentValidator environmentValidator, terraformManager terraformApplier) DeleteLBs {
+	environmentValidator environmentValidator, terraformManager terraformApplier, planner planner) DeleteLBs {
 	return DeleteLBs{
 		logger:               logger,
 		stateValidator:       stateValidator,
@@ -32,6 +36,7 @@ func NewDeleteLBs(logger logger, stateValidator stateValidator, boshManager bosh
 		stateStore:           stateStore,
 		environmentValidator: environmentValidator,
 		terraformManager:     terraformManager,
+		planner:              planner,
 	}
 }
 
@@ -48,7 +53,12 @@ func (d DeleteLBs) CheckFastFails(subcommandFlags []string, state storage.State)
 		}
 	}
 
-	return nil
+	config, err := d.parseFlags(subcommandFlags)
+	if err != nil {
+		return err
+	}
+
+	return validateCloudConfigMode(config.cloudConfigMode)
 }
 
 func (d DeleteLBs) Execute(subcommandFlags []string, state storage.State) error {
@@ -73,8 +83,12 @@ func (d DeleteLBs) Execute(subcommandFlags []string, state storage.State) error
 
 	state.LB = storage.LB{}
 
+	if config.dryRun {
+		return d.planner.Plan(state, config.jsonReport)
+	}
+
 	if !state.NoDirector {
-		err = d.cloudConfigManager.Update(state)
+		err = updateCloudConfig(d.cloudConfigManager, state, config.cloudConfigMode)
 		if err != nil {
 			return fmt.Errorf("Update cloud config: %s", err)
 		}
@@ -98,6 +112,9 @@ func (DeleteLBs) parseFlags(subcommandFlags []string) (config, error) {
 
 	c := config{}
 	lbFlags.Bool(&c.skipIfMissing, "skip-if-missing", "", false)
+	lbFlags.Bool(&c.dryRun, "", "dry-run", false)
+	lbFlags.String(&c.jsonReport, "json-report", "")
+	lbFlags.String(&c.cloudConfigMode, "cloud-config-mode", cloudConfigModeApply)
 
 	err := lbFlags.Parse(subcommandFlags)
 	if err != nil {
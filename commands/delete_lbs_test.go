@@ -24,6 +24,7 @@ var _ = Describe("DeleteLBs", func() {
 		cloudConfigManager   *fakes.CloudConfigManager
 		terraformManager     *commandsFakes.TerraformApplier
 		stateStore           *fakes.StateStore
+		planner              *fakes.Planner
 
 		incomingState storage.State
 	)
@@ -37,6 +38,7 @@ var _ = Describe("DeleteLBs", func() {
 		cloudConfigManager = &fakes.CloudConfigManager{}
 		terraformManager = &commandsFakes.TerraformApplier{}
 		stateStore = &fakes.StateStore{}
+		planner = &fakes.Planner{}
 
 		incomingState = storage.State{
 			LB: storage.LB{
@@ -47,7 +49,7 @@ var _ = Describe("DeleteLBs", func() {
 			TFState: "some-tf-state",
 		}
 
-		command = commands.NewDeleteLBs(logger, stateValidator, boshManager, cloudConfigManager, stateStore, environmentValidator, terraformManager)
+		command = commands.NewDeleteLBs(logger, stateValidator, boshManager, cloudConfigManager, stateStore, environmentValidator, terraformManager, planner)
 	})
 
 	Describe("CheckFastFails", func() {
@@ -90,6 +92,13 @@ var _ = Describe("DeleteLBs", func() {
 				Expect(err).NotTo(HaveOccurred())
 			})
 		})
+
+		Context("when the cloud config mode is not known", func() {
+			It("returns an error", func() {
+				err := command.CheckFastFails([]string{"--cloud-config-mode", "preview"}, storage.State{})
+				Expect(err).To(MatchError("--cloud-config-mode must be apply, diff or skip"))
+			})
+		})
 	})
 
 	Describe("Execute", func() {
@@ -142,6 +151,17 @@ var _ = Describe("DeleteLBs", func() {
 			})
 		})
 
+		Context("when the cloud config mode is skip", func() {
+			It("leaves the director's cloud config alone", func() {
+				err := command.Execute([]string{"--cloud-config-mode", "skip"}, incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(cloudConfigManager.UpdateCall.CallCount).To(Equal(0))
+				Expect(cloudConfigManager.DiffCall.CallCount).To(Equal(0))
+				Expect(terraformManager.ApplyCallCount()).To(Equal(1))
+			})
+		})
+
 		Context("when there is no lb", func() {
 			It("returns an error", func() {
 				err := command.Execute([]string{}, storage.State{
@@ -229,6 +249,30 @@ var _ = Describe("DeleteLBs", func() {
 			})
 		})
 
+		Context("when --dry-run is provided", func() {
+			It("plans the state without load balancers instead of deleting them", func() {
+				err := command.Execute([]string{"--dry-run", "--json-report", "some-report.json"}, incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				expectedState := incomingState
+				expectedState.LB = storage.LB{}
+
+				Expect(planner.PlanCall.CallCount).To(Equal(1))
+				Expect(planner.PlanCall.Receives.State).To(Equal(expectedState))
+				Expect(planner.PlanCall.Receives.JSONReportPath).To(Equal("some-report.json"))
+
+				Expect(cloudConfigManager.UpdateCall.CallCount).To(Equal(0))
+				Expect(terraformManager.ApplyCallCount()).To(Equal(0))
+				Expect(stateStore.SetCall.CallCount).To(Equal(0))
+			})
+
+			It("returns an error when there is no lb", func() {
+				err := command.Execute([]string{"--dry-run"}, storage.State{})
+				Expect(err).To(MatchError(commands.LBNotFound))
+				Expect(planner.PlanCall.CallCount).To(Equal(0))
+			})
+		})
+
 		Context("when --skip-if-missing is provided", func() {
 			DescribeTable("no-ops", func(state storage.State) {
 				err := command.Execute([]string{
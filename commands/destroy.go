@@ -3,6 +3,7 @@ package commands
 import (
 	"fmt"
 	"io"
+	"sort"
 	"strings"
 
 	"github.com/cloudfoundry/bosh-bootloader/bosh"
@@ -28,6 +29,7 @@ type destroyConfig struct {
 
 type NetworkDeletionValidator interface {
 	ValidateSafeToDelete(networkName string, envID string) error
+	ValidateSubnetsSafeToDelete(networkName string, subnets []string, envID string) error
 }
 
 func NewDestroy(logger logger, stdin io.Reader,
@@ -91,7 +93,17 @@ func (d Destroy) CheckFastFails(subcommandFlags []string, state storage.State) e
 		}
 		networkName = output.(string)
 	} else if state.IAAS == "azure" {
-		return nil
+		output, ok := terraformOutputs["bosh_resource_group_name"]
+		if !ok {
+			return nil
+		}
+		networkName = output.(string)
+	}
+
+	// bbl never deletes a network it did not create, so only VMs in the
+	// subnets it created there are in the way of deleting the environment.
+	if state.ExistingNetwork != "" {
+		return d.networkDeletionValidator.ValidateSubnetsSafeToDelete(networkName, ownSubnets(state.IAAS, terraformOutputs), state.EnvID)
 	}
 
 	err = d.networkDeletionValidator.ValidateSafeToDelete(networkName, state.EnvID)
@@ -102,6 +114,37 @@ func (d Destroy) CheckFastFails(subcommandFlags []string, state storage.State) e
 	return nil
 }
 
+// ownSubnets returns the subnets bbl created for the environment, as IDs on
+// AWS and names on GCP and Azure.
+func ownSubnets(iaas string, terraformOutputs map[string]interface{}) []string {
+	subnets := []string{}
+	addSubnet := func(subnet interface{}) {
+		if name, ok := subnet.(string); ok && name != "" {
+			subnets = append(subnets, name)
+		}
+	}
+
+	switch iaas {
+	case "aws":
+		addSubnet(terraformOutputs["bosh_subnet_id"])
+		internalSubnets, _ := terraformOutputs["internal_az_subnet_id_mapping"].(map[string]interface{})
+		azs := []string{}
+		for az := range internalSubnets {
+			azs = append(azs, az)
+		}
+		sort.Strings(azs)
+		for _, az := range azs {
+			addSubnet(internalSubnets[az])
+		}
+	case "gcp":
+		addSubnet(terraformOutputs["subnetwork_name"])
+	case "azure":
+		addSubnet(terraformOutputs["bosh_subnet_name"])
+	}
+
+	return subnets
+}
+
 func (d Destroy) Execute(subcommandFlags []string, state storage.State) error {
 	config, err := d.parseFlags(subcommandFlags)
 	if err != nil {
@@ -148,6 +148,19 @@ var _ = Describe("Destroy", func() {
 				})
 			})
 
+			Context("when bbl was deployed into an existing network", func() {
+				It("checks only bbl's subnetwork for BOSH deployed VMs", func() {
+					bblState.ExistingNetwork = "some-network-name"
+
+					err := destroy.CheckFastFails([]string{}, bblState)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(networkDeletionValidator.ValidateSafeToDeleteCall.CallCount).To(Equal(0))
+					Expect(networkDeletionValidator.ValidateSubnetsSafeToDeleteCall.Receives.NetworkName).To(Equal("some-network-name"))
+					Expect(networkDeletionValidator.ValidateSubnetsSafeToDeleteCall.Receives.Subnets).To(Equal([]string{"some-subnetwork-name"}))
+				})
+			})
+
 			Context("when terraform output provider fails to get terraform outputs", func() {
 				It("does not fast fail", func() {
 					terraformManager.GetOutputsCall.Returns.Error = errors.New("terraform output provider failed")
@@ -186,6 +199,44 @@ var _ = Describe("Destroy", func() {
 				})
 			})
 
+			Context("when bbl was deployed into an existing vpc", func() {
+				BeforeEach(func() {
+					state.ExistingNetwork = "some-vpc-id"
+					terraformManager.GetOutputsCall.Returns.Outputs = map[string]interface{}{
+						"vpc_id":         "some-vpc-id",
+						"bosh_subnet_id": "some-bosh-subnet-id",
+						"internal_az_subnet_id_mapping": map[string]interface{}{
+							"us-east-1b": "some-internal-subnet-id-b",
+							"us-east-1a": "some-internal-subnet-id-a",
+						},
+					}
+				})
+
+				It("checks only bbl's subnets for BOSH deployed VMs", func() {
+					err := destroy.CheckFastFails([]string{}, state)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(networkDeletionValidator.ValidateSafeToDeleteCall.CallCount).To(Equal(0))
+					Expect(networkDeletionValidator.ValidateSubnetsSafeToDeleteCall.CallCount).To(Equal(1))
+					Expect(networkDeletionValidator.ValidateSubnetsSafeToDeleteCall.Receives.NetworkName).To(Equal("some-vpc-id"))
+					Expect(networkDeletionValidator.ValidateSubnetsSafeToDeleteCall.Receives.Subnets).To(Equal([]string{
+						"some-bosh-subnet-id",
+						"some-internal-subnet-id-a",
+						"some-internal-subnet-id-b",
+					}))
+					Expect(networkDeletionValidator.ValidateSubnetsSafeToDeleteCall.Receives.EnvID).To(Equal("some-env-id"))
+				})
+
+				Context("when VMs still exist in bbl's subnets", func() {
+					It("fails fast", func() {
+						networkDeletionValidator.ValidateSubnetsSafeToDeleteCall.Returns.Error = errors.New("subnets are not safe to delete")
+
+						err := destroy.CheckFastFails([]string{}, state)
+						Expect(err).To(MatchError("subnets are not safe to delete"))
+					})
+				})
+			})
+
 			Context("when terraform manager fails to get outputs", func() {
 				It("does not fast fail", func() {
 					terraformManager.GetOutputsCall.Returns.Error = errors.New("failed to get outputs")
@@ -200,30 +251,55 @@ var _ = Describe("Destroy", func() {
 		})
 
 		Context("when iaas is azure", func() {
-			// Replace this test with the pended test below when Azure supports ValidateSafeToDelete
-			It("returns an error while instances exist in the azure network", func() {
-				networkDeletionValidator.ValidateSafeToDeleteCall.Returns.Error = errors.New("validation failed")
-				err := destroy.CheckFastFails([]string{}, storage.State{
-					IAAS: "azure",
-				})
-				Expect(err).NotTo(HaveOccurred())
+			var state storage.State
+
+			BeforeEach(func() {
+				state = storage.State{
+					IAAS:  "azure",
+					EnvID: "some-env-id",
+				}
 			})
 
-			PContext("when instances exist in the azure network", func() {
+			Context("when instances exist in the azure resource group", func() {
 				BeforeEach(func() {
 					terraformManager.GetOutputsCall.Returns.Outputs = map[string]interface{}{
-						"virtual_network_name": "some-network-id",
+						"bosh_resource_group_name": "some-resource-group",
 					}
 					networkDeletionValidator.ValidateSafeToDeleteCall.Returns.Error = errors.New("validation failed")
 				})
 
 				It("returns an error", func() {
-					err := destroy.CheckFastFails([]string{}, storage.State{
-						IAAS:  "azure",
-						EnvID: "some-env-id",
-					})
-					Expect(networkDeletionValidator.ValidateSafeToDeleteCall.Receives.NetworkName).To(Equal("some-network-id"))
+					err := destroy.CheckFastFails([]string{}, state)
 					Expect(err).To(MatchError("validation failed"))
+
+					Expect(networkDeletionValidator.ValidateSafeToDeleteCall.Receives.NetworkName).To(Equal("some-resource-group"))
+					Expect(networkDeletionValidator.ValidateSafeToDeleteCall.Receives.EnvID).To(Equal("some-env-id"))
+				})
+			})
+
+			Context("when the resource group has not been created", func() {
+				It("does not fast fail", func() {
+					err := destroy.CheckFastFails([]string{}, state)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(networkDeletionValidator.ValidateSafeToDeleteCall.CallCount).To(Equal(0))
+				})
+			})
+
+			Context("when bbl was deployed into an existing virtual network", func() {
+				It("checks only bbl's subnet for BOSH deployed VMs", func() {
+					state.ExistingNetwork = "some-resource-group/some-vnet"
+					terraformManager.GetOutputsCall.Returns.Outputs = map[string]interface{}{
+						"bosh_resource_group_name": "some-resource-group",
+						"bosh_subnet_name":         "some-env-id-bosh-sn",
+					}
+
+					err := destroy.CheckFastFails([]string{}, state)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(networkDeletionValidator.ValidateSafeToDeleteCall.CallCount).To(Equal(0))
+					Expect(networkDeletionValidator.ValidateSubnetsSafeToDeleteCall.Receives.NetworkName).To(Equal("some-resource-group"))
+					Expect(networkDeletionValidator.ValidateSubnetsSafeToDeleteCall.Receives.Subnets).To(Equal([]string{"some-env-id-bosh-sn"}))
 				})
 			})
 		})
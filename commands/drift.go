@@ -0,0 +1,155 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/cloudfoundry/bosh-bootloader/bosh"
+	"github.com/cloudfoundry/bosh-bootloader/flags"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+	"github.com/cloudfoundry/bosh-bootloader/terraform"
+)
+
+const (
+	DriftCommand = "drift"
+)
+
+// Drift reports changes made outside of bbl to the infrastructure and to the
+// director's cloud config.
+type Drift struct {
+	logger             logger
+	stateValidator     stateValidator
+	terraformManager   terraformPlanner
+	cloudConfigManager cloudConfigManager
+}
+
+// DriftReport lists the terraform changes needed to undo the drift, and the
+// cloud config changes made on the director since bbl last uploaded it.
+type DriftReport struct {
+	Terraform   terraform.Plan        `json:"terraform"`
+	CloudConfig []bosh.ManifestChange `json:"cloudConfig"`
+}
+
+func (r DriftReport) HasDrift() bool {
+	return r.Terraform.HasChanges() || len(r.CloudConfig) > 0
+}
+
+// DriftDetectedError is returned by Drift when the environment has drifted, so
+// that bbl can exit with a distinct status.
+type DriftDetectedError struct{}
+
+func (DriftDetectedError) Error() string {
+	return "Drift detected"
+}
+
+func NewDrift(logger logger, stateValidator stateValidator, terraformManager terraformPlanner, cloudConfigManager cloudConfigManager) Drift {
+	return Drift{
+		logger:             logger,
+		stateValidator:     stateValidator,
+		terraformManager:   terraformManager,
+		cloudConfigManager: cloudConfigManager,
+	}
+}
+
+func (d Drift) CheckFastFails(args []string, state storage.State) error {
+	err := d.stateValidator.Validate()
+	if err != nil {
+		return err
+	}
+
+	if state.TFState == "" {
+		return errors.New("bbl drift requires an environment created by bbl up")
+	}
+
+	return nil
+}
+
+func (d Drift) Execute(args []string, state storage.State) error {
+	var jsonReportPath string
+	driftFlags := flags.New("drift")
+	driftFlags.String(&jsonReportPath, "json-report", "")
+
+	err := driftFlags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	report, err := d.report(state)
+	if err != nil {
+		return err
+	}
+
+	d.print(report)
+
+	if jsonReportPath != "" {
+		contents, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err //not tested
+		}
+
+		err = ioutil.WriteFile(jsonReportPath, contents, 0600)
+		if err != nil {
+			return fmt.Errorf("Write drift report: %s", err)
+		}
+	}
+
+	if report.HasDrift() {
+		return DriftDetectedError{}
+	}
+
+	return nil
+}
+
+func (d Drift) report(state storage.State) (DriftReport, error) {
+	terraformPlan, err := d.terraformManager.Plan(state)
+	if err != nil {
+		return DriftReport{}, fmt.Errorf("Terraform plan: %s", err)
+	}
+
+	report := DriftReport{
+		Terraform:   terraformPlan,
+		CloudConfig: []bosh.ManifestChange{},
+	}
+
+	if state.NoDirector {
+		return report, nil
+	}
+
+	desiredCloudConfig, err := d.cloudConfigManager.Generate(state)
+	if err != nil {
+		return DriftReport{}, fmt.Errorf("Generate cloud config: %s", err)
+	}
+
+	currentCloudConfig, err := d.cloudConfigManager.Current(state)
+	if err != nil {
+		return DriftReport{}, fmt.Errorf("Get director cloud config: %s", err)
+	}
+
+	report.CloudConfig, err = bosh.DiffManifests(desiredCloudConfig, currentCloudConfig)
+	if err != nil {
+		return DriftReport{}, fmt.Errorf("Diff cloud config: %s", err)
+	}
+
+	return report, nil
+}
+
+func (d Drift) print(report DriftReport) {
+	if report.Terraform.HasChanges() {
+		d.logger.Printf("terraform: %d to add, %d to change, %d to destroy\n", report.Terraform.Add, report.Terraform.Change, report.Terraform.Destroy)
+		d.logger.Println(report.Terraform.Output)
+	} else {
+		d.logger.Println("terraform: no drift")
+	}
+
+	if len(report.CloudConfig) == 0 {
+		d.logger.Println("cloud config: no drift")
+		return
+	}
+
+	d.logger.Printf("cloud config: %d changes\n", len(report.CloudConfig))
+	for _, change := range report.CloudConfig {
+		d.logger.Printf("  %s\n", change)
+	}
+}
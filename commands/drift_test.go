@@ -0,0 +1,167 @@
+package commands_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/cloudfoundry/bosh-bootloader/bosh"
+	"github.com/cloudfoundry/bosh-bootloader/commands"
+	"github.com/cloudfoundry/bosh-bootloader/fakes"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+	"github.com/cloudfoundry/bosh-bootloader/terraform"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Drift", func() {
+	var (
+		drift commands.Drift
+
+		logger             *fakes.Logger
+		stateValidator     *fakes.StateValidator
+		terraformManager   *fakes.TerraformManager
+		cloudConfigManager *fakes.CloudConfigManager
+
+		state storage.State
+	)
+
+	BeforeEach(func() {
+		logger = &fakes.Logger{}
+		stateValidator = &fakes.StateValidator{}
+
+		terraformManager = &fakes.TerraformManager{}
+		terraformManager.PlanCall.Returns.Plan = terraform.Plan{Output: "No changes."}
+
+		cloudConfigManager = &fakes.CloudConfigManager{}
+		cloudConfigManager.GenerateCall.Returns.CloudConfig = "vm_types:\n- name: default\n  cloud_properties: {instance_type: m4.large}\n"
+		cloudConfigManager.CurrentCall.Returns.CloudConfig = "vm_types:\n- name: default\n  cloud_properties: {instance_type: m4.large}\n"
+
+		state = storage.State{
+			IAAS:    "aws",
+			TFState: "some-tf-state",
+		}
+
+		drift = commands.NewDrift(logger, stateValidator, terraformManager, cloudConfigManager)
+	})
+
+	Describe("CheckFastFails", func() {
+		It("returns an error when the state is not valid", func() {
+			stateValidator.ValidateCall.Returns.Error = errors.New("no state")
+
+			err := drift.CheckFastFails([]string{}, state)
+			Expect(err).To(MatchError("no state"))
+		})
+
+		It("returns an error when there is no infrastructure", func() {
+			err := drift.CheckFastFails([]string{}, storage.State{IAAS: "aws"})
+			Expect(err).To(MatchError("bbl drift requires an environment created by bbl up"))
+		})
+	})
+
+	Describe("Execute", func() {
+		It("reports no drift", func() {
+			err := drift.Execute([]string{}, state)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(terraformManager.PlanCall.Receives.BBLState).To(Equal(state))
+			Expect(cloudConfigManager.GenerateCall.Receives.State).To(Equal(state))
+			Expect(cloudConfigManager.CurrentCall.Receives.State).To(Equal(state))
+
+			Expect(logger.PrintlnCall.Messages).To(Equal([]string{
+				"terraform: no drift",
+				"cloud config: no drift",
+			}))
+		})
+
+		It("reports terraform and cloud config drift and returns a drift error", func() {
+			terraformManager.PlanCall.Returns.Plan = terraform.Plan{Change: 1, Output: "some-terraform-output"}
+			cloudConfigManager.CurrentCall.Returns.CloudConfig = "vm_types:\n- name: default\n  cloud_properties: {instance_type: m4.xlarge}\n"
+
+			err := drift.Execute([]string{}, state)
+			Expect(err).To(Equal(commands.DriftDetectedError{}))
+			Expect(err).To(MatchError("Drift detected"))
+
+			Expect(logger.PrintfCall.Messages).To(Equal([]string{
+				"terraform: 0 to add, 1 to change, 0 to destroy\n",
+				"cloud config: 1 changes\n",
+				"  ~ /vm_types/name=default/cloud_properties/instance_type: m4.large -> m4.xlarge\n",
+			}))
+			Expect(logger.PrintlnCall.Messages).To(Equal([]string{"some-terraform-output"}))
+		})
+
+		It("writes the report as json when --json-report is passed", func() {
+			cloudConfigManager.CurrentCall.Returns.CloudConfig = "vm_types:\n- name: default\n  cloud_properties: {instance_type: m4.large}\n- name: extra\n"
+
+			tempDir, err := ioutil.TempDir("", "")
+			Expect(err).NotTo(HaveOccurred())
+			reportPath := filepath.Join(tempDir, "drift.json")
+
+			err = drift.Execute([]string{"--json-report", reportPath}, state)
+			Expect(err).To(Equal(commands.DriftDetectedError{}))
+
+			info, err := os.Stat(reportPath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info.Mode().Perm()).To(Equal(os.FileMode(0600)))
+
+			contents, err := ioutil.ReadFile(reportPath)
+			Expect(err).NotTo(HaveOccurred())
+
+			var report commands.DriftReport
+			err = json.Unmarshal(contents, &report)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(report).To(Equal(commands.DriftReport{
+				Terraform:   terraform.Plan{Output: "No changes."},
+				CloudConfig: []bosh.ManifestChange{{Type: "added", Path: "/vm_types/name=extra"}},
+			}))
+		})
+
+		Context("when there is no director", func() {
+			It("only checks the infrastructure", func() {
+				state.NoDirector = true
+
+				err := drift.Execute([]string{}, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(cloudConfigManager.GenerateCall.CallCount).To(Equal(0))
+				Expect(cloudConfigManager.CurrentCall.CallCount).To(Equal(0))
+			})
+		})
+
+		Context("failure cases", func() {
+			It("returns an error when terraform plan fails", func() {
+				terraformManager.PlanCall.Returns.Error = errors.New("failed to plan")
+
+				err := drift.Execute([]string{}, state)
+				Expect(err).To(MatchError("Terraform plan: failed to plan"))
+			})
+
+			It("returns an error when the cloud config cannot be generated", func() {
+				cloudConfigManager.GenerateCall.Returns.Error = errors.New("failed to generate")
+
+				err := drift.Execute([]string{}, state)
+				Expect(err).To(MatchError("Generate cloud config: failed to generate"))
+			})
+
+			It("returns an error when the director's cloud config cannot be fetched", func() {
+				cloudConfigManager.CurrentCall.Returns.Error = errors.New("failed to fetch")
+
+				err := drift.Execute([]string{}, state)
+				Expect(err).To(MatchError("Get director cloud config: failed to fetch"))
+			})
+
+			It("returns an error when the report cannot be written", func() {
+				err := drift.Execute([]string{"--json-report", "/non/existent/drift.json"}, state)
+				Expect(err).To(MatchError(ContainSubstring("Write drift report:")))
+			})
+
+			It("returns an error when the flags cannot be parsed", func() {
+				err := drift.Execute([]string{"--unknown-flag"}, state)
+				Expect(err).To(MatchError("flag provided but not defined: -unknown-flag"))
+			})
+		})
+	})
+})
@@ -0,0 +1,58 @@
+package commands
+
+import (
+	"errors"
+
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+)
+
+var errStateEncryptionKeyRequired = errors.New("--state-encryption-key or --state-encryption-key-file must be provided (or BBL_STATE_ENCRYPTION_KEY or BBL_STATE_ENCRYPTION_KEY_FILE must be set)")
+
+type stateEncrypter interface {
+	EncryptState() error
+	DecryptState() error
+}
+
+type EncryptState struct {
+	logger         logger
+	stateValidator stateValidator
+	stateBackend   storage.Backend
+}
+
+func NewEncryptState(logger logger, stateValidator stateValidator, stateBackend storage.Backend) EncryptState {
+	return EncryptState{
+		logger:         logger,
+		stateValidator: stateValidator,
+		stateBackend:   stateBackend,
+	}
+}
+
+func (e EncryptState) CheckFastFails(subcommandFlags []string, state storage.State) error {
+	if _, ok := e.stateBackend.(stateEncrypter); !ok {
+		return errStateEncryptionKeyRequired
+	}
+
+	err := e.stateValidator.Validate()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (e EncryptState) Execute(subcommandFlags []string, state storage.State) error {
+	encrypter, ok := e.stateBackend.(stateEncrypter)
+	if !ok {
+		return errStateEncryptionKeyRequired
+	}
+
+	e.logger.Step("encrypting bbl-state.json")
+	err := encrypter.EncryptState()
+	if err != nil {
+		return err
+	}
+
+	e.logger.Printf("bbl-state.json in %s is encrypted\n", e.stateBackend.Location())
+
+	return nil
+}
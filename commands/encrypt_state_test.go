@@ -0,0 +1,78 @@
+package commands_test
+
+import (
+	"errors"
+
+	"github.com/cloudfoundry/bosh-bootloader/commands"
+	"github.com/cloudfoundry/bosh-bootloader/fakes"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("encrypt-state", func() {
+	var (
+		logger         *fakes.Logger
+		stateValidator *fakes.StateValidator
+		stateBackend   *fakes.EncryptedStateBackend
+
+		command commands.EncryptState
+	)
+
+	BeforeEach(func() {
+		logger = &fakes.Logger{}
+		stateValidator = &fakes.StateValidator{}
+		stateBackend = &fakes.EncryptedStateBackend{}
+		stateBackend.LocationCall.Returns.Location = "some-state-dir"
+
+		command = commands.NewEncryptState(logger, stateValidator, stateBackend)
+	})
+
+	Describe("CheckFastFails", func() {
+		It("validates the state", func() {
+			err := command.CheckFastFails([]string{}, storage.State{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(stateValidator.ValidateCall.CallCount).To(Equal(1))
+		})
+
+		Context("when no state encryption key is provided", func() {
+			It("returns an error", func() {
+				command = commands.NewEncryptState(logger, stateValidator, storage.NewLocalBackend("some-state-dir"))
+
+				err := command.CheckFastFails([]string{}, storage.State{})
+				Expect(err).To(MatchError("--state-encryption-key or --state-encryption-key-file must be provided (or BBL_STATE_ENCRYPTION_KEY or BBL_STATE_ENCRYPTION_KEY_FILE must be set)"))
+			})
+		})
+
+		Context("when the state does not exist", func() {
+			It("returns an error", func() {
+				stateValidator.ValidateCall.Returns.Error = errors.New("failed to validate state")
+
+				err := command.CheckFastFails([]string{}, storage.State{})
+				Expect(err).To(MatchError("failed to validate state"))
+			})
+		})
+	})
+
+	Describe("Execute", func() {
+		It("encrypts the state", func() {
+			err := command.Execute([]string{}, storage.State{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(stateBackend.EncryptStateCall.CallCount).To(Equal(1))
+			Expect(logger.StepCall.Messages).To(Equal([]string{"encrypting bbl-state.json"}))
+			Expect(logger.PrintfCall.Messages).To(Equal([]string{"bbl-state.json in some-state-dir is encrypted\n"}))
+		})
+
+		Context("when encrypting fails", func() {
+			It("returns an error", func() {
+				stateBackend.EncryptStateCall.Returns.Error = errors.New("failed to encrypt")
+
+				err := command.Execute([]string{}, storage.State{})
+				Expect(err).To(MatchError("failed to encrypt"))
+			})
+		})
+	})
+})
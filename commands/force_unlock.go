@@ -0,0 +1,43 @@
+package commands
+
+import "github.com/cloudfoundry/bosh-bootloader/storage"
+
+type stateLocker interface {
+	ReadLease() (storage.Lease, error)
+	ReleaseLease(storage.Lease) error
+}
+
+type ForceUnlock struct {
+	logger      logger
+	stateLocker stateLocker
+}
+
+func NewForceUnlock(logger logger, stateLocker stateLocker) ForceUnlock {
+	return ForceUnlock{
+		logger:      logger,
+		stateLocker: stateLocker,
+	}
+}
+
+func (f ForceUnlock) CheckFastFails(subcommandFlags []string, state storage.State) error {
+	return nil
+}
+
+func (f ForceUnlock) Execute(subcommandFlags []string, state storage.State) error {
+	lease, err := f.stateLocker.ReadLease()
+	if err == storage.LeaseNotFound {
+		f.logger.Println("bbl state is not locked.")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if lease.Host != "" {
+		f.logger.Step("removing lock held by %s (bbl %s, pid %d on %s)", lease.Holder, lease.Command, lease.PID, lease.Host)
+	} else {
+		f.logger.Step("removing lock held by %s", lease.Holder)
+	}
+
+	return f.stateLocker.ReleaseLease(lease)
+}
@@ -0,0 +1,77 @@
+package commands_test
+
+import (
+	"errors"
+
+	"github.com/cloudfoundry/bosh-bootloader/commands"
+	"github.com/cloudfoundry/bosh-bootloader/fakes"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("force-unlock", func() {
+	var (
+		logger      *fakes.Logger
+		stateLocker *fakes.StateLocker
+
+		command commands.ForceUnlock
+	)
+
+	BeforeEach(func() {
+		logger = &fakes.Logger{}
+		stateLocker = &fakes.StateLocker{}
+
+		command = commands.NewForceUnlock(logger, stateLocker)
+	})
+
+	Describe("Execute", func() {
+		It("releases the current lock", func() {
+			lease := storage.Lease{
+				ID:      "some-lease-id",
+				Holder:  "some-user",
+				PID:     1234,
+				Host:    "some-host",
+				Command: "up",
+			}
+			stateLocker.ReadLeaseCall.Returns.Lease = lease
+
+			err := command.Execute([]string{}, storage.State{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(stateLocker.ReleaseLeaseCall.Receives.Lease).To(Equal(lease))
+			Expect(logger.StepCall.Messages).To(Equal([]string{"removing lock held by some-user (bbl up, pid 1234 on some-host)"}))
+		})
+
+		Context("when the state is not locked", func() {
+			It("does nothing", func() {
+				stateLocker.ReadLeaseCall.Returns.Error = storage.LeaseNotFound
+
+				err := command.Execute([]string{}, storage.State{})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(stateLocker.ReleaseLeaseCall.CallCount).To(Equal(0))
+				Expect(logger.PrintlnCall.Messages).To(Equal([]string{"bbl state is not locked."}))
+			})
+		})
+
+		Context("when reading the lock fails", func() {
+			It("returns an error", func() {
+				stateLocker.ReadLeaseCall.Returns.Error = errors.New("failed to read lock")
+
+				err := command.Execute([]string{}, storage.State{})
+				Expect(err).To(MatchError("failed to read lock"))
+			})
+		})
+
+		Context("when releasing the lock fails", func() {
+			It("returns an error", func() {
+				stateLocker.ReleaseLeaseCall.Returns.Error = errors.New("failed to release lock")
+
+				err := command.Execute([]string{}, storage.State{})
+				Expect(err).To(MatchError("failed to release lock"))
+			})
+		})
+	})
+})
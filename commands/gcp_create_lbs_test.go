@@ -133,6 +133,21 @@ var _ = Describe("GCPCreateLBs", func() {
 			Expect(cloudConfigManager.UpdateCall.Receives.State).To(Equal(bblState))
 		})
 
+		Context("when the cloud config mode is diff", func() {
+			It("prints the cloud config changes without uploading them", func() {
+				terraformManager.ApplyCall.Returns.BBLState = bblState
+
+				err := command.Execute(commands.CreateLBsConfig{GCP: commands.GCPCreateLBsConfig{
+					LBType: "concourse",
+				}, CloudConfigMode: "diff"}, bblState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(cloudConfigManager.DiffCall.CallCount).To(Equal(1))
+				Expect(cloudConfigManager.DiffCall.Receives.State).To(Equal(bblState))
+				Expect(cloudConfigManager.UpdateCall.CallCount).To(Equal(0))
+			})
+		})
+
 		Context("when there is no BOSH director", func() {
 			It("does not call the CloudConfigManager", func() {
 				terraformManager.ApplyCall.Returns.BBLState.NoDirector = true
@@ -26,11 +26,21 @@ type boshManager interface {
 	CreateJumpbox(bblState storage.State, terraformOutputs map[string]interface{}) (storage.State, error)
 	DeleteDirector(bblState storage.State, terraformOutputs map[string]interface{}) error
 	DeleteJumpbox(bblState storage.State, terraformOutputs map[string]interface{}) error
-	GetDirectorDeploymentVars(bblState storage.State, terraformOutputs map[string]interface{}) string
-	GetJumpboxDeploymentVars(bblState storage.State, terraformOutputs map[string]interface{}) string
+	GetDirectorDeploymentVars(bblState storage.State, terraformOutputs map[string]interface{}) (string, error)
+	GetJumpboxDeploymentVars(bblState storage.State, terraformOutputs map[string]interface{}) (string, error)
 	Version() (string, error)
 }
 
+type runtimeConfigManager interface {
+	Update(state storage.State) error
+	Delete(state storage.State, runtimeConfigs []string, cpiConfig bool) error
+	Generate(state storage.State) string
+}
+
+type planner interface {
+	Plan(state storage.State, jsonReportPath string) error
+}
+
 type envIDManager interface {
 	Sync(storage.State, string) (storage.State, error)
 }
@@ -62,6 +72,7 @@ type networkInstancesChecker interface {
 
 type certificateValidator interface {
 	Validate(command, certPath, keyPath, chainPath string) error
+	ValidatePKCS12(command, certPath, passwordPath string) error
 }
 
 type logger interface {
@@ -78,5 +89,7 @@ type stateStore interface {
 
 type cloudConfigManager interface {
 	Update(state storage.State) error
+	Diff(state storage.State) error
 	Generate(state storage.State) (string, error)
+	Current(state storage.State) (string, error)
 }
@@ -44,7 +44,11 @@ func (b JumpboxDeploymentVars) Execute(args []string, state storage.State) error
 		return fmt.Errorf("get terraform outputs: %s", err)
 	}
 
-	vars := b.boshManager.GetJumpboxDeploymentVars(state, terraformOutputs)
+	vars, err := b.boshManager.GetJumpboxDeploymentVars(state, terraformOutputs)
+	if err != nil {
+		return err
+	}
+
 	b.logger.Println(vars)
 	return nil
 }
@@ -0,0 +1,100 @@
+package commands
+
+import (
+	"github.com/cloudfoundry/bosh-bootloader/flags"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+)
+
+type stateReader interface {
+	ReadState() ([]byte, error)
+}
+
+type MigrateState struct {
+	logger         logger
+	stateValidator stateValidator
+	stateReader    stateReader
+	stateStore     stateStore
+}
+
+type migrateStateConfig struct {
+	DryRun bool
+}
+
+func NewMigrateState(logger logger, stateValidator stateValidator, stateReader stateReader, stateStore stateStore) MigrateState {
+	return MigrateState{
+		logger:         logger,
+		stateValidator: stateValidator,
+		stateReader:    stateReader,
+		stateStore:     stateStore,
+	}
+}
+
+func (m MigrateState) CheckFastFails(subcommandFlags []string, state storage.State) error {
+	_, err := m.parseFlags(subcommandFlags)
+	if err != nil {
+		return err
+	}
+
+	err = m.stateValidator.Validate()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m MigrateState) Execute(subcommandFlags []string, state storage.State) error {
+	config, err := m.parseFlags(subcommandFlags)
+	if err != nil {
+		return err
+	}
+
+	contents, err := m.stateReader.ReadState()
+	if err != nil {
+		return err
+	}
+
+	_, steps, err := storage.MigrateState(contents)
+	if err != nil {
+		return err
+	}
+
+	if len(steps) == 0 {
+		m.logger.Printf("bbl-state.json is already at version %d\n", storage.STATE_VERSION)
+		return nil
+	}
+
+	for _, step := range steps {
+		m.logger.Printf("v%d -> v%d: %s\n", step.From, step.To, step.Description)
+		for _, change := range step.Changes {
+			m.logger.Printf("  - %s\n", change)
+		}
+	}
+
+	if config.DryRun {
+		m.logger.Println("--dry-run provided, bbl-state.json was not changed")
+		return nil
+	}
+
+	m.logger.Step("backing up bbl-state.json to %s", storage.BackupFileName(steps[0].From))
+	err = m.stateStore.Set(state)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m MigrateState) parseFlags(subcommandFlags []string) (migrateStateConfig, error) {
+	migrateStateFlags := flags.New("migrate-state")
+
+	config := migrateStateConfig{}
+	migrateStateFlags.Bool(&config.DryRun, "", "dry-run", false)
+
+	err := migrateStateFlags.Parse(subcommandFlags)
+	if err != nil {
+		return config, err
+	}
+
+	return config, nil
+}
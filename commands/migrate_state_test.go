@@ -0,0 +1,124 @@
+package commands_test
+
+import (
+	"errors"
+
+	"github.com/cloudfoundry/bosh-bootloader/commands"
+	"github.com/cloudfoundry/bosh-bootloader/fakes"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("migrate-state", func() {
+	var (
+		logger         *fakes.Logger
+		stateValidator *fakes.StateValidator
+		stateReader    *fakes.StateReader
+		stateStore     *fakes.StateStore
+
+		command commands.MigrateState
+	)
+
+	BeforeEach(func() {
+		logger = &fakes.Logger{}
+		stateValidator = &fakes.StateValidator{}
+		stateReader = &fakes.StateReader{}
+		stateStore = &fakes.StateStore{}
+
+		stateReader.ReadStateCall.Returns.Contents = []byte(`{
+			"version": 10,
+			"aws": {
+				"accessKeyId": "some-access-key-id",
+				"region": "some-region"
+			}
+		}`)
+
+		command = commands.NewMigrateState(logger, stateValidator, stateReader, stateStore)
+	})
+
+	Describe("CheckFastFails", func() {
+		Context("when the state does not exist", func() {
+			It("returns an error", func() {
+				stateValidator.ValidateCall.Returns.Error = errors.New("failed to validate state")
+
+				err := command.CheckFastFails([]string{}, storage.State{})
+				Expect(err).To(MatchError("failed to validate state"))
+			})
+		})
+
+		Context("when an unknown flag is provided", func() {
+			It("returns an error", func() {
+				err := command.CheckFastFails([]string{"--some-flag"}, storage.State{})
+				Expect(err).To(MatchError("flag provided but not defined: -some-flag"))
+			})
+		})
+	})
+
+	Describe("Execute", func() {
+		It("prints the migration steps and saves the migrated state", func() {
+			state := storage.State{Version: 13, IAAS: "aws"}
+
+			err := command.Execute([]string{}, state)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(logger.PrintfCall.Messages).To(Equal([]string{
+				"v10 -> v11: no schema changes\n",
+				"v11 -> v12: stop persisting IAAS credentials\n",
+				"  - removed aws.accessKeyId\n",
+				"v12 -> v13: store the director ops file in a list of ops files\n",
+			}))
+			Expect(logger.StepCall.Messages).To(Equal([]string{"backing up bbl-state.json to bbl-state.v10.backup.json"}))
+
+			Expect(stateStore.SetCall.CallCount).To(Equal(1))
+			Expect(stateStore.SetCall.Receives[0].State).To(Equal(state))
+		})
+
+		Context("when --dry-run is provided", func() {
+			It("does not save the state", func() {
+				err := command.Execute([]string{"--dry-run"}, storage.State{})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(logger.PrintfCall.Messages).To(HaveLen(4))
+				Expect(logger.PrintlnCall.Messages).To(Equal([]string{"--dry-run provided, bbl-state.json was not changed"}))
+				Expect(stateStore.SetCall.CallCount).To(Equal(0))
+			})
+		})
+
+		Context("when the state is already current", func() {
+			It("does nothing", func() {
+				stateReader.ReadStateCall.Returns.Contents = []byte(`{"version": 13}`)
+
+				err := command.Execute([]string{}, storage.State{})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(logger.PrintfCall.Messages).To(Equal([]string{"bbl-state.json is already at version 13\n"}))
+				Expect(stateStore.SetCall.CallCount).To(Equal(0))
+			})
+		})
+
+		Context("failure cases", func() {
+			It("returns an error when the state cannot be read", func() {
+				stateReader.ReadStateCall.Returns.Error = errors.New("failed to read state")
+
+				err := command.Execute([]string{}, storage.State{})
+				Expect(err).To(MatchError("failed to read state"))
+			})
+
+			It("returns an error when the state cannot be migrated", func() {
+				stateReader.ReadStateCall.Returns.Contents = []byte(`{"version": 3, "stack": {"name": "some-stack"}}`)
+
+				err := command.Execute([]string{}, storage.State{})
+				Expect(err).To(MatchError(ContainSubstring(`CloudFormation stack "some-stack"`)))
+			})
+
+			It("returns an error when the state cannot be saved", func() {
+				stateStore.SetCall.Returns = []fakes.SetCallReturn{{Error: errors.New("failed to save state")}}
+
+				err := command.Execute([]string{}, storage.State{})
+				Expect(err).To(MatchError("failed to save state"))
+			})
+		})
+	})
+})
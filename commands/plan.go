@@ -0,0 +1,23 @@
+package commands
+
+import "github.com/cloudfoundry/bosh-bootloader/storage"
+
+// Plan is "bbl up --dry-run": it prints the changes up would make to the
+// infrastructure, the jumpbox and the director without applying them.
+type Plan struct {
+	up Up
+}
+
+func NewPlan(up Up) Plan {
+	return Plan{
+		up: up,
+	}
+}
+
+func (p Plan) CheckFastFails(args []string, state storage.State) error {
+	return p.up.CheckFastFails(args, state)
+}
+
+func (p Plan) Execute(args []string, state storage.State) error {
+	return p.up.Execute(append([]string{"--dry-run"}, args...), state)
+}
@@ -0,0 +1,75 @@
+package commands_test
+
+import (
+	"errors"
+	"io/ioutil"
+
+	"github.com/cloudfoundry/bosh-bootloader/commands"
+	"github.com/cloudfoundry/bosh-bootloader/fakes"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Plan", func() {
+	var (
+		command commands.Plan
+
+		iaasUp           *fakes.UpCmd
+		boshManager      *fakes.BOSHManager
+		terraformManager *fakes.TerraformManager
+		stateStore       *fakes.StateStore
+		envIDManager     *fakes.EnvIDManager
+		planner          *fakes.Planner
+	)
+
+	BeforeEach(func() {
+		iaasUp = &fakes.UpCmd{}
+		boshManager = &fakes.BOSHManager{}
+		boshManager.VersionCall.Returns.Version = "2.0.24"
+		terraformManager = &fakes.TerraformManager{}
+		stateStore = &fakes.StateStore{}
+		envIDManager = &fakes.EnvIDManager{}
+		planner = &fakes.Planner{}
+
+		tempDir, err := ioutil.TempDir("", "")
+		Expect(err).NotTo(HaveOccurred())
+		stateStore.GetBblDirCall.Returns.Directory = tempDir
+
+		up := commands.NewUp(iaasUp, boshManager, &fakes.CloudConfigManager{}, &fakes.RuntimeConfigManager{}, stateStore, envIDManager, terraformManager, planner)
+		command = commands.NewPlan(up)
+	})
+
+	Describe("CheckFastFails", func() {
+		It("checks the same things as up", func() {
+			boshManager.VersionCall.Returns.Version = "1.9.1"
+
+			err := command.CheckFastFails([]string{}, storage.State{})
+			Expect(err).To(MatchError("BOSH version must be at least v2.0.24"))
+		})
+	})
+
+	Describe("Execute", func() {
+		It("runs up as a dry run", func() {
+			envIDManager.SyncCall.Returns.State = storage.State{EnvID: "some-name"}
+
+			err := command.Execute([]string{"--name", "some-name", "--json-report", "some-report.json"}, storage.State{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(planner.PlanCall.CallCount).To(Equal(1))
+			Expect(planner.PlanCall.Receives.State).To(Equal(storage.State{EnvID: "some-name"}))
+			Expect(planner.PlanCall.Receives.JSONReportPath).To(Equal("some-report.json"))
+
+			Expect(terraformManager.ApplyCall.CallCount).To(Equal(0))
+			Expect(stateStore.SetCall.CallCount).To(Equal(0))
+		})
+
+		It("returns errors from up", func() {
+			iaasUp.ExecuteCall.Returns.Error = errors.New("cherry")
+
+			err := command.Execute([]string{}, storage.State{})
+			Expect(err).To(MatchError("cherry"))
+		})
+	})
+})
@@ -0,0 +1,178 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/cloudfoundry/bosh-bootloader/bosh"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+	"github.com/cloudfoundry/bosh-bootloader/terraform"
+)
+
+type terraformPlanner interface {
+	Plan(storage.State) (terraform.Plan, error)
+	GetOutputs(storage.State) (map[string]interface{}, error)
+}
+
+type manifestInterpolator interface {
+	InterpolateJumpbox(bblState storage.State, terraformOutputs map[string]interface{}) (string, error)
+	InterpolateDirector(bblState storage.State, terraformOutputs map[string]interface{}) (string, error)
+}
+
+// Planner reports what applying a desired bbl state would change, without
+// changing the infrastructure, the BOSH environments or the saved state.
+type Planner struct {
+	terraformManager terraformPlanner
+	boshManager      manifestInterpolator
+	logger           logger
+}
+
+type PlanReport struct {
+	Terraform terraform.Plan `json:"terraform"`
+	Jumpbox   DeploymentPlan `json:"jumpbox"`
+	Director  DeploymentPlan `json:"director"`
+}
+
+// DeploymentPlan describes the change to a create-env deployment. Action is
+// one of "create", "update", "none" or "skip".
+type DeploymentPlan struct {
+	Action  string                `json:"action"`
+	Changes []bosh.ManifestChange `json:"changes"`
+}
+
+func NewPlanner(terraformManager terraformPlanner, boshManager manifestInterpolator, logger logger) Planner {
+	return Planner{
+		terraformManager: terraformManager,
+		boshManager:      boshManager,
+		logger:           logger,
+	}
+}
+
+// Plan prints a report of the changes needed to reach state and, when
+// jsonReportPath is set, also writes it there as JSON.
+func (p Planner) Plan(state storage.State, jsonReportPath string) error {
+	report, err := p.report(state)
+	if err != nil {
+		return err
+	}
+
+	p.print(report)
+
+	if jsonReportPath == "" {
+		return nil
+	}
+
+	contents, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err //not tested
+	}
+
+	err = ioutil.WriteFile(jsonReportPath, contents, 0600)
+	if err != nil {
+		return fmt.Errorf("Write plan report: %s", err)
+	}
+
+	return nil
+}
+
+func (p Planner) report(state storage.State) (PlanReport, error) {
+	terraformPlan, err := p.terraformManager.Plan(state)
+	if err != nil {
+		return PlanReport{}, fmt.Errorf("Terraform plan: %s", err)
+	}
+
+	report := PlanReport{
+		Terraform: terraformPlan,
+		Jumpbox:   DeploymentPlan{Action: "create", Changes: []bosh.ManifestChange{}},
+		Director:  DeploymentPlan{Action: "create", Changes: []bosh.ManifestChange{}},
+	}
+
+	if state.NoDirector {
+		report.Jumpbox.Action = "skip"
+		report.Director.Action = "skip"
+		return report, nil
+	}
+
+	// Without infrastructure there are no terraform outputs to interpolate
+	// the manifests with, and both environments will be created anyway.
+	if state.TFState == "" {
+		return report, nil
+	}
+
+	terraformOutputs, err := p.terraformManager.GetOutputs(state)
+	if err != nil {
+		return PlanReport{}, fmt.Errorf("Parse terraform outputs: %s", err)
+	}
+
+	if state.Jumpbox.Manifest != "" {
+		manifest, err := p.boshManager.InterpolateJumpbox(state, terraformOutputs)
+		if err != nil {
+			return PlanReport{}, err
+		}
+
+		report.Jumpbox, err = deploymentPlan(state.Jumpbox.Manifest, manifest)
+		if err != nil {
+			return PlanReport{}, fmt.Errorf("Diff jumpbox manifest: %s", err)
+		}
+	}
+
+	if state.BOSH.Manifest != "" {
+		manifest, err := p.boshManager.InterpolateDirector(state, terraformOutputs)
+		if err != nil {
+			return PlanReport{}, err
+		}
+
+		report.Director, err = deploymentPlan(state.BOSH.Manifest, manifest)
+		if err != nil {
+			return PlanReport{}, fmt.Errorf("Diff director manifest: %s", err)
+		}
+	}
+
+	return report, nil
+}
+
+func deploymentPlan(currentManifest, manifest string) (DeploymentPlan, error) {
+	changes, err := bosh.DiffManifests(currentManifest, manifest)
+	if err != nil {
+		return DeploymentPlan{}, err
+	}
+
+	action := "none"
+	if len(changes) > 0 {
+		action = "update"
+	}
+
+	return DeploymentPlan{Action: action, Changes: changes}, nil
+}
+
+func (p Planner) print(report PlanReport) {
+	if report.Terraform.HasChanges() {
+		p.logger.Printf("terraform: %d to add, %d to change, %d to destroy\n", report.Terraform.Add, report.Terraform.Change, report.Terraform.Destroy)
+		p.logger.Println(report.Terraform.Output)
+	} else {
+		p.logger.Println("terraform: no changes")
+	}
+
+	for _, deployment := range []struct {
+		name string
+		plan DeploymentPlan
+	}{
+		{"jumpbox", report.Jumpbox},
+		{"director", report.Director},
+	} {
+		switch deployment.plan.Action {
+		case "create":
+			p.logger.Printf("%s: will be created\n", deployment.name)
+		case "skip":
+			p.logger.Printf("%s: skipped, --no-director is set\n", deployment.name)
+		case "none":
+			p.logger.Printf("%s: no changes\n", deployment.name)
+		case "update":
+			p.logger.Printf("%s: %d changes\n", deployment.name, len(deployment.plan.Changes))
+			for _, change := range deployment.plan.Changes {
+				p.logger.Printf("  %s\n", change)
+			}
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package commands_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/cloudfoundry/bosh-bootloader/bosh"
+	"github.com/cloudfoundry/bosh-bootloader/commands"
+	"github.com/cloudfoundry/bosh-bootloader/fakes"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+	"github.com/cloudfoundry/bosh-bootloader/terraform"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Planner", func() {
+	var (
+		planner commands.Planner
+
+		terraformManager *fakes.TerraformManager
+		boshManager      *fakes.BOSHManager
+		logger           *fakes.Logger
+
+		state storage.State
+	)
+
+	BeforeEach(func() {
+		terraformManager = &fakes.TerraformManager{}
+		terraformManager.PlanCall.Returns.Plan = terraform.Plan{Add: 1, Output: "some-terraform-output"}
+		terraformManager.GetOutputsCall.Returns.Outputs = map[string]interface{}{"some-output": "some-value"}
+
+		boshManager = &fakes.BOSHManager{}
+		boshManager.InterpolateJumpboxCall.Returns.Manifest = "name: jumpbox\n"
+		boshManager.InterpolateDirectorCall.Returns.Manifest = "name: bosh\ninstances: 2\n"
+
+		logger = &fakes.Logger{}
+
+		state = storage.State{
+			TFState: "some-tf-state",
+			Jumpbox: storage.Jumpbox{Manifest: "name: jumpbox\n"},
+			BOSH:    storage.BOSH{Manifest: "name: bosh\ninstances: 1\n"},
+		}
+
+		planner = commands.NewPlanner(terraformManager, boshManager, logger)
+	})
+
+	It("prints the terraform plan and the manifest changes", func() {
+		err := planner.Plan(state, "")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(terraformManager.PlanCall.Receives.BBLState).To(Equal(state))
+		Expect(terraformManager.GetOutputsCall.Receives.BBLState).To(Equal(state))
+
+		Expect(boshManager.InterpolateJumpboxCall.Receives.State).To(Equal(state))
+		Expect(boshManager.InterpolateJumpboxCall.Receives.TerraformOutputs).To(Equal(map[string]interface{}{"some-output": "some-value"}))
+		Expect(boshManager.InterpolateDirectorCall.Receives.State).To(Equal(state))
+
+		Expect(logger.PrintfCall.Messages).To(Equal([]string{
+			"terraform: 1 to add, 0 to change, 0 to destroy\n",
+			"jumpbox: no changes\n",
+			"director: 1 changes\n",
+			"  ~ /instances: 1 -> 2\n",
+		}))
+		Expect(logger.PrintlnCall.Messages).To(Equal([]string{"some-terraform-output"}))
+	})
+
+	Context("when there is no infrastructure yet", func() {
+		It("reports that the jumpbox and director will be created", func() {
+			err := planner.Plan(storage.State{}, "")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(terraformManager.GetOutputsCall.CallCount).To(Equal(0))
+			Expect(logger.PrintfCall.Messages).To(ContainElement("jumpbox: will be created\n"))
+			Expect(logger.PrintfCall.Messages).To(ContainElement("director: will be created\n"))
+		})
+	})
+
+	Context("when the environment has no director", func() {
+		It("skips the jumpbox and director", func() {
+			terraformManager.PlanCall.Returns.Plan = terraform.Plan{}
+			state.NoDirector = true
+
+			err := planner.Plan(state, "")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(boshManager.InterpolateJumpboxCall.CallCount).To(Equal(0))
+			Expect(logger.PrintlnCall.Messages).To(Equal([]string{"terraform: no changes"}))
+			Expect(logger.PrintfCall.Messages).To(Equal([]string{
+				"jumpbox: skipped, --no-director is set\n",
+				"director: skipped, --no-director is set\n",
+			}))
+		})
+	})
+
+	Context("when a json report path is given", func() {
+		It("writes the report as json", func() {
+			tempDir, err := ioutil.TempDir("", "")
+			Expect(err).NotTo(HaveOccurred())
+			reportPath := filepath.Join(tempDir, "report.json")
+
+			err = planner.Plan(state, reportPath)
+			Expect(err).NotTo(HaveOccurred())
+
+			info, err := os.Stat(reportPath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info.Mode().Perm()).To(Equal(os.FileMode(0600)))
+
+			contents, err := ioutil.ReadFile(reportPath)
+			Expect(err).NotTo(HaveOccurred())
+
+			var report commands.PlanReport
+			err = json.Unmarshal(contents, &report)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(report).To(Equal(commands.PlanReport{
+				Terraform: terraform.Plan{Add: 1, Output: "some-terraform-output"},
+				Jumpbox:   commands.DeploymentPlan{Action: "none", Changes: []bosh.ManifestChange{}},
+				Director: commands.DeploymentPlan{Action: "update", Changes: []bosh.ManifestChange{
+					{Type: "changed", Path: "/instances", Old: "1", New: "2"},
+				}},
+			}))
+		})
+
+		It("returns an error when the report cannot be written", func() {
+			err := planner.Plan(state, filepath.Join(os.TempDir(), "missing-dir", "report.json"))
+			Expect(err).To(MatchError(ContainSubstring("Write plan report: ")))
+		})
+	})
+
+	Context("failure cases", func() {
+		It("returns an error when terraform plan fails", func() {
+			terraformManager.PlanCall.Returns.Error = errors.New("pear")
+
+			err := planner.Plan(state, "")
+			Expect(err).To(MatchError("Terraform plan: pear"))
+		})
+
+		It("returns an error when the terraform outputs cannot be read", func() {
+			terraformManager.GetOutputsCall.Returns.Error = errors.New("plum")
+
+			err := planner.Plan(state, "")
+			Expect(err).To(MatchError("Parse terraform outputs: plum"))
+		})
+
+		It("returns an error when the jumpbox manifest cannot be interpolated", func() {
+			boshManager.InterpolateJumpboxCall.Returns.Error = errors.New("lime")
+
+			err := planner.Plan(state, "")
+			Expect(err).To(MatchError("lime"))
+		})
+
+		It("returns an error when the director manifest cannot be diffed", func() {
+			boshManager.InterpolateDirectorCall.Returns.Manifest = "name: ["
+
+			err := planner.Plan(state, "")
+			Expect(err).To(MatchError(ContainSubstring("Diff director manifest: Parse new manifest:")))
+		})
+	})
+})
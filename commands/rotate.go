@@ -2,30 +2,62 @@ package commands
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/cloudfoundry/bosh-bootloader/bosh"
 	"github.com/cloudfoundry/bosh-bootloader/storage"
 )
 
+const (
+	// boshVMsPrivateKey is the credential name for the key pair terraform
+	// generates for the VMs the director creates on aws and azure.
+	boshVMsPrivateKey  = "bosh_vms_private_key"
+	boshVMsKeyResource = "tls_private_key.bosh_vms"
+)
+
 type sshKeyDeleter interface {
 	Delete(storage.State) (storage.State, error)
 }
 
+type credentialManager interface {
+	Delete(state storage.State, names []string, all bool) (storage.State, []string, error)
+}
+
+type terraformTainter interface {
+	Taint(storage.State, string) (storage.State, error)
+}
+
 type up interface {
 	CheckFastFails([]string, storage.State) error
 	Execute([]string, storage.State) error
 }
 
 type Rotate struct {
-	stateValidator stateValidator
-	sshKeyDeleter  sshKeyDeleter
-	up             up
+	logger             logger
+	stateValidator     stateValidator
+	sshKeyDeleter      sshKeyDeleter
+	certificateManager certificateManager
+	credentialManager  credentialManager
+	terraformManager   terraformTainter
+	up                 up
+}
+
+type rotateConfig struct {
+	certs          *string
+	credentials    []string
+	allCredentials bool
 }
 
-func NewRotate(stateValidator stateValidator, sshKeyDeleter sshKeyDeleter, up up) Rotate {
+func NewRotate(logger logger, stateValidator stateValidator, sshKeyDeleter sshKeyDeleter, certificateManager certificateManager,
+	credentialManager credentialManager, terraformManager terraformTainter, up up) Rotate {
 	return Rotate{
-		stateValidator: stateValidator,
-		sshKeyDeleter:  sshKeyDeleter,
-		up:             up,
+		logger:             logger,
+		stateValidator:     stateValidator,
+		sshKeyDeleter:      sshKeyDeleter,
+		certificateManager: certificateManager,
+		credentialManager:  credentialManager,
+		terraformManager:   terraformManager,
+		up:                 up,
 	}
 }
 
@@ -35,7 +67,23 @@ func (r Rotate) CheckFastFails(subcommandFlags []string, state storage.State) er
 		return fmt.Errorf("validate state: %s", err)
 	}
 
-	err = r.up.CheckFastFails(subcommandFlags, state)
+	config, upFlags, err := parseRotateFlags(subcommandFlags)
+	if err != nil {
+		return err
+	}
+
+	if config.certs != nil {
+		_, err = bosh.ParseCertificateSelector(*config.certs)
+		if err != nil {
+			return fmt.Errorf("--certs: %s", err)
+		}
+	}
+
+	if containsString(config.credentials, boshVMsPrivateKey) && !hasBOSHVMsKeyPair(state) {
+		return fmt.Errorf("--credentials: %s is only created by bbl on aws and azure", boshVMsPrivateKey)
+	}
+
+	err = r.up.CheckFastFails(upFlags, state)
 	if err != nil {
 		return fmt.Errorf("up: %s", err)
 	}
@@ -43,15 +91,153 @@ func (r Rotate) CheckFastFails(subcommandFlags []string, state storage.State) er
 }
 
 func (r Rotate) Execute(args []string, state storage.State) error {
-	updatedState, err := r.sshKeyDeleter.Delete(state)
+	config, upArgs, err := parseRotateFlags(args)
 	if err != nil {
-		return fmt.Errorf("delete ssh key: %s", err)
+		return err
+	}
+
+	if config.certs == nil && len(config.credentials) == 0 && !config.allCredentials {
+		updatedState, err := r.sshKeyDeleter.Delete(state)
+		if err != nil {
+			return fmt.Errorf("delete ssh key: %s", err)
+		}
+
+		return r.redeploy(upArgs, updatedState)
+	}
+
+	var rotated []string
+	if config.certs != nil {
+		selector, err := bosh.ParseCertificateSelector(*config.certs)
+		if err != nil {
+			return fmt.Errorf("--certs: %s", err)
+		}
+
+		var deleted []string
+		state, deleted, err = r.certificateManager.Delete(state, selector)
+		if err != nil {
+			return fmt.Errorf("delete certificates: %s", err)
+		}
+		rotated = append(rotated, deleted...)
 	}
 
-	err = r.up.Execute(args, updatedState)
+	if len(config.credentials) > 0 || config.allCredentials {
+		var names []string
+		for _, name := range config.credentials {
+			if name != boshVMsPrivateKey {
+				names = append(names, name)
+			}
+		}
+
+		if len(names) > 0 || config.allCredentials {
+			var deleted []string
+			state, deleted, err = r.credentialManager.Delete(state, names, config.allCredentials)
+			if err != nil {
+				return fmt.Errorf("delete credentials: %s", err)
+			}
+			rotated = append(rotated, deleted...)
+		}
+
+		if containsString(config.credentials, boshVMsPrivateKey) || (config.allCredentials && hasBOSHVMsKeyPair(state)) {
+			state, err = r.terraformManager.Taint(state, boshVMsKeyResource)
+			if err != nil {
+				return fmt.Errorf("taint %s: %s", boshVMsKeyResource, err)
+			}
+			rotated = append(rotated, fmt.Sprintf("terraform/%s", boshVMsPrivateKey))
+		}
+	}
+
+	if len(rotated) == 0 {
+		r.logger.Println("Nothing to rotate.")
+		return nil
+	}
+
+	r.logger.Printf("rotating: %s\n", strings.Join(rotated, ", "))
+
+	err = r.redeploy(upArgs, state)
+	if err != nil {
+		return err
+	}
+
+	r.logger.Println("Rotated:")
+	for _, name := range rotated {
+		r.logger.Printf("  %s\n", name)
+	}
+	if containsString(rotated, "director/admin_password") {
+		r.logger.Println(`The director password has changed, run "bbl print-env" to target the director again.`)
+	}
+
+	return nil
+}
+
+func (r Rotate) redeploy(args []string, state storage.State) error {
+	err := r.up.Execute(args, state)
 	if err != nil {
 		return fmt.Errorf("up: %s", err)
 	}
 
 	return nil
 }
+
+func hasBOSHVMsKeyPair(state storage.State) bool {
+	return state.IAAS == "aws" || state.IAAS == "azure"
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// parseRotateFlags takes the rotate flags out of args, leaving the flags that
+// are passed on to up.
+func parseRotateFlags(args []string) (rotateConfig, []string, error) {
+	var (
+		config    rotateConfig
+		remaining []string
+	)
+
+	for i := 0; i < len(args); i++ {
+		name, value, hasValue := splitFlag(args[i])
+
+		switch name {
+		case "certs", "credentials":
+			if !hasValue {
+				if i+1 == len(args) {
+					return rotateConfig{}, nil, fmt.Errorf("flag needs an argument: -%s", name)
+				}
+				i++
+				value = args[i]
+			}
+
+			if name == "certs" {
+				config.certs = &value
+			} else {
+				config.credentials = append(config.credentials, strings.Split(value, ",")...)
+			}
+		case "all":
+			config.allCredentials = !hasValue || value == "true"
+		default:
+			remaining = append(remaining, args[i])
+		}
+	}
+
+	return config, remaining, nil
+}
+
+// splitFlag returns the name of a -flag or --flag argument and its value when
+// it is given as --flag=value.
+func splitFlag(arg string) (string, string, bool) {
+	if !strings.HasPrefix(arg, "-") {
+		return "", "", false
+	}
+
+	name := strings.TrimLeft(arg, "-")
+	if i := strings.Index(name, "="); i >= 0 {
+		return name[:i], name[i+1:], true
+	}
+
+	return name, "", false
+}
@@ -3,6 +3,7 @@ package commands_test
 import (
 	"errors"
 
+	"github.com/cloudfoundry/bosh-bootloader/bosh"
 	"github.com/cloudfoundry/bosh-bootloader/commands"
 	"github.com/cloudfoundry/bosh-bootloader/fakes"
 	"github.com/cloudfoundry/bosh-bootloader/storage"
@@ -12,17 +13,25 @@ import (
 
 var _ = Describe("Rotate", func() {
 	var (
-		stateValidator *fakes.StateValidator
-		sshKeyDeleter  *fakes.SSHKeyDeleter
-		up             *fakes.Up
-		rotate         commands.Rotate
+		logger             *fakes.Logger
+		stateValidator     *fakes.StateValidator
+		sshKeyDeleter      *fakes.SSHKeyDeleter
+		certificateManager *fakes.CertificateManager
+		credentialManager  *fakes.CredentialManager
+		terraformManager   *fakes.TerraformManager
+		up                 *fakes.Up
+		rotate             commands.Rotate
 	)
 
 	BeforeEach(func() {
+		logger = &fakes.Logger{}
 		stateValidator = &fakes.StateValidator{}
 		sshKeyDeleter = &fakes.SSHKeyDeleter{}
+		certificateManager = &fakes.CertificateManager{}
+		credentialManager = &fakes.CredentialManager{}
+		terraformManager = &fakes.TerraformManager{}
 		up = &fakes.Up{}
-		rotate = commands.NewRotate(stateValidator, sshKeyDeleter, up)
+		rotate = commands.NewRotate(logger, stateValidator, sshKeyDeleter, certificateManager, credentialManager, terraformManager, up)
 	})
 
 	Describe("CheckFastFails", func() {
@@ -66,6 +75,38 @@ var _ = Describe("Rotate", func() {
 			})
 		})
 
+		Context("when --certs is passed", func() {
+			It("does not pass it to up.CheckFastFails", func() {
+				err := rotate.CheckFastFails([]string{"--certs", "all", "--debug"}, storage.State{})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(up.CheckFastFailsCall.Receives.SubcommandFlags).To(Equal([]string{"--debug"}))
+			})
+
+			It("returns an error when the certificates cannot be parsed", func() {
+				err := rotate.CheckFastFails([]string{"--certs=expiring-within=soon"}, storage.State{})
+				Expect(err).To(MatchError(ContainSubstring("--certs: expiring-within: time: invalid duration")))
+			})
+
+			It("returns an error when no value is given", func() {
+				err := rotate.CheckFastFails([]string{"--certs"}, storage.State{})
+				Expect(err).To(MatchError("flag needs an argument: -certs"))
+			})
+		})
+
+		Context("when --credentials is passed", func() {
+			It("does not pass it to up.CheckFastFails", func() {
+				err := rotate.CheckFastFails([]string{"--credentials=admin_password", "--all", "--debug"}, storage.State{})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(up.CheckFastFailsCall.Receives.SubcommandFlags).To(Equal([]string{"--debug"}))
+			})
+
+			It("returns an error when the bosh vms key pair is not created by bbl", func() {
+				err := rotate.CheckFastFails([]string{"--credentials", "bosh_vms_private_key"}, storage.State{IAAS: "gcp"})
+				Expect(err).To(MatchError("--credentials: bosh_vms_private_key is only created by bbl on aws and azure"))
+			})
+		})
 	})
 
 	Describe("Execute", func() {
@@ -120,6 +161,136 @@ var _ = Describe("Rotate", func() {
 			})
 		})
 
+		Context("when --certs is passed", func() {
+			BeforeEach(func() {
+				args = []string{"--certs", "director_ssl", "some-arg"}
+				certificateManager.DeleteCall.Returns.State = newState
+				certificateManager.DeleteCall.Returns.Deleted = []string{"director/director_ssl"}
+			})
+
+			It("deletes the certificates instead of the ssh key and calls up", func() {
+				err := rotate.Execute(args, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				expectedSelector, err := bosh.ParseCertificateSelector("director_ssl")
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(sshKeyDeleter.DeleteCall.CallCount).To(Equal(0))
+				Expect(certificateManager.DeleteCall.Receives.State).To(Equal(state))
+				Expect(certificateManager.DeleteCall.Receives.Selector).To(Equal(expectedSelector))
+				Expect(logger.PrintfCall.Messages).To(Equal([]string{
+					"rotating: director/director_ssl\n",
+					"  director/director_ssl\n",
+				}))
+
+				Expect(up.ExecuteCall.Receives.Args).To(Equal([]string{"some-arg"}))
+				Expect(up.ExecuteCall.Receives.State).To(Equal(newState))
+			})
+
+			It("does not call up when there are no certificates to rotate", func() {
+				certificateManager.DeleteCall.Returns.Deleted = nil
+
+				err := rotate.Execute(args, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(logger.PrintlnCall.Messages).To(Equal([]string{"Nothing to rotate."}))
+				Expect(up.ExecuteCall.CallCount).To(Equal(0))
+			})
+
+			It("wraps and returns the error from the certificate manager", func() {
+				certificateManager.DeleteCall.Returns.Error = errors.New("papaya")
+
+				err := rotate.Execute(args, state)
+				Expect(err).To(MatchError("delete certificates: papaya"))
+			})
+		})
+
+		Context("when --credentials is passed", func() {
+			var (
+				deleteCredentialsState storage.State
+				taintState             storage.State
+			)
+
+			BeforeEach(func() {
+				state.IAAS = "aws"
+				deleteCredentialsState = storage.State{EnvID: "some-env-id", IAAS: "aws", TFState: "some-tf-state"}
+				taintState = storage.State{EnvID: "some-env-id", IAAS: "aws", TFState: "some-tainted-tf-state"}
+
+				credentialManager.DeleteCall.Returns.State = deleteCredentialsState
+				credentialManager.DeleteCall.Returns.Deleted = []string{"director/admin_password", "director/nats_password"}
+				terraformManager.TaintCall.Returns.BBLState = taintState
+			})
+
+			It("deletes the credentials, redeploys and prints a summary", func() {
+				err := rotate.Execute([]string{"--credentials", "admin_password,nats_password"}, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(sshKeyDeleter.DeleteCall.CallCount).To(Equal(0))
+				Expect(credentialManager.DeleteCall.Receives.State).To(Equal(state))
+				Expect(credentialManager.DeleteCall.Receives.Names).To(Equal([]string{"admin_password", "nats_password"}))
+				Expect(credentialManager.DeleteCall.Receives.All).To(BeFalse())
+				Expect(terraformManager.TaintCall.CallCount).To(Equal(0))
+
+				Expect(up.ExecuteCall.Receives.State).To(Equal(deleteCredentialsState))
+
+				Expect(logger.PrintfCall.Messages).To(Equal([]string{
+					"rotating: director/admin_password, director/nats_password\n",
+					"  director/admin_password\n",
+					"  director/nats_password\n",
+				}))
+				Expect(logger.PrintlnCall.Messages).To(Equal([]string{
+					"Rotated:",
+					`The director password has changed, run "bbl print-env" to target the director again.`,
+				}))
+			})
+
+			It("taints the bosh vms key pair when it is named", func() {
+				err := rotate.Execute([]string{"--credentials", "bosh_vms_private_key"}, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(credentialManager.DeleteCall.CallCount).To(Equal(0))
+				Expect(terraformManager.TaintCall.Receives.BBLState).To(Equal(state))
+				Expect(terraformManager.TaintCall.Receives.Resource).To(Equal("tls_private_key.bosh_vms"))
+				Expect(up.ExecuteCall.Receives.State).To(Equal(taintState))
+			})
+
+			It("deletes every credential and taints the key pair when --all is passed", func() {
+				err := rotate.Execute([]string{"--all"}, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(credentialManager.DeleteCall.Receives.Names).To(BeEmpty())
+				Expect(credentialManager.DeleteCall.Receives.All).To(BeTrue())
+				Expect(terraformManager.TaintCall.Receives.BBLState).To(Equal(deleteCredentialsState))
+				Expect(up.ExecuteCall.Receives.State).To(Equal(taintState))
+				Expect(logger.PrintfCall.Messages).To(ContainElement("  terraform/bosh_vms_private_key\n"))
+			})
+
+			It("does not taint the key pair with --all when bbl does not create it", func() {
+				deleteCredentialsState.IAAS = "gcp"
+				credentialManager.DeleteCall.Returns.State = deleteCredentialsState
+
+				err := rotate.Execute([]string{"--all"}, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(terraformManager.TaintCall.CallCount).To(Equal(0))
+				Expect(up.ExecuteCall.Receives.State).To(Equal(deleteCredentialsState))
+			})
+
+			It("wraps and returns the error from the credential manager", func() {
+				credentialManager.DeleteCall.Returns.Error = errors.New("kumquat")
+
+				err := rotate.Execute([]string{"--credentials", "admin_password"}, state)
+				Expect(err).To(MatchError("delete credentials: kumquat"))
+			})
+
+			It("wraps and returns the error from terraform taint", func() {
+				terraformManager.TaintCall.Returns.Error = errors.New("persimmon")
+
+				err := rotate.Execute([]string{"--all"}, state)
+				Expect(err).To(MatchError("taint tls_private_key.bosh_vms: persimmon"))
+			})
+		})
+
 		Context("when up returns an error", func() {
 			BeforeEach(func() {
 				up.ExecuteCall.Returns.Error = errors.New("fig")
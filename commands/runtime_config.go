@@ -0,0 +1,43 @@
+package commands
+
+import (
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+)
+
+const (
+	RuntimeConfigCommand = "runtime-config"
+)
+
+type RuntimeConfig struct {
+	logger               logger
+	stateValidator       stateValidator
+	runtimeConfigManager runtimeConfigManager
+}
+
+func NewRuntimeConfig(logger logger, stateValidator stateValidator, runtimeConfigManager runtimeConfigManager) RuntimeConfig {
+	return RuntimeConfig{
+		logger:               logger,
+		stateValidator:       stateValidator,
+		runtimeConfigManager: runtimeConfigManager,
+	}
+}
+
+func (r RuntimeConfig) CheckFastFails(subcommandFlags []string, state storage.State) error {
+	err := r.stateValidator.Validate()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r RuntimeConfig) Execute(args []string, state storage.State) error {
+	contents := r.runtimeConfigManager.Generate(state)
+	if contents == "" {
+		r.logger.Println("no runtime configs or cpi config are saved in the state")
+		return nil
+	}
+
+	r.logger.Println(contents)
+	return nil
+}
@@ -0,0 +1,67 @@
+package commands_test
+
+import (
+	"errors"
+
+	"github.com/cloudfoundry/bosh-bootloader/commands"
+	"github.com/cloudfoundry/bosh-bootloader/fakes"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RuntimeConfig", func() {
+	var (
+		logger               *fakes.Logger
+		stateValidator       *fakes.StateValidator
+		runtimeConfigManager *fakes.RuntimeConfigManager
+		runtimeConfig        commands.RuntimeConfig
+		state                storage.State
+	)
+
+	BeforeEach(func() {
+		logger = &fakes.Logger{}
+		stateValidator = &fakes.StateValidator{}
+		runtimeConfigManager = &fakes.RuntimeConfigManager{}
+
+		runtimeConfigManager.GenerateCall.Returns.RuntimeConfig = "some-runtime-config"
+
+		state = storage.State{
+			RuntimeConfigs: []storage.UserFile{{Name: "dns.yml", Contents: "some-dns-config"}},
+		}
+
+		runtimeConfig = commands.NewRuntimeConfig(logger, stateValidator, runtimeConfigManager)
+	})
+
+	Describe("CheckFastFails", func() {
+		Context("when the state validator fails", func() {
+			BeforeEach(func() {
+				stateValidator.ValidateCall.Returns.Error = errors.New("failed to validate state")
+			})
+
+			It("returns an error", func() {
+				err := runtimeConfig.CheckFastFails([]string{}, storage.State{})
+				Expect(err).To(MatchError("failed to validate state"))
+			})
+		})
+	})
+
+	Describe("Execute", func() {
+		It("prints the runtime configs that bbl up uploads", func() {
+			err := runtimeConfig.Execute([]string{}, state)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(runtimeConfigManager.GenerateCall.Receives.State).To(Equal(state))
+			Expect(logger.PrintlnCall.Messages).To(Equal([]string{"some-runtime-config"}))
+		})
+
+		Context("when there are no runtime configs", func() {
+			It("says so", func() {
+				runtimeConfigManager.GenerateCall.Returns.RuntimeConfig = ""
+
+				err := runtimeConfig.Execute([]string{}, storage.State{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(logger.PrintlnCall.Messages).To(Equal([]string{"no runtime configs or cpi config are saved in the state"}))
+			})
+		})
+	})
+})
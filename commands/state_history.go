@@ -0,0 +1,47 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+)
+
+type stateHistory interface {
+	Snapshots() ([]storage.Snapshot, error)
+	RestoreSnapshot(id string) error
+}
+
+type StateHistory struct {
+	logger       logger
+	stateHistory stateHistory
+}
+
+func NewStateHistory(logger logger, stateHistory stateHistory) StateHistory {
+	return StateHistory{
+		logger:       logger,
+		stateHistory: stateHistory,
+	}
+}
+
+func (s StateHistory) CheckFastFails(subcommandFlags []string, state storage.State) error {
+	return nil
+}
+
+func (s StateHistory) Execute(subcommandFlags []string, state storage.State) error {
+	snapshots, err := s.stateHistory.Snapshots()
+	if err != nil {
+		return err
+	}
+
+	if len(snapshots) == 0 {
+		s.logger.Println("No state snapshots found.")
+		return nil
+	}
+
+	s.logger.Printf("%-28s %-22s %s\n", "ID", "CREATED", "SIZE")
+	for _, snapshot := range snapshots {
+		s.logger.Printf("%-28s %-22s %d\n", snapshot.ID, snapshot.Created.Format(time.RFC3339), snapshot.Size)
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package commands_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/cloudfoundry/bosh-bootloader/commands"
+	"github.com/cloudfoundry/bosh-bootloader/fakes"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("state-history", func() {
+	var (
+		logger       *fakes.Logger
+		stateHistory *fakes.StateHistory
+
+		command commands.StateHistory
+	)
+
+	BeforeEach(func() {
+		logger = &fakes.Logger{}
+		stateHistory = &fakes.StateHistory{}
+
+		command = commands.NewStateHistory(logger, stateHistory)
+	})
+
+	Describe("Execute", func() {
+		It("prints the state snapshots", func() {
+			stateHistory.SnapshotsCall.Returns.Snapshots = []storage.Snapshot{
+				{
+					ID:      "20171001T120000.000000000Z",
+					Created: time.Date(2017, time.October, 1, 12, 0, 0, 0, time.UTC),
+					Size:    123,
+				},
+				{
+					ID:      "20171001T130000.000000000Z",
+					Created: time.Date(2017, time.October, 1, 13, 0, 0, 0, time.UTC),
+					Size:    456,
+				},
+			}
+
+			err := command.Execute([]string{}, storage.State{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(logger.PrintfCall.Messages).To(Equal([]string{
+				"ID                           CREATED                SIZE\n",
+				"20171001T120000.000000000Z   2017-10-01T12:00:00Z   123\n",
+				"20171001T130000.000000000Z   2017-10-01T13:00:00Z   456\n",
+			}))
+		})
+
+		Context("when there are no snapshots", func() {
+			It("says so", func() {
+				err := command.Execute([]string{}, storage.State{})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(logger.PrintlnCall.Messages).To(Equal([]string{"No state snapshots found."}))
+			})
+		})
+
+		Context("when listing snapshots fails", func() {
+			It("returns an error", func() {
+				stateHistory.SnapshotsCall.Returns.Error = errors.New("failed to list")
+
+				err := command.Execute([]string{}, storage.State{})
+				Expect(err).To(MatchError("failed to list"))
+			})
+		})
+	})
+})
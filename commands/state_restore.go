@@ -0,0 +1,39 @@
+package commands
+
+import (
+	"errors"
+
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+)
+
+type StateRestore struct {
+	logger       logger
+	stateHistory stateHistory
+}
+
+func NewStateRestore(logger logger, stateHistory stateHistory) StateRestore {
+	return StateRestore{
+		logger:       logger,
+		stateHistory: stateHistory,
+	}
+}
+
+func (s StateRestore) CheckFastFails(subcommandFlags []string, state storage.State) error {
+	if len(subcommandFlags) != 1 {
+		return errors.New("state-restore requires exactly one snapshot ID; run `bbl state-history` to list them")
+	}
+
+	return nil
+}
+
+func (s StateRestore) Execute(subcommandFlags []string, state storage.State) error {
+	id := subcommandFlags[0]
+
+	s.logger.Step("restoring bbl-state.json from snapshot %s", id)
+	err := s.stateHistory.RestoreSnapshot(id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
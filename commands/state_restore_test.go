@@ -0,0 +1,56 @@
+package commands_test
+
+import (
+	"errors"
+
+	"github.com/cloudfoundry/bosh-bootloader/commands"
+	"github.com/cloudfoundry/bosh-bootloader/fakes"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("state-restore", func() {
+	var (
+		logger       *fakes.Logger
+		stateHistory *fakes.StateHistory
+
+		command commands.StateRestore
+	)
+
+	BeforeEach(func() {
+		logger = &fakes.Logger{}
+		stateHistory = &fakes.StateHistory{}
+
+		command = commands.NewStateRestore(logger, stateHistory)
+	})
+
+	Describe("CheckFastFails", func() {
+		Context("when no snapshot ID is given", func() {
+			It("returns an error", func() {
+				err := command.CheckFastFails([]string{}, storage.State{})
+				Expect(err).To(MatchError("state-restore requires exactly one snapshot ID; run `bbl state-history` to list them"))
+			})
+		})
+	})
+
+	Describe("Execute", func() {
+		It("restores the snapshot", func() {
+			err := command.Execute([]string{"some-snapshot-id"}, storage.State{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(stateHistory.RestoreSnapshotCall.Receives.ID).To(Equal("some-snapshot-id"))
+			Expect(logger.StepCall.Messages).To(Equal([]string{"restoring bbl-state.json from snapshot some-snapshot-id"}))
+		})
+
+		Context("when restoring fails", func() {
+			It("returns an error", func() {
+				stateHistory.RestoreSnapshotCall.Returns.Error = errors.New("failed to restore")
+
+				err := command.Execute([]string{"some-snapshot-id"}, storage.State{})
+				Expect(err).To(MatchError("failed to restore"))
+			})
+		})
+	})
+})
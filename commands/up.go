@@ -6,6 +6,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/cloudfoundry/bosh-bootloader/bosh"
 	"github.com/cloudfoundry/bosh-bootloader/flags"
@@ -13,12 +14,14 @@ import (
 )
 
 type Up struct {
-	upCmd              UpCmd
-	boshManager        boshManager
-	cloudConfigManager cloudConfigManager
-	stateStore         stateStore
-	envIDManager       envIDManager
-	terraformManager   terraformApplier
+	upCmd                UpCmd
+	boshManager          boshManager
+	cloudConfigManager   cloudConfigManager
+	runtimeConfigManager runtimeConfigManager
+	stateStore           stateStore
+	envIDManager         envIDManager
+	terraformManager     terraformApplier
+	planner              planner
 }
 
 type UpCmd interface {
@@ -27,19 +30,54 @@ type UpCmd interface {
 
 type UpConfig struct {
 	Name       string
-	OpsFile    string
 	NoDirector bool
+	DryRun     bool
+	JSONReport string
+	Recreate   bool
+
+	CloudConfigMode string
+
+	OpsFiles        []storage.UserFile
+	RemoveOpsFiles  []string
+	VarsFiles       []storage.UserFile
+	RemoveVarsFiles []string
+	Vars            []string
+	RemoveVars      []string
+
+	JumpboxOpsFiles       []storage.UserFile
+	RemoveJumpboxOpsFiles []string
+
+	CloudConfigOpsFiles       []storage.UserFile
+	RemoveCloudConfigOpsFiles []string
+
+	RuntimeConfigs       []storage.UserFile
+	RemoveRuntimeConfigs []string
+	CPIConfig            string
+	RemoveCPIConfig      bool
+
+	TerraformOverrides string
+	ExistingNetwork    string
+	InternalCIDR       string
+
+	BOSHDeploymentDir    string
+	JumpboxDeploymentDir string
+
+	DirectorVMType   string
+	DirectorDiskSize int
+	JumpboxVMType    string
 }
 
-func NewUp(upCmd UpCmd, boshManager boshManager, cloudConfigManager cloudConfigManager,
-	stateStore stateStore, envIDManager envIDManager, terraformManager terraformApplier) Up {
+func NewUp(upCmd UpCmd, boshManager boshManager, cloudConfigManager cloudConfigManager, runtimeConfigManager runtimeConfigManager,
+	stateStore stateStore, envIDManager envIDManager, terraformManager terraformApplier, planner planner) Up {
 	return Up{
-		upCmd:              upCmd,
-		boshManager:        boshManager,
-		cloudConfigManager: cloudConfigManager,
-		stateStore:         stateStore,
-		envIDManager:       envIDManager,
-		terraformManager:   terraformManager,
+		upCmd:                upCmd,
+		boshManager:          boshManager,
+		cloudConfigManager:   cloudConfigManager,
+		runtimeConfigManager: runtimeConfigManager,
+		stateStore:           stateStore,
+		envIDManager:         envIDManager,
+		terraformManager:     terraformManager,
+		planner:              planner,
 	}
 }
 
@@ -60,6 +98,83 @@ func (u Up) CheckFastFails(args []string, state storage.State) error {
 		return fmt.Errorf("The director name cannot be changed for an existing environment. Current name is %s.", state.EnvID)
 	}
 
+	if state.TFState != "" && config.ExistingNetwork != state.ExistingNetwork {
+		return errors.New("--existing-network cannot be changed for an existing environment.")
+	}
+
+	if state.IAAS == "azure" && config.ExistingNetwork != "" {
+		parts := strings.Split(config.ExistingNetwork, "/")
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return errors.New("--existing-network must be given as <resource-group>/<virtual-network> on azure.")
+		}
+	}
+
+	internalNetwork, err := bosh.ParseInternalNetwork(config.InternalCIDR)
+	if err != nil {
+		return err
+	}
+
+	currentInternalCIDR := state.InternalCIDR
+	if currentInternalCIDR == "" {
+		currentInternalCIDR = bosh.DEFAULT_INTERNAL_CIDR
+	}
+	if state.TFState != "" && internalNetwork.CIDR().String() != currentInternalCIDR {
+		return fmt.Errorf("--internal-cidr cannot be changed for an existing environment. Current internal CIDR is %s.", currentInternalCIDR)
+	}
+
+	_, err = applyUserFiles(config, state.BOSH)
+	if err != nil {
+		return err
+	}
+
+	_, err = applyJumpboxOpsFiles(config, state.Jumpbox)
+	if err != nil {
+		return err
+	}
+
+	_, err = applyCloudConfigOpsFiles(config, state.CloudConfigOpsFiles)
+	if err != nil {
+		return err
+	}
+
+	_, err = applyDirectorConfigs(config, state)
+	if err != nil {
+		return err
+	}
+
+	err = bosh.ValidateVMType(state.IAAS, config.DirectorVMType)
+	if err != nil {
+		return fmt.Errorf("--director-vm-type: %s", err)
+	}
+
+	err = bosh.ValidateVMType(state.IAAS, config.JumpboxVMType)
+	if err != nil {
+		return fmt.Errorf("--jumpbox-vm-type: %s", err)
+	}
+
+	if config.DirectorDiskSize < 0 {
+		return errors.New("--director-disk-size must be a positive number of GB")
+	}
+
+	err = validateCloudConfigMode(config.CloudConfigMode)
+	if err != nil {
+		return err
+	}
+
+	if config.BOSHDeploymentDir != "" {
+		err = bosh.ValidateDeploymentDir(config.BOSHDeploymentDir, bosh.DirectorDeploymentFiles(state.IAAS))
+		if err != nil {
+			return fmt.Errorf("BOSH deployment dir: %s", err)
+		}
+	}
+
+	if config.JumpboxDeploymentDir != "" {
+		err = bosh.ValidateDeploymentDir(config.JumpboxDeploymentDir, bosh.JumpboxDeploymentFiles(state.IAAS))
+		if err != nil {
+			return fmt.Errorf("Jumpbox deployment dir: %s", err)
+		}
+	}
+
 	return nil
 }
 
@@ -69,21 +184,25 @@ func (u Up) Execute(args []string, state storage.State) error {
 		return fmt.Errorf("Terraform validate version: %s", err)
 	}
 
-	state, err = u.upCmd.Execute(state)
+	config, err := u.ParseArgs(args, state)
 	if err != nil {
 		return err
 	}
 
-	err = u.stateStore.Set(state)
-	if err != nil {
-		return fmt.Errorf("Save state after IAAS up: %s", err)
+	if config.DryRun {
+		return u.plan(config, state)
 	}
 
-	config, err := u.ParseArgs(args, state)
+	state, err = u.upCmd.Execute(state)
 	if err != nil {
 		return err
 	}
 
+	err = u.stateStore.Set(state)
+	if err != nil {
+		return fmt.Errorf("Save state after IAAS up: %s", err)
+	}
+
 	if config.NoDirector {
 		if !state.BOSH.IsEmpty() {
 			return errors.New(`Director already exists, you must re-create your environment to use "--no-director"`)
@@ -92,12 +211,28 @@ func (u Up) Execute(args []string, state storage.State) error {
 		state.NoDirector = true
 	}
 
-	var opsFileContents []byte
-	if config.OpsFile != "" {
-		opsFileContents, err = ioutil.ReadFile(config.OpsFile)
-		if err != nil {
-			return fmt.Errorf("Reading ops-file contents: %v", err)
-		}
+	state.TerraformOverrides = config.TerraformOverrides
+	state.ExistingNetwork = config.ExistingNetwork
+	state.InternalCIDR = config.InternalCIDR
+	state.BOSHDeploymentDir = config.BOSHDeploymentDir
+	state.JumpboxDeploymentDir = config.JumpboxDeploymentDir
+	state.DirectorVMType = config.DirectorVMType
+	state.DirectorDiskSize = config.DirectorDiskSize
+	state.JumpboxVMType = config.JumpboxVMType
+
+	state.CloudConfigOpsFiles, err = applyCloudConfigOpsFiles(config, state.CloudConfigOpsFiles)
+	if err != nil {
+		return err //not tested
+	}
+
+	state, err = applyDirectorConfigs(config, state)
+	if err != nil {
+		return err //not tested
+	}
+
+	if config.Recreate {
+		state.Jumpbox.Fingerprint = ""
+		state.BOSH.Fingerprint = ""
 	}
 
 	state, err = u.envIDManager.Sync(state, config.Name)
@@ -129,6 +264,11 @@ func (u Up) Execute(args []string, state storage.State) error {
 		return fmt.Errorf("Parse terraform outputs: %s", err)
 	}
 
+	state.Jumpbox, err = applyJumpboxOpsFiles(config, state.Jumpbox)
+	if err != nil {
+		return err //not tested
+	}
+
 	state, err = u.boshManager.CreateJumpbox(state, terraformOutputs)
 	if err != nil {
 		return fmt.Errorf("Create jumpbox: %s", err)
@@ -139,7 +279,11 @@ func (u Up) Execute(args []string, state storage.State) error {
 		return fmt.Errorf("Save state after create jumpbox: %s", err)
 	}
 
-	state.BOSH.UserOpsFile = string(opsFileContents)
+	state.BOSH, err = applyUserFiles(config, state.BOSH)
+	if err != nil {
+		return err //not tested
+	}
+
 	state, err = u.boshManager.CreateDirector(state, terraformOutputs)
 	switch err.(type) {
 	case bosh.ManagerCreateError:
@@ -157,36 +301,383 @@ func (u Up) Execute(args []string, state storage.State) error {
 		return fmt.Errorf("Save state after create director: %s", err)
 	}
 
-	err = u.cloudConfigManager.Update(state)
+	err = updateCloudConfig(u.cloudConfigManager, state, config.CloudConfigMode)
 	if err != nil {
 		return fmt.Errorf("Update cloud config: %s", err)
 	}
 
+	err = u.runtimeConfigManager.Update(state)
+	if err != nil {
+		return fmt.Errorf("Update runtime configs: %s", err)
+	}
+
+	err = u.runtimeConfigManager.Delete(state, config.RemoveRuntimeConfigs, config.RemoveCPIConfig)
+	if err != nil {
+		return fmt.Errorf("Delete runtime configs: %s", err)
+	}
+
 	return nil
 }
 
-func (u Up) ParseArgs(args []string, state storage.State) (UpConfig, error) {
-	opsFileDir, err := u.stateStore.GetBblDir()
+// plan reports what Execute would change for config without saving any state.
+func (u Up) plan(config UpConfig, state storage.State) error {
+	state, err := u.upCmd.Execute(state)
 	if err != nil {
-		return UpConfig{}, err //not tested
+		return err
+	}
+
+	if config.NoDirector {
+		if !state.BOSH.IsEmpty() {
+			return errors.New(`Director already exists, you must re-create your environment to use "--no-director"`)
+		}
+
+		state.NoDirector = true
 	}
 
-	prevOpsFilePath := filepath.Join(opsFileDir, "previous-user-ops-file.yml")
-	err = ioutil.WriteFile(prevOpsFilePath, []byte(state.BOSH.UserOpsFile), os.ModePerm)
+	state.BOSH, err = applyUserFiles(config, state.BOSH)
 	if err != nil {
-		return UpConfig{}, err //not tested
+		return err
+	}
+
+	state.Jumpbox, err = applyJumpboxOpsFiles(config, state.Jumpbox)
+	if err != nil {
+		return err
+	}
+
+	state.CloudConfigOpsFiles, err = applyCloudConfigOpsFiles(config, state.CloudConfigOpsFiles)
+	if err != nil {
+		return err
 	}
 
-	var config UpConfig
+	state, err = applyDirectorConfigs(config, state)
+	if err != nil {
+		return err
+	}
+
+	state.TerraformOverrides = config.TerraformOverrides
+	state.ExistingNetwork = config.ExistingNetwork
+	state.InternalCIDR = config.InternalCIDR
+	state.BOSHDeploymentDir = config.BOSHDeploymentDir
+	state.JumpboxDeploymentDir = config.JumpboxDeploymentDir
+	state.DirectorVMType = config.DirectorVMType
+	state.DirectorDiskSize = config.DirectorDiskSize
+	state.JumpboxVMType = config.JumpboxVMType
+
+	state, err = u.envIDManager.Sync(state, config.Name)
+	if err != nil {
+		return fmt.Errorf("Env id manager sync: %s", err)
+	}
+
+	return u.planner.Plan(state, config.JSONReport)
+}
+
+func (u Up) ParseArgs(args []string, state storage.State) (UpConfig, error) {
+	var (
+		config                  UpConfig
+		opsFilePaths            []string
+		varsFilePaths           []string
+		jumpboxOpsFilePaths     []string
+		cloudConfigOpsFilePaths []string
+		runtimeConfigPaths      []string
+		cpiConfigPath           string
+	)
+
 	upFlags := flags.New("up")
 	upFlags.String(&config.Name, "name", "")
-	upFlags.String(&config.OpsFile, "ops-file", prevOpsFilePath)
+	upFlags.StringSlice(&opsFilePaths, "ops-file")
+	upFlags.StringSlice(&config.RemoveOpsFiles, "remove-ops-file")
+	upFlags.StringSlice(&varsFilePaths, "vars-file")
+	upFlags.StringSlice(&config.RemoveVarsFiles, "remove-vars-file")
+	upFlags.StringSlice(&config.Vars, "var")
+	upFlags.StringSlice(&config.RemoveVars, "remove-var")
+	upFlags.StringSlice(&jumpboxOpsFilePaths, "jumpbox-ops-file")
+	upFlags.StringSlice(&config.RemoveJumpboxOpsFiles, "remove-jumpbox-ops-file")
+	upFlags.StringSlice(&cloudConfigOpsFilePaths, "cloud-config-ops-file")
+	upFlags.StringSlice(&config.RemoveCloudConfigOpsFiles, "remove-cloud-config-ops-file")
+	upFlags.StringSlice(&runtimeConfigPaths, "runtime-config")
+	upFlags.StringSlice(&config.RemoveRuntimeConfigs, "remove-runtime-config")
+	upFlags.String(&cpiConfigPath, "cpi-config", "")
+	upFlags.Bool(&config.RemoveCPIConfig, "", "remove-cpi-config", false)
 	upFlags.Bool(&config.NoDirector, "", "no-director", state.NoDirector)
+	upFlags.Bool(&config.DryRun, "", "dry-run", false)
+	upFlags.Bool(&config.Recreate, "", "recreate", false)
+	upFlags.String(&config.CloudConfigMode, "cloud-config-mode", cloudConfigModeApply)
+	upFlags.String(&config.JSONReport, "json-report", "")
+	upFlags.String(&config.TerraformOverrides, "terraform-overrides", state.TerraformOverrides)
+	upFlags.String(&config.ExistingNetwork, "existing-network", state.ExistingNetwork)
+	upFlags.String(&config.InternalCIDR, "internal-cidr", state.InternalCIDR)
+	upFlags.String(&config.BOSHDeploymentDir, "bosh-deployment-dir", state.BOSHDeploymentDir)
+	upFlags.String(&config.JumpboxDeploymentDir, "jumpbox-deployment-dir", state.JumpboxDeploymentDir)
+	upFlags.String(&config.DirectorVMType, "director-vm-type", state.DirectorVMType)
+	upFlags.Int(&config.DirectorDiskSize, "director-disk-size", state.DirectorDiskSize)
+	upFlags.String(&config.JumpboxVMType, "jumpbox-vm-type", state.JumpboxVMType)
+
+	err := upFlags.Parse(args)
+	if err != nil {
+		return UpConfig{}, err
+	}
+
+	config.OpsFiles, err = readUserFiles(opsFilePaths)
+	if err != nil {
+		return UpConfig{}, fmt.Errorf("Reading ops-file contents: %v", err)
+	}
+
+	config.VarsFiles, err = readUserFiles(varsFilePaths)
+	if err != nil {
+		return UpConfig{}, fmt.Errorf("Reading vars-file contents: %v", err)
+	}
+
+	config.JumpboxOpsFiles, err = readUserFiles(jumpboxOpsFilePaths)
+	if err != nil {
+		return UpConfig{}, fmt.Errorf("Reading jumpbox-ops-file contents: %v", err)
+	}
+
+	config.CloudConfigOpsFiles, err = readUserFiles(cloudConfigOpsFilePaths)
+	if err != nil {
+		return UpConfig{}, fmt.Errorf("Reading cloud-config-ops-file contents: %v", err)
+	}
+
+	config.RuntimeConfigs, err = readUserFiles(runtimeConfigPaths)
+	if err != nil {
+		return UpConfig{}, fmt.Errorf("Reading runtime-config contents: %v", err)
+	}
+
+	if cpiConfigPath != "" {
+		cpiConfig, err := ioutil.ReadFile(cpiConfigPath)
+		if err != nil {
+			return UpConfig{}, fmt.Errorf("Reading cpi-config contents: %v", err)
+		}
+		config.CPIConfig = string(cpiConfig)
+	}
+
+	for _, v := range config.Vars {
+		if !strings.Contains(v, "=") {
+			return UpConfig{}, fmt.Errorf("--var must be given as key=value: %s", v)
+		}
+	}
 
-	err = upFlags.Parse(args)
+	config.TerraformOverrides, err = terraformOverridesDir(config.TerraformOverrides)
 	if err != nil {
 		return UpConfig{}, err
 	}
 
+	config.BOSHDeploymentDir, err = absPath(config.BOSHDeploymentDir)
+	if err != nil {
+		return UpConfig{}, err //not tested
+	}
+
+	config.JumpboxDeploymentDir, err = absPath(config.JumpboxDeploymentDir)
+	if err != nil {
+		return UpConfig{}, err //not tested
+	}
+
 	return config, nil
 }
+
+func terraformOverridesDir(path string) (string, error) {
+	if path == "" {
+		return "", nil
+	}
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return "", err //not tested
+	}
+
+	info, err := os.Stat(absPath)
+	if err != nil {
+		return "", fmt.Errorf("Terraform overrides: %s", err)
+	}
+
+	if !info.IsDir() {
+		return "", fmt.Errorf("Terraform overrides: %s is not a directory", path)
+	}
+
+	return absPath, nil
+}
+
+func absPath(path string) (string, error) {
+	if path == "" {
+		return "", nil
+	}
+
+	return filepath.Abs(path)
+}
+
+// readUserFiles reads the files at paths, naming each after its base name.
+// Two paths with the same base name would replace each other in the state, so
+// they are rejected.
+func readUserFiles(paths []string) ([]storage.UserFile, error) {
+	var files []storage.UserFile
+	for i, path := range paths {
+		name := filepath.Base(path)
+		for _, other := range paths[:i] {
+			if filepath.Base(other) == name {
+				return nil, fmt.Errorf("%s and %s are both named %s, rename one of them", other, path, name)
+			}
+		}
+
+		contents, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		files = append(files, storage.UserFile{
+			Name:     name,
+			Contents: string(contents),
+		})
+	}
+
+	return files, nil
+}
+
+// applyUserFiles updates the ops files, vars files and vars applied to the
+// director with the ones in config. Files are identified by their name, so
+// passing a file again replaces it in place, and vars by their key.
+func applyUserFiles(config UpConfig, boshState storage.BOSH) (storage.BOSH, error) {
+	var err error
+
+	boshState.UserOpsFiles, err = mergeUserFiles(boshState.UserOpsFiles, config.OpsFiles, config.RemoveOpsFiles)
+	if err != nil {
+		return storage.BOSH{}, fmt.Errorf("--remove-ops-file: %s", err)
+	}
+
+	boshState.UserVarsFiles, err = mergeUserFiles(boshState.UserVarsFiles, config.VarsFiles, config.RemoveVarsFiles)
+	if err != nil {
+		return storage.BOSH{}, fmt.Errorf("--remove-vars-file: %s", err)
+	}
+
+	boshState.UserVars, err = removeVars(boshState.UserVars, config.RemoveVars)
+	if err != nil {
+		return storage.BOSH{}, fmt.Errorf("--remove-var: %s", err)
+	}
+
+	for _, v := range config.Vars {
+		boshState.UserVars = mergeVar(boshState.UserVars, v)
+	}
+
+	return boshState, nil
+}
+
+// applyJumpboxOpsFiles updates the ops files applied to the jumpbox the same
+// way applyUserFiles does for the director.
+func applyJumpboxOpsFiles(config UpConfig, jumpbox storage.Jumpbox) (storage.Jumpbox, error) {
+	var err error
+
+	jumpbox.UserOpsFiles, err = mergeUserFiles(jumpbox.UserOpsFiles, config.JumpboxOpsFiles, config.RemoveJumpboxOpsFiles)
+	if err != nil {
+		return storage.Jumpbox{}, fmt.Errorf("--remove-jumpbox-ops-file: %s", err)
+	}
+
+	return jumpbox, nil
+}
+
+// applyCloudConfigOpsFiles updates the ops files applied to the cloud config
+// the same way applyUserFiles does for the director.
+func applyCloudConfigOpsFiles(config UpConfig, opsFiles []storage.UserFile) ([]storage.UserFile, error) {
+	merged, err := mergeUserFiles(opsFiles, config.CloudConfigOpsFiles, config.RemoveCloudConfigOpsFiles)
+	if err != nil {
+		return nil, fmt.Errorf("--remove-cloud-config-ops-file: %s", err)
+	}
+
+	return merged, nil
+}
+
+// applyDirectorConfigs updates the runtime configs and CPI config uploaded to
+// the director after it is created.
+func applyDirectorConfigs(config UpConfig, state storage.State) (storage.State, error) {
+	if config.CPIConfig != "" && config.RemoveCPIConfig {
+		return storage.State{}, errors.New("--cpi-config and --remove-cpi-config cannot be used together")
+	}
+
+	var err error
+	state.RuntimeConfigs, err = mergeUserFiles(state.RuntimeConfigs, config.RuntimeConfigs, config.RemoveRuntimeConfigs)
+	if err != nil {
+		return storage.State{}, fmt.Errorf("--remove-runtime-config: %s", err)
+	}
+
+	if config.RemoveCPIConfig {
+		state.CPIConfig = ""
+	}
+
+	if config.CPIConfig != "" {
+		state.CPIConfig = config.CPIConfig
+	}
+
+	return state, nil
+}
+
+func mergeUserFiles(files, added []storage.UserFile, removed []string) ([]storage.UserFile, error) {
+	merged := append([]storage.UserFile{}, files...)
+
+	for _, name := range removed {
+		i := indexOfUserFile(merged, name)
+		if i == -1 {
+			return nil, fmt.Errorf("%s is not applied", name)
+		}
+		merged = append(merged[:i], merged[i+1:]...)
+	}
+
+	for _, file := range added {
+		i := indexOfUserFile(merged, file.Name)
+		if i == -1 {
+			merged = append(merged, file)
+		} else {
+			merged[i] = file
+		}
+	}
+
+	if len(merged) == 0 {
+		return nil, nil
+	}
+
+	return merged, nil
+}
+
+func indexOfUserFile(files []storage.UserFile, name string) int {
+	for i, file := range files {
+		if file.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func mergeVar(vars []string, v string) []string {
+	merged := append([]string{}, vars...)
+
+	i := indexOfVar(merged, strings.SplitN(v, "=", 2)[0])
+	if i == -1 {
+		return append(merged, v)
+	}
+
+	merged[i] = v
+	return merged
+}
+
+func removeVars(vars, removed []string) ([]string, error) {
+	remaining := append([]string{}, vars...)
+
+	for _, key := range removed {
+		i := indexOfVar(remaining, key)
+		if i == -1 {
+			return nil, fmt.Errorf("%s is not applied", key)
+		}
+		remaining = append(remaining[:i], remaining[i+1:]...)
+	}
+
+	if len(remaining) == 0 {
+		return nil, nil
+	}
+
+	return remaining, nil
+}
+
+func indexOfVar(vars []string, key string) int {
+	for i, v := range vars {
+		if strings.SplitN(v, "=", 2)[0] == key {
+			return i
+		}
+	}
+	return -1
+}
@@ -2,6 +2,7 @@ package commands_test
 
 import (
 	"errors"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -19,12 +20,14 @@ var _ = Describe("Up", func() {
 	var (
 		command commands.Up
 
-		iaasUp             *fakes.UpCmd
-		boshManager        *fakes.BOSHManager
-		terraformManager   *fakes.TerraformManager
-		cloudConfigManager *fakes.CloudConfigManager
-		stateStore         *fakes.StateStore
-		envIDManager       *fakes.EnvIDManager
+		iaasUp               *fakes.UpCmd
+		boshManager          *fakes.BOSHManager
+		terraformManager     *fakes.TerraformManager
+		cloudConfigManager   *fakes.CloudConfigManager
+		runtimeConfigManager *fakes.RuntimeConfigManager
+		stateStore           *fakes.StateStore
+		envIDManager         *fakes.EnvIDManager
+		planner              *fakes.Planner
 
 		tempDir string
 	)
@@ -36,8 +39,10 @@ var _ = Describe("Up", func() {
 
 		terraformManager = &fakes.TerraformManager{}
 		cloudConfigManager = &fakes.CloudConfigManager{}
+		runtimeConfigManager = &fakes.RuntimeConfigManager{}
 		stateStore = &fakes.StateStore{}
 		envIDManager = &fakes.EnvIDManager{}
+		planner = &fakes.Planner{}
 
 		var err error
 		tempDir, err = ioutil.TempDir("", "")
@@ -45,7 +50,7 @@ var _ = Describe("Up", func() {
 
 		stateStore.GetBblDirCall.Returns.Directory = tempDir
 
-		command = commands.NewUp(iaasUp, boshManager, cloudConfigManager, stateStore, envIDManager, terraformManager)
+		command = commands.NewUp(iaasUp, boshManager, cloudConfigManager, runtimeConfigManager, stateStore, envIDManager, terraformManager, planner)
 	})
 
 	Describe("CheckFastFails", func() {
@@ -121,6 +126,174 @@ var _ = Describe("Up", func() {
 				})
 			})
 		})
+
+		Context("when --existing-network is passed", func() {
+			It("returns an error if it differs from the network of an existing environment", func() {
+				err := command.CheckFastFails([]string{
+					"--existing-network", "some-other-vpc",
+				}, storage.State{TFState: "some-tf-state", ExistingNetwork: "some-vpc"})
+				Expect(err).To(MatchError("--existing-network cannot be changed for an existing environment."))
+			})
+
+			It("returns an error if an azure network is not given as <resource-group>/<virtual-network>", func() {
+				err := command.CheckFastFails([]string{
+					"--existing-network", "some-vnet",
+				}, storage.State{IAAS: "azure"})
+				Expect(err).To(MatchError("--existing-network must be given as <resource-group>/<virtual-network> on azure."))
+
+				err = command.CheckFastFails([]string{
+					"--existing-network", "some-rg/some-vnet",
+				}, storage.State{IAAS: "azure"})
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		Context("when --internal-cidr is passed", func() {
+			It("returns an error if the cidr cannot be used", func() {
+				err := command.CheckFastFails([]string{
+					"--internal-cidr", "10.0.1.0/16",
+				}, storage.State{})
+				Expect(err).To(MatchError("Internal CIDR 10.0.1.0/16 is not aligned to its prefix length, did you mean 10.0.0.0/16?"))
+			})
+
+			It("returns an error if it differs from the cidr of an existing environment", func() {
+				err := command.CheckFastFails([]string{
+					"--internal-cidr", "172.16.0.0/16",
+				}, storage.State{TFState: "some-tf-state"})
+				Expect(err).To(MatchError("--internal-cidr cannot be changed for an existing environment. Current internal CIDR is 10.0.0.0/16."))
+			})
+
+			It("allows the default cidr to be passed for an existing environment", func() {
+				err := command.CheckFastFails([]string{
+					"--internal-cidr", "10.0.0.0/16",
+				}, storage.State{TFState: "some-tf-state"})
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		Context("when files to remove are passed", func() {
+			It("returns an error if an ops file is not applied", func() {
+				err := command.CheckFastFails([]string{
+					"--remove-ops-file", "missing.yml",
+				}, storage.State{BOSH: storage.BOSH{UserOpsFiles: []storage.UserFile{{Name: "syslog.yml"}}}})
+				Expect(err).To(MatchError("--remove-ops-file: missing.yml is not applied"))
+			})
+
+			It("returns an error if a vars file is not applied", func() {
+				err := command.CheckFastFails([]string{
+					"--remove-vars-file", "missing.yml",
+				}, storage.State{})
+				Expect(err).To(MatchError("--remove-vars-file: missing.yml is not applied"))
+			})
+
+			It("returns an error if a var is not applied", func() {
+				err := command.CheckFastFails([]string{
+					"--remove-var", "missing_var",
+				}, storage.State{BOSH: storage.BOSH{UserVars: []string{"some_var=some-value"}}})
+				Expect(err).To(MatchError("--remove-var: missing_var is not applied"))
+			})
+
+			It("returns an error if a jumpbox ops file is not applied", func() {
+				err := command.CheckFastFails([]string{
+					"--remove-jumpbox-ops-file", "missing.yml",
+				}, storage.State{})
+				Expect(err).To(MatchError("--remove-jumpbox-ops-file: missing.yml is not applied"))
+			})
+
+			It("returns an error if a cloud config ops file is not applied", func() {
+				err := command.CheckFastFails([]string{
+					"--remove-cloud-config-ops-file", "missing.yml",
+				}, storage.State{})
+				Expect(err).To(MatchError("--remove-cloud-config-ops-file: missing.yml is not applied"))
+			})
+
+			It("returns an error if a runtime config is not uploaded", func() {
+				err := command.CheckFastFails([]string{
+					"--remove-runtime-config", "missing.yml",
+				}, storage.State{})
+				Expect(err).To(MatchError("--remove-runtime-config: missing.yml is not applied"))
+			})
+		})
+
+		Context("when --cpi-config and --remove-cpi-config are both passed", func() {
+			It("returns an error", func() {
+				err := ioutil.WriteFile(filepath.Join(tempDir, "cpi.yml"), []byte("some-cpi-config"), os.ModePerm)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = command.CheckFastFails([]string{
+					"--cpi-config", filepath.Join(tempDir, "cpi.yml"),
+					"--remove-cpi-config",
+				}, storage.State{})
+				Expect(err).To(MatchError("--cpi-config and --remove-cpi-config cannot be used together"))
+			})
+		})
+
+		Context("when deployment dirs are passed", func() {
+			BeforeEach(func() {
+				for _, file := range append(bosh.DirectorDeploymentFiles("gcp"), bosh.JumpboxDeploymentFiles("gcp")...) {
+					err := os.MkdirAll(filepath.Dir(filepath.Join(tempDir, file)), os.ModePerm)
+					Expect(err).NotTo(HaveOccurred())
+
+					err = ioutil.WriteFile(filepath.Join(tempDir, file), []byte{}, os.ModePerm)
+					Expect(err).NotTo(HaveOccurred())
+				}
+			})
+
+			It("does not fail when they have the files bbl needs", func() {
+				err := command.CheckFastFails([]string{
+					"--bosh-deployment-dir", tempDir,
+					"--jumpbox-deployment-dir", tempDir,
+				}, storage.State{IAAS: "gcp"})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns an error when the bosh-deployment dir is missing files", func() {
+				err := command.CheckFastFails([]string{
+					"--bosh-deployment-dir", tempDir,
+				}, storage.State{IAAS: "aws"})
+				Expect(err).To(MatchError(fmt.Sprintf("BOSH deployment dir: %s is missing aws/cpi.yml, aws/iam-instance-profile.yml", tempDir)))
+			})
+
+			It("returns an error when the jumpbox-deployment dir is missing files", func() {
+				err := command.CheckFastFails([]string{
+					"--jumpbox-deployment-dir", tempDir,
+				}, storage.State{IAAS: "azure"})
+				Expect(err).To(MatchError(fmt.Sprintf("Jumpbox deployment dir: %s is missing azure/cpi.yml", tempDir)))
+			})
+		})
+
+		Context("when VM types and a disk size are passed", func() {
+			It("does not fail when they suit the iaas", func() {
+				err := command.CheckFastFails([]string{
+					"--director-vm-type", "m4.2xlarge",
+					"--director-disk-size", "128",
+					"--jumpbox-vm-type", "t2.small",
+				}, storage.State{IAAS: "aws"})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns an error when the director VM type is not one of the iaas", func() {
+				err := command.CheckFastFails([]string{"--director-vm-type", "n1-standard-2"}, storage.State{IAAS: "aws"})
+				Expect(err).To(MatchError("--director-vm-type: n1-standard-2 is not a valid aws VM type"))
+			})
+
+			It("returns an error when the jumpbox VM type is not one of the iaas", func() {
+				err := command.CheckFastFails([]string{"--jumpbox-vm-type", "t2.small"}, storage.State{IAAS: "gcp"})
+				Expect(err).To(MatchError("--jumpbox-vm-type: t2.small is not a valid gcp VM type"))
+			})
+
+			It("returns an error when the disk size is negative", func() {
+				err := command.CheckFastFails([]string{"--director-disk-size", "-1"}, storage.State{IAAS: "gcp"})
+				Expect(err).To(MatchError("--director-disk-size must be a positive number of GB"))
+			})
+		})
+
+		Context("when --cloud-config-mode is passed", func() {
+			It("returns an error when the mode is not known", func() {
+				err := command.CheckFastFails([]string{"--cloud-config-mode", "preview"}, storage.State{})
+				Expect(err).To(MatchError("--cloud-config-mode must be apply, diff or skip"))
+			})
+		})
 	})
 
 	Describe("Execute", func() {
@@ -183,27 +356,193 @@ var _ = Describe("Up", func() {
 			Expect(cloudConfigManager.UpdateCall.CallCount).To(Equal(1))
 			Expect(cloudConfigManager.UpdateCall.Receives.State).To(Equal(createDirectorState))
 
+			Expect(runtimeConfigManager.UpdateCall.CallCount).To(Equal(1))
+			Expect(runtimeConfigManager.UpdateCall.Receives.State).To(Equal(createDirectorState))
+
 			Expect(stateStore.SetCall.CallCount).To(Equal(5))
 		})
 
-		Context("when the config has ops files", func() {
-			var opsFilePath string
+		Context("when the config has ops files, vars files and vars", func() {
+			BeforeEach(func() {
+				for name, contents := range map[string]string{
+					"syslog.yml":      "some-syslog-ops",
+					"bbr.yml":         "some-bbr-ops",
+					"syslog-vars.yml": "some-syslog-vars",
+				} {
+					err := ioutil.WriteFile(filepath.Join(tempDir, name), []byte(contents), os.ModePerm)
+					Expect(err).NotTo(HaveOccurred())
+				}
+
+				createJumpboxState.BOSH = storage.BOSH{
+					UserOpsFiles: []storage.UserFile{
+						{Name: "credhub.yml", Contents: "some-credhub-ops"},
+						{Name: "syslog.yml", Contents: "some-old-syslog-ops"},
+					},
+					UserVars: []string{"syslog_address=old.example.com", "some_var=some-value"},
+				}
+				boshManager.CreateJumpboxCall.Returns.State = createJumpboxState
+			})
+
+			It("passes them to the bosh manager in order, replacing ones with the same name or key", func() {
+				err := command.Execute([]string{
+					"--ops-file", filepath.Join(tempDir, "syslog.yml"),
+					"--ops-file", filepath.Join(tempDir, "bbr.yml"),
+					"--vars-file", filepath.Join(tempDir, "syslog-vars.yml"),
+					"--var", "syslog_address=logs.example.com",
+				}, incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				boshState := boshManager.CreateDirectorCall.Receives.State.BOSH
+				Expect(boshState.UserOpsFiles).To(Equal([]storage.UserFile{
+					{Name: "credhub.yml", Contents: "some-credhub-ops"},
+					{Name: "syslog.yml", Contents: "some-syslog-ops"},
+					{Name: "bbr.yml", Contents: "some-bbr-ops"},
+				}))
+				Expect(boshState.UserVarsFiles).To(Equal([]storage.UserFile{
+					{Name: "syslog-vars.yml", Contents: "some-syslog-vars"},
+				}))
+				Expect(boshState.UserVars).To(Equal([]string{"syslog_address=logs.example.com", "some_var=some-value"}))
+			})
 
+			It("removes ops files passed to --remove-ops-file", func() {
+				err := command.Execute([]string{"--remove-ops-file", "credhub.yml"}, incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(boshManager.CreateDirectorCall.Receives.State.BOSH.UserOpsFiles).To(Equal([]storage.UserFile{
+					{Name: "syslog.yml", Contents: "some-old-syslog-ops"},
+				}))
+			})
+
+			It("removes vars passed to --remove-var by their key", func() {
+				err := command.Execute([]string{"--remove-var", "syslog_address"}, incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(boshManager.CreateDirectorCall.Receives.State.BOSH.UserVars).To(Equal([]string{"some_var=some-value"}))
+			})
+
+			It("keeps the applied files when no flags are passed", func() {
+				err := command.Execute([]string{}, incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(boshManager.CreateDirectorCall.Receives.State.BOSH).To(Equal(createJumpboxState.BOSH))
+			})
+		})
+
+		Context("when jumpbox ops files are passed", func() {
+			BeforeEach(func() {
+				err := ioutil.WriteFile(filepath.Join(tempDir, "jumpbox-users.yml"), []byte("some-users-ops"), os.ModePerm)
+				Expect(err).NotTo(HaveOccurred())
+
+				terraformApplyState.Jumpbox = storage.Jumpbox{
+					UserOpsFiles: []storage.UserFile{
+						{Name: "jumpbox-users.yml", Contents: "some-old-users-ops"},
+						{Name: "jumpbox-disk.yml", Contents: "some-disk-ops"},
+					},
+				}
+				terraformManager.ApplyCall.Returns.BBLState = terraformApplyState
+			})
+
+			It("passes them to the bosh manager, replacing ones with the same name", func() {
+				err := command.Execute([]string{
+					"--jumpbox-ops-file", filepath.Join(tempDir, "jumpbox-users.yml"),
+				}, incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(boshManager.CreateJumpboxCall.Receives.State.Jumpbox.UserOpsFiles).To(Equal([]storage.UserFile{
+					{Name: "jumpbox-users.yml", Contents: "some-users-ops"},
+					{Name: "jumpbox-disk.yml", Contents: "some-disk-ops"},
+				}))
+			})
+
+			It("removes ops files passed to --remove-jumpbox-ops-file", func() {
+				err := command.Execute([]string{"--remove-jumpbox-ops-file", "jumpbox-disk.yml"}, incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(boshManager.CreateJumpboxCall.Receives.State.Jumpbox.UserOpsFiles).To(Equal([]storage.UserFile{
+					{Name: "jumpbox-users.yml", Contents: "some-old-users-ops"},
+				}))
+			})
+		})
+
+		Context("when cloud config ops files are passed", func() {
 			BeforeEach(func() {
-				opsFile, err := ioutil.TempFile("", "ops-file")
+				err := ioutil.WriteFile(filepath.Join(tempDir, "vm-types.yml"), []byte("some-vm-types-ops"), os.ModePerm)
 				Expect(err).NotTo(HaveOccurred())
 
-				opsFilePath = opsFile.Name()
-				opsFileContents := "some-ops-file-contents"
-				err = ioutil.WriteFile(opsFilePath, []byte(opsFileContents), os.ModePerm)
+				iaasState.CloudConfigOpsFiles = []storage.UserFile{
+					{Name: "vm-types.yml", Contents: "some-old-vm-types-ops"},
+					{Name: "vm-extensions.yml", Contents: "some-vm-extensions-ops"},
+				}
+				iaasUp.ExecuteCall.Returns.State = iaasState
+			})
+
+			It("saves them in the state, replacing ones with the same name", func() {
+				err := command.Execute([]string{
+					"--cloud-config-ops-file", filepath.Join(tempDir, "vm-types.yml"),
+				}, incomingState)
 				Expect(err).NotTo(HaveOccurred())
+
+				Expect(envIDManager.SyncCall.Receives.State.CloudConfigOpsFiles).To(Equal([]storage.UserFile{
+					{Name: "vm-types.yml", Contents: "some-vm-types-ops"},
+					{Name: "vm-extensions.yml", Contents: "some-vm-extensions-ops"},
+				}))
 			})
 
-			It("passes the ops file contents to the bosh manager", func() {
-				err := command.Execute([]string{"--ops-file", opsFilePath}, incomingState)
+			It("removes ops files passed to --remove-cloud-config-ops-file", func() {
+				err := command.Execute([]string{"--remove-cloud-config-ops-file", "vm-extensions.yml"}, incomingState)
 				Expect(err).NotTo(HaveOccurred())
 
-				Expect(boshManager.CreateDirectorCall.Receives.State.BOSH.UserOpsFile).To(Equal("some-ops-file-contents"))
+				Expect(envIDManager.SyncCall.Receives.State.CloudConfigOpsFiles).To(Equal([]storage.UserFile{
+					{Name: "vm-types.yml", Contents: "some-old-vm-types-ops"},
+				}))
+			})
+		})
+
+		Context("when runtime configs and a cpi config are passed", func() {
+			BeforeEach(func() {
+				err := ioutil.WriteFile(filepath.Join(tempDir, "dns.yml"), []byte("some-dns-config"), os.ModePerm)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = ioutil.WriteFile(filepath.Join(tempDir, "cpi.yml"), []byte("some-cpi-config"), os.ModePerm)
+				Expect(err).NotTo(HaveOccurred())
+
+				iaasState.RuntimeConfigs = []storage.UserFile{
+					{Name: "dns.yml", Contents: "some-old-dns-config"},
+					{Name: "os-conf.yml", Contents: "some-os-conf-config"},
+				}
+				iaasState.CPIConfig = "some-old-cpi-config"
+				iaasUp.ExecuteCall.Returns.State = iaasState
+			})
+
+			It("saves them in the state, replacing ones with the same name", func() {
+				err := command.Execute([]string{
+					"--runtime-config", filepath.Join(tempDir, "dns.yml"),
+					"--cpi-config", filepath.Join(tempDir, "cpi.yml"),
+				}, incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(envIDManager.SyncCall.Receives.State.RuntimeConfigs).To(Equal([]storage.UserFile{
+					{Name: "dns.yml", Contents: "some-dns-config"},
+					{Name: "os-conf.yml", Contents: "some-os-conf-config"},
+				}))
+				Expect(envIDManager.SyncCall.Receives.State.CPIConfig).To(Equal("some-cpi-config"))
+			})
+
+			It("removes the ones passed to --remove-runtime-config and --remove-cpi-config and deletes them from the director", func() {
+				err := command.Execute([]string{
+					"--remove-runtime-config", "os-conf.yml",
+					"--remove-cpi-config",
+				}, incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(envIDManager.SyncCall.Receives.State.RuntimeConfigs).To(Equal([]storage.UserFile{
+					{Name: "dns.yml", Contents: "some-old-dns-config"},
+				}))
+				Expect(envIDManager.SyncCall.Receives.State.CPIConfig).To(Equal(""))
+
+				Expect(runtimeConfigManager.DeleteCall.CallCount).To(Equal(1))
+				Expect(runtimeConfigManager.DeleteCall.Receives.RuntimeConfigs).To(Equal([]string{"os-conf.yml"}))
+				Expect(runtimeConfigManager.DeleteCall.Receives.CPIConfig).To(BeTrue())
 			})
 		})
 
@@ -231,6 +570,148 @@ var _ = Describe("Up", func() {
 				Expect(stateStore.SetCall.Receives[2].State.NoDirector).To(BeTrue())
 				Expect(stateStore.SetCall.CallCount).To(Equal(3))
 				Expect(cloudConfigManager.UpdateCall.CallCount).To(Equal(0))
+				Expect(runtimeConfigManager.UpdateCall.CallCount).To(Equal(0))
+			})
+		})
+
+		Context("when --dry-run is passed", func() {
+			It("plans the desired state without applying or saving it", func() {
+				err := command.Execute([]string{"--dry-run", "--name", "some-name", "--json-report", "/some/report.json"}, incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(terraformManager.ValidateVersionCall.CallCount).To(Equal(1))
+				Expect(iaasUp.ExecuteCall.Receives.State).To(Equal(incomingState))
+				Expect(envIDManager.SyncCall.Receives.State).To(Equal(iaasState))
+				Expect(envIDManager.SyncCall.Receives.Name).To(Equal("some-name"))
+
+				Expect(planner.PlanCall.CallCount).To(Equal(1))
+				Expect(planner.PlanCall.Receives.State).To(Equal(envIDManagerState))
+				Expect(planner.PlanCall.Receives.JSONReportPath).To(Equal("/some/report.json"))
+
+				Expect(stateStore.SetCall.CallCount).To(Equal(0))
+				Expect(terraformManager.ApplyCall.CallCount).To(Equal(0))
+				Expect(boshManager.CreateJumpboxCall.CallCount).To(Equal(0))
+				Expect(boshManager.CreateDirectorCall.CallCount).To(Equal(0))
+				Expect(cloudConfigManager.UpdateCall.CallCount).To(Equal(0))
+			})
+
+			It("plans with the ops file contents and no-director flag", func() {
+				opsFilePath := filepath.Join(tempDir, "some-ops-file")
+				err := ioutil.WriteFile(opsFilePath, []byte("some-ops-file-contents"), os.ModePerm)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = command.Execute([]string{"--dry-run", "--no-director", "--ops-file", opsFilePath}, incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(envIDManager.SyncCall.Receives.State.NoDirector).To(BeTrue())
+				Expect(envIDManager.SyncCall.Receives.State.BOSH.UserOpsFiles).To(Equal([]storage.UserFile{
+					{Name: "some-ops-file", Contents: "some-ops-file-contents"},
+				}))
+			})
+
+			It("returns an error when the planner fails", func() {
+				planner.PlanCall.Returns.Error = errors.New("fig")
+
+				err := command.Execute([]string{"--dry-run"}, incomingState)
+				Expect(err).To(MatchError("fig"))
+			})
+		})
+
+		Context("when --terraform-overrides is passed", func() {
+			It("saves the overrides dir in the state before applying terraform", func() {
+				err := command.Execute([]string{"--terraform-overrides", tempDir}, incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(envIDManager.SyncCall.Receives.State.TerraformOverrides).To(Equal(tempDir))
+			})
+		})
+
+		Context("when --existing-network is passed", func() {
+			It("saves the network in the state before applying terraform", func() {
+				err := command.Execute([]string{"--existing-network", "some-vpc"}, incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(envIDManager.SyncCall.Receives.State.ExistingNetwork).To(Equal("some-vpc"))
+			})
+		})
+
+		Context("when --internal-cidr is passed", func() {
+			It("saves the cidr in the state before applying terraform", func() {
+				err := command.Execute([]string{"--internal-cidr", "172.16.0.0/16"}, incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(envIDManager.SyncCall.Receives.State.InternalCIDR).To(Equal("172.16.0.0/16"))
+			})
+		})
+
+		Context("when deployment dirs are passed", func() {
+			It("saves them in the state before applying terraform", func() {
+				err := command.Execute([]string{
+					"--bosh-deployment-dir", "/some/bosh-deployment",
+					"--jumpbox-deployment-dir", "/some/jumpbox-deployment",
+				}, incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(envIDManager.SyncCall.Receives.State.BOSHDeploymentDir).To(Equal("/some/bosh-deployment"))
+				Expect(envIDManager.SyncCall.Receives.State.JumpboxDeploymentDir).To(Equal("/some/jumpbox-deployment"))
+			})
+		})
+
+		Context("when VM types and a disk size are passed", func() {
+			It("saves them in the state before applying terraform", func() {
+				err := command.Execute([]string{
+					"--director-vm-type", "n1-standard-4",
+					"--director-disk-size", "128",
+					"--jumpbox-vm-type", "n1-standard-1",
+				}, incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(envIDManager.SyncCall.Receives.State.DirectorVMType).To(Equal("n1-standard-4"))
+				Expect(envIDManager.SyncCall.Receives.State.DirectorDiskSize).To(Equal(128))
+				Expect(envIDManager.SyncCall.Receives.State.JumpboxVMType).To(Equal("n1-standard-1"))
+			})
+		})
+
+		Context("when --cloud-config-mode is passed", func() {
+			It("only prints the cloud config changes when it is diff", func() {
+				err := command.Execute([]string{"--cloud-config-mode", "diff"}, incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(cloudConfigManager.DiffCall.CallCount).To(Equal(1))
+				Expect(cloudConfigManager.DiffCall.Receives.State).To(Equal(createDirectorState))
+				Expect(cloudConfigManager.UpdateCall.CallCount).To(Equal(0))
+			})
+
+			It("leaves the cloud config alone when it is skip", func() {
+				err := command.Execute([]string{"--cloud-config-mode", "skip"}, incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(cloudConfigManager.DiffCall.CallCount).To(Equal(0))
+				Expect(cloudConfigManager.UpdateCall.CallCount).To(Equal(0))
+			})
+		})
+
+		Context("when --recreate is passed", func() {
+			It("clears the jumpbox and director fingerprints so that create-env runs", func() {
+				iaasState.Jumpbox.Fingerprint = "some-jumpbox-fingerprint"
+				iaasState.BOSH.Fingerprint = "some-director-fingerprint"
+				iaasUp.ExecuteCall.Returns.State = iaasState
+
+				err := command.Execute([]string{"--recreate"}, incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(envIDManager.SyncCall.Receives.State.Jumpbox.Fingerprint).To(BeEmpty())
+				Expect(envIDManager.SyncCall.Receives.State.BOSH.Fingerprint).To(BeEmpty())
+			})
+
+			It("keeps the fingerprints otherwise", func() {
+				iaasState.BOSH.Fingerprint = "some-director-fingerprint"
+				iaasUp.ExecuteCall.Returns.State = iaasState
+
+				err := command.Execute([]string{}, incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(envIDManager.SyncCall.Receives.State.BOSH.Fingerprint).To(Equal("some-director-fingerprint"))
 			})
 		})
 
@@ -289,6 +770,90 @@ var _ = Describe("Up", func() {
 				})
 			})
 
+			Context("when two ops files have the same name", func() {
+				var somePath, otherPath string
+
+				BeforeEach(func() {
+					somePath = filepath.Join(tempDir, "some", "syslog.yml")
+					otherPath = filepath.Join(tempDir, "other", "syslog.yml")
+					for _, path := range []string{somePath, otherPath} {
+						err := os.MkdirAll(filepath.Dir(path), os.ModePerm)
+						Expect(err).NotTo(HaveOccurred())
+
+						err = ioutil.WriteFile(path, []byte{}, os.ModePerm)
+						Expect(err).NotTo(HaveOccurred())
+					}
+				})
+
+				It("returns an error", func() {
+					err := command.Execute([]string{"--ops-file", somePath, "--ops-file", otherPath}, storage.State{})
+					Expect(err).To(MatchError(fmt.Sprintf("Reading ops-file contents: %s and %s are both named syslog.yml, rename one of them", somePath, otherPath)))
+				})
+			})
+
+			Context("when two runtime configs have the same name", func() {
+				var somePath, otherPath string
+
+				BeforeEach(func() {
+					somePath = filepath.Join(tempDir, "some", "dns.yml")
+					otherPath = filepath.Join(tempDir, "other", "dns.yml")
+					for _, path := range []string{somePath, otherPath} {
+						err := os.MkdirAll(filepath.Dir(path), os.ModePerm)
+						Expect(err).NotTo(HaveOccurred())
+
+						err = ioutil.WriteFile(path, []byte{}, os.ModePerm)
+						Expect(err).NotTo(HaveOccurred())
+					}
+				})
+
+				It("returns an error", func() {
+					err := command.Execute([]string{"--runtime-config", somePath, "--runtime-config", otherPath}, storage.State{})
+					Expect(err).To(MatchError(fmt.Sprintf("Reading runtime-config contents: %s and %s are both named dns.yml, rename one of them", somePath, otherPath)))
+				})
+			})
+
+			Context("when the jumpbox ops file cannot be read", func() {
+				It("returns an error", func() {
+					err := command.Execute([]string{"--jumpbox-ops-file", "some/fake/path"}, storage.State{})
+					Expect(err).To(MatchError("Reading jumpbox-ops-file contents: open some/fake/path: no such file or directory"))
+				})
+			})
+
+			Context("when the cloud config ops file cannot be read", func() {
+				It("returns an error", func() {
+					err := command.Execute([]string{"--cloud-config-ops-file", "some/fake/path"}, storage.State{})
+					Expect(err).To(MatchError("Reading cloud-config-ops-file contents: open some/fake/path: no such file or directory"))
+				})
+			})
+
+			Context("when the runtime config cannot be read", func() {
+				It("returns an error", func() {
+					err := command.Execute([]string{"--runtime-config", "some/fake/path"}, storage.State{})
+					Expect(err).To(MatchError("Reading runtime-config contents: open some/fake/path: no such file or directory"))
+				})
+			})
+
+			Context("when the cpi config cannot be read", func() {
+				It("returns an error", func() {
+					err := command.Execute([]string{"--cpi-config", "some/fake/path"}, storage.State{})
+					Expect(err).To(MatchError("Reading cpi-config contents: open some/fake/path: no such file or directory"))
+				})
+			})
+
+			Context("when the vars file cannot be read", func() {
+				It("returns an error", func() {
+					err := command.Execute([]string{"--vars-file", "some/fake/path"}, storage.State{})
+					Expect(err).To(MatchError("Reading vars-file contents: open some/fake/path: no such file or directory"))
+				})
+			})
+
+			Context("when a var is not a key=value pair", func() {
+				It("returns an error", func() {
+					err := command.Execute([]string{"--var", "some-var"}, storage.State{})
+					Expect(err).To(MatchError("--var must be given as key=value: some-var"))
+				})
+			})
+
 			Context("when the env id manager fails", func() {
 				BeforeEach(func() {
 					envIDManager.SyncCall.Returns.Error = errors.New("apple")
@@ -399,6 +964,28 @@ var _ = Describe("Up", func() {
 				})
 			})
 
+			Context("when the runtime configs cannot be uploaded", func() {
+				BeforeEach(func() {
+					runtimeConfigManager.UpdateCall.Returns.Error = errors.New("guava")
+				})
+
+				It("returns an error", func() {
+					err := command.Execute([]string{}, storage.State{})
+					Expect(err).To(MatchError("Update runtime configs: guava"))
+				})
+			})
+
+			Context("when the removed runtime configs cannot be deleted", func() {
+				BeforeEach(func() {
+					runtimeConfigManager.DeleteCall.Returns.Error = errors.New("lychee")
+				})
+
+				It("returns an error", func() {
+					err := command.Execute([]string{}, storage.State{})
+					Expect(err).To(MatchError("Delete runtime configs: lychee"))
+				})
+			})
+
 			Context("when the terraform manager fails with terraformManagerError", func() {
 				var (
 					managerError *fakes.TerraformManagerError
@@ -482,58 +1069,28 @@ var _ = Describe("Up", func() {
 	})
 
 	Describe("ParseArgs", func() {
-		Context("when the --ops-file flag is specified", func() {
-			var providedOpsFilePath string
-			BeforeEach(func() {
-				opsFileDir, err := ioutil.TempDir("", "")
+		Context("when the --ops-file and --vars-file flags are specified", func() {
+			It("returns a config with the contents of each file, named after the file", func() {
+				opsFilePath := filepath.Join(tempDir, "some-ops-file.yml")
+				err := ioutil.WriteFile(opsFilePath, []byte("some-ops-file-contents"), os.ModePerm)
 				Expect(err).NotTo(HaveOccurred())
 
-				providedOpsFilePath = filepath.Join(opsFileDir, "some-ops-file")
-
-				err = ioutil.WriteFile(providedOpsFilePath, []byte("some-ops-file-contents"), os.ModePerm)
+				varsFilePath := filepath.Join(tempDir, "some-vars-file.yml")
+				err = ioutil.WriteFile(varsFilePath, []byte("some-vars-file-contents"), os.ModePerm)
 				Expect(err).NotTo(HaveOccurred())
-			})
 
-			It("returns a config with the ops-file path", func() {
 				config, err := command.ParseArgs([]string{
-					"--ops-file", providedOpsFilePath,
+					"--ops-file", opsFilePath,
+					"--vars-file", varsFilePath,
+					"--var", "some_var=some-value",
+					"--remove-ops-file", "old-ops-file.yml",
 				}, storage.State{})
 				Expect(err).NotTo(HaveOccurred())
 
-				Expect(config.OpsFile).To(Equal(providedOpsFilePath))
-			})
-		})
-
-		Context("when the --ops-file flag is not specified", func() {
-			It("creates a default ops-file with the contents of state.BOSH.UserOpsFile", func() {
-				config, err := command.ParseArgs([]string{}, storage.State{
-					BOSH: storage.BOSH{
-						UserOpsFile: "some-ops-file-contents",
-					},
-				})
-				Expect(err).NotTo(HaveOccurred())
-
-				filePath := config.OpsFile
-				fileContents, err := ioutil.ReadFile(filePath)
-				Expect(err).NotTo(HaveOccurred())
-
-				Expect(string(fileContents)).To(Equal("some-ops-file-contents"))
-			})
-
-			It("writes the previous user ops file to the .bbl directory", func() {
-				config, err := command.ParseArgs([]string{}, storage.State{
-					BOSH: storage.BOSH{
-						UserOpsFile: "some-ops-file-contents",
-					},
-				})
-				Expect(err).NotTo(HaveOccurred())
-
-				filePath := config.OpsFile
-				fileContents, err := ioutil.ReadFile(filePath)
-				Expect(err).NotTo(HaveOccurred())
-
-				Expect(filePath).To(Equal(filepath.Join(tempDir, "previous-user-ops-file.yml")))
-				Expect(string(fileContents)).To(Equal("some-ops-file-contents"))
+				Expect(config.OpsFiles).To(Equal([]storage.UserFile{{Name: "some-ops-file.yml", Contents: "some-ops-file-contents"}}))
+				Expect(config.VarsFiles).To(Equal([]storage.UserFile{{Name: "some-vars-file.yml", Contents: "some-vars-file-contents"}}))
+				Expect(config.Vars).To(Equal([]string{"some_var=some-value"}))
+				Expect(config.RemoveOpsFiles).To(Equal([]string{"old-ops-file.yml"}))
 			})
 		})
 
@@ -569,6 +1126,97 @@ var _ = Describe("Up", func() {
 			})
 		})
 
+		Context("when the user provides the dry-run and json-report flags", func() {
+			It("passes them in the up config", func() {
+				config, err := command.ParseArgs([]string{
+					"--dry-run", "--json-report", "some-report.json",
+				}, storage.State{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(config.DryRun).To(BeTrue())
+				Expect(config.JSONReport).To(Equal("some-report.json"))
+			})
+		})
+
+		Context("when the user provides the terraform-overrides flag", func() {
+			It("passes the absolute path of the directory in the up config", func() {
+				workingDir, err := os.Getwd()
+				Expect(err).NotTo(HaveOccurred())
+				relativeDir, err := filepath.Rel(workingDir, tempDir)
+				Expect(err).NotTo(HaveOccurred())
+
+				config, err := command.ParseArgs([]string{
+					"--terraform-overrides", relativeDir,
+				}, storage.State{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(config.TerraformOverrides).To(Equal(tempDir))
+			})
+
+			It("defaults to the directory saved in the state", func() {
+				config, err := command.ParseArgs([]string{}, storage.State{TerraformOverrides: tempDir})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(config.TerraformOverrides).To(Equal(tempDir))
+			})
+
+			It("can be cleared with an empty value", func() {
+				config, err := command.ParseArgs([]string{"--terraform-overrides", ""}, storage.State{TerraformOverrides: tempDir})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(config.TerraformOverrides).To(BeEmpty())
+			})
+
+			It("returns an error when the directory does not exist", func() {
+				_, err := command.ParseArgs([]string{"--terraform-overrides", "/some/missing/dir"}, storage.State{})
+				Expect(err).To(MatchError("Terraform overrides: stat /some/missing/dir: no such file or directory"))
+			})
+
+			It("returns an error when the path is not a directory", func() {
+				filePath := filepath.Join(tempDir, "some-file.tf")
+				err := ioutil.WriteFile(filePath, []byte{}, os.ModePerm)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = command.ParseArgs([]string{"--terraform-overrides", filePath}, storage.State{})
+				Expect(err).To(MatchError(fmt.Sprintf("Terraform overrides: %s is not a directory", filePath)))
+			})
+		})
+
+		Context("when the user provides the deployment dir flags", func() {
+			It("passes their absolute paths in the up config", func() {
+				workingDir, err := os.Getwd()
+				Expect(err).NotTo(HaveOccurred())
+
+				config, err := command.ParseArgs([]string{
+					"--bosh-deployment-dir", "bosh-deployment",
+					"--jumpbox-deployment-dir", "jumpbox-deployment",
+				}, storage.State{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(config.BOSHDeploymentDir).To(Equal(filepath.Join(workingDir, "bosh-deployment")))
+				Expect(config.JumpboxDeploymentDir).To(Equal(filepath.Join(workingDir, "jumpbox-deployment")))
+			})
+
+			It("defaults to the dirs saved in the state", func() {
+				config, err := command.ParseArgs([]string{}, storage.State{
+					BOSHDeploymentDir:    "/some/bosh-deployment",
+					JumpboxDeploymentDir: "/some/jumpbox-deployment",
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(config.BOSHDeploymentDir).To(Equal("/some/bosh-deployment"))
+				Expect(config.JumpboxDeploymentDir).To(Equal("/some/jumpbox-deployment"))
+			})
+		})
+
+		Context("when the user provides the sizing flags", func() {
+			It("defaults to the sizing saved in the state", func() {
+				config, err := command.ParseArgs([]string{"--director-vm-type", "m4.xlarge"}, storage.State{
+					DirectorVMType:   "m4.large",
+					DirectorDiskSize: 64,
+					JumpboxVMType:    "t2.small",
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(config.DirectorVMType).To(Equal("m4.xlarge"))
+				Expect(config.DirectorDiskSize).To(Equal(64))
+				Expect(config.JumpboxVMType).To(Equal("t2.small"))
+			})
+		})
+
 		Context("failure cases", func() {
 			Context("when undefined flags are passed", func() {
 				It("returns an error", func() {
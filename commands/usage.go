@@ -15,6 +15,10 @@ Usage:
 Global Options:
   --help      [-h]       Prints usage
   --state-dir            Directory containing bbl-state.json
+  --config               Path to a bbl.yml config file (Defaults to bbl.yml in the state dir)
+  --state-backend        Where to store bbl-state.json: "local" (default), s3://bucket/path or an http(s) object store URL
+  --state-encryption-key Passphrase used to encrypt bbl-state.json at rest (Defaults to environment variable BBL_STATE_ENCRYPTION_KEY)
+  --state-history-size   Number of bbl-state.json snapshots to keep (Defaults to 10)
   --debug                Prints debugging output
   --version              Prints version
 %s
@@ -29,15 +33,19 @@ Commands:
   help                    Prints usage
   version                 Prints version
   up                      Deploys BOSH director on an IAAS
+  plan                    Prints the changes up would make without applying them
+  drift                   Reports changes made outside of bbl to the environment
   destroy                 Tears down BOSH director infrastructure
   lbs                     Prints attached load balancer(s)
   create-lbs              Attaches load balancer(s)
   update-lbs              Updates load balancer(s)
   delete-lbs              Deletes attached load balancer(s)
-  rotate                  Rotates SSH key for the jumpbox user
+  rotate                  Rotates the jumpbox SSH key, certificates or credentials
+  certs                   Prints certificates and their expiry
   bosh-deployment-vars    Prints required variables for BOSH deployment
   jumpbox-deployment-vars Prints required variables for jumpbox deployment
   cloud-config            Prints suggested cloud configuration for BOSH environment
+  runtime-config          Prints the runtime configs and CPI config uploaded by up
   jumpbox-address         Prints BOSH jumpbox address
   director-address        Prints BOSH director address
   director-username       Prints BOSH director username
@@ -47,6 +55,12 @@ Commands:
   latest-error            Prints the output from the latest call to terraform
   print-env               Prints BOSH friendly environment variables
   ssh-key                 Prints SSH private key
+  encrypt-state           Encrypts bbl-state.json
+  decrypt-state           Decrypts bbl-state.json
+  state-history           Lists snapshots of bbl-state.json
+  state-restore           Restores bbl-state.json from a snapshot
+  force-unlock            Removes a stale lock on the state
+  migrate-state           Migrates bbl-state.json to the current schema version
 
   Use "bbl [command] --help" for more information about a command.`
 
@@ -0,0 +1,161 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/cloudfoundry/bosh-bootloader/aws"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+)
+
+var assumeAWSRole = aws.AssumeRole
+
+// resolveCredentials fills in IAAS credentials that were not given as flags,
+// environment variables or in the config file from the usual provider chains:
+// AWS shared credentials files and assumed roles, GCP application default
+// credentials and Azure auth files.
+func resolveCredentials(globalFlags globalFlags, state storage.State) (storage.State, error) {
+	var err error
+
+	switch state.IAAS {
+	case "aws":
+		state.AWS, err = resolveAWSCredentials(globalFlags, state.AWS)
+	case "gcp":
+		state.GCP, err = resolveGCPCredentials(state.GCP)
+	case "azure":
+		state.Azure, err = resolveAzureCredentials(globalFlags, state.Azure)
+	}
+
+	return state, err
+}
+
+func resolveAWSCredentials(globalFlags globalFlags, state storage.AWS) (storage.AWS, error) {
+	if state.AccessKeyID == "" && state.SecretAccessKey == "" {
+		value, err := credentials.NewSharedCredentials(globalFlags.AWSSharedCredentialsFile, globalFlags.AWSProfile).Get()
+		switch {
+		case err == nil:
+			state.AccessKeyID = value.AccessKeyID
+			state.SecretAccessKey = value.SecretAccessKey
+			state.SessionToken = value.SessionToken
+		case globalFlags.AWSProfile != "" || globalFlags.AWSSharedCredentialsFile != "":
+			return storage.AWS{}, fmt.Errorf("Reading AWS shared credentials: %s", err)
+		}
+	}
+
+	if globalFlags.AWSAssumeRole == "" {
+		return state, nil
+	}
+
+	if state.AccessKeyID == "" || state.SecretAccessKey == "" {
+		return storage.AWS{}, fmt.Errorf("AWS credentials must be provided to assume role %s", globalFlags.AWSAssumeRole)
+	}
+
+	config, err := assumeAWSRole(aws.Config{
+		AccessKeyID:     state.AccessKeyID,
+		SecretAccessKey: state.SecretAccessKey,
+		SessionToken:    state.SessionToken,
+		Region:          state.Region,
+	}, globalFlags.AWSAssumeRole)
+	if err != nil {
+		return storage.AWS{}, fmt.Errorf("Assuming AWS role %s: %s", globalFlags.AWSAssumeRole, err)
+	}
+
+	state.AccessKeyID = config.AccessKeyID
+	state.SecretAccessKey = config.SecretAccessKey
+	state.SessionToken = config.SessionToken
+
+	return state, nil
+}
+
+func resolveGCPCredentials(state storage.GCP) (storage.GCP, error) {
+	if state.ServiceAccountKey != "" {
+		return state, nil
+	}
+
+	path := os.Getenv("GOOGLE_APPLICATION_CREDENTIALS")
+	if path == "" {
+		path = wellKnownGCPCredentialsFile()
+		if _, err := os.Stat(path); err != nil {
+			return state, nil
+		}
+	}
+
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return storage.GCP{}, fmt.Errorf("Reading GCP application default credentials: %s", err)
+	}
+
+	var credentialsFile struct {
+		Type string `json:"type"`
+	}
+	err = json.Unmarshal(contents, &credentialsFile)
+	if err != nil {
+		return storage.GCP{}, fmt.Errorf("Parsing GCP application default credentials %s: %s", path, err)
+	}
+	if credentialsFile.Type != "service_account" {
+		return storage.GCP{}, fmt.Errorf("GCP application default credentials %s must be a service account key, not %q credentials", path, credentialsFile.Type)
+	}
+
+	serviceAccountKey, projectID, err := parseServiceAccountKey(string(contents))
+	if err != nil {
+		return storage.GCP{}, err
+	}
+	state.ServiceAccountKey = serviceAccountKey
+	state.ProjectID = projectID
+
+	return state, nil
+}
+
+func wellKnownGCPCredentialsFile() string {
+	const file = "application_default_credentials.json"
+	if runtime.GOOS == "windows" {
+		return filepath.Join(os.Getenv("APPDATA"), "gcloud", file)
+	}
+	return filepath.Join(os.Getenv("HOME"), ".config", "gcloud", file)
+}
+
+// resolveAzureCredentials reads the Azure auth file. Its values replace any
+// left in the state, but not those given as flags, environment variables or in
+// the config file.
+func resolveAzureCredentials(globalFlags globalFlags, state storage.Azure) (storage.Azure, error) {
+	path := globalFlags.AzureAuthFile
+	if path == "" {
+		path = os.Getenv("AZURE_AUTH_LOCATION")
+	}
+	if path == "" {
+		return state, nil
+	}
+
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return storage.Azure{}, fmt.Errorf("Reading Azure auth file: %s", err)
+	}
+
+	var authFile struct {
+		ClientID       string `json:"clientId"`
+		ClientSecret   string `json:"clientSecret"`
+		SubscriptionID string `json:"subscriptionId"`
+		TenantID       string `json:"tenantId"`
+	}
+	err = json.Unmarshal(contents, &authFile)
+	if err != nil {
+		return storage.Azure{}, fmt.Errorf("Parsing Azure auth file %s: %s", path, err)
+	}
+
+	setFromFile := func(value *string, fromFlags, fromFile string) {
+		if fromFlags == "" && fromFile != "" {
+			*value = fromFile
+		}
+	}
+	setFromFile(&state.ClientID, globalFlags.AzureClientID, authFile.ClientID)
+	setFromFile(&state.ClientSecret, globalFlags.AzureClientSecret, authFile.ClientSecret)
+	setFromFile(&state.SubscriptionID, globalFlags.AzureSubscriptionID, authFile.SubscriptionID)
+	setFromFile(&state.TenantID, globalFlags.AzureTenantID, authFile.TenantID)
+
+	return state, nil
+}
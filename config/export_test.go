@@ -0,0 +1,11 @@
+package config
+
+import "github.com/cloudfoundry/bosh-bootloader/aws"
+
+func SetAssumeAWSRole(f func(aws.Config, string) (aws.Config, error)) {
+	assumeAWSRole = f
+}
+
+func ResetAssumeAWSRole() {
+	assumeAWSRole = aws.AssumeRole
+}
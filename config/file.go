@@ -0,0 +1,271 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+const ConfigFileName = "bbl.yml"
+
+type configFile struct {
+	IAAS string `yaml:"iaas"`
+
+	AWS struct {
+		AccessKeyID     string `yaml:"access-key-id"`
+		SecretAccessKey string `yaml:"secret-access-key"`
+		Region          string `yaml:"region"`
+
+		Profile               string `yaml:"profile"`
+		SharedCredentialsFile string `yaml:"shared-credentials-file"`
+		AssumeRole            string `yaml:"assume-role"`
+	} `yaml:"aws"`
+
+	Azure struct {
+		ClientID       string `yaml:"client-id"`
+		ClientSecret   string `yaml:"client-secret"`
+		Location       string `yaml:"location"`
+		SubscriptionID string `yaml:"subscription-id"`
+		TenantID       string `yaml:"tenant-id"`
+		AuthFile       string `yaml:"auth-file"`
+	} `yaml:"azure"`
+
+	GCP struct {
+		ServiceAccountKey string `yaml:"service-account-key"`
+		Zone              string `yaml:"zone"`
+		Region            string `yaml:"region"`
+	} `yaml:"gcp"`
+
+	Up struct {
+		Name       string `yaml:"name"`
+		OpsFile    string `yaml:"ops-file"`
+		NoDirector *bool  `yaml:"no-director"`
+
+		OpsFiles  []string `yaml:"ops-files"`
+		VarsFiles []string `yaml:"vars-files"`
+		Vars      []string `yaml:"vars"`
+
+		JumpboxOpsFiles     []string `yaml:"jumpbox-ops-files"`
+		CloudConfigOpsFiles []string `yaml:"cloud-config-ops-files"`
+		RuntimeConfigs      []string `yaml:"runtime-configs"`
+		CPIConfig           string   `yaml:"cpi-config"`
+
+		TerraformOverrides string `yaml:"terraform-overrides"`
+		ExistingNetwork    string `yaml:"existing-network"`
+		InternalCIDR       string `yaml:"internal-cidr"`
+
+		BOSHDeploymentDir    string `yaml:"bosh-deployment-dir"`
+		JumpboxDeploymentDir string `yaml:"jumpbox-deployment-dir"`
+
+		DirectorVMType   string `yaml:"director-vm-type"`
+		DirectorDiskSize int    `yaml:"director-disk-size"`
+		JumpboxVMType    string `yaml:"jumpbox-vm-type"`
+
+		CloudConfigMode string `yaml:"cloud-config-mode"`
+	} `yaml:"up"`
+
+	CreateLBs struct {
+		Type   string `yaml:"type"`
+		Cert   string `yaml:"cert"`
+		Key    string `yaml:"key"`
+		Chain  string `yaml:"chain"`
+		Domain string `yaml:"domain"`
+
+		CloudConfigMode string `yaml:"cloud-config-mode"`
+	} `yaml:"create-lbs"`
+
+	DeleteLBs struct {
+		SkipIfMissing   *bool  `yaml:"skip-if-missing"`
+		CloudConfigMode string `yaml:"cloud-config-mode"`
+	} `yaml:"delete-lbs"`
+
+	Destroy struct {
+		NoConfirm     *bool `yaml:"no-confirm"`
+		SkipIfMissing *bool `yaml:"skip-if-missing"`
+	} `yaml:"destroy"`
+}
+
+// loadConfigFile reads the config file given by --config, falling back to
+// bbl.yml in the state dir when it exists. Relative paths in the file are
+// resolved against the directory containing it.
+func loadConfigFile(path, stateDir string) (configFile, error) {
+	var file configFile
+
+	if path == "" {
+		path = filepath.Join(stateDir, ConfigFileName)
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			return file, nil
+		}
+	}
+
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return file, fmt.Errorf("Reading config file: %s", err)
+	}
+
+	err = yaml.UnmarshalStrict(contents, &file)
+	if err != nil {
+		return file, fmt.Errorf("Parsing config file %s: %s", path, err)
+	}
+
+	dir := filepath.Dir(path)
+	file.AWS.SharedCredentialsFile = resolvePath(dir, file.AWS.SharedCredentialsFile)
+	file.Azure.AuthFile = resolvePath(dir, file.Azure.AuthFile)
+	file.Up.OpsFile = resolvePath(dir, file.Up.OpsFile)
+	for i := range file.Up.OpsFiles {
+		file.Up.OpsFiles[i] = resolvePath(dir, file.Up.OpsFiles[i])
+	}
+	for i := range file.Up.VarsFiles {
+		file.Up.VarsFiles[i] = resolvePath(dir, file.Up.VarsFiles[i])
+	}
+	for i := range file.Up.JumpboxOpsFiles {
+		file.Up.JumpboxOpsFiles[i] = resolvePath(dir, file.Up.JumpboxOpsFiles[i])
+	}
+	for i := range file.Up.CloudConfigOpsFiles {
+		file.Up.CloudConfigOpsFiles[i] = resolvePath(dir, file.Up.CloudConfigOpsFiles[i])
+	}
+	for i := range file.Up.RuntimeConfigs {
+		file.Up.RuntimeConfigs[i] = resolvePath(dir, file.Up.RuntimeConfigs[i])
+	}
+	file.Up.CPIConfig = resolvePath(dir, file.Up.CPIConfig)
+	file.Up.TerraformOverrides = resolvePath(dir, file.Up.TerraformOverrides)
+	file.Up.BOSHDeploymentDir = resolvePath(dir, file.Up.BOSHDeploymentDir)
+	file.Up.JumpboxDeploymentDir = resolvePath(dir, file.Up.JumpboxDeploymentDir)
+	file.CreateLBs.Cert = resolvePath(dir, file.CreateLBs.Cert)
+	file.CreateLBs.Key = resolvePath(dir, file.CreateLBs.Key)
+	file.CreateLBs.Chain = resolvePath(dir, file.CreateLBs.Chain)
+	if !strings.HasPrefix(strings.TrimSpace(file.GCP.ServiceAccountKey), "{") {
+		file.GCP.ServiceAccountKey = resolvePath(dir, file.GCP.ServiceAccountKey)
+	}
+
+	return file, nil
+}
+
+// applyGlobals fills in every global setting that was not given as a flag or
+// environment variable.
+func (f configFile) applyGlobals(globalFlags *globalFlags) {
+	setDefault := func(value *string, fromFile string) {
+		if *value == "" {
+			*value = fromFile
+		}
+	}
+
+	setDefault(&globalFlags.IAAS, f.IAAS)
+
+	setDefault(&globalFlags.AWSAccessKeyID, f.AWS.AccessKeyID)
+	setDefault(&globalFlags.AWSSecretAccessKey, f.AWS.SecretAccessKey)
+	setDefault(&globalFlags.AWSRegion, f.AWS.Region)
+	setDefault(&globalFlags.AWSProfile, f.AWS.Profile)
+	setDefault(&globalFlags.AWSSharedCredentialsFile, f.AWS.SharedCredentialsFile)
+	setDefault(&globalFlags.AWSAssumeRole, f.AWS.AssumeRole)
+
+	setDefault(&globalFlags.AzureClientID, f.Azure.ClientID)
+	setDefault(&globalFlags.AzureClientSecret, f.Azure.ClientSecret)
+	setDefault(&globalFlags.AzureLocation, f.Azure.Location)
+	setDefault(&globalFlags.AzureSubscriptionID, f.Azure.SubscriptionID)
+	setDefault(&globalFlags.AzureTenantID, f.Azure.TenantID)
+	setDefault(&globalFlags.AzureAuthFile, f.Azure.AuthFile)
+
+	setDefault(&globalFlags.GCPServiceAccountKey, f.GCP.ServiceAccountKey)
+	setDefault(&globalFlags.GCPZone, f.GCP.Zone)
+	setDefault(&globalFlags.GCPRegion, f.GCP.Region)
+}
+
+// commandArgs returns the settings for command as flags. They are placed
+// before the flags given on the command line, which therefore win. Flags that
+// can be repeated accumulate instead of replacing each other, so the file's
+// values for them are dropped when the command line sets them too.
+func (f configFile) commandArgs(command string, commandLine []string) []string {
+	given := flagNames(commandLine)
+
+	args := []string{}
+	addString := func(name, value string) {
+		if value != "" {
+			args = append(args, fmt.Sprintf("--%s=%s", name, value))
+		}
+	}
+	addStrings := func(name string, values ...string) {
+		if given[name] {
+			return
+		}
+		for _, value := range values {
+			addString(name, value)
+		}
+	}
+	addInt := func(name string, value int) {
+		if value != 0 {
+			args = append(args, fmt.Sprintf("--%s=%d", name, value))
+		}
+	}
+	addBool := func(name string, value *bool) {
+		if value != nil {
+			args = append(args, fmt.Sprintf("--%s=%s", name, strconv.FormatBool(*value)))
+		}
+	}
+
+	switch command {
+	case "up", "plan":
+		addString("name", f.Up.Name)
+		addStrings("ops-file", append([]string{f.Up.OpsFile}, f.Up.OpsFiles...)...)
+		addStrings("vars-file", f.Up.VarsFiles...)
+		addStrings("var", f.Up.Vars...)
+		addStrings("jumpbox-ops-file", f.Up.JumpboxOpsFiles...)
+		addStrings("cloud-config-ops-file", f.Up.CloudConfigOpsFiles...)
+		addStrings("runtime-config", f.Up.RuntimeConfigs...)
+		addString("cpi-config", f.Up.CPIConfig)
+		addBool("no-director", f.Up.NoDirector)
+		addString("terraform-overrides", f.Up.TerraformOverrides)
+		addString("existing-network", f.Up.ExistingNetwork)
+		addString("internal-cidr", f.Up.InternalCIDR)
+		addString("bosh-deployment-dir", f.Up.BOSHDeploymentDir)
+		addString("jumpbox-deployment-dir", f.Up.JumpboxDeploymentDir)
+		addString("director-vm-type", f.Up.DirectorVMType)
+		addInt("director-disk-size", f.Up.DirectorDiskSize)
+		addString("jumpbox-vm-type", f.Up.JumpboxVMType)
+		addString("cloud-config-mode", f.Up.CloudConfigMode)
+	case "create-lbs", "update-lbs":
+		addString("type", f.CreateLBs.Type)
+		addString("cert", f.CreateLBs.Cert)
+		addString("key", f.CreateLBs.Key)
+		addString("chain", f.CreateLBs.Chain)
+		addString("domain", f.CreateLBs.Domain)
+		addString("cloud-config-mode", f.CreateLBs.CloudConfigMode)
+	case "delete-lbs":
+		addBool("skip-if-missing", f.DeleteLBs.SkipIfMissing)
+		addString("cloud-config-mode", f.DeleteLBs.CloudConfigMode)
+	case "destroy", "down":
+		addBool("no-confirm", f.Destroy.NoConfirm)
+		addBool("skip-if-missing", f.Destroy.SkipIfMissing)
+	}
+
+	return args
+}
+
+// flagNames returns the names of the flags in args, whether they were given as
+// --name value, --name=value or -name.
+func flagNames(args []string) map[string]bool {
+	names := map[string]bool{}
+	for _, arg := range args {
+		if !strings.HasPrefix(arg, "-") {
+			continue
+		}
+		name := strings.TrimLeft(arg, "-")
+		if i := strings.Index(name, "="); i >= 0 {
+			name = name[:i]
+		}
+		names[name] = true
+	}
+	return names
+}
+
+func resolvePath(dir, path string) string {
+	if path == "" || filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(dir, path)
+}
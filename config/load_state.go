@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/cloudfoundry/bosh-bootloader/application"
 	"github.com/cloudfoundry/bosh-bootloader/storage"
@@ -18,16 +20,31 @@ type globalFlags struct {
 	Version  bool   `short:"v" long:"version"`
 	StateDir string `short:"s" long:"state-dir"`
 	IAAS     string `long:"iaas"                    env:"BBL_IAAS"`
+	Config   string `long:"config"                  env:"BBL_CONFIG"`
+
+	StateBackend                string `long:"state-backend"                   env:"BBL_STATE_BACKEND"`
+	StateBackendAccessKeyID     string `long:"state-backend-access-key-id"     env:"BBL_STATE_BACKEND_ACCESS_KEY_ID"`
+	StateBackendSecretAccessKey string `long:"state-backend-secret-access-key" env:"BBL_STATE_BACKEND_SECRET_ACCESS_KEY"`
+	StateBackendRegion          string `long:"state-backend-region"            env:"BBL_STATE_BACKEND_REGION"`
+
+	StateEncryptionKey     string `long:"state-encryption-key"      env:"BBL_STATE_ENCRYPTION_KEY"`
+	StateEncryptionKeyFile string `long:"state-encryption-key-file" env:"BBL_STATE_ENCRYPTION_KEY_FILE"`
+	StateHistorySize       int    `long:"state-history-size"        env:"BBL_STATE_HISTORY_SIZE" default:"10"`
 
 	AWSAccessKeyID     string `long:"aws-access-key-id"       env:"BBL_AWS_ACCESS_KEY_ID"`
 	AWSSecretAccessKey string `long:"aws-secret-access-key"   env:"BBL_AWS_SECRET_ACCESS_KEY"`
 	AWSRegion          string `long:"aws-region"              env:"BBL_AWS_REGION"`
 
+	AWSProfile               string `long:"aws-profile"                 env:"BBL_AWS_PROFILE"`
+	AWSSharedCredentialsFile string `long:"aws-shared-credentials-file" env:"BBL_AWS_SHARED_CREDENTIALS_FILE"`
+	AWSAssumeRole            string `long:"aws-assume-role"             env:"BBL_AWS_ASSUME_ROLE"`
+
 	AzureClientID       string `long:"azure-client-id"        env:"BBL_AZURE_CLIENT_ID"`
 	AzureClientSecret   string `long:"azure-client-secret"    env:"BBL_AZURE_CLIENT_SECRET"`
 	AzureLocation       string `long:"azure-location"         env:"BBL_AZURE_LOCATION"`
 	AzureSubscriptionID string `long:"azure-subscription-id"  env:"BBL_AZURE_SUBSCRIPTION_ID"`
 	AzureTenantID       string `long:"azure-tenant-id"        env:"BBL_AZURE_TENANT_ID"`
+	AzureAuthFile       string `long:"azure-auth-file"        env:"BBL_AZURE_AUTH_FILE"`
 
 	GCPServiceAccountKey string `long:"gcp-service-account-key" env:"BBL_GCP_SERVICE_ACCOUNT_KEY"`
 	GCPProjectID         string `long:"gcp-project-id"          env:"BBL_GCP_PROJECT_ID"`
@@ -39,7 +56,7 @@ type logger interface {
 	Println(string)
 }
 
-func NewConfig(getState func(string) (storage.State, error), logger logger) Config {
+func NewConfig(getState func(storage.Backend) (storage.State, error), logger logger) Config {
 	return Config{
 		getState: getState,
 		logger:   logger,
@@ -47,7 +64,7 @@ func NewConfig(getState func(string) (storage.State, error), logger logger) Conf
 }
 
 type Config struct {
-	getState func(string) (storage.State, error)
+	getState func(storage.Backend) (storage.State, error)
 	logger   logger
 }
 
@@ -93,11 +110,40 @@ func (c Config) Bootstrap(args []string) (application.Configuration, error) {
 		}
 	}
 
+	configFile, err := loadConfigFile(globalFlags.Config, globalFlags.StateDir)
+	if err != nil {
+		return application.Configuration{}, err
+	}
+	configFile.applyGlobals(&globalFlags)
+
 	if globalFlags.GCPProjectID != "" {
 		c.logger.Println("Deprecation warning: the --gcp-project-id (BBL_GCP_PROJECT_ID) flag is now ignored.")
 	}
 
-	state, err := c.getState(globalFlags.StateDir)
+	encryptionKey, err := stateEncryptionKey(globalFlags)
+	if err != nil {
+		return application.Configuration{}, err
+	}
+
+	baseBackend, err := storage.NewBackend(storage.BackendConfig{
+		StateDir:        globalFlags.StateDir,
+		Backend:         globalFlags.StateBackend,
+		AccessKeyID:     globalFlags.StateBackendAccessKeyID,
+		SecretAccessKey: globalFlags.StateBackendSecretAccessKey,
+		Region:          globalFlags.StateBackendRegion,
+	})
+	if err != nil {
+		return application.Configuration{}, err
+	}
+
+	stateHistory := storage.NewHistoryBackend(baseBackend, filepath.Join(globalFlags.StateDir, storage.HistoryDirName), globalFlags.StateHistorySize)
+
+	var stateBackend storage.Backend = stateHistory
+	if encryptionKey != "" {
+		stateBackend = storage.NewEncryptedBackend(stateHistory, encryptionKey)
+	}
+
+	state, err := c.getState(stateBackend)
 	if err != nil {
 		return application.Configuration{}, err
 	}
@@ -107,18 +153,49 @@ func (c Config) Bootstrap(args []string) (application.Configuration, error) {
 		return application.Configuration{}, err
 	}
 
+	if NeedsIAASCreds(remainingArgs[0]) {
+		state, err = resolveCredentials(globalFlags, state)
+		if err != nil {
+			return application.Configuration{}, err
+		}
+	}
+
 	return application.Configuration{
 		Global: application.GlobalConfiguration{
-			Debug:    globalFlags.Debug,
-			StateDir: globalFlags.StateDir,
+			Debug:        globalFlags.Debug,
+			StateDir:     globalFlags.StateDir,
+			StateBackend: stateBackend,
+			StateHistory: stateHistory,
 		},
 		State:           state,
 		Command:         remainingArgs[0],
-		SubcommandFlags: remainingArgs[1:],
+		SubcommandFlags: append(configFile.commandArgs(remainingArgs[0], remainingArgs[1:]), remainingArgs[1:]...),
 		ShowCommandHelp: globalFlags.Help,
 	}, nil
 }
 
+func stateEncryptionKey(globalFlags globalFlags) (string, error) {
+	if globalFlags.StateEncryptionKeyFile == "" {
+		return globalFlags.StateEncryptionKey, nil
+	}
+
+	if globalFlags.StateEncryptionKey != "" {
+		return "", errors.New("Only one of --state-encryption-key and --state-encryption-key-file may be provided.")
+	}
+
+	key, err := ioutil.ReadFile(globalFlags.StateEncryptionKeyFile)
+	if err != nil {
+		return "", fmt.Errorf("Reading state encryption key file: %s", err)
+	}
+
+	encryptionKey := strings.TrimSpace(string(key))
+	if encryptionKey == "" {
+		return "", fmt.Errorf("State encryption key file %s is empty.", globalFlags.StateEncryptionKeyFile)
+	}
+
+	return encryptionKey, nil
+}
+
 func updateIAASState(globalFlags globalFlags, state storage.State) (storage.State, error) {
 	if globalFlags.IAAS != "" {
 		if state.IAAS != "" && globalFlags.IAAS != state.IAAS {
@@ -240,6 +317,8 @@ func ValidateIAAS(state storage.State, command string) error {
 func NeedsIAASCreds(command string) bool {
 	_, ok := map[string]struct{}{
 		"up":         struct{}{},
+		"plan":       struct{}{},
+		"drift":      struct{}{},
 		"down":       struct{}{},
 		"destroy":    struct{}{},
 		"create-lbs": struct{}{},
@@ -250,6 +329,45 @@ func NeedsIAASCreds(command string) bool {
 	return ok
 }
 
+// ReloadState reads the state again once the state lock is held. The IAAS
+// settings and credentials Bootstrap resolved from flags, the environment and
+// credential files are kept rather than resolved a second time.
+func (c Config) ReloadState(appConfig application.Configuration) (application.Configuration, error) {
+	state, err := c.getState(appConfig.Global.StateBackend)
+	if err != nil {
+		return application.Configuration{}, err
+	}
+
+	state.IAAS = appConfig.State.IAAS
+	state.AWS = appConfig.State.AWS
+	state.GCP = appConfig.State.GCP
+	state.Azure = appConfig.State.Azure
+
+	appConfig.State = state
+	return appConfig, nil
+}
+
+// WritesState reports whether command can modify the state, and so must hold
+// the state lock while it runs. plan and drift need IAAS credentials but only
+// read the state.
+func WritesState(command string) bool {
+	if command == "plan" || command == "drift" {
+		return false
+	}
+
+	if NeedsIAASCreds(command) {
+		return true
+	}
+
+	_, ok := map[string]struct{}{
+		"encrypt-state": struct{}{},
+		"decrypt-state": struct{}{},
+		"state-restore": struct{}{},
+		"migrate-state": struct{}{},
+	}[command]
+	return ok
+}
+
 func validateAWS(aws storage.AWS) error {
 	if aws.AccessKeyID == "" {
 		return errors.New("AWS access key ID must be provided")
@@ -4,8 +4,10 @@ import (
 	"errors"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 
 	"github.com/cloudfoundry/bosh-bootloader/application"
+	"github.com/cloudfoundry/bosh-bootloader/aws"
 	"github.com/cloudfoundry/bosh-bootloader/config"
 	"github.com/cloudfoundry/bosh-bootloader/fakes"
 	"github.com/cloudfoundry/bosh-bootloader/storage"
@@ -22,7 +24,7 @@ var _ = Describe("LoadState", func() {
 
 	BeforeEach(func() {
 		fakeLogger = &fakes.Logger{}
-		getState := func(string) (storage.State, error) {
+		getState := func(storage.Backend) (storage.State, error) {
 			return storage.State{}, nil
 		}
 		c = config.NewConfig(getState, fakeLogger)
@@ -141,11 +143,11 @@ var _ = Describe("LoadState", func() {
 		})
 
 		Describe("reading a previous state file", func() {
-			var getStateArg string
+			var getStateArg storage.Backend
 
 			BeforeEach(func() {
-				getState := func(dir string) (storage.State, error) {
-					getStateArg = dir
+				getState := func(backend storage.Backend) (storage.State, error) {
+					getStateArg = backend
 
 					return storage.State{
 						IAAS:  "aws",
@@ -175,7 +177,7 @@ var _ = Describe("LoadState", func() {
 				workingDir, err := os.Getwd()
 				Expect(err).NotTo(HaveOccurred())
 
-				Expect(getStateArg).To(Equal(workingDir))
+				Expect(getStateArg).To(Equal(localBackend(workingDir, 10)))
 				Expect(appConfig.Global.StateDir).To(Equal(workingDir))
 			})
 
@@ -188,14 +190,130 @@ var _ = Describe("LoadState", func() {
 					})
 					Expect(err).NotTo(HaveOccurred())
 
-					Expect(getStateArg).To(Equal("some-state-dir"))
+					Expect(getStateArg).To(Equal(localBackend("some-state-dir", 10)))
 					Expect(appConfig.Global.StateDir).To(Equal("some-state-dir"))
+					Expect(appConfig.Global.StateBackend).To(Equal(localBackend("some-state-dir", 10)))
+				})
+			})
+
+			Context("when a state history size is specified", func() {
+				It("keeps that many snapshots of the state", func() {
+					appConfig, err := c.Bootstrap([]string{
+						"bbl",
+						"--state-dir", "some-state-dir",
+						"--state-history-size", "3",
+						"create-lbs",
+					})
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(getStateArg).To(Equal(localBackend("some-state-dir", 3)))
+					Expect(appConfig.Global.StateHistory).To(Equal(localBackend("some-state-dir", 3)))
+				})
+			})
+
+			Context("when a state backend is specified", func() {
+				It("returns state from the object store", func() {
+					appConfig, err := c.Bootstrap([]string{
+						"bbl",
+						"--state-backend", "https://some-object-store/some-bucket/some-env",
+						"--state-backend-access-key-id", "some-access-key-id",
+						"--state-backend-secret-access-key", "some-secret-access-key",
+						"create-lbs",
+					})
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(getStateArg).To(BeAssignableToTypeOf(storage.HistoryBackend{}))
+					Expect(getStateArg.Location()).To(Equal("https://some-object-store/some-bucket/some-env"))
+					Expect(appConfig.Global.StateBackend).To(Equal(getStateArg))
+				})
+
+				It("can be configured by environment variable", func() {
+					os.Setenv("BBL_STATE_BACKEND", "s3://some-bucket/some-env")
+					os.Setenv("BBL_STATE_BACKEND_REGION", "some-region")
+
+					_, err := c.Bootstrap([]string{
+						"bbl",
+						"create-lbs",
+					})
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(getStateArg.Location()).To(Equal("https://s3.some-region.amazonaws.com/some-bucket/some-env"))
+				})
+
+				Context("when the state backend is invalid", func() {
+					It("returns an error", func() {
+						_, err := c.Bootstrap([]string{
+							"bbl",
+							"--state-backend", "ftp://some-host",
+							"create-lbs",
+						})
+
+						Expect(err).To(MatchError(ContainSubstring(`Unknown state backend "ftp://some-host"`)))
+					})
+				})
+			})
+
+			Context("when a state encryption key is specified", func() {
+				It("encrypts the state with the key", func() {
+					appConfig, err := c.Bootstrap([]string{
+						"bbl",
+						"--state-dir", "some-state-dir",
+						"--state-encryption-key", "some-key",
+						"create-lbs",
+					})
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(getStateArg).To(Equal(storage.NewEncryptedBackend(localBackend("some-state-dir", 10), "some-key")))
+					Expect(appConfig.Global.StateBackend).To(Equal(getStateArg))
+				})
+
+				It("reads the key from a file", func() {
+					keyFile, err := ioutil.TempFile("", "")
+					Expect(err).NotTo(HaveOccurred())
+					_, err = keyFile.WriteString("some-key\n")
+					Expect(err).NotTo(HaveOccurred())
+
+					os.Setenv("BBL_STATE_ENCRYPTION_KEY_FILE", keyFile.Name())
+
+					_, err = c.Bootstrap([]string{
+						"bbl",
+						"--state-dir", "some-state-dir",
+						"create-lbs",
+					})
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(getStateArg).To(Equal(storage.NewEncryptedBackend(localBackend("some-state-dir", 10), "some-key")))
+				})
+
+				Context("when both a key and a key file are provided", func() {
+					It("returns an error", func() {
+						_, err := c.Bootstrap([]string{
+							"bbl",
+							"--state-encryption-key", "some-key",
+							"--state-encryption-key-file", "some-key-file",
+							"create-lbs",
+						})
+
+						Expect(err).To(MatchError("Only one of --state-encryption-key and --state-encryption-key-file may be provided."))
+					})
+				})
+
+				Context("when the key file cannot be read", func() {
+					It("returns an error", func() {
+						_, err := c.Bootstrap([]string{
+							"bbl",
+							"--state-encryption-key-file", "/some/missing/key-file",
+							"create-lbs",
+						})
+
+						Expect(err).To(MatchError(ContainSubstring("Reading state encryption key file:")))
+					})
 				})
 			})
 
 			Context("when invalid state dir is passed in", func() {
 				BeforeEach(func() {
-					getState := func(string) (storage.State, error) {
+					getState := func(storage.Backend) (storage.State, error) {
 						return storage.State{}, errors.New("some state dir error")
 					}
 					c = config.NewConfig(getState, fakeLogger)
@@ -227,6 +345,231 @@ var _ = Describe("LoadState", func() {
 			})
 		})
 
+		Context("when a config file is provided", func() {
+			var (
+				stateDir   string
+				configPath string
+			)
+
+			BeforeEach(func() {
+				var err error
+				stateDir, err = ioutil.TempDir("", "")
+				Expect(err).NotTo(HaveOccurred())
+
+				configPath = filepath.Join(stateDir, "bbl.yml")
+				err = ioutil.WriteFile(configPath, []byte(`---
+iaas: aws
+aws:
+  access-key-id: config-access-key-id
+  secret-access-key: config-secret-access-key
+  region: config-region
+up:
+  name: config-env-id
+  ops-file: ops/some-ops-file.yml
+  no-director: true
+  ops-files:
+  - ops/other-ops-file.yml
+  - /some/ops-file.yml
+  vars-files:
+  - vars.yml
+  vars:
+  - some_var=some-value
+  jumpbox-ops-files:
+  - jumpbox-users.yml
+  cloud-config-ops-files:
+  - vm-types.yml
+  runtime-configs:
+  - dns.yml
+  - /some/os-conf.yml
+  cpi-config: cpi.yml
+  terraform-overrides: terraform
+  existing-network: some-vpc
+  internal-cidr: 172.16.0.0/16
+  bosh-deployment-dir: bosh-deployment
+  jumpbox-deployment-dir: /some/jumpbox-deployment
+  director-vm-type: m4.2xlarge
+  director-disk-size: 128
+  jumpbox-vm-type: t2.small
+  cloud-config-mode: diff
+create-lbs:
+  type: cf
+  cert: /some/cert
+  key: some-key
+  cloud-config-mode: diff
+delete-lbs:
+  cloud-config-mode: skip
+destroy:
+  no-confirm: true
+`), os.ModePerm)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("reads bbl.yml from the state dir", func() {
+				appConfig, err := c.Bootstrap([]string{"bbl", "--state-dir", stateDir, "up"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(appConfig.State.IAAS).To(Equal("aws"))
+				Expect(appConfig.State.AWS).To(Equal(storage.AWS{
+					AccessKeyID:     "config-access-key-id",
+					SecretAccessKey: "config-secret-access-key",
+					Region:          "config-region",
+				}))
+			})
+
+			It("reads the file given by --config", func() {
+				otherDir, err := ioutil.TempDir("", "")
+				Expect(err).NotTo(HaveOccurred())
+
+				appConfig, err := c.Bootstrap([]string{"bbl", "--state-dir", otherDir, "--config", configPath, "up"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(appConfig.State.IAAS).To(Equal("aws"))
+			})
+
+			It("passes per-command settings before the command line flags, resolving relative paths", func() {
+				appConfig, err := c.Bootstrap([]string{"bbl", "--state-dir", stateDir, "up", "--name", "flag-env-id"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(appConfig.SubcommandFlags).To(Equal(application.StringSlice{
+					"--name=config-env-id",
+					"--ops-file=" + filepath.Join(stateDir, "ops", "some-ops-file.yml"),
+					"--ops-file=" + filepath.Join(stateDir, "ops", "other-ops-file.yml"),
+					"--ops-file=/some/ops-file.yml",
+					"--vars-file=" + filepath.Join(stateDir, "vars.yml"),
+					"--var=some_var=some-value",
+					"--jumpbox-ops-file=" + filepath.Join(stateDir, "jumpbox-users.yml"),
+					"--cloud-config-ops-file=" + filepath.Join(stateDir, "vm-types.yml"),
+					"--runtime-config=" + filepath.Join(stateDir, "dns.yml"),
+					"--runtime-config=/some/os-conf.yml",
+					"--cpi-config=" + filepath.Join(stateDir, "cpi.yml"),
+					"--no-director=true",
+					"--terraform-overrides=" + filepath.Join(stateDir, "terraform"),
+					"--existing-network=some-vpc",
+					"--internal-cidr=172.16.0.0/16",
+					"--bosh-deployment-dir=" + filepath.Join(stateDir, "bosh-deployment"),
+					"--jumpbox-deployment-dir=/some/jumpbox-deployment",
+					"--director-vm-type=m4.2xlarge",
+					"--director-disk-size=128",
+					"--jumpbox-vm-type=t2.small",
+					"--cloud-config-mode=diff",
+					"--name", "flag-env-id",
+				}))
+
+				appConfig, err = c.Bootstrap([]string{"bbl", "--state-dir", stateDir, "update-lbs"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(appConfig.SubcommandFlags).To(Equal(application.StringSlice{
+					"--type=cf",
+					"--cert=/some/cert",
+					"--key=" + filepath.Join(stateDir, "some-key"),
+					"--cloud-config-mode=diff",
+				}))
+
+				appConfig, err = c.Bootstrap([]string{"bbl", "--state-dir", stateDir, "delete-lbs"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(appConfig.SubcommandFlags).To(Equal(application.StringSlice{"--cloud-config-mode=skip"}))
+
+				appConfig, err = c.Bootstrap([]string{"bbl", "--state-dir", stateDir, "down"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(appConfig.SubcommandFlags).To(Equal(application.StringSlice{"--no-confirm=true"}))
+			})
+
+			It("drops the file's values for repeatable flags that are given on the command line", func() {
+				appConfig, err := c.Bootstrap([]string{"bbl", "--state-dir", stateDir, "up",
+					"--ops-file", "/flag/ops-file.yml",
+					"--var=flag_var=flag-value",
+					"--runtime-config", "/flag/runtime-config.yml",
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(appConfig.SubcommandFlags).To(Equal(application.StringSlice{
+					"--name=config-env-id",
+					"--vars-file=" + filepath.Join(stateDir, "vars.yml"),
+					"--jumpbox-ops-file=" + filepath.Join(stateDir, "jumpbox-users.yml"),
+					"--cloud-config-ops-file=" + filepath.Join(stateDir, "vm-types.yml"),
+					"--cpi-config=" + filepath.Join(stateDir, "cpi.yml"),
+					"--no-director=true",
+					"--terraform-overrides=" + filepath.Join(stateDir, "terraform"),
+					"--existing-network=some-vpc",
+					"--internal-cidr=172.16.0.0/16",
+					"--bosh-deployment-dir=" + filepath.Join(stateDir, "bosh-deployment"),
+					"--jumpbox-deployment-dir=/some/jumpbox-deployment",
+					"--director-vm-type=m4.2xlarge",
+					"--director-disk-size=128",
+					"--jumpbox-vm-type=t2.small",
+					"--cloud-config-mode=diff",
+					"--ops-file", "/flag/ops-file.yml",
+					"--var=flag_var=flag-value",
+					"--runtime-config", "/flag/runtime-config.yml",
+				}))
+			})
+
+			Describe("precedence", func() {
+				BeforeEach(func() {
+					getState := func(storage.Backend) (storage.State, error) {
+						return storage.State{
+							IAAS: "aws",
+							AWS: storage.AWS{
+								AccessKeyID:     "state-access-key-id",
+								SecretAccessKey: "state-secret-access-key",
+								Region:          "config-region",
+							},
+						}, nil
+					}
+					c = config.NewConfig(getState, fakeLogger)
+				})
+
+				AfterEach(func() {
+					os.Unsetenv("BBL_AWS_SECRET_ACCESS_KEY")
+				})
+
+				It("prefers flags over env vars over the config file over the state", func() {
+					os.Setenv("BBL_AWS_SECRET_ACCESS_KEY", "env-secret-access-key")
+
+					appConfig, err := c.Bootstrap([]string{
+						"bbl",
+						"--state-dir", stateDir,
+						"--aws-region", "config-region",
+						"up",
+					})
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(appConfig.State.AWS).To(Equal(storage.AWS{
+						AccessKeyID:     "config-access-key-id",
+						SecretAccessKey: "env-secret-access-key",
+						Region:          "config-region",
+					}))
+
+					appConfig, err = c.Bootstrap([]string{
+						"bbl",
+						"--state-dir", stateDir,
+						"--aws-secret-access-key", "flag-secret-access-key",
+						"up",
+					})
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(appConfig.State.AWS.SecretAccessKey).To(Equal("flag-secret-access-key"))
+				})
+			})
+
+			Context("failure cases", func() {
+				It("returns an error when --config does not exist", func() {
+					_, err := c.Bootstrap([]string{"bbl", "--config", "/some/missing/bbl.yml", "up"})
+					Expect(err).To(MatchError(ContainSubstring("Reading config file:")))
+				})
+
+				It("returns an error when the config file has unknown keys", func() {
+					err := ioutil.WriteFile(configPath, []byte("some-unknown-key: some-value\n"), os.ModePerm)
+					Expect(err).NotTo(HaveOccurred())
+
+					_, err = c.Bootstrap([]string{"bbl", "--state-dir", stateDir, "up"})
+					Expect(err).To(MatchError(ContainSubstring("Parsing config file " + configPath)))
+				})
+			})
+		})
+
 		Context("using AWS", func() {
 			Context("when a previous state does not exist", func() {
 				Context("when configuration is passed in by flag", func() {
@@ -308,11 +651,11 @@ var _ = Describe("LoadState", func() {
 			})
 
 			Context("when a previous state exists", func() {
-				var getStateArg string
+				var getStateArg storage.Backend
 
 				BeforeEach(func() {
-					getState := func(dir string) (storage.State, error) {
-						getStateArg = dir
+					getState := func(backend storage.Backend) (storage.State, error) {
+						getStateArg = backend
 
 						return storage.State{
 							IAAS: "aws",
@@ -528,11 +871,11 @@ var _ = Describe("LoadState", func() {
 			})
 
 			Context("when a previous state exists", func() {
-				var getStateArg string
+				var getStateArg storage.Backend
 
 				BeforeEach(func() {
-					getState := func(dir string) (storage.State, error) {
-						getStateArg = dir
+					getState := func(backend storage.Backend) (storage.State, error) {
+						getStateArg = backend
 
 						return storage.State{
 							IAAS: "gcp",
@@ -685,11 +1028,11 @@ var _ = Describe("LoadState", func() {
 			})
 
 			Context("when a previous state exists", func() {
-				var getStateArg string
+				var getStateArg storage.Backend
 
 				BeforeEach(func() {
-					getState := func(dir string) (storage.State, error) {
-						getStateArg = dir
+					getState := func(backend storage.Backend) (storage.State, error) {
+						getStateArg = backend
 
 						return storage.State{
 							IAAS: "azure",
@@ -719,7 +1062,7 @@ var _ = Describe("LoadState", func() {
 						workingDir, err := os.Getwd()
 						Expect(err).NotTo(HaveOccurred())
 
-						Expect(getStateArg).To(Equal(workingDir))
+						Expect(getStateArg).To(Equal(localBackend(workingDir, 10)))
 					})
 				})
 
@@ -752,6 +1095,279 @@ var _ = Describe("LoadState", func() {
 				)
 			})
 		})
+
+		Describe("credential provider chains", func() {
+			var tempDir string
+
+			BeforeEach(func() {
+				var err error
+				tempDir, err = ioutil.TempDir("", "")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			Context("using AWS", func() {
+				var credentialsPath string
+
+				BeforeEach(func() {
+					credentialsPath = filepath.Join(tempDir, "credentials")
+					err := ioutil.WriteFile(credentialsPath, []byte(`[default]
+aws_access_key_id = default-access-key-id
+aws_secret_access_key = default-secret-access-key
+
+[some-profile]
+aws_access_key_id = profile-access-key-id
+aws_secret_access_key = profile-secret-access-key
+aws_session_token = profile-session-token
+`), os.ModePerm)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				AfterEach(func() {
+					config.ResetAssumeAWSRole()
+				})
+
+				It("reads the given profile from a shared credentials file", func() {
+					appConfig, err := c.Bootstrap([]string{
+						"bbl", "--iaas", "aws", "--aws-region", "some-region",
+						"--aws-shared-credentials-file", credentialsPath,
+						"--aws-profile", "some-profile",
+						"up",
+					})
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(appConfig.State.AWS).To(Equal(storage.AWS{
+						AccessKeyID:     "profile-access-key-id",
+						SecretAccessKey: "profile-secret-access-key",
+						SessionToken:    "profile-session-token",
+						Region:          "some-region",
+					}))
+				})
+
+				It("falls back to the default profile of AWS_SHARED_CREDENTIALS_FILE", func() {
+					os.Setenv("AWS_SHARED_CREDENTIALS_FILE", credentialsPath)
+
+					appConfig, err := c.Bootstrap([]string{"bbl", "--iaas", "aws", "--aws-region", "some-region", "up"})
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(appConfig.State.AWS.AccessKeyID).To(Equal("default-access-key-id"))
+					Expect(appConfig.State.AWS.SecretAccessKey).To(Equal("default-secret-access-key"))
+				})
+
+				It("prefers access keys that are given directly", func() {
+					appConfig, err := c.Bootstrap([]string{
+						"bbl", "--iaas", "aws", "--aws-region", "some-region",
+						"--aws-access-key-id", "some-access-key-id",
+						"--aws-secret-access-key", "some-secret-access-key",
+						"--aws-shared-credentials-file", credentialsPath,
+						"up",
+					})
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(appConfig.State.AWS.AccessKeyID).To(Equal("some-access-key-id"))
+				})
+
+				It("does not resolve credentials for commands that do not need them", func() {
+					appConfig, err := c.Bootstrap([]string{
+						"bbl", "--iaas", "aws",
+						"--aws-shared-credentials-file", credentialsPath,
+						"--aws-assume-role", "some-role-arn",
+						"lbs",
+					})
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(appConfig.State.AWS.AccessKeyID).To(BeEmpty())
+				})
+
+				It("assumes the given role with the resolved credentials", func() {
+					var (
+						receivedConfig  aws.Config
+						receivedRoleARN string
+					)
+					config.SetAssumeAWSRole(func(c aws.Config, roleARN string) (aws.Config, error) {
+						receivedConfig = c
+						receivedRoleARN = roleARN
+						return aws.Config{
+							AccessKeyID:     "role-access-key-id",
+							SecretAccessKey: "role-secret-access-key",
+							SessionToken:    "role-session-token",
+							Region:          c.Region,
+						}, nil
+					})
+
+					appConfig, err := c.Bootstrap([]string{
+						"bbl", "--iaas", "aws", "--aws-region", "some-region",
+						"--aws-shared-credentials-file", credentialsPath,
+						"--aws-profile", "some-profile",
+						"--aws-assume-role", "some-role-arn",
+						"up",
+					})
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(receivedRoleARN).To(Equal("some-role-arn"))
+					Expect(receivedConfig).To(Equal(aws.Config{
+						AccessKeyID:     "profile-access-key-id",
+						SecretAccessKey: "profile-secret-access-key",
+						SessionToken:    "profile-session-token",
+						Region:          "some-region",
+					}))
+					Expect(appConfig.State.AWS).To(Equal(storage.AWS{
+						AccessKeyID:     "role-access-key-id",
+						SecretAccessKey: "role-secret-access-key",
+						SessionToken:    "role-session-token",
+						Region:          "some-region",
+					}))
+				})
+
+				Context("failure cases", func() {
+					It("returns an error when the profile does not exist", func() {
+						_, err := c.Bootstrap([]string{
+							"bbl", "--iaas", "aws",
+							"--aws-shared-credentials-file", credentialsPath,
+							"--aws-profile", "some-missing-profile",
+							"up",
+						})
+						Expect(err).To(MatchError(ContainSubstring("Reading AWS shared credentials:")))
+					})
+
+					It("returns an error when there are no credentials to assume a role with", func() {
+						_, err := c.Bootstrap([]string{"bbl", "--iaas", "aws", "--aws-assume-role", "some-role-arn", "up"})
+						Expect(err).To(MatchError("AWS credentials must be provided to assume role some-role-arn"))
+					})
+
+					It("returns an error when the role cannot be assumed", func() {
+						config.SetAssumeAWSRole(func(aws.Config, string) (aws.Config, error) {
+							return aws.Config{}, errors.New("access denied")
+						})
+
+						_, err := c.Bootstrap([]string{
+							"bbl", "--iaas", "aws",
+							"--aws-access-key-id", "some-access-key-id",
+							"--aws-secret-access-key", "some-secret-access-key",
+							"--aws-assume-role", "some-role-arn",
+							"up",
+						})
+						Expect(err).To(MatchError("Assuming AWS role some-role-arn: access denied"))
+					})
+				})
+			})
+
+			Context("using GCP", func() {
+				var credentialsPath string
+
+				BeforeEach(func() {
+					credentialsPath = filepath.Join(tempDir, "application_default_credentials.json")
+					err := ioutil.WriteFile(credentialsPath, []byte(`{"type": "service_account", "project_id": "some-project-id"}`), os.ModePerm)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("uses the service account key in GOOGLE_APPLICATION_CREDENTIALS", func() {
+					os.Setenv("GOOGLE_APPLICATION_CREDENTIALS", credentialsPath)
+
+					appConfig, err := c.Bootstrap([]string{"bbl", "--iaas", "gcp", "up"})
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(appConfig.State.GCP.ServiceAccountKey).To(Equal(`{"type": "service_account", "project_id": "some-project-id"}`))
+					Expect(appConfig.State.GCP.ProjectID).To(Equal("some-project-id"))
+				})
+
+				It("uses the gcloud application default credentials file", func() {
+					os.Setenv("HOME", tempDir)
+					err := os.MkdirAll(filepath.Join(tempDir, ".config", "gcloud"), os.ModePerm)
+					Expect(err).NotTo(HaveOccurred())
+					err = os.Rename(credentialsPath, filepath.Join(tempDir, ".config", "gcloud", "application_default_credentials.json"))
+					Expect(err).NotTo(HaveOccurred())
+
+					appConfig, err := c.Bootstrap([]string{"bbl", "--iaas", "gcp", "up"})
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(appConfig.State.GCP.ProjectID).To(Equal("some-project-id"))
+				})
+
+				It("returns an error for user credentials", func() {
+					err := ioutil.WriteFile(credentialsPath, []byte(`{"type": "authorized_user"}`), os.ModePerm)
+					Expect(err).NotTo(HaveOccurred())
+					os.Setenv("GOOGLE_APPLICATION_CREDENTIALS", credentialsPath)
+
+					_, err = c.Bootstrap([]string{"bbl", "--iaas", "gcp", "up"})
+					Expect(err).To(MatchError(ContainSubstring(`must be a service account key, not "authorized_user" credentials`)))
+				})
+			})
+
+			Context("using Azure", func() {
+				var authFilePath string
+
+				BeforeEach(func() {
+					authFilePath = filepath.Join(tempDir, "azure.json")
+					err := ioutil.WriteFile(authFilePath, []byte(`{
+						"clientId": "file-client-id",
+						"clientSecret": "file-client-secret",
+						"subscriptionId": "file-subscription-id",
+						"tenantId": "file-tenant-id"
+					}`), os.ModePerm)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("fills in credentials from the auth file", func() {
+					appConfig, err := c.Bootstrap([]string{
+						"bbl", "--iaas", "azure",
+						"--azure-auth-file", authFilePath,
+						"--azure-client-id", "some-client-id",
+						"--azure-location", "some-location",
+						"up",
+					})
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(appConfig.State.Azure).To(Equal(storage.Azure{
+						ClientID:       "some-client-id",
+						ClientSecret:   "file-client-secret",
+						Location:       "some-location",
+						SubscriptionID: "file-subscription-id",
+						TenantID:       "file-tenant-id",
+					}))
+				})
+
+				It("prefers the auth file over credentials left in the state", func() {
+					getState := func(storage.Backend) (storage.State, error) {
+						return storage.State{
+							IAAS: "azure",
+							Azure: storage.Azure{
+								ClientID:       "state-client-id",
+								ClientSecret:   "state-client-secret",
+								SubscriptionID: "state-subscription-id",
+								TenantID:       "state-tenant-id",
+								Location:       "state-location",
+							},
+						}, nil
+					}
+					c = config.NewConfig(getState, fakeLogger)
+
+					appConfig, err := c.Bootstrap([]string{"bbl", "--azure-auth-file", authFilePath, "up"})
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(appConfig.State.Azure).To(Equal(storage.Azure{
+						ClientID:       "file-client-id",
+						ClientSecret:   "file-client-secret",
+						Location:       "state-location",
+						SubscriptionID: "file-subscription-id",
+						TenantID:       "file-tenant-id",
+					}))
+				})
+
+				It("falls back to AZURE_AUTH_LOCATION", func() {
+					os.Setenv("AZURE_AUTH_LOCATION", authFilePath)
+
+					appConfig, err := c.Bootstrap([]string{"bbl", "--iaas", "azure", "up"})
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(appConfig.State.Azure.ClientSecret).To(Equal("file-client-secret"))
+				})
+
+				It("returns an error when the auth file cannot be read", func() {
+					_, err := c.Bootstrap([]string{"bbl", "--iaas", "azure", "--azure-auth-file", "/some/missing/file", "up"})
+					Expect(err).To(MatchError(ContainSubstring("Reading Azure auth file:")))
+				})
+			})
+		})
 	})
 
 	Describe("ValidateIAAS", func() {
@@ -898,3 +1514,71 @@ var _ = Describe("LoadState", func() {
 		)
 	})
 })
+
+var _ = Describe("ReloadState", func() {
+	It("reads the state again, keeping the resolved IAAS settings and credentials", func() {
+		getState := func(storage.Backend) (storage.State, error) {
+			return storage.State{
+				IAAS:  "aws",
+				EnvID: "some-env-id",
+				AWS:   storage.AWS{Region: "some-region"},
+			}, nil
+		}
+		c := config.NewConfig(getState, &fakes.Logger{})
+
+		appConfig, err := c.ReloadState(application.Configuration{
+			Command: "up",
+			State: storage.State{
+				IAAS: "aws",
+				AWS: storage.AWS{
+					AccessKeyID:     "some-access-key-id",
+					SecretAccessKey: "some-secret-access-key",
+					SessionToken:    "some-session-token",
+					Region:          "some-region",
+				},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(appConfig.Command).To(Equal("up"))
+		Expect(appConfig.State.EnvID).To(Equal("some-env-id"))
+		Expect(appConfig.State.AWS).To(Equal(storage.AWS{
+			AccessKeyID:     "some-access-key-id",
+			SecretAccessKey: "some-secret-access-key",
+			SessionToken:    "some-session-token",
+			Region:          "some-region",
+		}))
+	})
+
+	It("returns an error when the state cannot be read", func() {
+		getState := func(storage.Backend) (storage.State, error) {
+			return storage.State{}, errors.New("failed to read state")
+		}
+		c := config.NewConfig(getState, &fakes.Logger{})
+
+		_, err := c.ReloadState(application.Configuration{})
+		Expect(err).To(MatchError("failed to read state"))
+	})
+})
+
+var _ = DescribeTable("WritesState",
+	func(command string, writesState bool) {
+		Expect(config.WritesState(command)).To(Equal(writesState))
+	},
+	Entry("up", "up", true),
+	Entry("destroy", "destroy", true),
+	Entry("rotate", "rotate", true),
+	Entry("plan", "plan", false),
+	Entry("drift", "drift", false),
+	Entry("encrypt-state", "encrypt-state", true),
+	Entry("decrypt-state", "decrypt-state", true),
+	Entry("state-restore", "state-restore", true),
+	Entry("migrate-state", "migrate-state", true),
+	Entry("state-history", "state-history", false),
+	Entry("lbs", "lbs", false),
+	Entry("force-unlock", "force-unlock", false),
+)
+
+func localBackend(stateDir string, historySize int) storage.HistoryBackend {
+	return storage.NewHistoryBackend(storage.NewLocalBackend(stateDir), filepath.Join(stateDir, storage.HistoryDirName), historySize)
+}
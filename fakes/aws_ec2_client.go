@@ -34,6 +34,16 @@ type AWSEC2Client struct {
 			Error  error
 		}
 	}
+
+	DescribeSubnetsCall struct {
+		Receives struct {
+			Input *awsec2.DescribeSubnetsInput
+		}
+		Returns struct {
+			Output *awsec2.DescribeSubnetsOutput
+			Error  error
+		}
+	}
 }
 
 func (c *AWSEC2Client) DescribeAvailabilityZones(input *awsec2.DescribeAvailabilityZonesInput) (*awsec2.DescribeAvailabilityZonesOutput, error) {
@@ -53,3 +63,9 @@ func (c *AWSEC2Client) DescribeVpcs(input *awsec2.DescribeVpcsInput) (*awsec2.De
 
 	return c.DescribeVpcsCall.Returns.Output, c.DescribeVpcsCall.Returns.Error
 }
+
+func (c *AWSEC2Client) DescribeSubnets(input *awsec2.DescribeSubnetsInput) (*awsec2.DescribeSubnetsOutput, error) {
+	c.DescribeSubnetsCall.Receives.Input = input
+
+	return c.DescribeSubnetsCall.Returns.Output, c.DescribeSubnetsCall.Returns.Error
+}
@@ -0,0 +1,22 @@
+package fakes
+
+import "github.com/Azure/go-autorest/autorest"
+
+type AzureGroupsClient struct {
+	CheckExistenceCall struct {
+		CallCount int
+		Receives  struct {
+			ResourceGroupName string
+		}
+		Returns struct {
+			Response autorest.Response
+			Error    error
+		}
+	}
+}
+
+func (a *AzureGroupsClient) CheckExistence(resourceGroupName string) (autorest.Response, error) {
+	a.CheckExistenceCall.CallCount++
+	a.CheckExistenceCall.Receives.ResourceGroupName = resourceGroupName
+	return a.CheckExistenceCall.Returns.Response, a.CheckExistenceCall.Returns.Error
+}
@@ -0,0 +1,57 @@
+package fakes
+
+import (
+	"github.com/Azure/azure-sdk-for-go/arm/resources/resources"
+	"github.com/Azure/go-autorest/autorest"
+)
+
+type AzureResourcesClient struct {
+	CheckExistenceCall struct {
+		CallCount int
+		Receives  struct {
+			ResourceGroupName         string
+			ResourceProviderNamespace string
+			ParentResourcePath        string
+			ResourceType              string
+			ResourceName              string
+		}
+		Returns struct {
+			Response autorest.Response
+			Error    error
+		}
+	}
+	GetCall struct {
+		CallCount int
+		Receives  struct {
+			ResourceGroupName         string
+			ResourceProviderNamespace string
+			ParentResourcePath        string
+			ResourceType              string
+			ResourceName              string
+		}
+		Returns struct {
+			Resource resources.GenericResource
+			Error    error
+		}
+	}
+}
+
+func (a *AzureResourcesClient) CheckExistence(resourceGroupName, resourceProviderNamespace, parentResourcePath, resourceType, resourceName string) (autorest.Response, error) {
+	a.CheckExistenceCall.CallCount++
+	a.CheckExistenceCall.Receives.ResourceGroupName = resourceGroupName
+	a.CheckExistenceCall.Receives.ResourceProviderNamespace = resourceProviderNamespace
+	a.CheckExistenceCall.Receives.ParentResourcePath = parentResourcePath
+	a.CheckExistenceCall.Receives.ResourceType = resourceType
+	a.CheckExistenceCall.Receives.ResourceName = resourceName
+	return a.CheckExistenceCall.Returns.Response, a.CheckExistenceCall.Returns.Error
+}
+
+func (a *AzureResourcesClient) Get(resourceGroupName, resourceProviderNamespace, parentResourcePath, resourceType, resourceName string) (resources.GenericResource, error) {
+	a.GetCall.CallCount++
+	a.GetCall.Receives.ResourceGroupName = resourceGroupName
+	a.GetCall.Receives.ResourceProviderNamespace = resourceProviderNamespace
+	a.GetCall.Receives.ParentResourcePath = parentResourcePath
+	a.GetCall.Receives.ResourceType = resourceType
+	a.GetCall.Receives.ResourceName = resourceName
+	return a.GetCall.Returns.Resource, a.GetCall.Returns.Error
+}
@@ -0,0 +1,38 @@
+package fakes
+
+import "github.com/Azure/azure-sdk-for-go/arm/compute"
+
+type AzureVirtualMachinesClient struct {
+	ListCall struct {
+		CallCount int
+		Receives  struct {
+			ResourceGroupName string
+		}
+		Returns struct {
+			Result compute.VirtualMachineListResult
+			Error  error
+		}
+	}
+	ListNextResultsCall struct {
+		CallCount int
+		Receives  struct {
+			LastResults compute.VirtualMachineListResult
+		}
+		Returns struct {
+			Result compute.VirtualMachineListResult
+			Error  error
+		}
+	}
+}
+
+func (a *AzureVirtualMachinesClient) List(resourceGroupName string) (compute.VirtualMachineListResult, error) {
+	a.ListCall.CallCount++
+	a.ListCall.Receives.ResourceGroupName = resourceGroupName
+	return a.ListCall.Returns.Result, a.ListCall.Returns.Error
+}
+
+func (a *AzureVirtualMachinesClient) ListNextResults(lastResults compute.VirtualMachineListResult) (compute.VirtualMachineListResult, error) {
+	a.ListNextResultsCall.CallCount++
+	a.ListNextResultsCall.Receives.LastResults = lastResults
+	return a.ListNextResultsCall.Returns.Result, a.ListNextResultsCall.Returns.Error
+}
@@ -5,6 +5,17 @@ import (
 	"golang.org/x/net/proxy"
 )
 
+type UpdateConfigCallReceive struct {
+	Type    string
+	Name    string
+	Content []byte
+}
+
+type DeleteConfigCallReceive struct {
+	Type string
+	Name string
+}
+
 type BOSHClient struct {
 	UpdateCloudConfigCall struct {
 		CallCount int
@@ -23,6 +34,30 @@ type BOSHClient struct {
 		}
 	}
 
+	CloudConfigCall struct {
+		CallCount int
+		Returns   struct {
+			CloudConfig string
+			Error       error
+		}
+	}
+
+	UpdateConfigCall struct {
+		CallCount int
+		Receives  []UpdateConfigCallReceive
+		Returns   struct {
+			Error error
+		}
+	}
+
+	DeleteConfigCall struct {
+		CallCount int
+		Receives  []DeleteConfigCallReceive
+		Returns   struct {
+			Error error
+		}
+	}
+
 	InfoCall struct {
 		CallCount int
 		Returns   struct {
@@ -38,6 +73,30 @@ func (c *BOSHClient) UpdateCloudConfig(yaml []byte) error {
 	return c.UpdateCloudConfigCall.Returns.Error
 }
 
+func (c *BOSHClient) CloudConfig() (string, error) {
+	c.CloudConfigCall.CallCount++
+	return c.CloudConfigCall.Returns.CloudConfig, c.CloudConfigCall.Returns.Error
+}
+
+func (c *BOSHClient) UpdateConfig(configType, name string, content []byte) error {
+	c.UpdateConfigCall.CallCount++
+	c.UpdateConfigCall.Receives = append(c.UpdateConfigCall.Receives, UpdateConfigCallReceive{
+		Type:    configType,
+		Name:    name,
+		Content: content,
+	})
+	return c.UpdateConfigCall.Returns.Error
+}
+
+func (c *BOSHClient) DeleteConfig(configType, name string) error {
+	c.DeleteConfigCall.CallCount++
+	c.DeleteConfigCall.Receives = append(c.DeleteConfigCall.Receives, DeleteConfigCallReceive{
+		Type: configType,
+		Name: name,
+	})
+	return c.DeleteConfigCall.Returns.Error
+}
+
 func (c *BOSHClient) ConfigureHTTPClient(socks5Client proxy.Dialer) {
 	c.ConfigureHTTPClientCall.CallCount++
 	c.ConfigureHTTPClientCall.Receives.Socks5Client = socks5Client
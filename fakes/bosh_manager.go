@@ -59,7 +59,30 @@ type BOSHManager struct {
 			TerraformOutputs map[string]interface{}
 		}
 		Returns struct {
-			Vars string
+			Vars  string
+			Error error
+		}
+	}
+	InterpolateJumpboxCall struct {
+		CallCount int
+		Receives  struct {
+			State            storage.State
+			TerraformOutputs map[string]interface{}
+		}
+		Returns struct {
+			Manifest string
+			Error    error
+		}
+	}
+	InterpolateDirectorCall struct {
+		CallCount int
+		Receives  struct {
+			State            storage.State
+			TerraformOutputs map[string]interface{}
+		}
+		Returns struct {
+			Manifest string
+			Error    error
 		}
 	}
 	GetJumpboxDeploymentVarsCall struct {
@@ -69,7 +92,8 @@ type BOSHManager struct {
 			TerraformOutputs map[string]interface{}
 		}
 		Returns struct {
-			Vars string
+			Vars  string
+			Error error
 		}
 	}
 }
@@ -102,18 +126,32 @@ func (b *BOSHManager) DeleteJumpbox(state storage.State, terraformOutputs map[st
 	return b.DeleteJumpboxCall.Returns.Error
 }
 
-func (b *BOSHManager) GetDirectorDeploymentVars(state storage.State, terraformOutputs map[string]interface{}) string {
+func (b *BOSHManager) InterpolateJumpbox(state storage.State, terraformOutputs map[string]interface{}) (string, error) {
+	b.InterpolateJumpboxCall.CallCount++
+	b.InterpolateJumpboxCall.Receives.State = state
+	b.InterpolateJumpboxCall.Receives.TerraformOutputs = terraformOutputs
+	return b.InterpolateJumpboxCall.Returns.Manifest, b.InterpolateJumpboxCall.Returns.Error
+}
+
+func (b *BOSHManager) InterpolateDirector(state storage.State, terraformOutputs map[string]interface{}) (string, error) {
+	b.InterpolateDirectorCall.CallCount++
+	b.InterpolateDirectorCall.Receives.State = state
+	b.InterpolateDirectorCall.Receives.TerraformOutputs = terraformOutputs
+	return b.InterpolateDirectorCall.Returns.Manifest, b.InterpolateDirectorCall.Returns.Error
+}
+
+func (b *BOSHManager) GetDirectorDeploymentVars(state storage.State, terraformOutputs map[string]interface{}) (string, error) {
 	b.GetDirectorDeploymentVarsCall.CallCount++
 	b.GetDirectorDeploymentVarsCall.Receives.State = state
 	b.GetDirectorDeploymentVarsCall.Receives.TerraformOutputs = terraformOutputs
-	return b.GetDirectorDeploymentVarsCall.Returns.Vars
+	return b.GetDirectorDeploymentVarsCall.Returns.Vars, b.GetDirectorDeploymentVarsCall.Returns.Error
 }
 
-func (b *BOSHManager) GetJumpboxDeploymentVars(state storage.State, terraformOutputs map[string]interface{}) string {
+func (b *BOSHManager) GetJumpboxDeploymentVars(state storage.State, terraformOutputs map[string]interface{}) (string, error) {
 	b.GetJumpboxDeploymentVarsCall.CallCount++
 	b.GetJumpboxDeploymentVarsCall.Receives.State = state
 	b.GetJumpboxDeploymentVarsCall.Receives.TerraformOutputs = terraformOutputs
-	return b.GetJumpboxDeploymentVarsCall.Returns.Vars
+	return b.GetJumpboxDeploymentVarsCall.Returns.Vars, b.GetJumpboxDeploymentVarsCall.Returns.Error
 }
 
 func (b *BOSHManager) Version() (string, error) {
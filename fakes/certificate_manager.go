@@ -1,67 +1,47 @@
 package fakes
 
-import "github.com/cloudfoundry/bosh-bootloader/aws/iam"
+import (
+	"github.com/cloudfoundry/bosh-bootloader/bosh"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+)
 
 type CertificateManager struct {
-	CreateCall struct {
+	ListCall struct {
 		CallCount int
 		Receives  struct {
-			Certificate     string
-			PrivateKey      string
-			Chain           string
-			CertificateName string
+			State storage.State
 		}
 		Returns struct {
-			Error error
+			Certificates []bosh.Certificate
+			Error        error
 		}
 	}
 
 	DeleteCall struct {
 		CallCount int
 		Receives  struct {
-			CertificateName string
+			State    storage.State
+			Selector bosh.CertificateSelector
 		}
 		Returns struct {
-			Error error
-		}
-	}
-
-	DescribeCall struct {
-		CallCount int
-		Stub      func(string) (iam.Certificate, error)
-		Receives  struct {
-			CertificateName string
-		}
-		Returns struct {
-			Certificate iam.Certificate
-			Error       error
+			State   storage.State
+			Deleted []string
+			Error   error
 		}
 	}
 }
 
-func (c *CertificateManager) Create(certificate, privatekey, chain, certificateName string) error {
-	c.CreateCall.CallCount++
-	c.CreateCall.Receives.Certificate = certificate
-	c.CreateCall.Receives.PrivateKey = privatekey
-	c.CreateCall.Receives.Chain = chain
-	c.CreateCall.Receives.CertificateName = certificateName
+func (c *CertificateManager) List(state storage.State) ([]bosh.Certificate, error) {
+	c.ListCall.CallCount++
+	c.ListCall.Receives.State = state
 
-	return c.CreateCall.Returns.Error
+	return c.ListCall.Returns.Certificates, c.ListCall.Returns.Error
 }
 
-func (c *CertificateManager) Delete(certificateName string) error {
+func (c *CertificateManager) Delete(state storage.State, selector bosh.CertificateSelector) (storage.State, []string, error) {
 	c.DeleteCall.CallCount++
-	c.DeleteCall.Receives.CertificateName = certificateName
-	return c.DeleteCall.Returns.Error
-}
-
-func (c *CertificateManager) Describe(certificateName string) (iam.Certificate, error) {
-	c.DescribeCall.CallCount++
-	c.DescribeCall.Receives.CertificateName = certificateName
-
-	if c.DescribeCall.Stub != nil {
-		return c.DescribeCall.Stub(certificateName)
-	}
+	c.DeleteCall.Receives.State = state
+	c.DeleteCall.Receives.Selector = selector
 
-	return c.DescribeCall.Returns.Certificate, c.DescribeCall.Returns.Error
+	return c.DeleteCall.Returns.State, c.DeleteCall.Returns.Deleted, c.DeleteCall.Returns.Error
 }
@@ -13,6 +13,18 @@ type CertificateValidator struct {
 			ChainPath       string
 		}
 	}
+
+	ValidatePKCS12Call struct {
+		CallCount int
+		Returns   struct {
+			Error error
+		}
+		Receives struct {
+			Command         string
+			CertificatePath string
+			PasswordPath    string
+		}
+	}
 }
 
 func (c *CertificateValidator) Validate(command, certificatePath, keyPath, chainPath string) error {
@@ -23,3 +35,11 @@ func (c *CertificateValidator) Validate(command, certificatePath, keyPath, chain
 	c.ValidateCall.Receives.ChainPath = chainPath
 	return c.ValidateCall.Returns.Error
 }
+
+func (c *CertificateValidator) ValidatePKCS12(command, certificatePath, passwordPath string) error {
+	c.ValidatePKCS12Call.CallCount++
+	c.ValidatePKCS12Call.Receives.Command = command
+	c.ValidatePKCS12Call.Receives.CertificatePath = certificatePath
+	c.ValidatePKCS12Call.Receives.PasswordPath = passwordPath
+	return c.ValidatePKCS12Call.Returns.Error
+}
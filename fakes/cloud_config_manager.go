@@ -14,6 +14,25 @@ type CloudConfigManager struct {
 			Error error
 		}
 	}
+	DiffCall struct {
+		CallCount int
+		Receives  struct {
+			State storage.State
+		}
+		Returns struct {
+			Error error
+		}
+	}
+	CurrentCall struct {
+		CallCount int
+		Receives  struct {
+			State storage.State
+		}
+		Returns struct {
+			CloudConfig string
+			Error       error
+		}
+	}
 	GenerateCall struct {
 		CallCount int
 		Receives  struct {
@@ -32,8 +51,20 @@ func (c *CloudConfigManager) Update(state storage.State) error {
 	return c.UpdateCall.Returns.Error
 }
 
+func (c *CloudConfigManager) Diff(state storage.State) error {
+	c.DiffCall.CallCount++
+	c.DiffCall.Receives.State = state
+	return c.DiffCall.Returns.Error
+}
+
 func (c *CloudConfigManager) Generate(state storage.State) (string, error) {
 	c.GenerateCall.CallCount++
 	c.GenerateCall.Receives.State = state
 	return c.GenerateCall.Returns.CloudConfig, c.GenerateCall.Returns.Error
 }
+
+func (c *CloudConfigManager) Current(state storage.State) (string, error) {
+	c.CurrentCall.CallCount++
+	c.CurrentCall.Receives.State = state
+	return c.CurrentCall.Returns.CloudConfig, c.CurrentCall.Returns.Error
+}
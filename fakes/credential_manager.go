@@ -0,0 +1,28 @@
+package fakes
+
+import "github.com/cloudfoundry/bosh-bootloader/storage"
+
+type CredentialManager struct {
+	DeleteCall struct {
+		CallCount int
+		Receives  struct {
+			State storage.State
+			Names []string
+			All   bool
+		}
+		Returns struct {
+			State   storage.State
+			Deleted []string
+			Error   error
+		}
+	}
+}
+
+func (c *CredentialManager) Delete(state storage.State, names []string, all bool) (storage.State, []string, error) {
+	c.DeleteCall.CallCount++
+	c.DeleteCall.Receives.State = state
+	c.DeleteCall.Receives.Names = names
+	c.DeleteCall.Receives.All = all
+
+	return c.DeleteCall.Returns.State, c.DeleteCall.Returns.Deleted, c.DeleteCall.Returns.Error
+}
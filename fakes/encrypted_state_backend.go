@@ -0,0 +1,69 @@
+package fakes
+
+import "github.com/cloudfoundry/bosh-bootloader/storage"
+
+type EncryptedStateBackend struct {
+	LocationCall struct {
+		CallCount int
+		Returns   struct {
+			Location string
+		}
+	}
+
+	EncryptStateCall struct {
+		CallCount int
+		Returns   struct {
+			Error error
+		}
+	}
+
+	DecryptStateCall struct {
+		CallCount int
+		Returns   struct {
+			Error error
+		}
+	}
+}
+
+func (e *EncryptedStateBackend) Location() string {
+	e.LocationCall.CallCount++
+	return e.LocationCall.Returns.Location
+}
+
+func (e *EncryptedStateBackend) ReadState() ([]byte, error) {
+	return nil, nil
+}
+
+func (e *EncryptedStateBackend) WriteState(contents []byte) error {
+	return nil
+}
+
+func (e *EncryptedStateBackend) DeleteState() error {
+	return nil
+}
+
+func (e *EncryptedStateBackend) BackupState(name string) error {
+	return nil
+}
+
+func (e *EncryptedStateBackend) AcquireLease(lease storage.Lease) error {
+	return nil
+}
+
+func (e *EncryptedStateBackend) ReleaseLease(lease storage.Lease) error {
+	return nil
+}
+
+func (e *EncryptedStateBackend) ReadLease() (storage.Lease, error) {
+	return storage.Lease{}, storage.LeaseNotFound
+}
+
+func (e *EncryptedStateBackend) EncryptState() error {
+	e.EncryptStateCall.CallCount++
+	return e.EncryptStateCall.Returns.Error
+}
+
+func (e *EncryptedStateBackend) DecryptState() error {
+	e.DecryptStateCall.CallCount++
+	return e.DecryptStateCall.Returns.Error
+}
@@ -58,6 +58,17 @@ type GCPComputeClient struct {
 			Error       error
 		}
 	}
+	GetSubnetworksCall struct {
+		CallCount int
+		Receives  struct {
+			Name      string
+			ProjectID string
+		}
+		Returns struct {
+			SubnetworkList *compute.SubnetworkAggregatedList
+			Error          error
+		}
+	}
 }
 
 func (g *GCPComputeClient) ListInstances(projectID, zone string) (*compute.InstanceList, error) {
@@ -94,3 +105,10 @@ func (g *GCPComputeClient) GetNetworks(name, projectID string) (*compute.Network
 	g.GetNetworksCall.Receives.ProjectID = projectID
 	return g.GetNetworksCall.Returns.NetworkList, g.GetNetworksCall.Returns.Error
 }
+
+func (g *GCPComputeClient) GetSubnetworks(name, projectID string) (*compute.SubnetworkAggregatedList, error) {
+	g.GetSubnetworksCall.CallCount++
+	g.GetSubnetworksCall.Receives.Name = name
+	g.GetSubnetworksCall.Receives.ProjectID = projectID
+	return g.GetSubnetworksCall.Returns.SubnetworkList, g.GetSubnetworksCall.Returns.Error
+}
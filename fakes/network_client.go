@@ -11,6 +11,17 @@ type NetworkClient struct {
 			Error  error
 		}
 	}
+	CheckSubnetExistsCall struct {
+		CallCount int
+		Receives  struct {
+			NetworkName string
+			SubnetName  string
+		}
+		Returns struct {
+			Exists bool
+			Error  error
+		}
+	}
 }
 
 func (n *NetworkClient) CheckExists(name string) (bool, error) {
@@ -18,3 +29,10 @@ func (n *NetworkClient) CheckExists(name string) (bool, error) {
 	n.CheckExistsCall.Receives.Name = name
 	return n.CheckExistsCall.Returns.Exists, n.CheckExistsCall.Returns.Error
 }
+
+func (n *NetworkClient) CheckSubnetExists(networkName, subnetName string) (bool, error) {
+	n.CheckSubnetExistsCall.CallCount++
+	n.CheckSubnetExistsCall.Receives.NetworkName = networkName
+	n.CheckSubnetExistsCall.Receives.SubnetName = subnetName
+	return n.CheckSubnetExistsCall.Returns.Exists, n.CheckSubnetExistsCall.Returns.Error
+}
@@ -11,6 +11,17 @@ type NetworkDeletionValidator struct {
 			EnvID       string
 		}
 	}
+	ValidateSubnetsSafeToDeleteCall struct {
+		CallCount int
+		Returns   struct {
+			Error error
+		}
+		Receives struct {
+			NetworkName string
+			Subnets     []string
+			EnvID       string
+		}
+	}
 }
 
 func (n *NetworkDeletionValidator) ValidateSafeToDelete(networkName string, envID string) error {
@@ -20,3 +31,12 @@ func (n *NetworkDeletionValidator) ValidateSafeToDelete(networkName string, envI
 
 	return n.ValidateSafeToDeleteCall.Returns.Error
 }
+
+func (n *NetworkDeletionValidator) ValidateSubnetsSafeToDelete(networkName string, subnets []string, envID string) error {
+	n.ValidateSubnetsSafeToDeleteCall.CallCount++
+	n.ValidateSubnetsSafeToDeleteCall.Receives.NetworkName = networkName
+	n.ValidateSubnetsSafeToDeleteCall.Receives.Subnets = subnets
+	n.ValidateSubnetsSafeToDeleteCall.Receives.EnvID = envID
+
+	return n.ValidateSubnetsSafeToDeleteCall.Returns.Error
+}
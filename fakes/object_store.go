@@ -0,0 +1,91 @@
+package fakes
+
+import (
+	"crypto/md5"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+)
+
+// ObjectStore is an in-memory S3-compatible object store that honours
+// If-Match and If-None-Match preconditions on PUT.
+type ObjectStore struct {
+	mutex   sync.Mutex
+	objects map[string][]byte
+
+	Requests []*http.Request
+
+	ResponseStatus int
+}
+
+func NewObjectStore() *ObjectStore {
+	return &ObjectStore{
+		objects: map[string][]byte{},
+	}
+}
+
+func (o *ObjectStore) Object(path string) ([]byte, bool) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	contents, ok := o.objects[path]
+	return contents, ok
+}
+
+func (o *ObjectStore) SetObject(path string, contents []byte) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	o.objects[path] = contents
+}
+
+func (o *ObjectStore) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	o.Requests = append(o.Requests, req)
+
+	if o.ResponseStatus != 0 {
+		w.WriteHeader(o.ResponseStatus)
+		return
+	}
+
+	contents, exists := o.objects[req.URL.Path]
+
+	switch req.Method {
+	case "GET":
+		if !exists {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("ETag", etag(contents))
+		w.Write(contents)
+	case "PUT":
+		if req.Header.Get("If-None-Match") == "*" && exists {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			return
+		}
+		if match := req.Header.Get("If-Match"); match != "" && (!exists || match != etag(contents)) {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			return
+		}
+
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		o.objects[req.URL.Path] = body
+		w.WriteHeader(http.StatusOK)
+	case "DELETE":
+		delete(o.objects, req.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func etag(contents []byte) string {
+	return fmt.Sprintf("%q", fmt.Sprintf("%x", md5.Sum(contents)))
+}
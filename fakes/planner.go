@@ -0,0 +1,23 @@
+package fakes
+
+import "github.com/cloudfoundry/bosh-bootloader/storage"
+
+type Planner struct {
+	PlanCall struct {
+		CallCount int
+		Receives  struct {
+			State          storage.State
+			JSONReportPath string
+		}
+		Returns struct {
+			Error error
+		}
+	}
+}
+
+func (p *Planner) Plan(state storage.State, jsonReportPath string) error {
+	p.PlanCall.CallCount++
+	p.PlanCall.Receives.State = state
+	p.PlanCall.Receives.JSONReportPath = jsonReportPath
+	return p.PlanCall.Returns.Error
+}
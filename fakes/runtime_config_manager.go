@@ -0,0 +1,57 @@
+package fakes
+
+import (
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+)
+
+type RuntimeConfigManager struct {
+	UpdateCall struct {
+		CallCount int
+		Receives  struct {
+			State storage.State
+		}
+		Returns struct {
+			Error error
+		}
+	}
+	DeleteCall struct {
+		CallCount int
+		Receives  struct {
+			State          storage.State
+			RuntimeConfigs []string
+			CPIConfig      bool
+		}
+		Returns struct {
+			Error error
+		}
+	}
+	GenerateCall struct {
+		CallCount int
+		Receives  struct {
+			State storage.State
+		}
+		Returns struct {
+			RuntimeConfig string
+		}
+	}
+}
+
+func (r *RuntimeConfigManager) Update(state storage.State) error {
+	r.UpdateCall.CallCount++
+	r.UpdateCall.Receives.State = state
+	return r.UpdateCall.Returns.Error
+}
+
+func (r *RuntimeConfigManager) Delete(state storage.State, runtimeConfigs []string, cpiConfig bool) error {
+	r.DeleteCall.CallCount++
+	r.DeleteCall.Receives.State = state
+	r.DeleteCall.Receives.RuntimeConfigs = runtimeConfigs
+	r.DeleteCall.Receives.CPIConfig = cpiConfig
+	return r.DeleteCall.Returns.Error
+}
+
+func (r *RuntimeConfigManager) Generate(state storage.State) string {
+	r.GenerateCall.CallCount++
+	r.GenerateCall.Receives.State = state
+	return r.GenerateCall.Returns.RuntimeConfig
+}
@@ -0,0 +1,34 @@
+package fakes
+
+import "github.com/cloudfoundry/bosh-bootloader/storage"
+
+type StateHistory struct {
+	SnapshotsCall struct {
+		CallCount int
+		Returns   struct {
+			Snapshots []storage.Snapshot
+			Error     error
+		}
+	}
+
+	RestoreSnapshotCall struct {
+		CallCount int
+		Receives  struct {
+			ID string
+		}
+		Returns struct {
+			Error error
+		}
+	}
+}
+
+func (s *StateHistory) Snapshots() ([]storage.Snapshot, error) {
+	s.SnapshotsCall.CallCount++
+	return s.SnapshotsCall.Returns.Snapshots, s.SnapshotsCall.Returns.Error
+}
+
+func (s *StateHistory) RestoreSnapshot(id string) error {
+	s.RestoreSnapshotCall.CallCount++
+	s.RestoreSnapshotCall.Receives.ID = id
+	return s.RestoreSnapshotCall.Returns.Error
+}
@@ -0,0 +1,34 @@
+package fakes
+
+import "github.com/cloudfoundry/bosh-bootloader/storage"
+
+type StateLocker struct {
+	ReadLeaseCall struct {
+		CallCount int
+		Returns   struct {
+			Lease storage.Lease
+			Error error
+		}
+	}
+
+	ReleaseLeaseCall struct {
+		CallCount int
+		Receives  struct {
+			Lease storage.Lease
+		}
+		Returns struct {
+			Error error
+		}
+	}
+}
+
+func (s *StateLocker) ReadLease() (storage.Lease, error) {
+	s.ReadLeaseCall.CallCount++
+	return s.ReadLeaseCall.Returns.Lease, s.ReadLeaseCall.Returns.Error
+}
+
+func (s *StateLocker) ReleaseLease(lease storage.Lease) error {
+	s.ReleaseLeaseCall.CallCount++
+	s.ReleaseLeaseCall.Receives.Lease = lease
+	return s.ReleaseLeaseCall.Returns.Error
+}
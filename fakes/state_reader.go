@@ -0,0 +1,16 @@
+package fakes
+
+type StateReader struct {
+	ReadStateCall struct {
+		CallCount int
+		Returns   struct {
+			Contents []byte
+			Error    error
+		}
+	}
+}
+
+func (s *StateReader) ReadState() ([]byte, error) {
+	s.ReadStateCall.CallCount++
+	return s.ReadStateCall.Returns.Contents, s.ReadStateCall.Returns.Error
+}
@@ -11,9 +11,10 @@ type TerraformExecutor struct {
 	ApplyCall struct {
 		CallCount int
 		Receives  struct {
-			Inputs   map[string]string
-			Template string
-			TFState  string
+			Inputs       map[string]string
+			Template     string
+			OverridesDir string
+			TFState      string
 		}
 		Returns struct {
 			TFState string
@@ -23,9 +24,36 @@ type TerraformExecutor struct {
 	DestroyCall struct {
 		CallCount int
 		Receives  struct {
-			Inputs   map[string]string
-			Template string
-			TFState  string
+			Inputs       map[string]string
+			Template     string
+			OverridesDir string
+			TFState      string
+		}
+		Returns struct {
+			TFState string
+			Error   error
+		}
+	}
+	PlanCall struct {
+		CallCount int
+		Receives  struct {
+			Inputs       map[string]string
+			Template     string
+			OverridesDir string
+			TFState      string
+		}
+		Returns struct {
+			Output string
+			Error  error
+		}
+	}
+	TaintCall struct {
+		CallCount int
+		Receives  struct {
+			Template     string
+			OverridesDir string
+			Resource     string
+			TFState      string
 		}
 		Returns struct {
 			TFState string
@@ -76,22 +104,42 @@ type TerraformExecutor struct {
 	}
 }
 
-func (t *TerraformExecutor) Apply(inputs map[string]string, template, tfState string) (string, error) {
+func (t *TerraformExecutor) Apply(inputs map[string]string, template, overridesDir, tfState string) (string, error) {
 	t.ApplyCall.CallCount++
 	t.ApplyCall.Receives.Inputs = inputs
 	t.ApplyCall.Receives.Template = template
+	t.ApplyCall.Receives.OverridesDir = overridesDir
 	t.ApplyCall.Receives.TFState = tfState
 	return t.ApplyCall.Returns.TFState, t.ApplyCall.Returns.Error
 }
 
-func (t *TerraformExecutor) Destroy(inputs map[string]string, template, tfState string) (string, error) {
+func (t *TerraformExecutor) Destroy(inputs map[string]string, template, overridesDir, tfState string) (string, error) {
 	t.DestroyCall.CallCount++
 	t.DestroyCall.Receives.Inputs = inputs
 	t.DestroyCall.Receives.Template = template
+	t.DestroyCall.Receives.OverridesDir = overridesDir
 	t.DestroyCall.Receives.TFState = tfState
 	return t.DestroyCall.Returns.TFState, t.DestroyCall.Returns.Error
 }
 
+func (t *TerraformExecutor) Plan(inputs map[string]string, template, overridesDir, tfState string) (string, error) {
+	t.PlanCall.CallCount++
+	t.PlanCall.Receives.Inputs = inputs
+	t.PlanCall.Receives.Template = template
+	t.PlanCall.Receives.OverridesDir = overridesDir
+	t.PlanCall.Receives.TFState = tfState
+	return t.PlanCall.Returns.Output, t.PlanCall.Returns.Error
+}
+
+func (t *TerraformExecutor) Taint(template, overridesDir, resource, tfState string) (string, error) {
+	t.TaintCall.CallCount++
+	t.TaintCall.Receives.Template = template
+	t.TaintCall.Receives.OverridesDir = overridesDir
+	t.TaintCall.Receives.Resource = resource
+	t.TaintCall.Receives.TFState = tfState
+	return t.TaintCall.Returns.TFState, t.TaintCall.Returns.Error
+}
+
 func (t *TerraformExecutor) Import(addr, id, tfstate string, creds storage.AWS) (string, error) {
 	t.ImportCall.CallCount++
 	t.ImportCall.Receives.Imports = append(t.ImportCall.Receives.Imports, Import{
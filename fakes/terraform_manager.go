@@ -2,6 +2,7 @@ package fakes
 
 import (
 	"github.com/cloudfoundry/bosh-bootloader/storage"
+	"github.com/cloudfoundry/bosh-bootloader/terraform"
 )
 
 type TerraformManager struct {
@@ -25,6 +26,27 @@ type TerraformManager struct {
 			Error    error
 		}
 	}
+	PlanCall struct {
+		CallCount int
+		Receives  struct {
+			BBLState storage.State
+		}
+		Returns struct {
+			Plan  terraform.Plan
+			Error error
+		}
+	}
+	TaintCall struct {
+		CallCount int
+		Receives  struct {
+			BBLState storage.State
+			Resource string
+		}
+		Returns struct {
+			BBLState storage.State
+			Error    error
+		}
+	}
 	ImportCall struct {
 		CallCount int
 		Receives  struct {
@@ -75,6 +97,19 @@ func (t *TerraformManager) Destroy(bblState storage.State) (storage.State, error
 	return t.DestroyCall.Returns.BBLState, t.DestroyCall.Returns.Error
 }
 
+func (t *TerraformManager) Plan(bblState storage.State) (terraform.Plan, error) {
+	t.PlanCall.CallCount++
+	t.PlanCall.Receives.BBLState = bblState
+	return t.PlanCall.Returns.Plan, t.PlanCall.Returns.Error
+}
+
+func (t *TerraformManager) Taint(bblState storage.State, resource string) (storage.State, error) {
+	t.TaintCall.CallCount++
+	t.TaintCall.Receives.BBLState = bblState
+	t.TaintCall.Receives.Resource = resource
+	return t.TaintCall.Returns.BBLState, t.TaintCall.Returns.Error
+}
+
 func (t *TerraformManager) Import(bblState storage.State, outputs map[string]string) (storage.State, error) {
 	t.ImportCall.CallCount++
 	t.ImportCall.Receives.BBLState = bblState
@@ -0,0 +1,20 @@
+package fakes
+
+type VirtualNetworkClient struct {
+	VirtualNetworkAddressSpaceCall struct {
+		CallCount int
+		Receives  struct {
+			ExistingNetwork string
+		}
+		Returns struct {
+			AddressSpace []string
+			Error        error
+		}
+	}
+}
+
+func (v *VirtualNetworkClient) VirtualNetworkAddressSpace(existingNetwork string) ([]string, error) {
+	v.VirtualNetworkAddressSpaceCall.CallCount++
+	v.VirtualNetworkAddressSpaceCall.Receives.ExistingNetwork = existingNetwork
+	return v.VirtualNetworkAddressSpaceCall.Returns.AddressSpace, v.VirtualNetworkAddressSpaceCall.Returns.Error
+}
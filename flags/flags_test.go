@@ -9,15 +9,21 @@ import (
 
 var _ = Describe("Flags", func() {
 	var (
-		f         flags.Flags
-		boolVal   bool
-		stringVal string
+		f              flags.Flags
+		boolVal        bool
+		stringVal      string
+		intVal         int
+		stringSliceVal []string
 	)
 
 	BeforeEach(func() {
+		stringSliceVal = nil
+
 		f = flags.New("test")
 		f.Bool(&boolVal, "b", "bool", false)
 		f.String(&stringVal, "string", "")
+		f.Int(&intVal, "int", 0)
+		f.StringSlice(&stringSliceVal, "string-slice")
 	})
 
 	Describe("Parse", func() {
@@ -49,6 +55,33 @@ var _ = Describe("Flags", func() {
 				Expect(stringVal).To(Equal("string_value"))
 			})
 		})
+
+		Context("Int flags", func() {
+			It("can parse int fields from flags", func() {
+				err := f.Parse([]string{"--int", "64"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(intVal).To(Equal(64))
+			})
+
+			It("returns an error when the value is not a number", func() {
+				err := f.Parse([]string{"--int", "big"})
+				Expect(err).To(MatchError(ContainSubstring(`invalid value "big" for flag -int`)))
+			})
+		})
+
+		Context("StringSlice flags", func() {
+			It("collects every occurrence in order", func() {
+				err := f.Parse([]string{"--string-slice", "first", "--string-slice=second"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(stringSliceVal).To(Equal([]string{"first", "second"}))
+			})
+
+			It("is empty when the flag is not given", func() {
+				err := f.Parse([]string{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(stringSliceVal).To(BeEmpty())
+			})
+		})
 	})
 
 	Describe("Args", func() {
@@ -19,6 +19,7 @@ type ComputeClient interface {
 	GetZone(zone, projectID string) (*compute.Zone, error)
 	GetRegion(region, projectID string) (*compute.Region, error)
 	GetNetworks(name, projectID string) (*compute.NetworkList, error)
+	GetSubnetworks(name, projectID string) (*compute.SubnetworkAggregatedList, error)
 }
 
 func (c Client) ProjectID() string {
@@ -58,7 +59,48 @@ func (c Client) CheckExists(networkName string) (bool, error) {
 	return false, nil
 }
 
+// CheckSubnetExists reports whether networkName holds a subnetwork named
+// subnetName in any region.
+func (c Client) CheckSubnetExists(networkName, subnetName string) (bool, error) {
+	subnetworkList, err := c.computeClient.GetSubnetworks(subnetName, c.projectID)
+	if err != nil {
+		return false, err
+	}
+
+	for _, scopedList := range subnetworkList.Items {
+		for _, subnetwork := range scopedList.Subnetworks {
+			if strings.HasSuffix(subnetwork.Network, "/"+networkName) {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
 func (c Client) ValidateSafeToDelete(networkName string, envID string) error {
+	return c.validateSafeToDelete(func(networkInterface *compute.NetworkInterface) bool {
+		return strings.Contains(networkInterface.Network, networkName)
+	}, "network")
+}
+
+// ValidateSubnetsSafeToDelete only looks for VMs in subnetworks, for an
+// environment deployed into a network that bbl does not own.
+func (c Client) ValidateSubnetsSafeToDelete(networkName string, subnetworks []string, envID string) error {
+	return c.validateSafeToDelete(func(networkInterface *compute.NetworkInterface) bool {
+		if !strings.Contains(networkInterface.Network, networkName) {
+			return false
+		}
+		for _, subnetwork := range subnetworks {
+			if strings.HasSuffix(networkInterface.Subnetwork, "/"+subnetwork) {
+				return true
+			}
+		}
+		return false
+	}, fmt.Sprintf("subnetworks %s", strings.Join(subnetworks, ", ")))
+}
+
+func (c Client) validateSafeToDelete(inScope func(*compute.NetworkInterface) bool, scope string) error {
 	instanceList, err := c.listInstances()
 	if err != nil {
 		return err
@@ -66,10 +108,10 @@ func (c Client) ValidateSafeToDelete(networkName string, envID string) error {
 
 	var runningInstances []*compute.Instance
 	for _, instance := range instanceList.Items {
-		isInNetwork := c.isInNetwork(networkName, instance.NetworkInterfaces)
+		isInScope := c.isInScope(inScope, instance.NetworkInterfaces)
 		isBoshDirector := c.isBoshDirector(instance.Metadata)
 
-		if isInNetwork && !isBoshDirector {
+		if isInScope && !isBoshDirector {
 			runningInstances = append(runningInstances, instance)
 		}
 	}
@@ -94,13 +136,13 @@ func (c Client) ValidateSafeToDelete(networkName string, envID string) error {
 		}
 	}
 
-	return fmt.Errorf("bbl environment is not safe to delete; vms still exist in network:\n%s",
-		strings.Join(errorMessages, "\n"))
+	return fmt.Errorf("bbl environment is not safe to delete; vms still exist in %s:\n%s",
+		scope, strings.Join(errorMessages, "\n"))
 }
 
-func (c Client) isInNetwork(networkName string, networkInterfaces []*compute.NetworkInterface) bool {
+func (c Client) isInScope(inScope func(*compute.NetworkInterface) bool, networkInterfaces []*compute.NetworkInterface) bool {
 	for _, networkInterface := range networkInterfaces {
-		if strings.Contains(networkInterface.Network, networkName) {
+		if inScope(networkInterface) {
 			return true
 		}
 	}
@@ -198,4 +198,86 @@ var _ = Describe("Client", func() {
 			})
 		})
 	})
+
+	Describe("ValidateSubnetsSafeToDelete", func() {
+		BeforeEach(func() {
+			computeClient = &fakes.GCPComputeClient{}
+			client = gcp.NewClientWithInjectedComputeClient(computeClient, "some-project-id", "some-zone")
+
+			computeClient.ListInstancesCall.Returns.InstanceList = &compute.InstanceList{
+				Items: []*compute.Instance{
+					{
+						Name: "other-subnetwork-vm",
+						NetworkInterfaces: []*compute.NetworkInterface{{
+							Network:    "http://some-host/some-network",
+							Subnetwork: "http://some-host/some-other-subnetwork",
+						}},
+						Metadata: &compute.Metadata{},
+					},
+				},
+			}
+		})
+
+		It("ignores VMs in other subnetworks of the network", func() {
+			err := client.ValidateSubnetsSafeToDelete("some-network", []string{"some-env-id-subnet"}, "some-env-id")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when VMs exist in the given subnetworks", func() {
+			It("returns an error", func() {
+				computeClient.ListInstancesCall.Returns.InstanceList.Items = append(computeClient.ListInstancesCall.Returns.InstanceList.Items, &compute.Instance{
+					Name: "some-vm",
+					NetworkInterfaces: []*compute.NetworkInterface{{
+						Network:    "http://some-host/some-network",
+						Subnetwork: "http://some-host/some-env-id-subnet",
+					}},
+					Metadata: &compute.Metadata{},
+				})
+
+				err := client.ValidateSubnetsSafeToDelete("some-network", []string{"some-env-id-subnet"}, "some-env-id")
+				Expect(err).To(MatchError(`bbl environment is not safe to delete; vms still exist in subnetworks some-env-id-subnet:
+some-vm (not managed by bosh)`))
+			})
+		})
+	})
+
+	Describe("CheckSubnetExists", func() {
+		BeforeEach(func() {
+			computeClient = &fakes.GCPComputeClient{}
+			client = gcp.NewClientWithInjectedComputeClient(computeClient, "some-project-id", "some-zone")
+		})
+
+		It("looks for the subnetwork in the network", func() {
+			computeClient.GetSubnetworksCall.Returns.SubnetworkList = &compute.SubnetworkAggregatedList{
+				Items: map[string]compute.SubnetworksScopedList{
+					"regions/some-region": {
+						Subnetworks: []*compute.Subnetwork{{
+							Name:    "some-env-id-subnet",
+							Network: "http://some-host/some-network",
+						}},
+					},
+				},
+			}
+
+			exists, err := client.CheckSubnetExists("some-network", "some-env-id-subnet")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(exists).To(BeTrue())
+
+			Expect(computeClient.GetSubnetworksCall.Receives.Name).To(Equal("some-env-id-subnet"))
+			Expect(computeClient.GetSubnetworksCall.Receives.ProjectID).To(Equal("some-project-id"))
+
+			exists, err = client.CheckSubnetExists("some-other-network", "some-env-id-subnet")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(exists).To(BeFalse())
+		})
+
+		Context("when listing subnetworks fails", func() {
+			It("returns an error", func() {
+				computeClient.GetSubnetworksCall.Returns.Error = errors.New("fails to list subnetworks")
+
+				_, err := client.CheckSubnetExists("some-network", "some-env-id-subnet")
+				Expect(err).To(MatchError("fails to list subnetworks"))
+			})
+		})
+	})
 })
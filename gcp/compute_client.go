@@ -51,3 +51,8 @@ func (g gcpComputeClient) GetNetworks(name, projectID string) (*compute.NetworkL
 	networksListCall := g.service.Networks.List(projectID)
 	return networksListCall.Filter(fmt.Sprintf("name eq %s", name)).Do()
 }
+
+func (g gcpComputeClient) GetSubnetworks(name, projectID string) (*compute.SubnetworkAggregatedList, error) {
+	subnetworksListCall := g.service.Subnetworks.AggregatedList(projectID)
+	return subnetworksListCall.Filter(fmt.Sprintf("name eq %s", name)).Do()
+}
@@ -21,6 +21,7 @@ type envIDGenerator interface {
 
 type NetworkClient interface {
 	CheckExists(networkName string) (bool, error)
+	CheckSubnetExists(networkName, subnetName string) (bool, error)
 }
 
 func NewEnvIDManager(envIDGenerator envIDGenerator, networkClient NetworkClient) EnvIDManager {
@@ -35,7 +36,7 @@ func (e EnvIDManager) Sync(state storage.State, envID string) (storage.State, er
 		return state, nil
 	}
 
-	err := e.checkFastFail(state.IAAS, envID)
+	err := e.checkFastFail(state.IAAS, state.ExistingNetwork, envID)
 	if err != nil {
 		return storage.State{}, err
 	}
@@ -57,18 +58,18 @@ func (e EnvIDManager) Sync(state storage.State, envID string) (storage.State, er
 	return state, nil
 }
 
-func (e EnvIDManager) checkFastFail(iaas, envID string) error {
-	var networkName string
-	switch iaas {
-	case "aws":
-		networkName = envID + "-vpc"
-	case "azure":
-		return nil
-	case "gcp":
-		networkName = envID + "-network"
+// checkFastFail looks for the network bbl would create for envID or, when
+// deploying into an existing network, for the subnet it would create there.
+func (e EnvIDManager) checkFastFail(iaas, existingNetwork, envID string) error {
+	var (
+		exists bool
+		err    error
+	)
+	if existingNetwork == "" {
+		exists, err = e.networkClient.CheckExists(networkName(iaas, envID))
+	} else {
+		exists, err = e.networkClient.CheckSubnetExists(existingNetwork, subnetName(iaas, envID))
 	}
-
-	exists, err := e.networkClient.CheckExists(networkName)
 	if err != nil {
 		return err
 	}
@@ -80,6 +81,30 @@ func (e EnvIDManager) checkFastFail(iaas, envID string) error {
 	return nil
 }
 
+func networkName(iaas, envID string) string {
+	switch iaas {
+	case "aws":
+		return envID + "-vpc"
+	case "azure":
+		return envID + "-bosh"
+	case "gcp":
+		return envID + "-network"
+	}
+	return ""
+}
+
+func subnetName(iaas, envID string) string {
+	switch iaas {
+	case "aws":
+		return envID + "-bosh-subnet"
+	case "azure":
+		return envID + "-bosh-sn"
+	case "gcp":
+		return envID + "-subnet"
+	}
+	return ""
+}
+
 func (e EnvIDManager) validateName(envID string) error {
 	if envID == "" {
 		return nil
@@ -7,6 +7,7 @@ import (
 	"github.com/cloudfoundry/bosh-bootloader/helpers"
 	"github.com/cloudfoundry/bosh-bootloader/storage"
 	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 )
 
@@ -69,29 +70,63 @@ var _ = Describe("EnvIDManager", func() {
 				})
 			})
 
-			Context("for azure", func() {
-				PIt("fails if an environment with that name was already created", func() {
-					//The Azure client requires a Check Exists
-					//This should replace the test below with the same description
-					//once CheckExists is implemented.
+			Context("when deploying into an existing network", func() {
+				It("checks for the environment's subnet instead of its network", func() {
 					networkClient.CheckExistsCall.Returns.Exists = true
-					_, err := envIDManager.Sync(storage.State{
-						IAAS: "azure",
-					}, "existing-env")
+					state, err := envIDManager.Sync(storage.State{
+						IAAS:            "aws",
+						ExistingNetwork: "some-vpc",
+					}, "new-env")
 
-					Expect(networkClient.CheckExistsCall.CallCount).To(Equal(1))
-					Expect(networkClient.CheckExistsCall.Receives.Name).To(Equal("existing-env-bosh-vn"))
+					Expect(err).NotTo(HaveOccurred())
+					Expect(state.EnvID).To(Equal("new-env"))
+					Expect(networkClient.CheckExistsCall.CallCount).To(Equal(0))
+					Expect(networkClient.CheckSubnetExistsCall.CallCount).To(Equal(1))
+					Expect(networkClient.CheckSubnetExistsCall.Receives.NetworkName).To(Equal("some-vpc"))
+					Expect(networkClient.CheckSubnetExistsCall.Receives.SubnetName).To(Equal("new-env-bosh-subnet"))
+				})
 
-					Expect(err).To(MatchError("It looks like a bbl environment already exists with the name 'existing-env'. Please provide a different name."))
+				DescribeTable("fails if the environment's subnet already exists in the network",
+					func(iaas, existingNetwork, subnetName string) {
+						networkClient.CheckSubnetExistsCall.Returns.Exists = true
+						_, err := envIDManager.Sync(storage.State{
+							IAAS:            iaas,
+							ExistingNetwork: existingNetwork,
+						}, "existing-env")
+
+						Expect(networkClient.CheckSubnetExistsCall.Receives.NetworkName).To(Equal(existingNetwork))
+						Expect(networkClient.CheckSubnetExistsCall.Receives.SubnetName).To(Equal(subnetName))
+						Expect(err).To(MatchError("It looks like a bbl environment already exists with the name 'existing-env'. Please provide a different name."))
+					},
+					Entry("aws", "aws", "some-vpc", "existing-env-bosh-subnet"),
+					Entry("gcp", "gcp", "some-network", "existing-env-subnet"),
+					Entry("azure", "azure", "some-resource-group/some-vnet", "existing-env-bosh-sn"),
+				)
+
+				Context("when the subnet cannot be checked", func() {
+					It("returns an error", func() {
+						networkClient.CheckSubnetExistsCall.Returns.Error = errors.New("failed to list subnets")
+						_, err := envIDManager.Sync(storage.State{
+							IAAS:            "gcp",
+							ExistingNetwork: "some-network",
+						}, "existing-env")
+
+						Expect(err).To(MatchError("failed to list subnets"))
+					})
 				})
+			})
 
+			Context("for azure", func() {
 				It("fails if an environment with that name was already created", func() {
+					networkClient.CheckExistsCall.Returns.Exists = true
 					_, err := envIDManager.Sync(storage.State{
 						IAAS: "azure",
 					}, "existing-env")
 
-					Expect(err).NotTo(HaveOccurred())
-					Expect(networkClient.CheckExistsCall.CallCount).To(Equal(0))
+					Expect(networkClient.CheckExistsCall.CallCount).To(Equal(1))
+					Expect(networkClient.CheckExistsCall.Receives.Name).To(Equal("existing-env-bosh"))
+
+					Expect(err).To(MatchError("It looks like a bbl environment already exists with the name 'existing-env'. Please provide a different name."))
 				})
 			})
 		})
@@ -0,0 +1,13 @@
+package runtimeconfig_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestRuntimeConfig(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "runtimeconfig")
+}
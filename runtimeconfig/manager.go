@@ -0,0 +1,130 @@
+package runtimeconfig
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/cloudfoundry/bosh-bootloader/bosh"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+)
+
+type Manager struct {
+	logger             logger
+	boshClientProvider boshClientProvider
+}
+
+type logger interface {
+	Step(string, ...interface{})
+}
+
+type boshClientProvider interface {
+	Client(jumpbox storage.Jumpbox, directorAddress, directorUsername, directorPassword, caCert string) (bosh.Client, error)
+}
+
+type config struct {
+	configType string
+	name       string
+	content    string
+}
+
+func NewManager(logger logger, boshClientProvider boshClientProvider) Manager {
+	return Manager{
+		logger:             logger,
+		boshClientProvider: boshClientProvider,
+	}
+}
+
+// Generate returns the runtime configs and CPI config Update uploads, one
+// YAML document per config.
+func (m Manager) Generate(state storage.State) string {
+	var documents []string
+	for _, c := range configs(state) {
+		documents = append(documents, fmt.Sprintf("--- # %s config %s\n%s", c.configType, c.name, strings.TrimSuffix(c.content, "\n")))
+	}
+
+	return strings.Join(documents, "\n")
+}
+
+// Update uploads the runtime configs and CPI config saved in state to the
+// director.
+func (m Manager) Update(state storage.State) error {
+	configs := configs(state)
+	if len(configs) == 0 {
+		return nil
+	}
+
+	boshClient, err := m.boshClientProvider.Client(state.Jumpbox, state.BOSH.DirectorAddress, state.BOSH.DirectorUsername, state.BOSH.DirectorPassword, state.BOSH.DirectorSSLCA)
+	if err != nil {
+		return err // not tested
+	}
+
+	for _, c := range configs {
+		m.logger.Step("applying %s config %s", c.configType, c.name)
+		err = boshClient.UpdateConfig(c.configType, c.name, []byte(c.content))
+		if err != nil {
+			return fmt.Errorf("%s config %s: %s", c.configType, c.name, err)
+		}
+	}
+
+	return nil
+}
+
+// Delete deletes the runtime configs uploaded from the named files, and the CPI
+// config when cpiConfig is true, from the director.
+func (m Manager) Delete(state storage.State, runtimeConfigs []string, cpiConfig bool) error {
+	var configs []config
+	for _, file := range runtimeConfigs {
+		configs = append(configs, config{configType: "runtime", name: configName(file)})
+	}
+
+	if cpiConfig {
+		configs = append(configs, config{configType: "cpi", name: "default"})
+	}
+
+	if len(configs) == 0 {
+		return nil
+	}
+
+	boshClient, err := m.boshClientProvider.Client(state.Jumpbox, state.BOSH.DirectorAddress, state.BOSH.DirectorUsername, state.BOSH.DirectorPassword, state.BOSH.DirectorSSLCA)
+	if err != nil {
+		return err // not tested
+	}
+
+	for _, c := range configs {
+		m.logger.Step("deleting %s config %s", c.configType, c.name)
+		err = boshClient.DeleteConfig(c.configType, c.name)
+		if err != nil {
+			return fmt.Errorf("%s config %s: %s", c.configType, c.name, err)
+		}
+	}
+
+	return nil
+}
+
+// configs names each runtime config after its file name without the
+// extension, so dns.yml is uploaded as the runtime config "dns".
+func configs(state storage.State) []config {
+	var configs []config
+	for _, file := range state.RuntimeConfigs {
+		configs = append(configs, config{
+			configType: "runtime",
+			name:       configName(file.Name),
+			content:    file.Contents,
+		})
+	}
+
+	if state.CPIConfig != "" {
+		configs = append(configs, config{
+			configType: "cpi",
+			name:       "default",
+			content:    state.CPIConfig,
+		})
+	}
+
+	return configs
+}
+
+func configName(fileName string) string {
+	return strings.TrimSuffix(fileName, filepath.Ext(fileName))
+}
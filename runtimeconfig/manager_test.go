@@ -0,0 +1,138 @@
+package runtimeconfig_test
+
+import (
+	"errors"
+
+	"github.com/cloudfoundry/bosh-bootloader/fakes"
+	"github.com/cloudfoundry/bosh-bootloader/runtimeconfig"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Manager", func() {
+	var (
+		logger             *fakes.Logger
+		boshClient         *fakes.BOSHClient
+		boshClientProvider *fakes.BOSHClientProvider
+		manager            runtimeconfig.Manager
+		state              storage.State
+	)
+
+	BeforeEach(func() {
+		logger = &fakes.Logger{}
+		boshClient = &fakes.BOSHClient{}
+		boshClientProvider = &fakes.BOSHClientProvider{}
+		boshClientProvider.ClientCall.Returns.Client = boshClient
+
+		manager = runtimeconfig.NewManager(logger, boshClientProvider)
+
+		state = storage.State{
+			Jumpbox: storage.Jumpbox{URL: "some-jumpbox-url"},
+			BOSH: storage.BOSH{
+				DirectorAddress:  "some-director-address",
+				DirectorUsername: "some-director-username",
+				DirectorPassword: "some-director-password",
+				DirectorSSLCA:    "some-director-ssl-ca",
+			},
+			RuntimeConfigs: []storage.UserFile{
+				{Name: "dns.yml", Contents: "addons:\n- name: bosh-dns\n"},
+				{Name: "os-conf.yml", Contents: "addons:\n- name: os-conf\n"},
+			},
+			CPIConfig: "cpis: []\n",
+		}
+	})
+
+	Describe("Generate", func() {
+		It("returns each config as a YAML document", func() {
+			Expect(manager.Generate(state)).To(Equal(`--- # runtime config dns
+addons:
+- name: bosh-dns
+--- # runtime config os-conf
+addons:
+- name: os-conf
+--- # cpi config default
+cpis: []`))
+		})
+
+		It("returns nothing when there are no configs", func() {
+			Expect(manager.Generate(storage.State{})).To(Equal(""))
+		})
+	})
+
+	Describe("Update", func() {
+		It("uploads the runtime configs and the cpi config", func() {
+			err := manager.Update(state)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(boshClientProvider.ClientCall.Receives.Jumpbox).To(Equal(storage.Jumpbox{URL: "some-jumpbox-url"}))
+			Expect(boshClientProvider.ClientCall.Receives.DirectorAddress).To(Equal("some-director-address"))
+			Expect(boshClientProvider.ClientCall.Receives.DirectorUsername).To(Equal("some-director-username"))
+			Expect(boshClientProvider.ClientCall.Receives.DirectorPassword).To(Equal("some-director-password"))
+			Expect(boshClientProvider.ClientCall.Receives.DirectorCACert).To(Equal("some-director-ssl-ca"))
+
+			Expect(boshClient.UpdateConfigCall.Receives).To(Equal([]fakes.UpdateConfigCallReceive{
+				{Type: "runtime", Name: "dns", Content: []byte("addons:\n- name: bosh-dns\n")},
+				{Type: "runtime", Name: "os-conf", Content: []byte("addons:\n- name: os-conf\n")},
+				{Type: "cpi", Name: "default", Content: []byte("cpis: []\n")},
+			}))
+
+			Expect(logger.StepCall.Messages).To(Equal([]string{
+				"applying runtime config dns",
+				"applying runtime config os-conf",
+				"applying cpi config default",
+			}))
+		})
+
+		It("does not connect to the director when there are no configs", func() {
+			err := manager.Update(storage.State{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(boshClientProvider.ClientCall.CallCount).To(Equal(0))
+		})
+
+		Context("when a config fails to upload", func() {
+			It("returns an error", func() {
+				boshClient.UpdateConfigCall.Returns.Error = errors.New("papaya")
+
+				err := manager.Update(state)
+				Expect(err).To(MatchError("runtime config dns: papaya"))
+			})
+		})
+	})
+
+	Describe("Delete", func() {
+		It("deletes the named runtime configs and the cpi config from the director", func() {
+			err := manager.Delete(state, []string{"os-conf.yml"}, true)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(boshClientProvider.ClientCall.Receives.DirectorAddress).To(Equal("some-director-address"))
+			Expect(boshClient.DeleteConfigCall.Receives).To(Equal([]fakes.DeleteConfigCallReceive{
+				{Type: "runtime", Name: "os-conf"},
+				{Type: "cpi", Name: "default"},
+			}))
+
+			Expect(logger.StepCall.Messages).To(Equal([]string{
+				"deleting runtime config os-conf",
+				"deleting cpi config default",
+			}))
+		})
+
+		It("does not connect to the director when nothing was removed", func() {
+			err := manager.Delete(state, nil, false)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(boshClientProvider.ClientCall.CallCount).To(Equal(0))
+		})
+
+		Context("when a config fails to delete", func() {
+			It("returns an error", func() {
+				boshClient.DeleteConfigCall.Returns.Error = errors.New("kiwi")
+
+				err := manager.Delete(state, []string{"os-conf.yml"}, false)
+				Expect(err).To(MatchError("runtime config os-conf: kiwi"))
+			})
+		})
+	})
+})
@@ -3,5 +3,6 @@ package storage
 type AWS struct {
 	AccessKeyID     string `json:"accessKeyId,omitempty"`
 	SecretAccessKey string `json:"secretAccessKey,omitempty"`
+	SessionToken    string `json:"sessionToken,omitempty"`
 	Region          string `json:"region"`
 }
@@ -1,8 +1,8 @@
 package storage
 
 type Azure struct {
-	ClientID       string `json:"clientId"`
-	ClientSecret   string `json:"clientSecret"`
+	ClientID       string `json:"clientId,omitempty"`
+	ClientSecret   string `json:"clientSecret,omitempty"`
 	Location       string `json:"location"`
 	SubscriptionID string `json:"subscriptionId"`
 	TenantID       string `json:"tenantId"`
@@ -0,0 +1,127 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"syscall"
+	"time"
+)
+
+var (
+	StateNotFound error = errors.New("bbl state not found")
+	LeaseNotFound error = errors.New("bbl state is not locked")
+
+	hostname     = os.Hostname
+	processAlive = isProcessAlive
+)
+
+type Backend interface {
+	Location() string
+	ReadState() ([]byte, error)
+	WriteState(contents []byte) error
+	DeleteState() error
+	BackupState(name string) error
+	AcquireLease(lease Lease) error
+	ReleaseLease(lease Lease) error
+	ReadLease() (Lease, error)
+}
+
+type Lease struct {
+	ID      string    `json:"id"`
+	Holder  string    `json:"holder"`
+	Expires time.Time `json:"expires"`
+	PID     int       `json:"pid,omitempty"`
+	Host    string    `json:"host,omitempty"`
+	Command string    `json:"command,omitempty"`
+}
+
+func (l Lease) Expired(now time.Time) bool {
+	return now.After(l.Expires)
+}
+
+// Stale reports whether the lease can be taken over: either it was not
+// renewed in time, or it was taken on this host by a process that has
+// since exited.
+func (l Lease) Stale(now time.Time) bool {
+	if l.Expired(now) {
+		return true
+	}
+
+	if l.PID == 0 || l.Host == "" {
+		return false
+	}
+
+	currentHost, err := hostname()
+	if err != nil || currentHost != l.Host {
+		return false
+	}
+
+	return !processAlive(l.PID)
+}
+
+func isProcessAlive(pid int) bool {
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+
+	err = process.Signal(syscall.Signal(0))
+	return err == nil || os.IsPermission(err)
+}
+
+type LeaseHeldError struct {
+	Lease Lease
+}
+
+func (e LeaseHeldError) Error() string {
+	holder := e.Lease.Holder
+	if e.Lease.Host != "" {
+		holder = fmt.Sprintf("%s (bbl %s, pid %d on %s)", holder, e.Lease.Command, e.Lease.PID, e.Lease.Host)
+	}
+
+	return fmt.Sprintf("bbl state is locked by %s until %s. If that bbl is no longer running, run `bbl force-unlock`.", holder, e.Lease.Expires.Format(time.RFC3339))
+}
+
+type BackendConfig struct {
+	StateDir        string
+	Backend         string
+	AccessKeyID     string
+	SecretAccessKey string
+	Region          string
+}
+
+func NewBackend(config BackendConfig) (Backend, error) {
+	if config.Backend == "" || config.Backend == "local" {
+		return NewLocalBackend(config.StateDir), nil
+	}
+
+	backendURL, err := url.Parse(config.Backend)
+	if err != nil {
+		return nil, fmt.Errorf("Parse state backend: %s", err)
+	}
+
+	region := config.Region
+	if region == "" {
+		region = "us-east-1"
+	}
+
+	switch backendURL.Scheme {
+	case "s3":
+		endpoint := "s3.amazonaws.com"
+		if region != "us-east-1" {
+			endpoint = fmt.Sprintf("s3.%s.amazonaws.com", region)
+		}
+		backendURL = &url.URL{
+			Scheme: "https",
+			Host:   endpoint,
+			Path:   "/" + backendURL.Host + backendURL.Path,
+		}
+	case "http", "https":
+	default:
+		return nil, fmt.Errorf("Unknown state backend %q: must be \"local\" or an s3:// or http(s):// object store URL", config.Backend)
+	}
+
+	return NewObjectStoreBackend(backendURL, config.AccessKeyID, config.SecretAccessKey, region), nil
+}
@@ -0,0 +1,46 @@
+package storage_test
+
+import (
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NewBackend", func() {
+	It("returns a local backend by default", func() {
+		backend, err := storage.NewBackend(storage.BackendConfig{StateDir: "some-state-dir"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(backend).To(Equal(storage.NewLocalBackend("some-state-dir")))
+	})
+
+	It("returns a local backend when local is requested", func() {
+		backend, err := storage.NewBackend(storage.BackendConfig{StateDir: "some-state-dir", Backend: "local"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(backend).To(Equal(storage.NewLocalBackend("some-state-dir")))
+	})
+
+	It("returns an object store backend for http urls", func() {
+		backend, err := storage.NewBackend(storage.BackendConfig{Backend: "https://some-object-store/some-bucket/some-env"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(backend).To(BeAssignableToTypeOf(storage.ObjectStoreBackend{}))
+		Expect(backend.Location()).To(Equal("https://some-object-store/some-bucket/some-env"))
+	})
+
+	It("expands s3 urls to the regional s3 endpoint", func() {
+		backend, err := storage.NewBackend(storage.BackendConfig{Backend: "s3://some-bucket/some-env", Region: "eu-west-1"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(backend.Location()).To(Equal("https://s3.eu-west-1.amazonaws.com/some-bucket/some-env"))
+
+		backend, err = storage.NewBackend(storage.BackendConfig{Backend: "s3://some-bucket/some-env"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(backend.Location()).To(Equal("https://s3.amazonaws.com/some-bucket/some-env"))
+	})
+
+	Context("when the backend is not recognized", func() {
+		It("returns an error", func() {
+			_, err := storage.NewBackend(storage.BackendConfig{Backend: "ftp://some-host"})
+			Expect(err).To(MatchError(`Unknown state backend "ftp://some-host": must be "local" or an s3:// or http(s):// object store URL`))
+		})
+	})
+})
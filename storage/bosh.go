@@ -13,7 +13,19 @@ type BOSH struct {
 	Variables              string                 `json:"variables"`
 	State                  map[string]interface{} `json:"state"`
 	Manifest               string                 `json:"manifest"`
-	UserOpsFile            string                 `json:"userOpsFile"`
+	UserOpsFiles           []UserFile             `json:"userOpsFiles,omitempty"`
+	UserVarsFiles          []UserFile             `json:"userVarsFiles,omitempty"`
+	UserVars               []string               `json:"userVars,omitempty"`
+	DeploymentSource       string                 `json:"deploymentSource,omitempty"`
+	Fingerprint            string                 `json:"fingerprint,omitempty"`
+}
+
+// UserFile is an ops file or vars file given to bbl up for the director or
+// jumpbox, kept in the state so that it is applied again on every up and
+// destroy.
+type UserFile struct {
+	Name     string `json:"name"`
+	Contents string `json:"contents"`
 }
 
 func (b BOSH) IsEmpty() bool {
@@ -0,0 +1,269 @@
+package storage
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+const (
+	encryptionCipher     = "aes-256-gcm"
+	encryptionKDF        = "pbkdf2-sha256"
+	encryptionIterations = 100000
+	encryptionKeyLength  = 32
+	encryptionSaltLength = 16
+
+	// maxEncryptionIterations bounds the PBKDF2 cost read from a state file,
+	// so a crafted envelope cannot make every bbl command hang.
+	maxEncryptionIterations = 10 * encryptionIterations
+)
+
+var (
+	randReader io.Reader = rand.Reader
+
+	StateEncrypted error = errors.New("bbl-state.json is encrypted: provide --state-encryption-key or --state-encryption-key-file to read it")
+)
+
+// snapshotRewriter is implemented by backends that keep copies of earlier
+// states, such as HistoryBackend.
+type snapshotRewriter interface {
+	RewriteSnapshots(rewrite func(contents []byte) ([]byte, error)) error
+}
+
+type encryptedState struct {
+	Encryption encryptionParameters `json:"encryption"`
+	Ciphertext string               `json:"ciphertext"`
+}
+
+type encryptionParameters struct {
+	Cipher     string `json:"cipher"`
+	KDF        string `json:"kdf"`
+	Iterations int    `json:"iterations"`
+	Salt       string `json:"salt"`
+	Nonce      string `json:"nonce"`
+}
+
+// EncryptedBackend encrypts bbl-state.json before handing it to the wrapped
+// backend. Every write uses a fresh salt and nonce, so the passphrase is the
+// only secret needed to read the state back.
+type EncryptedBackend struct {
+	backend    Backend
+	passphrase []byte
+}
+
+func NewEncryptedBackend(backend Backend, passphrase string) EncryptedBackend {
+	return EncryptedBackend{
+		backend:    backend,
+		passphrase: []byte(passphrase),
+	}
+}
+
+func (e EncryptedBackend) Location() string {
+	return e.backend.Location()
+}
+
+func (e EncryptedBackend) ReadState() ([]byte, error) {
+	contents, err := e.backend.ReadState()
+	if err != nil {
+		return nil, err
+	}
+
+	if !isEncrypted(contents) {
+		return contents, nil
+	}
+
+	return e.decrypt(contents)
+}
+
+func (e EncryptedBackend) WriteState(contents []byte) error {
+	ciphertext, err := e.encrypt(contents)
+	if err != nil {
+		return err
+	}
+
+	return e.backend.WriteState(ciphertext)
+}
+
+func (e EncryptedBackend) DeleteState() error {
+	return e.backend.DeleteState()
+}
+
+func (e EncryptedBackend) BackupState(name string) error {
+	return e.backend.BackupState(name)
+}
+
+func (e EncryptedBackend) AcquireLease(lease Lease) error {
+	return e.backend.AcquireLease(lease)
+}
+
+func (e EncryptedBackend) ReleaseLease(lease Lease) error {
+	return e.backend.ReleaseLease(lease)
+}
+
+func (e EncryptedBackend) ReadLease() (Lease, error) {
+	return e.backend.ReadLease()
+}
+
+func (e EncryptedBackend) EncryptState() error {
+	contents, err := e.ReadState()
+	if err != nil {
+		return err
+	}
+
+	err = e.WriteState(contents)
+	if err != nil {
+		return err
+	}
+
+	history, ok := e.backend.(snapshotRewriter)
+	if !ok {
+		return nil
+	}
+
+	err = history.RewriteSnapshots(func(contents []byte) ([]byte, error) {
+		if isEncrypted(contents) {
+			return contents, nil
+		}
+		return e.encrypt(contents)
+	})
+	if err != nil {
+		return fmt.Errorf("Encrypt state snapshots: %s", err)
+	}
+
+	return nil
+}
+
+func (e EncryptedBackend) DecryptState() error {
+	contents, err := e.ReadState()
+	if err != nil {
+		return err
+	}
+
+	err = e.backend.WriteState(contents)
+	if err != nil {
+		return err
+	}
+
+	history, ok := e.backend.(snapshotRewriter)
+	if !ok {
+		return nil
+	}
+
+	err = history.RewriteSnapshots(func(contents []byte) ([]byte, error) {
+		if !isEncrypted(contents) {
+			return contents, nil
+		}
+		return e.decrypt(contents)
+	})
+	if err != nil {
+		return fmt.Errorf("Decrypt state snapshots: %s", err)
+	}
+
+	return nil
+}
+
+func (e EncryptedBackend) encrypt(plaintext []byte) ([]byte, error) {
+	salt := make([]byte, encryptionSaltLength)
+	_, err := io.ReadFull(randReader, salt)
+	if err != nil {
+		return nil, fmt.Errorf("Generate salt: %s", err)
+	}
+
+	aead, err := e.aead(salt, encryptionIterations)
+	if err != nil {
+		return nil, err //not tested
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	_, err = io.ReadFull(randReader, nonce)
+	if err != nil {
+		return nil, fmt.Errorf("Generate nonce: %s", err)
+	}
+
+	return json.MarshalIndent(encryptedState{
+		Encryption: encryptionParameters{
+			Cipher:     encryptionCipher,
+			KDF:        encryptionKDF,
+			Iterations: encryptionIterations,
+			Salt:       base64.StdEncoding.EncodeToString(salt),
+			Nonce:      base64.StdEncoding.EncodeToString(nonce),
+		},
+		Ciphertext: base64.StdEncoding.EncodeToString(aead.Seal(nil, nonce, plaintext, nil)),
+	}, "", "\t")
+}
+
+func (e EncryptedBackend) decrypt(contents []byte) ([]byte, error) {
+	var state encryptedState
+	err := json.Unmarshal(contents, &state)
+	if err != nil {
+		return nil, err //not tested
+	}
+
+	if state.Encryption.Cipher != encryptionCipher || state.Encryption.KDF != encryptionKDF {
+		return nil, fmt.Errorf("Unsupported state encryption %s with %s", state.Encryption.Cipher, state.Encryption.KDF)
+	}
+
+	if state.Encryption.Iterations < 1 || state.Encryption.Iterations > maxEncryptionIterations {
+		return nil, fmt.Errorf("Unsupported state encryption iterations %d: must be between 1 and %d", state.Encryption.Iterations, maxEncryptionIterations)
+	}
+
+	salt, err := base64.StdEncoding.DecodeString(state.Encryption.Salt)
+	if err != nil {
+		return nil, fmt.Errorf("Decode salt: %s", err)
+	}
+
+	nonce, err := base64.StdEncoding.DecodeString(state.Encryption.Nonce)
+	if err != nil {
+		return nil, fmt.Errorf("Decode nonce: %s", err)
+	}
+
+	ciphertext, err := base64.StdEncoding.DecodeString(state.Ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("Decode ciphertext: %s", err)
+	}
+
+	aead, err := e.aead(salt, state.Encryption.Iterations)
+	if err != nil {
+		return nil, err //not tested
+	}
+
+	if len(nonce) != aead.NonceSize() {
+		return nil, errors.New("Decrypt bbl state: invalid nonce")
+	}
+
+	plaintext, err := aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, errors.New("Decrypt bbl state: the state encryption key is incorrect or the state has been modified")
+	}
+
+	return plaintext, nil
+}
+
+func (e EncryptedBackend) aead(salt []byte, iterations int) (cipher.AEAD, error) {
+	key := pbkdf2.Key(e.passphrase, salt, iterations, encryptionKeyLength, sha256.New)
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+func isEncrypted(contents []byte) bool {
+	var state encryptedState
+	err := json.Unmarshal(contents, &state)
+	if err != nil {
+		return false
+	}
+
+	return state.Ciphertext != "" && state.Encryption.Cipher != ""
+}
@@ -0,0 +1,198 @@
+package storage_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("EncryptedBackend", func() {
+	var (
+		tempDir      string
+		localBackend storage.LocalBackend
+		backend      storage.EncryptedBackend
+	)
+
+	BeforeEach(func() {
+		var err error
+		tempDir, err = ioutil.TempDir("", "")
+		Expect(err).NotTo(HaveOccurred())
+
+		localBackend = storage.NewLocalBackend(tempDir)
+		backend = storage.NewEncryptedBackend(localBackend, "some-passphrase")
+	})
+
+	It("returns the location of the wrapped backend", func() {
+		Expect(backend.Location()).To(Equal(tempDir))
+	})
+
+	It("encrypts the state on write and decrypts it on read", func() {
+		err := backend.WriteState([]byte(`{"bosh": {"directorPassword": "some-password"}}`))
+		Expect(err).NotTo(HaveOccurred())
+
+		contents, err := ioutil.ReadFile(filepath.Join(tempDir, "bbl-state.json"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(contents)).NotTo(ContainSubstring("some-password"))
+
+		var envelope map[string]interface{}
+		err = json.Unmarshal(contents, &envelope)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(envelope["encryption"]).To(HaveKeyWithValue("cipher", "aes-256-gcm"))
+		Expect(envelope["encryption"]).To(HaveKeyWithValue("kdf", "pbkdf2-sha256"))
+		Expect(envelope).To(HaveKey("ciphertext"))
+
+		contents, err = backend.ReadState()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(contents).To(MatchJSON(`{"bosh": {"directorPassword": "some-password"}}`))
+	})
+
+	It("reads a state that has not been encrypted yet", func() {
+		err := localBackend.WriteState([]byte(`{"version": 12}`))
+		Expect(err).NotTo(HaveOccurred())
+
+		contents, err := backend.ReadState()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(contents).To(MatchJSON(`{"version": 12}`))
+	})
+
+	Describe("EncryptState", func() {
+		It("encrypts an existing plaintext state", func() {
+			err := localBackend.WriteState([]byte(`{"version": 12}`))
+			Expect(err).NotTo(HaveOccurred())
+
+			err = backend.EncryptState()
+			Expect(err).NotTo(HaveOccurred())
+
+			contents, err := localBackend.ReadState()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(contents)).To(ContainSubstring("ciphertext"))
+		})
+
+		Context("when the wrapped backend keeps state history", func() {
+			It("encrypts the plaintext snapshots", func() {
+				historyDir := filepath.Join(tempDir, storage.HistoryDirName)
+				historyBackend := storage.NewHistoryBackend(localBackend, historyDir, 10)
+
+				err := historyBackend.WriteState([]byte(`{"bosh": {"directorPassword": "some-password"}}`))
+				Expect(err).NotTo(HaveOccurred())
+
+				backend = storage.NewEncryptedBackend(historyBackend, "some-passphrase")
+				err = backend.EncryptState()
+				Expect(err).NotTo(HaveOccurred())
+
+				snapshots, err := historyBackend.Snapshots()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(snapshots).NotTo(BeEmpty())
+
+				for _, snapshot := range snapshots {
+					contents, err := ioutil.ReadFile(filepath.Join(historyDir, "bbl-state-"+snapshot.ID+".json"))
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(contents)).NotTo(ContainSubstring("some-password"))
+					Expect(string(contents)).To(ContainSubstring("ciphertext"))
+				}
+
+				err = historyBackend.RestoreSnapshot(snapshots[0].ID)
+				Expect(err).NotTo(HaveOccurred())
+
+				contents, err := backend.ReadState()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(contents).To(MatchJSON(`{"bosh": {"directorPassword": "some-password"}}`))
+			})
+		})
+	})
+
+	Describe("DecryptState", func() {
+		It("writes the state back in plaintext", func() {
+			err := backend.WriteState([]byte(`{"version": 12}`))
+			Expect(err).NotTo(HaveOccurred())
+
+			err = backend.DecryptState()
+			Expect(err).NotTo(HaveOccurred())
+
+			contents, err := localBackend.ReadState()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(contents).To(MatchJSON(`{"version": 12}`))
+		})
+
+		Context("when the wrapped backend keeps state history", func() {
+			It("decrypts the encrypted snapshots", func() {
+				historyDir := filepath.Join(tempDir, storage.HistoryDirName)
+				historyBackend := storage.NewHistoryBackend(localBackend, historyDir, 10)
+				backend = storage.NewEncryptedBackend(historyBackend, "some-passphrase")
+
+				err := backend.WriteState([]byte(`{"bosh": {"directorPassword": "some-password"}}`))
+				Expect(err).NotTo(HaveOccurred())
+
+				err = backend.DecryptState()
+				Expect(err).NotTo(HaveOccurred())
+
+				snapshots, err := historyBackend.Snapshots()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(snapshots).NotTo(BeEmpty())
+
+				for _, snapshot := range snapshots {
+					contents, err := ioutil.ReadFile(filepath.Join(historyDir, "bbl-state-"+snapshot.ID+".json"))
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(contents)).NotTo(ContainSubstring("ciphertext"))
+				}
+
+				err = historyBackend.RestoreSnapshot(snapshots[0].ID)
+				Expect(err).NotTo(HaveOccurred())
+
+				contents, err := localBackend.ReadState()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(contents).To(MatchJSON(`{"bosh": {"directorPassword": "some-password"}}`))
+			})
+		})
+	})
+
+	Context("when the passphrase is wrong", func() {
+		It("returns an error", func() {
+			err := backend.WriteState([]byte(`{"version": 12}`))
+			Expect(err).NotTo(HaveOccurred())
+
+			backend = storage.NewEncryptedBackend(localBackend, "some-other-passphrase")
+			_, err = backend.ReadState()
+			Expect(err).To(MatchError("Decrypt bbl state: the state encryption key is incorrect or the state has been modified"))
+		})
+	})
+
+	Context("when the state asks for too many iterations", func() {
+		It("returns an error without deriving the key", func() {
+			err := backend.WriteState([]byte(`{"version": 12}`))
+			Expect(err).NotTo(HaveOccurred())
+
+			contents, err := localBackend.ReadState()
+			Expect(err).NotTo(HaveOccurred())
+
+			var envelope map[string]interface{}
+			err = json.Unmarshal(contents, &envelope)
+			Expect(err).NotTo(HaveOccurred())
+			envelope["encryption"].(map[string]interface{})["iterations"] = 1000000000
+
+			contents, err = json.Marshal(envelope)
+			Expect(err).NotTo(HaveOccurred())
+			err = localBackend.WriteState(contents)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = backend.ReadState()
+			Expect(err).To(MatchError("Unsupported state encryption iterations 1000000000: must be between 1 and 1000000"))
+		})
+	})
+
+	Context("when the wrapped backend fails to read", func() {
+		It("returns the error", func() {
+			err := os.RemoveAll(tempDir)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = backend.ReadState()
+			Expect(err).To(MatchError(ContainSubstring("no such file or directory")))
+		})
+	})
+})
@@ -2,6 +2,8 @@ package storage
 
 import (
 	"encoding/json"
+	"os"
+	"time"
 
 	uuid "github.com/nu7hatch/gouuid"
 )
@@ -21,3 +23,27 @@ func SetUUIDNewV4(f func() (uuid *uuid.UUID, err error)) {
 func ResetUUIDNewV4() {
 	uuidNewV4 = uuid.NewV4
 }
+
+func SetTimeNow(f func() time.Time) {
+	timeNow = f
+}
+
+func ResetTimeNow() {
+	timeNow = time.Now
+}
+
+func SetHostname(f func() (string, error)) {
+	hostname = f
+}
+
+func ResetHostname() {
+	hostname = os.Hostname
+}
+
+func SetProcessAlive(f func(pid int) bool) {
+	processAlive = f
+}
+
+func ResetProcessAlive() {
+	processAlive = isProcessAlive
+}
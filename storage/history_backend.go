@@ -0,0 +1,248 @@
+package storage
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultHistorySize = 10
+
+	// HistoryDirName is kept outside of .bbl so that snapshots survive
+	// `bbl destroy`, which removes .bbl along with the state.
+	HistoryDirName = "state-history"
+
+	snapshotPrefix   = "bbl-state-"
+	snapshotSuffix   = ".json"
+	snapshotIDFormat = "20060102T150405.000000000Z"
+)
+
+type Snapshot struct {
+	ID      string
+	Created time.Time
+	Size    int64
+}
+
+// HistoryBackend keeps a rotating set of snapshots of every state written to
+// the wrapped backend. Snapshots live in a local directory and hold exactly
+// the bytes handed to the wrapped backend, so when it sits underneath an
+// EncryptedBackend the snapshots are encrypted too.
+type HistoryBackend struct {
+	backend Backend
+	dir     string
+	size    int
+}
+
+func NewHistoryBackend(backend Backend, dir string, size int) HistoryBackend {
+	return HistoryBackend{
+		backend: backend,
+		dir:     dir,
+		size:    size,
+	}
+}
+
+func (h HistoryBackend) Location() string {
+	return h.backend.Location()
+}
+
+func (h HistoryBackend) ReadState() ([]byte, error) {
+	return h.backend.ReadState()
+}
+
+func (h HistoryBackend) WriteState(contents []byte) error {
+	if h.size > 0 {
+		err := h.saveExistingState()
+		if err != nil {
+			return err
+		}
+	}
+
+	err := h.backend.WriteState(contents)
+	if err != nil {
+		return err
+	}
+
+	if h.size <= 0 {
+		return nil
+	}
+
+	return h.saveSnapshot(contents)
+}
+
+func (h HistoryBackend) DeleteState() error {
+	return h.backend.DeleteState()
+}
+
+func (h HistoryBackend) BackupState(name string) error {
+	return h.backend.BackupState(name)
+}
+
+func (h HistoryBackend) AcquireLease(lease Lease) error {
+	return h.backend.AcquireLease(lease)
+}
+
+func (h HistoryBackend) ReleaseLease(lease Lease) error {
+	return h.backend.ReleaseLease(lease)
+}
+
+func (h HistoryBackend) ReadLease() (Lease, error) {
+	return h.backend.ReadLease()
+}
+
+func (h HistoryBackend) Snapshots() ([]Snapshot, error) {
+	files, err := ioutil.ReadDir(h.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []Snapshot{}, nil
+		}
+		return nil, err
+	}
+
+	snapshots := []Snapshot{}
+	for _, file := range files {
+		name := file.Name()
+		if file.IsDir() || !strings.HasPrefix(name, snapshotPrefix) || !strings.HasSuffix(name, snapshotSuffix) {
+			continue
+		}
+
+		id := strings.TrimSuffix(strings.TrimPrefix(name, snapshotPrefix), snapshotSuffix)
+		created, err := time.Parse(snapshotIDFormat, id)
+		if err != nil {
+			continue
+		}
+
+		snapshots = append(snapshots, Snapshot{
+			ID:      id,
+			Created: created,
+			Size:    file.Size(),
+		})
+	}
+
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].ID < snapshots[j].ID
+	})
+
+	return snapshots, nil
+}
+
+func (h HistoryBackend) RestoreSnapshot(id string) error {
+	_, err := time.Parse(snapshotIDFormat, id)
+	if err != nil {
+		return fmt.Errorf("Invalid state snapshot ID %q; run `bbl state-history` to list them", id)
+	}
+
+	contents, err := ioutil.ReadFile(h.snapshotPath(id))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("State snapshot %q not found", id)
+		}
+		return err
+	}
+
+	return h.WriteState(contents)
+}
+
+// RewriteSnapshots replaces the contents of every snapshot with the result of
+// rewrite. Snapshots that rewrite returns unchanged are left untouched.
+func (h HistoryBackend) RewriteSnapshots(rewrite func(contents []byte) ([]byte, error)) error {
+	snapshots, err := h.Snapshots()
+	if err != nil {
+		return err //not tested
+	}
+
+	for _, snapshot := range snapshots {
+		path := h.snapshotPath(snapshot.ID)
+		contents, err := ioutil.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("Read state snapshot: %s", err) //not tested
+		}
+
+		rewritten, err := rewrite(contents)
+		if err != nil {
+			return err
+		}
+
+		if string(rewritten) == string(contents) {
+			continue
+		}
+
+		err = writeFileAtomically(path, rewritten, OS_READ_WRITE_MODE)
+		if err != nil {
+			return fmt.Errorf("Write state snapshot: %s", err) //not tested
+		}
+	}
+
+	return nil
+}
+
+// saveExistingState snapshots the state that was written before history was
+// kept, so that the first write can still be undone.
+func (h HistoryBackend) saveExistingState() error {
+	snapshots, err := h.Snapshots()
+	if err != nil {
+		return err //not tested
+	}
+
+	if len(snapshots) > 0 {
+		return nil
+	}
+
+	contents, err := h.backend.ReadState()
+	if err != nil {
+		if err == StateNotFound || os.IsNotExist(err) {
+			return nil
+		}
+		return err //not tested
+	}
+
+	return h.saveSnapshot(contents)
+}
+
+func (h HistoryBackend) saveSnapshot(contents []byte) error {
+	err := os.MkdirAll(h.dir, os.ModePerm)
+	if err != nil {
+		return fmt.Errorf("Create state history dir: %s", err)
+	}
+
+	snapshots, err := h.Snapshots()
+	if err != nil {
+		return err //not tested
+	}
+
+	if len(snapshots) > 0 {
+		latest, err := ioutil.ReadFile(h.snapshotPath(snapshots[len(snapshots)-1].ID))
+		if err == nil && string(latest) == string(contents) {
+			return nil
+		}
+	}
+
+	id := timeNow().UTC().Format(snapshotIDFormat)
+	err = writeFileAtomically(h.snapshotPath(id), contents, OS_READ_WRITE_MODE)
+	if err != nil {
+		return fmt.Errorf("Write state snapshot: %s", err)
+	}
+
+	snapshots, err = h.Snapshots()
+	if err != nil {
+		return err //not tested
+	}
+
+	for len(snapshots) > h.size {
+		err = os.Remove(h.snapshotPath(snapshots[0].ID))
+		if err != nil {
+			return fmt.Errorf("Remove state snapshot: %s", err)
+		}
+		snapshots = snapshots[1:]
+	}
+
+	return nil
+}
+
+func (h HistoryBackend) snapshotPath(id string) string {
+	return filepath.Join(h.dir, snapshotPrefix+id+snapshotSuffix)
+}
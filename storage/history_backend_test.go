@@ -0,0 +1,206 @@
+package storage_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("HistoryBackend", func() {
+	var (
+		tempDir    string
+		historyDir string
+		backend    storage.HistoryBackend
+		now        time.Time
+	)
+
+	BeforeEach(func() {
+		var err error
+		tempDir, err = ioutil.TempDir("", "")
+		Expect(err).NotTo(HaveOccurred())
+
+		historyDir = filepath.Join(tempDir, storage.HistoryDirName)
+		backend = storage.NewHistoryBackend(storage.NewLocalBackend(tempDir), historyDir, 2)
+
+		now = time.Date(2017, time.October, 1, 12, 0, 0, 0, time.UTC)
+		storage.SetTimeNow(func() time.Time {
+			now = now.Add(time.Minute)
+			return now
+		})
+	})
+
+	AfterEach(func() {
+		storage.ResetTimeNow()
+	})
+
+	It("writes the state to the wrapped backend", func() {
+		err := backend.WriteState([]byte(`{"version": 12}`))
+		Expect(err).NotTo(HaveOccurred())
+
+		contents, err := backend.ReadState()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(contents).To(MatchJSON(`{"version": 12}`))
+		Expect(backend.Location()).To(Equal(tempDir))
+	})
+
+	It("snapshots every state that is written", func() {
+		err := backend.WriteState([]byte(`{"id": "first"}`))
+		Expect(err).NotTo(HaveOccurred())
+
+		err = backend.WriteState([]byte(`{"id": "second"}`))
+		Expect(err).NotTo(HaveOccurred())
+
+		snapshots, err := backend.Snapshots()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(snapshots).To(Equal([]storage.Snapshot{
+			{
+				ID:      "20171001T120100.000000000Z",
+				Created: time.Date(2017, time.October, 1, 12, 1, 0, 0, time.UTC),
+				Size:    15,
+			},
+			{
+				ID:      "20171001T120200.000000000Z",
+				Created: time.Date(2017, time.October, 1, 12, 2, 0, 0, time.UTC),
+				Size:    16,
+			},
+		}))
+
+		contents, err := ioutil.ReadFile(filepath.Join(historyDir, "bbl-state-20171001T120100.000000000Z.json"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(contents).To(MatchJSON(`{"id": "first"}`))
+	})
+
+	It("snapshots the existing state before the first write", func() {
+		err := storage.NewLocalBackend(tempDir).WriteState([]byte(`{"id": "existing"}`))
+		Expect(err).NotTo(HaveOccurred())
+
+		err = backend.WriteState([]byte(`{"id": "first"}`))
+		Expect(err).NotTo(HaveOccurred())
+
+		snapshots, err := backend.Snapshots()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(snapshots).To(HaveLen(2))
+
+		contents, err := ioutil.ReadFile(filepath.Join(historyDir, "bbl-state-"+snapshots[0].ID+".json"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(contents).To(MatchJSON(`{"id": "existing"}`))
+	})
+
+	It("does not snapshot a state identical to the latest snapshot", func() {
+		err := backend.WriteState([]byte(`{"id": "first"}`))
+		Expect(err).NotTo(HaveOccurred())
+
+		err = backend.WriteState([]byte(`{"id": "first"}`))
+		Expect(err).NotTo(HaveOccurred())
+
+		snapshots, err := backend.Snapshots()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(snapshots).To(HaveLen(1))
+	})
+
+	It("keeps only the configured number of snapshots", func() {
+		for _, id := range []string{"first", "second", "third"} {
+			err := backend.WriteState([]byte(`{"id": "` + id + `"}`))
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		snapshots, err := backend.Snapshots()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(snapshots).To(HaveLen(2))
+		Expect(snapshots[0].ID).To(Equal("20171001T120200.000000000Z"))
+		Expect(snapshots[1].ID).To(Equal("20171001T120300.000000000Z"))
+	})
+
+	It("leaves no temp files behind", func() {
+		err := backend.WriteState([]byte(`{"id": "first"}`))
+		Expect(err).NotTo(HaveOccurred())
+
+		files, err := ioutil.ReadDir(historyDir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(files).To(HaveLen(1))
+
+		files, err = ioutil.ReadDir(tempDir)
+		Expect(err).NotTo(HaveOccurred())
+		for _, file := range files {
+			Expect(file.Name()).NotTo(HavePrefix(".bbl-state.json"))
+		}
+	})
+
+	Context("when the history size is zero", func() {
+		It("does not snapshot the state", func() {
+			backend = storage.NewHistoryBackend(storage.NewLocalBackend(tempDir), historyDir, 0)
+
+			err := backend.WriteState([]byte(`{"id": "first"}`))
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = os.Stat(historyDir)
+			Expect(os.IsNotExist(err)).To(BeTrue())
+		})
+	})
+
+	Describe("Snapshots", func() {
+		Context("when there is no history", func() {
+			It("returns no snapshots", func() {
+				snapshots, err := backend.Snapshots()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(snapshots).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("RestoreSnapshot", func() {
+		It("writes the snapshot back as the current state", func() {
+			err := backend.WriteState([]byte(`{"id": "first"}`))
+			Expect(err).NotTo(HaveOccurred())
+
+			err = backend.WriteState([]byte(`{"id": "second"}`))
+			Expect(err).NotTo(HaveOccurred())
+
+			err = backend.RestoreSnapshot("20171001T120100.000000000Z")
+			Expect(err).NotTo(HaveOccurred())
+
+			contents, err := backend.ReadState()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(contents).To(MatchJSON(`{"id": "first"}`))
+		})
+
+		Context("when the snapshot does not exist", func() {
+			It("returns an error", func() {
+				err := backend.RestoreSnapshot("20171001T120100.000000000Z")
+				Expect(err).To(MatchError(`State snapshot "20171001T120100.000000000Z" not found`))
+			})
+		})
+
+		Context("when the snapshot ID is not a snapshot timestamp", func() {
+			It("returns an error without reading the file", func() {
+				err := ioutil.WriteFile(filepath.Join(tempDir, "bbl-state-secret.json"), []byte(`{"id": "outside"}`), os.ModePerm)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = backend.RestoreSnapshot("../bbl-state-secret")
+				Expect(err).To(MatchError("Invalid state snapshot ID \"../bbl-state-secret\"; run `bbl state-history` to list them"))
+
+				_, err = backend.ReadState()
+				Expect(err).To(Equal(storage.StateNotFound))
+			})
+		})
+	})
+
+	Context("when wrapped by an encrypted backend", func() {
+		It("keeps the snapshots encrypted", func() {
+			encryptedBackend := storage.NewEncryptedBackend(backend, "some-key")
+
+			err := encryptedBackend.WriteState([]byte(`{"id": "some-secret"}`))
+			Expect(err).NotTo(HaveOccurred())
+
+			contents, err := ioutil.ReadFile(filepath.Join(historyDir, "bbl-state-20171001T120100.000000000Z.json"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(contents)).NotTo(ContainSubstring("some-secret"))
+		})
+	})
+})
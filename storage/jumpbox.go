@@ -7,6 +7,10 @@ type Jumpbox struct {
 	Variables string                 `json:"variables"`
 	Manifest  string                 `json:"manifest"`
 	State     map[string]interface{} `json:"state"`
+
+	DeploymentSource string     `json:"deploymentSource,omitempty"`
+	UserOpsFiles     []UserFile `json:"userOpsFiles,omitempty"`
+	Fingerprint      string     `json:"fingerprint,omitempty"`
 }
 
 func (j Jumpbox) IsEmpty() bool {
@@ -0,0 +1,233 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const LockFileName = "bbl-state.lock"
+
+var timeNow = time.Now
+
+type LocalBackend struct {
+	dir string
+}
+
+func NewLocalBackend(dir string) LocalBackend {
+	return LocalBackend{
+		dir: dir,
+	}
+}
+
+func (b LocalBackend) Location() string {
+	return b.dir
+}
+
+func (b LocalBackend) ReadState() ([]byte, error) {
+	_, err := os.Stat(b.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	contents, err := ioutil.ReadFile(filepath.Join(b.dir, StateFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, StateNotFound
+		}
+		return nil, err
+	}
+
+	return contents, nil
+}
+
+func (b LocalBackend) WriteState(contents []byte) error {
+	return writeFileAtomically(filepath.Join(b.dir, StateFileName), contents, OS_READ_WRITE_MODE)
+}
+
+func (b LocalBackend) DeleteState() error {
+	err := os.Remove(filepath.Join(b.dir, StateFileName))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func (b LocalBackend) BackupState(name string) error {
+	contents, err := ioutil.ReadFile(filepath.Join(b.dir, StateFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	return writeFileAtomically(filepath.Join(b.dir, name), contents, OS_READ_WRITE_MODE)
+}
+
+func (b LocalBackend) AcquireLease(lease Lease) error {
+	lockFile := filepath.Join(b.dir, LockFileName)
+
+	contents, err := json.Marshal(lease)
+	if err != nil {
+		return err // not tested
+	}
+
+	current, err := b.readLease()
+	switch {
+	case os.IsNotExist(err):
+	case err != nil:
+		return err
+	case current.ID == lease.ID:
+		return writeFileAtomically(lockFile, contents, OS_READ_WRITE_MODE)
+	case current.Stale(timeNow()):
+		err = b.claimStaleLease(current, lease)
+		if err != nil {
+			return err
+		}
+	default:
+		return LeaseHeldError{Lease: current}
+	}
+
+	return b.createLease(contents)
+}
+
+// claimStaleLease moves the stale lock file aside under a name unique to
+// lease, and checks that what it moved is still the stale lease. Another bbl
+// that read the same stale lease may have taken it over in the meantime, in
+// which case its lock file is put back instead of being removed.
+func (b LocalBackend) claimStaleLease(stale, lease Lease) error {
+	lockFile := filepath.Join(b.dir, LockFileName)
+	claimFile := filepath.Join(b.dir, fmt.Sprintf(".%s.%s", LockFileName, lease.ID))
+
+	err := os.Rename(lockFile, claimFile)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer os.Remove(claimFile)
+
+	claimed, err := readLeaseFile(claimFile)
+	if err == nil && claimed.ID == stale.ID {
+		return nil
+	}
+
+	linkErr := os.Link(claimFile, lockFile)
+	if linkErr != nil && !os.IsExist(linkErr) {
+		return linkErr
+	}
+	if err != nil {
+		return err
+	}
+	return LeaseHeldError{Lease: claimed}
+}
+
+// createLease writes the lease to a temp file and links it into place, which
+// fails if a lock file already exists and never exposes a partial lock file.
+func (b LocalBackend) createLease(contents []byte) error {
+	file, err := ioutil.TempFile(b.dir, "."+LockFileName)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	_, err = file.Write(contents)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(file.Name(), OS_READ_WRITE_MODE)
+	}
+	if err != nil {
+		return err
+	}
+
+	err = os.Link(file.Name(), filepath.Join(b.dir, LockFileName))
+	if os.IsExist(err) {
+		current, readErr := b.readLease()
+		if readErr != nil {
+			return readErr
+		}
+		return LeaseHeldError{Lease: current}
+	}
+	return err
+}
+
+func (b LocalBackend) ReleaseLease(lease Lease) error {
+	current, err := b.readLease()
+	switch {
+	case os.IsNotExist(err):
+		return nil
+	case err != nil:
+		return err
+	case current.ID != lease.ID:
+		return nil
+	}
+
+	err = os.Remove(filepath.Join(b.dir, LockFileName))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func (b LocalBackend) ReadLease() (Lease, error) {
+	lease, err := b.readLease()
+	if os.IsNotExist(err) {
+		return Lease{}, LeaseNotFound
+	}
+	return lease, err
+}
+
+func (b LocalBackend) readLease() (Lease, error) {
+	return readLeaseFile(filepath.Join(b.dir, LockFileName))
+}
+
+func readLeaseFile(path string) (Lease, error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return Lease{}, err
+	}
+
+	var lease Lease
+	err = json.Unmarshal(contents, &lease)
+	if err != nil {
+		return Lease{}, err
+	}
+
+	return lease, nil
+}
+
+// writeFileAtomically writes to a temp file next to path and renames it into
+// place, so a crash mid-write never leaves a truncated file behind.
+func writeFileAtomically(path string, contents []byte, mode os.FileMode) error {
+	file, err := ioutil.TempFile(filepath.Dir(path), "."+filepath.Base(path))
+	if err != nil {
+		return err
+	}
+
+	_, err = file.Write(contents)
+	if err == nil {
+		err = file.Sync()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(file.Name(), mode)
+	}
+	if err == nil {
+		err = os.Rename(file.Name(), path)
+	}
+	if err != nil {
+		os.Remove(file.Name())
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,315 @@
+package storage_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LocalBackend", func() {
+	var (
+		tempDir string
+		backend storage.LocalBackend
+		now     time.Time
+	)
+
+	BeforeEach(func() {
+		var err error
+		tempDir, err = ioutil.TempDir("", "")
+		Expect(err).NotTo(HaveOccurred())
+
+		backend = storage.NewLocalBackend(tempDir)
+
+		now = time.Date(2017, time.October, 1, 12, 0, 0, 0, time.UTC)
+		storage.SetTimeNow(func() time.Time { return now })
+	})
+
+	AfterEach(func() {
+		storage.ResetTimeNow()
+	})
+
+	Describe("Location", func() {
+		It("returns the state dir", func() {
+			Expect(backend.Location()).To(Equal(tempDir))
+		})
+	})
+
+	Describe("ReadState", func() {
+		It("returns the contents of bbl-state.json", func() {
+			err := ioutil.WriteFile(filepath.Join(tempDir, "bbl-state.json"), []byte(`{"version": 12}`), os.ModePerm)
+			Expect(err).NotTo(HaveOccurred())
+
+			contents, err := backend.ReadState()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(contents).To(MatchJSON(`{"version": 12}`))
+		})
+
+		Context("when bbl-state.json does not exist", func() {
+			It("returns StateNotFound", func() {
+				_, err := backend.ReadState()
+				Expect(err).To(Equal(storage.StateNotFound))
+			})
+		})
+
+		Context("when the state dir does not exist", func() {
+			It("returns an error", func() {
+				backend = storage.NewLocalBackend("some-fake-directory")
+
+				_, err := backend.ReadState()
+				Expect(err).To(MatchError(ContainSubstring("no such file or directory")))
+			})
+		})
+	})
+
+	Describe("WriteState", func() {
+		It("writes bbl-state.json", func() {
+			err := backend.WriteState([]byte(`{"version": 12}`))
+			Expect(err).NotTo(HaveOccurred())
+
+			contents, err := ioutil.ReadFile(filepath.Join(tempDir, "bbl-state.json"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(contents).To(MatchJSON(`{"version": 12}`))
+		})
+	})
+
+	Describe("DeleteState", func() {
+		It("removes bbl-state.json", func() {
+			err := ioutil.WriteFile(filepath.Join(tempDir, "bbl-state.json"), []byte("{}"), os.ModePerm)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = backend.DeleteState()
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = os.Stat(filepath.Join(tempDir, "bbl-state.json"))
+			Expect(os.IsNotExist(err)).To(BeTrue())
+		})
+
+		Context("when bbl-state.json does not exist", func() {
+			It("does nothing", func() {
+				err := backend.DeleteState()
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("BackupState", func() {
+		It("copies bbl-state.json to the backup file", func() {
+			err := ioutil.WriteFile(filepath.Join(tempDir, "bbl-state.json"), []byte(`{"version": 7}`), os.ModePerm)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = backend.BackupState("some-backup.json")
+			Expect(err).NotTo(HaveOccurred())
+
+			contents, err := ioutil.ReadFile(filepath.Join(tempDir, "some-backup.json"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(contents).To(MatchJSON(`{"version": 7}`))
+		})
+
+		Context("when bbl-state.json does not exist", func() {
+			It("does nothing", func() {
+				err := backend.BackupState("some-backup.json")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = os.Stat(filepath.Join(tempDir, "some-backup.json"))
+				Expect(os.IsNotExist(err)).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("leases", func() {
+		var lease storage.Lease
+
+		BeforeEach(func() {
+			lease = storage.Lease{
+				ID:      "some-lease-id",
+				Holder:  "some-holder",
+				Expires: now.Add(10 * time.Minute),
+			}
+		})
+
+		It("writes a lock file into the state dir", func() {
+			err := backend.AcquireLease(lease)
+			Expect(err).NotTo(HaveOccurred())
+
+			contents, err := ioutil.ReadFile(filepath.Join(tempDir, "bbl-state.lock"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(contents).To(MatchJSON(`{
+				"id": "some-lease-id",
+				"holder": "some-holder",
+				"expires": "2017-10-01T12:10:00Z"
+			}`))
+		})
+
+		It("renews a lease it already holds", func() {
+			err := backend.AcquireLease(lease)
+			Expect(err).NotTo(HaveOccurred())
+
+			lease.Expires = now.Add(20 * time.Minute)
+			err = backend.AcquireLease(lease)
+			Expect(err).NotTo(HaveOccurred())
+
+			contents, err := ioutil.ReadFile(filepath.Join(tempDir, "bbl-state.lock"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(contents)).To(ContainSubstring("2017-10-01T12:20:00Z"))
+		})
+
+		It("removes the lock file on release", func() {
+			err := backend.AcquireLease(lease)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = backend.ReleaseLease(lease)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = os.Stat(filepath.Join(tempDir, "bbl-state.lock"))
+			Expect(os.IsNotExist(err)).To(BeTrue())
+		})
+
+		Context("when the state is not locked", func() {
+			It("returns LeaseNotFound", func() {
+				_, err := backend.ReadLease()
+				Expect(err).To(Equal(storage.LeaseNotFound))
+			})
+		})
+
+		Context("when another process on this host holds a lease", func() {
+			var processIsAlive bool
+
+			BeforeEach(func() {
+				processIsAlive = true
+				storage.SetHostname(func() (string, error) { return "some-host", nil })
+				storage.SetProcessAlive(func(pid int) bool {
+					Expect(pid).To(Equal(1234))
+					return processIsAlive
+				})
+
+				err := backend.AcquireLease(storage.Lease{
+					ID:      "other-lease-id",
+					Holder:  "other-holder",
+					Expires: now.Add(5 * time.Minute),
+					PID:     1234,
+					Host:    "some-host",
+					Command: "up",
+				})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				storage.ResetHostname()
+				storage.ResetProcessAlive()
+			})
+
+			It("returns a lease held error describing the holder", func() {
+				err := backend.AcquireLease(lease)
+				Expect(err).To(MatchError("bbl state is locked by other-holder (bbl up, pid 1234 on some-host) until 2017-10-01T12:05:00Z. If that bbl is no longer running, run `bbl force-unlock`."))
+			})
+
+			Context("when that process is no longer running", func() {
+				It("takes over the stale lease", func() {
+					processIsAlive = false
+
+					err := backend.AcquireLease(lease)
+					Expect(err).NotTo(HaveOccurred())
+
+					current, err := backend.ReadLease()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(current.ID).To(Equal("some-lease-id"))
+				})
+			})
+
+			Context("when the lease was taken on another host", func() {
+				It("does not check the process", func() {
+					storage.SetHostname(func() (string, error) { return "other-host", nil })
+					processIsAlive = false
+
+					err := backend.AcquireLease(lease)
+					Expect(err).To(BeAssignableToTypeOf(storage.LeaseHeldError{}))
+				})
+			})
+		})
+
+		Context("when another holder has an active lease", func() {
+			var otherLease storage.Lease
+
+			BeforeEach(func() {
+				otherLease = storage.Lease{
+					ID:      "other-lease-id",
+					Holder:  "other-holder",
+					Expires: now.Add(5 * time.Minute),
+				}
+				err := backend.AcquireLease(otherLease)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns a lease held error", func() {
+				err := backend.AcquireLease(lease)
+				Expect(err).To(MatchError("bbl state is locked by other-holder until 2017-10-01T12:05:00Z. If that bbl is no longer running, run `bbl force-unlock`."))
+			})
+
+			It("does not release the other lease", func() {
+				err := backend.ReleaseLease(lease)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = os.Stat(filepath.Join(tempDir, "bbl-state.lock"))
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			Context("when the other lease has expired", func() {
+				It("takes over the lease", func() {
+					now = now.Add(6 * time.Minute)
+
+					err := backend.AcquireLease(lease)
+					Expect(err).NotTo(HaveOccurred())
+
+					contents, err := ioutil.ReadFile(filepath.Join(tempDir, "bbl-state.lock"))
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(contents)).To(ContainSubstring("some-lease-id"))
+				})
+
+				It("lets only one of several concurrent bbls take over the lease", func() {
+					now = now.Add(6 * time.Minute)
+
+					var wg sync.WaitGroup
+					errs := make([]error, 20)
+					for i := range errs {
+						wg.Add(1)
+						go func(i int) {
+							defer wg.Done()
+							errs[i] = backend.AcquireLease(storage.Lease{
+								ID:      fmt.Sprintf("lease-id-%d", i),
+								Holder:  "some-holder",
+								Expires: now.Add(10 * time.Minute),
+							})
+						}(i)
+					}
+					wg.Wait()
+
+					var winners []string
+					for i, err := range errs {
+						if err == nil {
+							winners = append(winners, fmt.Sprintf("lease-id-%d", i))
+						} else {
+							Expect(err).To(BeAssignableToTypeOf(storage.LeaseHeldError{}))
+						}
+					}
+					Expect(winners).To(HaveLen(1))
+
+					current, err := backend.ReadLease()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(current.ID).To(Equal(winners[0]))
+
+					files, err := ioutil.ReadDir(tempDir)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(files).To(HaveLen(1))
+				})
+			})
+		})
+	})
+})
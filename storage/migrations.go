@@ -0,0 +1,244 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+const MinimumStateVersion = 3
+
+type document map[string]interface{}
+
+// Migration upgrades a state document from version From to From+1. Migrate
+// edits the document in place and returns a line for every change it made.
+type Migration struct {
+	From        int
+	Description string
+	Migrate     func(document) ([]string, error)
+}
+
+type MigrationStep struct {
+	From        int
+	To          int
+	Description string
+	Changes     []string
+}
+
+var Migrations = []Migration{
+	{From: 3, Description: "remove CloudFormation stack", Migrate: migrateV3},
+	{From: 4, Description: "move BOSH credentials into the director vars-store", Migrate: migrateV4},
+	{From: 5, Description: "move the SSH key pair into the jumpbox vars-store", Migrate: migrateV5},
+	{From: 6, Description: "remove jumpbox.enabled, the jumpbox is always deployed", Migrate: migrateV6},
+	{From: 7, Description: "no schema changes", Migrate: noChanges},
+	{From: 8, Description: "no schema changes", Migrate: noChanges},
+	{From: 9, Description: "no schema changes", Migrate: noChanges},
+	{From: 10, Description: "no schema changes", Migrate: noChanges},
+	{From: 11, Description: "stop persisting IAAS credentials", Migrate: migrateV11},
+	{From: 12, Description: "store the director ops file in a list of ops files", Migrate: migrateV12},
+}
+
+// MigrateState runs every migration needed to bring contents up to
+// STATE_VERSION and returns the upgraded document along with the steps that
+// were applied. Documents that are already current are returned unchanged.
+func MigrateState(contents []byte) ([]byte, []MigrationStep, error) {
+	doc := document{}
+	err := json.Unmarshal(contents, &doc)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	version, _ := doc["version"].(float64)
+	if int(version) >= STATE_VERSION {
+		return contents, []MigrationStep{}, nil
+	}
+
+	if int(version) < MinimumStateVersion {
+		return nil, nil, fmt.Errorf("Cannot migrate bbl state version %d: versions before %d are not supported", int(version), MinimumStateVersion)
+	}
+
+	steps := []MigrationStep{}
+	for _, migration := range Migrations {
+		if migration.From < int(version) {
+			continue
+		}
+
+		changes, err := migration.Migrate(doc)
+		if err != nil {
+			return nil, nil, fmt.Errorf("Migrate bbl state from version %d to %d: %s", migration.From, migration.From+1, err)
+		}
+		doc["version"] = migration.From + 1
+
+		steps = append(steps, MigrationStep{
+			From:        migration.From,
+			To:          migration.From + 1,
+			Description: migration.Description,
+			Changes:     changes,
+		})
+	}
+
+	migrated, err := json.Marshal(doc)
+	if err != nil {
+		return nil, nil, err //not tested
+	}
+
+	return migrated, steps, nil
+}
+
+func migrateV3(doc document) ([]string, error) {
+	changes := []string{}
+
+	if stack, ok := doc["stack"].(map[string]interface{}); ok {
+		if name, _ := stack["name"].(string); name != "" {
+			return nil, fmt.Errorf("the environment still uses CloudFormation stack %q; run `bbl up` with bbl v4 to move it to terraform first", name)
+		}
+	}
+
+	for _, key := range []string{"stack", "migratedFromCloudFormation"} {
+		if _, ok := doc[key]; ok {
+			delete(doc, key)
+			changes = append(changes, fmt.Sprintf("removed %s", key))
+		}
+	}
+
+	return changes, nil
+}
+
+func migrateV4(doc document) ([]string, error) {
+	bosh, ok := doc["bosh"].(map[string]interface{})
+	if !ok {
+		return []string{}, nil
+	}
+
+	credentials, ok := bosh["credentials"]
+	if !ok {
+		return []string{}, nil
+	}
+	delete(bosh, "credentials")
+
+	if variables, _ := bosh["variables"].(string); variables != "" {
+		return []string{"removed bosh.credentials, bosh.variables is already set"}, nil
+	}
+
+	variables, err := yaml.Marshal(credentials)
+	if err != nil {
+		return nil, err //not tested
+	}
+	bosh["variables"] = string(variables)
+
+	return []string{"moved bosh.credentials to bosh.variables"}, nil
+}
+
+func migrateV5(doc document) ([]string, error) {
+	keyPair, ok := doc["keyPair"].(map[string]interface{})
+	if !ok {
+		return []string{}, nil
+	}
+	delete(doc, "keyPair")
+
+	privateKey, _ := keyPair["privateKey"].(string)
+	if privateKey == "" {
+		return []string{"removed empty keyPair"}, nil
+	}
+
+	jumpbox, ok := doc["jumpbox"].(map[string]interface{})
+	if !ok {
+		jumpbox = map[string]interface{}{}
+		doc["jumpbox"] = jumpbox
+	}
+
+	variables := map[string]interface{}{}
+	if rawVariables, _ := jumpbox["variables"].(string); rawVariables != "" {
+		err := yaml.Unmarshal([]byte(rawVariables), &variables)
+		if err != nil {
+			return nil, fmt.Errorf("jumpbox.variables: %s", err)
+		}
+	}
+
+	if _, ok := variables["jumpbox_ssh"]; ok {
+		return []string{"removed keyPair, jumpbox_ssh is already in jumpbox.variables"}, nil
+	}
+
+	publicKey, _ := keyPair["publicKey"].(string)
+	variables["jumpbox_ssh"] = map[string]interface{}{
+		"private_key": privateKey,
+		"public_key":  publicKey,
+	}
+
+	rawVariables, err := yaml.Marshal(variables)
+	if err != nil {
+		return nil, err //not tested
+	}
+	jumpbox["variables"] = string(rawVariables)
+
+	return []string{"moved keyPair to jumpbox_ssh in jumpbox.variables"}, nil
+}
+
+func migrateV6(doc document) ([]string, error) {
+	jumpbox, ok := doc["jumpbox"].(map[string]interface{})
+	if !ok {
+		return []string{}, nil
+	}
+
+	if _, ok := jumpbox["enabled"]; !ok {
+		return []string{}, nil
+	}
+	delete(jumpbox, "enabled")
+
+	return []string{"removed jumpbox.enabled"}, nil
+}
+
+func migrateV11(doc document) ([]string, error) {
+	changes := []string{}
+
+	for _, field := range []struct{ iaas, key string }{
+		{"aws", "accessKeyId"},
+		{"aws", "secretAccessKey"},
+		{"gcp", "serviceAccountKey"},
+		{"gcp", "projectID"},
+	} {
+		section, ok := doc[field.iaas].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if _, ok := section[field.key]; ok {
+			delete(section, field.key)
+			changes = append(changes, fmt.Sprintf("removed %s.%s", field.iaas, field.key))
+		}
+	}
+
+	return changes, nil
+}
+
+func migrateV12(doc document) ([]string, error) {
+	bosh, ok := doc["bosh"].(map[string]interface{})
+	if !ok {
+		return []string{}, nil
+	}
+
+	opsFile, ok := bosh["userOpsFile"]
+	if !ok {
+		return []string{}, nil
+	}
+	delete(bosh, "userOpsFile")
+
+	contents, _ := opsFile.(string)
+	if contents == "" {
+		return []string{"removed empty bosh.userOpsFile"}, nil
+	}
+
+	bosh["userOpsFiles"] = []interface{}{
+		map[string]interface{}{
+			"name":     "user-ops-file.yml",
+			"contents": contents,
+		},
+	}
+
+	return []string{"moved bosh.userOpsFile to bosh.userOpsFiles"}, nil
+}
+
+func noChanges(doc document) ([]string, error) {
+	return []string{}, nil
+}
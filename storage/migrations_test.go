@@ -0,0 +1,160 @@
+package storage_test
+
+import (
+	"encoding/json"
+
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Migrations", func() {
+	It("has one migration for every version up to the current one", func() {
+		Expect(storage.Migrations).To(HaveLen(storage.STATE_VERSION - storage.MinimumStateVersion))
+		for i, migration := range storage.Migrations {
+			Expect(migration.From).To(Equal(storage.MinimumStateVersion + i))
+		}
+	})
+
+	DescribeTable("each migration step",
+		func(from int, before, after string, expectedChanges []string) {
+			var doc map[string]interface{}
+			err := json.Unmarshal([]byte(before), &doc)
+			Expect(err).NotTo(HaveOccurred())
+
+			migration := storage.Migrations[from-storage.MinimumStateVersion]
+			Expect(migration.From).To(Equal(from))
+
+			changes, err := migration.Migrate(doc)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changes).To(Equal(expectedChanges))
+
+			migrated, err := json.Marshal(doc)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(migrated).To(MatchJSON(after))
+		},
+		Entry("v3 removes an unused CloudFormation stack", 3,
+			`{"stack": {"name": "", "lbType": ""}, "migratedFromCloudFormation": true, "envID": "some-env"}`,
+			`{"envID": "some-env"}`,
+			[]string{"removed stack", "removed migratedFromCloudFormation"},
+		),
+		Entry("v3 without a stack", 3,
+			`{"envID": "some-env"}`,
+			`{"envID": "some-env"}`,
+			[]string{},
+		),
+		Entry("v4 moves BOSH credentials into the vars-store", 4,
+			`{"bosh": {"credentials": {"admin_password": "some-password"}}}`,
+			`{"bosh": {"variables": "admin_password: some-password\n"}}`,
+			[]string{"moved bosh.credentials to bosh.variables"},
+		),
+		Entry("v4 keeps an existing vars-store", 4,
+			`{"bosh": {"credentials": {"admin_password": "some-password"}, "variables": "admin_password: other-password\n"}}`,
+			`{"bosh": {"variables": "admin_password: other-password\n"}}`,
+			[]string{"removed bosh.credentials, bosh.variables is already set"},
+		),
+		Entry("v4 without BOSH credentials", 4,
+			`{"bosh": {"variables": "some-vars"}}`,
+			`{"bosh": {"variables": "some-vars"}}`,
+			[]string{},
+		),
+		Entry("v5 moves the key pair into the jumpbox vars-store", 5,
+			`{"keyPair": {"name": "some-key", "privateKey": "some-private-key", "publicKey": "some-public-key"}, "jumpbox": {"variables": "some_var: some-value\n"}}`,
+			`{"jumpbox": {"variables": "jumpbox_ssh:\n  private_key: some-private-key\n  public_key: some-public-key\nsome_var: some-value\n"}}`,
+			[]string{"moved keyPair to jumpbox_ssh in jumpbox.variables"},
+		),
+		Entry("v5 keeps an existing jumpbox ssh key", 5,
+			`{"keyPair": {"privateKey": "some-private-key"}, "jumpbox": {"variables": "jumpbox_ssh:\n  private_key: other-private-key\n"}}`,
+			`{"jumpbox": {"variables": "jumpbox_ssh:\n  private_key: other-private-key\n"}}`,
+			[]string{"removed keyPair, jumpbox_ssh is already in jumpbox.variables"},
+		),
+		Entry("v5 drops an empty key pair", 5,
+			`{"keyPair": {"name": ""}}`,
+			`{}`,
+			[]string{"removed empty keyPair"},
+		),
+		Entry("v6 removes jumpbox.enabled", 6,
+			`{"jumpbox": {"enabled": true, "url": "some-url"}}`,
+			`{"jumpbox": {"url": "some-url"}}`,
+			[]string{"removed jumpbox.enabled"},
+		),
+		Entry("v7 has no schema changes", 7, `{"envID": "some-env"}`, `{"envID": "some-env"}`, []string{}),
+		Entry("v8 has no schema changes", 8, `{"envID": "some-env"}`, `{"envID": "some-env"}`, []string{}),
+		Entry("v9 has no schema changes", 9, `{"envID": "some-env"}`, `{"envID": "some-env"}`, []string{}),
+		Entry("v10 has no schema changes", 10, `{"envID": "some-env"}`, `{"envID": "some-env"}`, []string{}),
+		Entry("v11 stops persisting IAAS credentials", 11,
+			`{"aws": {"accessKeyId": "some-id", "secretAccessKey": "some-secret", "region": "some-region"}, "gcp": {"serviceAccountKey": "some-key", "projectID": "some-project", "zone": "some-zone"}}`,
+			`{"aws": {"region": "some-region"}, "gcp": {"zone": "some-zone"}}`,
+			[]string{"removed aws.accessKeyId", "removed aws.secretAccessKey", "removed gcp.serviceAccountKey", "removed gcp.projectID"},
+		),
+		Entry("v12 moves the director ops file into a list", 12,
+			`{"bosh": {"userOpsFile": "some-ops", "manifest": "some-manifest"}}`,
+			`{"bosh": {"userOpsFiles": [{"name": "user-ops-file.yml", "contents": "some-ops"}], "manifest": "some-manifest"}}`,
+			[]string{"moved bosh.userOpsFile to bosh.userOpsFiles"},
+		),
+		Entry("v12 removes an empty director ops file", 12,
+			`{"bosh": {"userOpsFile": "", "manifest": "some-manifest"}}`,
+			`{"bosh": {"manifest": "some-manifest"}}`,
+			[]string{"removed empty bosh.userOpsFile"},
+		),
+		Entry("v12 without a director", 12, `{"envID": "some-env"}`, `{"envID": "some-env"}`, []string{}),
+	)
+
+	Describe("MigrateState", func() {
+		It("runs every step from the document's version to the current version", func() {
+			migrated, steps, err := storage.MigrateState([]byte(`{
+				"version": 5,
+				"jumpbox": {"enabled": true},
+				"aws": {"accessKeyId": "some-id", "region": "some-region"}
+			}`))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(migrated).To(MatchJSON(`{
+				"version": 13,
+				"jumpbox": {},
+				"aws": {"region": "some-region"}
+			}`))
+
+			Expect(steps).To(HaveLen(8))
+			Expect(steps[0]).To(Equal(storage.MigrationStep{
+				From:        5,
+				To:          6,
+				Description: "move the SSH key pair into the jumpbox vars-store",
+				Changes:     []string{},
+			}))
+			Expect(steps[1].Changes).To(Equal([]string{"removed jumpbox.enabled"}))
+			Expect(steps[7].To).To(Equal(13))
+		})
+
+		It("leaves a current document alone", func() {
+			migrated, steps, err := storage.MigrateState([]byte(`{"version": 13, "envID": "some-env"}`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(migrated).To(MatchJSON(`{"version": 13, "envID": "some-env"}`))
+			Expect(steps).To(BeEmpty())
+		})
+
+		Context("failure cases", func() {
+			It("refuses to migrate an environment still on CloudFormation", func() {
+				_, _, err := storage.MigrateState([]byte(`{"version": 3, "stack": {"name": "some-stack"}}`))
+				Expect(err).To(MatchError("Migrate bbl state from version 3 to 4: the environment still uses CloudFormation stack \"some-stack\"; run `bbl up` with bbl v4 to move it to terraform first"))
+			})
+
+			It("refuses to migrate versions before 3", func() {
+				_, _, err := storage.MigrateState([]byte(`{"version": 2}`))
+				Expect(err).To(MatchError("Cannot migrate bbl state version 2: versions before 3 are not supported"))
+			})
+
+			It("returns an error for invalid jumpbox variables", func() {
+				_, _, err := storage.MigrateState([]byte(`{"version": 5, "keyPair": {"privateKey": "some-key"}, "jumpbox": {"variables": "%%%"}}`))
+				Expect(err).To(MatchError(ContainSubstring("Migrate bbl state from version 5 to 6: jumpbox.variables:")))
+			})
+
+			It("returns an error for invalid json", func() {
+				_, _, err := storage.MigrateState([]byte(`%%%`))
+				Expect(err).To(MatchError(ContainSubstring("invalid character")))
+			})
+		})
+	})
+})
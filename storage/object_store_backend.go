@@ -0,0 +1,233 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/signer/v4"
+)
+
+var errPreconditionFailed error = errors.New("object store precondition failed")
+
+type ObjectStoreBackend struct {
+	baseURL    *url.URL
+	region     string
+	signer     *v4.Signer
+	httpClient *http.Client
+}
+
+func NewObjectStoreBackend(baseURL *url.URL, accessKeyID, secretAccessKey, region string) ObjectStoreBackend {
+	var signer *v4.Signer
+	if accessKeyID != "" && secretAccessKey != "" {
+		signer = v4.NewSigner(credentials.NewStaticCredentials(accessKeyID, secretAccessKey, ""))
+	}
+
+	return ObjectStoreBackend{
+		baseURL:    baseURL,
+		region:     region,
+		signer:     signer,
+		httpClient: http.DefaultClient,
+	}
+}
+
+func (b ObjectStoreBackend) Location() string {
+	return b.baseURL.String()
+}
+
+func (b ObjectStoreBackend) ReadState() ([]byte, error) {
+	contents, _, err := b.get(StateFileName)
+	return contents, err
+}
+
+func (b ObjectStoreBackend) WriteState(contents []byte) error {
+	return b.put(StateFileName, contents, nil)
+}
+
+func (b ObjectStoreBackend) DeleteState() error {
+	return b.delete(StateFileName)
+}
+
+func (b ObjectStoreBackend) BackupState(name string) error {
+	contents, _, err := b.get(StateFileName)
+	switch err {
+	case StateNotFound:
+		return nil
+	case nil:
+	default:
+		return err
+	}
+
+	return b.put(name, contents, nil)
+}
+
+func (b ObjectStoreBackend) AcquireLease(lease Lease) error {
+	contents, err := json.Marshal(lease)
+	if err != nil {
+		return err // not tested
+	}
+
+	currentContents, etag, err := b.get(LockFileName)
+	switch err {
+	case StateNotFound:
+		err = b.put(LockFileName, contents, map[string]string{"If-None-Match": "*"})
+	case nil:
+		var current Lease
+		err = json.Unmarshal(currentContents, &current)
+		if err != nil {
+			return fmt.Errorf("Read state lease: %s", err)
+		}
+
+		if current.ID != lease.ID && !current.Stale(timeNow()) {
+			return LeaseHeldError{Lease: current}
+		}
+
+		err = b.put(LockFileName, contents, map[string]string{"If-Match": etag})
+	default:
+		return err
+	}
+
+	if err == errPreconditionFailed {
+		return b.leaseHeldError()
+	}
+
+	return err
+}
+
+func (b ObjectStoreBackend) ReleaseLease(lease Lease) error {
+	current, err := b.ReadLease()
+	switch err {
+	case LeaseNotFound:
+		return nil
+	case nil:
+	default:
+		return err
+	}
+
+	if current.ID != lease.ID {
+		return nil
+	}
+
+	return b.delete(LockFileName)
+}
+
+func (b ObjectStoreBackend) ReadLease() (Lease, error) {
+	contents, _, err := b.get(LockFileName)
+	switch err {
+	case StateNotFound:
+		return Lease{}, LeaseNotFound
+	case nil:
+	default:
+		return Lease{}, err
+	}
+
+	var current Lease
+	err = json.Unmarshal(contents, &current)
+	if err != nil {
+		return Lease{}, fmt.Errorf("Read state lease: %s", err)
+	}
+
+	return current, nil
+}
+
+func (b ObjectStoreBackend) leaseHeldError() error {
+	current, err := b.ReadLease()
+	if err != nil {
+		return err
+	}
+
+	return LeaseHeldError{Lease: current}
+}
+
+func (b ObjectStoreBackend) get(name string) ([]byte, string, error) {
+	response, err := b.do("GET", name, nil, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	defer response.Body.Close()
+
+	switch response.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, "", StateNotFound
+	default:
+		return nil, "", unexpectedResponse(response)
+	}
+
+	contents, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, "", err // not tested
+	}
+
+	return contents, response.Header.Get("ETag"), nil
+}
+
+func (b ObjectStoreBackend) put(name string, contents []byte, headers map[string]string) error {
+	response, err := b.do("PUT", name, contents, headers)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	switch response.StatusCode {
+	case http.StatusOK, http.StatusCreated, http.StatusNoContent:
+		return nil
+	case http.StatusPreconditionFailed, http.StatusConflict:
+		return errPreconditionFailed
+	default:
+		return unexpectedResponse(response)
+	}
+}
+
+func (b ObjectStoreBackend) delete(name string) error {
+	response, err := b.do("DELETE", name, nil, nil)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	switch response.StatusCode {
+	case http.StatusOK, http.StatusNoContent, http.StatusNotFound:
+		return nil
+	default:
+		return unexpectedResponse(response)
+	}
+}
+
+func (b ObjectStoreBackend) do(method, name string, contents []byte, headers map[string]string) (*http.Response, error) {
+	objectURL := *b.baseURL
+	objectURL.Path = strings.TrimSuffix(objectURL.Path, "/") + "/" + name
+
+	body := bytes.NewReader(contents)
+	request, err := http.NewRequest(method, objectURL.String(), body)
+	if err != nil {
+		return nil, err // not tested
+	}
+	for k, v := range headers {
+		request.Header.Set(k, v)
+	}
+
+	if b.signer != nil {
+		_, err = b.signer.Sign(request, body, "s3", b.region, timeNow())
+		if err != nil {
+			return nil, fmt.Errorf("Sign object store request: %s", err)
+		}
+	}
+
+	response, err := b.httpClient.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s: %s", method, objectURL.String(), err)
+	}
+
+	return response, nil
+}
+
+func unexpectedResponse(response *http.Response) error {
+	return fmt.Errorf("unexpected http response %d %s", response.StatusCode, http.StatusText(response.StatusCode))
+}
@@ -0,0 +1,203 @@
+package storage_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"time"
+
+	"github.com/cloudfoundry/bosh-bootloader/fakes"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ObjectStoreBackend", func() {
+	var (
+		objectStore *fakes.ObjectStore
+		server      *httptest.Server
+		baseURL     *url.URL
+		backend     storage.ObjectStoreBackend
+		now         time.Time
+	)
+
+	BeforeEach(func() {
+		objectStore = fakes.NewObjectStore()
+		server = httptest.NewServer(objectStore)
+
+		var err error
+		baseURL, err = url.Parse(server.URL + "/some-bucket/some-env")
+		Expect(err).NotTo(HaveOccurred())
+
+		backend = storage.NewObjectStoreBackend(baseURL, "", "", "us-east-1")
+
+		now = time.Date(2017, time.October, 1, 12, 0, 0, 0, time.UTC)
+		storage.SetTimeNow(func() time.Time { return now })
+	})
+
+	AfterEach(func() {
+		server.Close()
+		storage.ResetTimeNow()
+	})
+
+	Describe("Location", func() {
+		It("returns the base url", func() {
+			Expect(backend.Location()).To(Equal(server.URL + "/some-bucket/some-env"))
+		})
+	})
+
+	Describe("state", func() {
+		It("writes, reads and deletes bbl-state.json", func() {
+			err := backend.WriteState([]byte(`{"version": 12}`))
+			Expect(err).NotTo(HaveOccurred())
+
+			contents, ok := objectStore.Object("/some-bucket/some-env/bbl-state.json")
+			Expect(ok).To(BeTrue())
+			Expect(contents).To(MatchJSON(`{"version": 12}`))
+
+			contents, err = backend.ReadState()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(contents).To(MatchJSON(`{"version": 12}`))
+
+			err = backend.DeleteState()
+			Expect(err).NotTo(HaveOccurred())
+
+			_, ok = objectStore.Object("/some-bucket/some-env/bbl-state.json")
+			Expect(ok).To(BeFalse())
+		})
+
+		It("copies bbl-state.json to a backup object", func() {
+			objectStore.SetObject("/some-bucket/some-env/bbl-state.json", []byte(`{"version": 7}`))
+
+			err := backend.BackupState("some-backup.json")
+			Expect(err).NotTo(HaveOccurred())
+
+			contents, ok := objectStore.Object("/some-bucket/some-env/some-backup.json")
+			Expect(ok).To(BeTrue())
+			Expect(contents).To(MatchJSON(`{"version": 7}`))
+		})
+
+		Context("when bbl-state.json does not exist", func() {
+			It("returns StateNotFound", func() {
+				_, err := backend.ReadState()
+				Expect(err).To(Equal(storage.StateNotFound))
+			})
+		})
+
+		Context("when credentials are provided", func() {
+			It("signs requests", func() {
+				backend = storage.NewObjectStoreBackend(baseURL, "some-access-key-id", "some-secret-access-key", "some-region")
+
+				err := backend.WriteState([]byte(`{}`))
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(objectStore.Requests).To(HaveLen(1))
+				authorization := objectStore.Requests[0].Header.Get("Authorization")
+				Expect(authorization).To(HavePrefix("AWS4-HMAC-SHA256 Credential=some-access-key-id/20171001/some-region/s3/aws4_request"))
+			})
+		})
+
+		Context("when the object store responds with an unexpected status", func() {
+			BeforeEach(func() {
+				objectStore.ResponseStatus = http.StatusForbidden
+			})
+
+			It("returns an error", func() {
+				_, err := backend.ReadState()
+				Expect(err).To(MatchError("unexpected http response 403 Forbidden"))
+
+				err = backend.WriteState([]byte(`{}`))
+				Expect(err).To(MatchError("unexpected http response 403 Forbidden"))
+
+				err = backend.DeleteState()
+				Expect(err).To(MatchError("unexpected http response 403 Forbidden"))
+			})
+		})
+	})
+
+	Describe("leases", func() {
+		var lease storage.Lease
+
+		BeforeEach(func() {
+			lease = storage.Lease{
+				ID:      "some-lease-id",
+				Holder:  "some-holder",
+				Expires: now.Add(10 * time.Minute),
+			}
+		})
+
+		It("stores the lease next to the state", func() {
+			err := backend.AcquireLease(lease)
+			Expect(err).NotTo(HaveOccurred())
+
+			contents, ok := objectStore.Object("/some-bucket/some-env/bbl-state.lock")
+			Expect(ok).To(BeTrue())
+			Expect(contents).To(MatchJSON(`{
+				"id": "some-lease-id",
+				"holder": "some-holder",
+				"expires": "2017-10-01T12:10:00Z"
+			}`))
+
+			Expect(objectStore.Requests[1].Header.Get("If-None-Match")).To(Equal("*"))
+		})
+
+		It("renews a lease it already holds", func() {
+			err := backend.AcquireLease(lease)
+			Expect(err).NotTo(HaveOccurred())
+
+			lease.Expires = now.Add(20 * time.Minute)
+			err = backend.AcquireLease(lease)
+			Expect(err).NotTo(HaveOccurred())
+
+			contents, _ := objectStore.Object("/some-bucket/some-env/bbl-state.lock")
+			Expect(string(contents)).To(ContainSubstring("2017-10-01T12:20:00Z"))
+		})
+
+		It("deletes the lease on release", func() {
+			err := backend.AcquireLease(lease)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = backend.ReleaseLease(lease)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, ok := objectStore.Object("/some-bucket/some-env/bbl-state.lock")
+			Expect(ok).To(BeFalse())
+		})
+
+		Context("when another holder has an active lease", func() {
+			BeforeEach(func() {
+				objectStore.SetObject("/some-bucket/some-env/bbl-state.lock", []byte(`{
+					"id": "other-lease-id",
+					"holder": "other-holder",
+					"expires": "2017-10-01T12:05:00Z"
+				}`))
+			})
+
+			It("returns a lease held error", func() {
+				err := backend.AcquireLease(lease)
+				Expect(err).To(MatchError("bbl state is locked by other-holder until 2017-10-01T12:05:00Z. If that bbl is no longer running, run `bbl force-unlock`."))
+			})
+
+			It("does not release the other lease", func() {
+				err := backend.ReleaseLease(lease)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, ok := objectStore.Object("/some-bucket/some-env/bbl-state.lock")
+				Expect(ok).To(BeTrue())
+			})
+
+			Context("when the other lease has expired", func() {
+				It("takes over the lease", func() {
+					now = now.Add(6 * time.Minute)
+
+					err := backend.AcquireLease(lease)
+					Expect(err).NotTo(HaveOccurred())
+
+					contents, _ := objectStore.Object("/some-bucket/some-env/bbl-state.lock")
+					Expect(string(contents)).To(ContainSubstring("some-lease-id"))
+				})
+			})
+		})
+	})
+})
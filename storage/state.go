@@ -1,17 +1,28 @@
 package storage
 
 type State struct {
-	Version        int     `json:"version"`
-	IAAS           string  `json:"iaas"`
-	ID             string  `json:"id"`
-	NoDirector     bool    `json:"noDirector"`
-	AWS            AWS     `json:"aws,omitempty"`
-	Azure          Azure   `json:"azure,omitempty"`
-	GCP            GCP     `json:"gcp,omitempty"`
-	Jumpbox        Jumpbox `json:"jumpbox,omitempty"`
-	BOSH           BOSH    `json:"bosh,omitempty"`
-	EnvID          string  `json:"envID"`
-	TFState        string  `json:"tfState"`
-	LB             LB      `json:"lb"`
-	LatestTFOutput string  `json:"latestTFOutput"`
+	Version              int        `json:"version"`
+	IAAS                 string     `json:"iaas"`
+	ID                   string     `json:"id"`
+	NoDirector           bool       `json:"noDirector"`
+	AWS                  AWS        `json:"aws,omitempty"`
+	Azure                Azure      `json:"azure,omitempty"`
+	GCP                  GCP        `json:"gcp,omitempty"`
+	Jumpbox              Jumpbox    `json:"jumpbox,omitempty"`
+	BOSH                 BOSH       `json:"bosh,omitempty"`
+	EnvID                string     `json:"envID"`
+	TFState              string     `json:"tfState"`
+	TerraformOverrides   string     `json:"terraformOverrides,omitempty"`
+	ExistingNetwork      string     `json:"existingNetwork,omitempty"`
+	InternalCIDR         string     `json:"internalCIDR,omitempty"`
+	BOSHDeploymentDir    string     `json:"boshDeploymentDir,omitempty"`
+	JumpboxDeploymentDir string     `json:"jumpboxDeploymentDir,omitempty"`
+	DirectorVMType       string     `json:"directorVMType,omitempty"`
+	DirectorDiskSize     int        `json:"directorDiskSize,omitempty"`
+	JumpboxVMType        string     `json:"jumpboxVMType,omitempty"`
+	CloudConfigOpsFiles  []UserFile `json:"cloudConfigOpsFiles,omitempty"`
+	RuntimeConfigs       []UserFile `json:"runtimeConfigs,omitempty"`
+	CPIConfig            string     `json:"cpiConfig,omitempty"`
+	LB                   LB         `json:"lb"`
+	LatestTFOutput       string     `json:"latestTFOutput"`
 }
@@ -0,0 +1,100 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+const LeaseDuration = 10 * time.Minute
+
+type StateLock struct {
+	backend  Backend
+	holder   string
+	command  string
+	duration time.Duration
+
+	mutex sync.Mutex
+	lease Lease
+	stop  chan struct{}
+}
+
+func NewStateLock(backend Backend, holder, command string, duration time.Duration) *StateLock {
+	return &StateLock{
+		backend:  backend,
+		holder:   holder,
+		command:  command,
+		duration: duration,
+	}
+}
+
+// Lock acquires a lease on the bbl state and keeps renewing it in the
+// background until Unlock is called, so that a crashed bbl only blocks
+// others until the lease expires.
+func (l *StateLock) Lock() error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	id, err := uuidNewV4()
+	if err != nil {
+		return fmt.Errorf("Create lease ID: %s", err)
+	}
+
+	host, err := hostname()
+	if err != nil {
+		return fmt.Errorf("Get hostname: %s", err) //not tested
+	}
+
+	l.lease = Lease{
+		ID:      id.String(),
+		Holder:  l.holder,
+		Expires: timeNow().Add(l.duration),
+		PID:     os.Getpid(),
+		Host:    host,
+		Command: l.command,
+	}
+
+	err = l.backend.AcquireLease(l.lease)
+	if err != nil {
+		return err
+	}
+
+	l.stop = make(chan struct{})
+	go l.renew(l.stop)
+
+	return nil
+}
+
+func (l *StateLock) Unlock() error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.stop == nil {
+		return nil
+	}
+	close(l.stop)
+	l.stop = nil
+
+	return l.backend.ReleaseLease(l.lease)
+}
+
+func (l *StateLock) renew(stop chan struct{}) {
+	ticker := time.NewTicker(l.duration / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			l.mutex.Lock()
+			lease := l.lease
+			lease.Expires = timeNow().Add(l.duration)
+			if err := l.backend.AcquireLease(lease); err == nil {
+				l.lease = lease
+			}
+			l.mutex.Unlock()
+		}
+	}
+}
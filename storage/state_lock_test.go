@@ -0,0 +1,83 @@
+package storage_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("StateLock", func() {
+	var (
+		tempDir string
+		backend storage.LocalBackend
+		lock    *storage.StateLock
+	)
+
+	BeforeEach(func() {
+		var err error
+		tempDir, err = ioutil.TempDir("", "")
+		Expect(err).NotTo(HaveOccurred())
+
+		backend = storage.NewLocalBackend(tempDir)
+		lock = storage.NewStateLock(backend, "some-holder", "up", time.Minute)
+	})
+
+	It("acquires and releases a lease on the state", func() {
+		err := lock.Lock()
+		Expect(err).NotTo(HaveOccurred())
+
+		contents, err := ioutil.ReadFile(filepath.Join(tempDir, "bbl-state.lock"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(contents)).To(ContainSubstring(`"holder":"some-holder"`))
+		Expect(string(contents)).To(ContainSubstring(fmt.Sprintf(`"pid":%d`, os.Getpid())))
+		Expect(string(contents)).To(ContainSubstring(`"command":"up"`))
+
+		hostname, err := os.Hostname()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(contents)).To(ContainSubstring(fmt.Sprintf(`"host":%q`, hostname)))
+
+		err = lock.Unlock()
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = os.Stat(filepath.Join(tempDir, "bbl-state.lock"))
+		Expect(os.IsNotExist(err)).To(BeTrue())
+	})
+
+	It("prevents a second lock on the same state", func() {
+		err := lock.Lock()
+		Expect(err).NotTo(HaveOccurred())
+		defer lock.Unlock()
+
+		otherLock := storage.NewStateLock(backend, "other-holder", "destroy", time.Minute)
+		err = otherLock.Lock()
+		Expect(err).To(MatchError(ContainSubstring("bbl state is locked by some-holder")))
+	})
+
+	It("renews the lease while held", func() {
+		lock = storage.NewStateLock(backend, "some-holder", "up", 200*time.Millisecond)
+
+		err := lock.Lock()
+		Expect(err).NotTo(HaveOccurred())
+		defer lock.Unlock()
+
+		time.Sleep(400 * time.Millisecond)
+
+		otherLock := storage.NewStateLock(backend, "other-holder", "destroy", time.Minute)
+		err = otherLock.Lock()
+		Expect(err).To(MatchError(ContainSubstring("bbl state is locked by some-holder")))
+	})
+
+	Context("when unlocking without a lock", func() {
+		It("does nothing", func() {
+			err := lock.Unlock()
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+})
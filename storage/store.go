@@ -4,7 +4,6 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io/ioutil"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -18,7 +17,7 @@ var (
 )
 
 const (
-	STATE_VERSION = 12
+	STATE_VERSION = 13
 
 	OS_READ_WRITE_MODE = os.FileMode(0644)
 	StateFileName      = "bbl-state.json"
@@ -31,12 +30,14 @@ type logger interface {
 type Store struct {
 	dir     string
 	version int
+	backend Backend
 }
 
-func NewStore(dir string) Store {
+func NewStore(dir string, backend Backend) Store {
 	return Store{
 		dir:     dir,
 		version: STATE_VERSION,
+		backend: backend,
 	}
 }
 
@@ -46,10 +47,9 @@ func (s Store) Set(state State) error {
 		return fmt.Errorf("Stat state dir: %s", err)
 	}
 
-	stateFile := filepath.Join(s.dir, StateFileName)
 	if reflect.DeepEqual(state, State{}) {
-		err := os.Remove(stateFile)
-		if err != nil && !os.IsNotExist(err) {
+		err := s.backend.DeleteState()
+		if err != nil {
 			return err
 		}
 
@@ -76,6 +76,11 @@ func (s Store) Set(state State) error {
 		return nil
 	}
 
+	err = s.backupOutdatedState()
+	if err != nil {
+		return fmt.Errorf("Back up bbl state: %s", err)
+	}
+
 	state.Version = s.version
 
 	if state.ID == "" {
@@ -88,14 +93,17 @@ func (s Store) Set(state State) error {
 
 	state.AWS.AccessKeyID = ""
 	state.AWS.SecretAccessKey = ""
+	state.AWS.SessionToken = ""
 	state.GCP.ServiceAccountKey = ""
 	state.GCP.ProjectID = ""
+	state.Azure.ClientID = ""
+	state.Azure.ClientSecret = ""
 
 	jsonData, err := marshalIndent(state, "", "\t")
 	if err != nil {
 		return err
 	}
-	err = ioutil.WriteFile(stateFile, jsonData, os.FileMode(0644))
+	err = s.backend.WriteState(jsonData)
 	if err != nil {
 		return err
 	}
@@ -103,25 +111,50 @@ func (s Store) Set(state State) error {
 	return nil
 }
 
+// backupOutdatedState keeps a copy of a state written by an older bbl before
+// it is overwritten with the migrated schema.
+func (s Store) backupOutdatedState() error {
+	contents, err := s.backend.ReadState()
+	if err == StateNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var stored struct {
+		Version int `json:"version"`
+	}
+	err = json.Unmarshal(contents, &stored)
+	if err != nil || stored.Version == 0 || stored.Version >= s.version {
+		return nil
+	}
+
+	return s.backend.BackupState(BackupFileName(stored.Version))
+}
+
+func BackupFileName(version int) string {
+	return fmt.Sprintf("bbl-state.v%d.backup.json", version)
+}
+
 var GetStateLogger logger
 
-func GetState(dir string) (State, error) {
+func GetState(backend Backend) (State, error) {
 	state := State{}
 
-	_, err := os.Stat(dir)
-	if err != nil {
-		return state, err
-	}
-
-	file, err := os.Open(filepath.Join(dir, StateFileName))
+	contents, err := backend.ReadState()
 	if err != nil {
-		if os.IsNotExist(err) {
+		if err == StateNotFound {
 			return state, nil
 		}
 		return state, err
 	}
 
-	err = json.NewDecoder(file).Decode(&state)
+	if isEncrypted(contents) {
+		return state, StateEncrypted
+	}
+
+	err = json.Unmarshal(contents, &state)
 	if err != nil {
 		return state, err
 	}
@@ -133,7 +166,7 @@ func GetState(dir string) (State, error) {
 		}
 	}
 
-	if state.Version < 3 {
+	if state.Version < MinimumStateVersion {
 		return state, errors.New("Existing bbl environment is incompatible with bbl v3. Create a new environment with v3 to continue.")
 	}
 
@@ -141,6 +174,19 @@ func GetState(dir string) (State, error) {
 		return state, fmt.Errorf("Existing bbl environment was created with a newer version of bbl. Please upgrade to a version of bbl compatible with schema version %d.\n", state.Version)
 	}
 
+	if state.Version < STATE_VERSION {
+		migrated, _, err := MigrateState(contents)
+		if err != nil {
+			return State{}, err
+		}
+
+		state = State{}
+		err = json.Unmarshal(migrated, &state)
+		if err != nil {
+			return state, err //not tested
+		}
+	}
+
 	return state, nil
 }
 
@@ -160,6 +206,13 @@ func (s Store) GetVarsDir() (string, error) {
 	return s.getDir("vars")
 }
 
+// DeleteVarsDir removes the vars directory. bbl writes the terraform state and
+// the create-env state and vars-stores there from bbl-state.json on every run,
+// so with an encrypted state they are deleted once the run finishes.
+func (s Store) DeleteVarsDir() error {
+	return os.RemoveAll(filepath.Join(s.dir, "vars"))
+}
+
 func (s Store) GetDirectorDeploymentDir() (string, error) {
 	return s.getDir("bosh-deployment")
 }
@@ -25,7 +25,7 @@ var _ = Describe("Store", func() {
 		var err error
 		tempDir, err = ioutil.TempDir("", "")
 
-		store = storage.NewStore(tempDir)
+		store = storage.NewStore(tempDir, storage.NewLocalBackend(tempDir))
 		Expect(err).NotTo(HaveOccurred())
 	})
 
@@ -49,6 +49,7 @@ var _ = Describe("Store", func() {
 					AWS: storage.AWS{
 						AccessKeyID:     "some-aws-access-key-id",
 						SecretAccessKey: "some-aws-secret-access-key",
+						SessionToken:    "some-aws-session-token",
 						Region:          "some-region",
 					},
 					Azure: storage.Azure{
@@ -91,9 +92,9 @@ var _ = Describe("Store", func() {
 						State: map[string]interface{}{
 							"key": "value",
 						},
-						Variables:   "some-vars",
-						Manifest:    "name: bosh",
-						UserOpsFile: "some-ops-file",
+						Variables:    "some-vars",
+						Manifest:     "name: bosh",
+						UserOpsFiles: []storage.UserFile{{Name: "some-ops-file.yml", Contents: "some-ops"}},
 					},
 					EnvID:   "some-env-id",
 					TFState: "some-tf-state",
@@ -103,15 +104,13 @@ var _ = Describe("Store", func() {
 				data, err := ioutil.ReadFile(filepath.Join(tempDir, "bbl-state.json"))
 				Expect(err).NotTo(HaveOccurred())
 				Expect(data).To(MatchJSON(`{
-				"version": 12,
+				"version": 13,
 				"iaas": "aws",
 				"noDirector": false,
 				"aws": {
 					"region": "some-region"
 				},
 				"azure": {
-					"clientId": "client-id",
-					"clientSecret": "client-secret",
 					"location": "location",
 					"subscriptionId": "subscription-id",
 					"tenantId": "tenant-id"
@@ -146,7 +145,7 @@ var _ = Describe("Store", func() {
 					"directorSSLPrivateKey": "some-bosh-ssl-private-key",
 					"variables":   "some-vars",
 					"manifest": "name: bosh",
-					"userOpsFile": "some-ops-file",
+					"userOpsFiles": [{"name": "some-ops-file.yml", "contents": "some-ops"}],
 					"state": {
 						"key": "value"
 					}
@@ -163,6 +162,27 @@ var _ = Describe("Store", func() {
 			})
 		})
 
+		Context("when the stored state was written by an older bbl", func() {
+			It("backs up the old state before overwriting it", func() {
+				err := ioutil.WriteFile(filepath.Join(tempDir, "bbl-state.json"), []byte(`{"version": 7, "envID": "some-env-id"}`), os.ModePerm)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = store.Set(storage.State{EnvID: "some-env-id"})
+				Expect(err).NotTo(HaveOccurred())
+
+				backup, err := ioutil.ReadFile(filepath.Join(tempDir, "bbl-state.v7.backup.json"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(backup).To(MatchJSON(`{"version": 7, "envID": "some-env-id"}`))
+
+				err = store.Set(storage.State{EnvID: "some-other-env-id"})
+				Expect(err).NotTo(HaveOccurred())
+
+				backup, err = ioutil.ReadFile(filepath.Join(tempDir, "bbl-state.v7.backup.json"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(backup).To(MatchJSON(`{"version": 7, "envID": "some-env-id"}`))
+			})
+		})
+
 		Context("when the state is empty", func() {
 			It("removes the bbl-state.json file", func() {
 				err := ioutil.WriteFile(filepath.Join(tempDir, "bbl-state.json"), []byte("{}"), os.ModePerm)
@@ -194,6 +214,7 @@ var _ = Describe("Store", func() {
 				Entry("bosh-deployment", "bosh-deployment", true),
 				Entry("jumpbox-deployment", "jumpbox-deployment", true),
 				Entry("vars", "vars", true),
+				Entry("state-history", "state-history", false),
 				Entry("non-bbl directory", "foo", false),
 			)
 
@@ -256,7 +277,7 @@ var _ = Describe("Store", func() {
 				})
 
 				It("returns an error", func() {
-					store = storage.NewStore("non-valid-dir")
+					store = storage.NewStore("non-valid-dir", storage.NewLocalBackend("non-valid-dir"))
 					err := store.Set(storage.State{})
 					Expect(err).To(MatchError(ContainSubstring("no such file or directory")))
 				})
@@ -291,10 +312,10 @@ var _ = Describe("Store", func() {
 			})
 
 			It("returns a new state", func() {
-				state, err := storage.GetState(tempDir)
+				state, err := storage.GetState(storage.NewLocalBackend(tempDir))
 				Expect(err).NotTo(HaveOccurred())
 				Expect(state).To(Equal(storage.State{
-					Version: 12,
+					Version: 13,
 				}))
 			})
 		})
@@ -308,7 +329,7 @@ var _ = Describe("Store", func() {
 			})
 
 			It("returns an error", func() {
-				_, err := storage.GetState(tempDir)
+				_, err := storage.GetState(storage.NewLocalBackend(tempDir))
 				Expect(err).To(MatchError("Existing bbl environment is incompatible with bbl v3. Create a new environment with v3 to continue."))
 			})
 		})
@@ -316,7 +337,7 @@ var _ = Describe("Store", func() {
 		Context("when there is a v11 state file", func() {
 			BeforeEach(func() {
 				err := ioutil.WriteFile(filepath.Join(tempDir, "bbl-state.json"), []byte(`{
-					"version": 12,
+					"version": 13,
 					"iaas": "aws",
 					"aws": {
 						"accessKeyId": "some-aws-access-key-id",
@@ -335,11 +356,11 @@ var _ = Describe("Store", func() {
 			})
 
 			It("returns the stored state information", func() {
-				state, err := storage.GetState(tempDir)
+				state, err := storage.GetState(storage.NewLocalBackend(tempDir))
 				Expect(err).NotTo(HaveOccurred())
 
 				Expect(state).To(Equal(storage.State{
-					Version: 12,
+					Version: 13,
 					IAAS:    "aws",
 					AWS: storage.AWS{
 						AccessKeyID:     "some-aws-access-key-id",
@@ -357,6 +378,46 @@ var _ = Describe("Store", func() {
 			})
 		})
 
+		Context("when there is a state file from an older version", func() {
+			BeforeEach(func() {
+				err := ioutil.WriteFile(filepath.Join(tempDir, "bbl-state.json"), []byte(`{
+					"version": 6,
+					"iaas": "gcp",
+					"keyPair": {
+						"privateKey": "some-private-key",
+						"publicKey": "some-public-key"
+					},
+					"jumpbox": {
+						"enabled": true,
+						"url": "some-jumpbox-url"
+					}
+				}`), os.ModePerm)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("migrates the state to the current version", func() {
+				state, err := storage.GetState(storage.NewLocalBackend(tempDir))
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(state).To(Equal(storage.State{
+					Version: 13,
+					IAAS:    "gcp",
+					Jumpbox: storage.Jumpbox{
+						URL: "some-jumpbox-url",
+					},
+				}))
+			})
+
+			It("does not modify the stored state", func() {
+				_, err := storage.GetState(storage.NewLocalBackend(tempDir))
+				Expect(err).NotTo(HaveOccurred())
+
+				contents, err := ioutil.ReadFile(filepath.Join(tempDir, "bbl-state.json"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(contents)).To(ContainSubstring(`"version": 6`))
+			})
+		})
+
 		Context("when there is a state file with a newer version than internal version", func() {
 			BeforeEach(func() {
 				err := ioutil.WriteFile(filepath.Join(tempDir, "bbl-state.json"), []byte(`{
@@ -366,14 +427,35 @@ var _ = Describe("Store", func() {
 			})
 
 			It("returns an error", func() {
-				_, err := storage.GetState(tempDir)
+				_, err := storage.GetState(storage.NewLocalBackend(tempDir))
 				Expect(err).To(MatchError("Existing bbl environment was created with a newer version of bbl. Please upgrade to a version of bbl compatible with schema version 9999.\n"))
 			})
 		})
 
+		Context("when the bbl-state.json file is encrypted", func() {
+			BeforeEach(func() {
+				err := storage.NewEncryptedBackend(storage.NewLocalBackend(tempDir), "some-key").WriteState([]byte(`{
+					"version": 13,
+					"iaas": "gcp"
+				}`))
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("decrypts the state with the encrypted backend", func() {
+				state, err := storage.GetState(storage.NewEncryptedBackend(storage.NewLocalBackend(tempDir), "some-key"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(state.IAAS).To(Equal("gcp"))
+			})
+
+			It("returns an error without an encryption key", func() {
+				_, err := storage.GetState(storage.NewLocalBackend(tempDir))
+				Expect(err).To(Equal(storage.StateEncrypted))
+			})
+		})
+
 		Context("when the bbl-state.json file doesn't exist", func() {
 			It("returns an empty state object", func() {
-				state, err := storage.GetState(tempDir)
+				state, err := storage.GetState(storage.NewLocalBackend(tempDir))
 				Expect(err).NotTo(HaveOccurred())
 
 				Expect(state).To(Equal(storage.State{}))
@@ -398,7 +480,7 @@ var _ = Describe("Store", func() {
 							err := os.Chmod(tempDir, os.FileMode(0000))
 							Expect(err).NotTo(HaveOccurred())
 
-							_, err = storage.GetState(tempDir)
+							_, err = storage.GetState(storage.NewLocalBackend(tempDir))
 							Expect(err).To(MatchError(ContainSubstring("permission denied")))
 						})
 					})
@@ -409,7 +491,7 @@ var _ = Describe("Store", func() {
 		Context("failure cases", func() {
 			Context("when the directory does not exist", func() {
 				It("returns an error", func() {
-					_, err := storage.GetState("some-fake-directory")
+					_, err := storage.GetState(storage.NewLocalBackend("some-fake-directory"))
 					Expect(err).To(MatchError(ContainSubstring("no such file or directory")))
 				})
 			})
@@ -419,7 +501,7 @@ var _ = Describe("Store", func() {
 					err := os.Chmod(tempDir, 0000)
 					Expect(err).NotTo(HaveOccurred())
 
-					_, err = storage.GetState(tempDir)
+					_, err = storage.GetState(storage.NewLocalBackend(tempDir))
 					Expect(err).To(MatchError(ContainSubstring("permission denied")))
 				})
 			})
@@ -429,7 +511,7 @@ var _ = Describe("Store", func() {
 					err := ioutil.WriteFile(filepath.Join(tempDir, "bbl-state.json"), []byte(`%%%%`), os.ModePerm)
 					Expect(err).NotTo(HaveOccurred())
 
-					_, err = storage.GetState(tempDir)
+					_, err = storage.GetState(storage.NewLocalBackend(tempDir))
 					Expect(err).To(MatchError(ContainSubstring("invalid character")))
 				})
 			})
@@ -495,6 +577,22 @@ var _ = Describe("Store", func() {
 		Entry("jumpbox-deployment", "jumpbox-deployment", func() (string, error) { return store.GetJumpboxDeploymentDir() }),
 	)
 
+	Describe("DeleteVarsDir", func() {
+		It("removes the vars directory and the files in it", func() {
+			varsDir, err := store.GetVarsDir()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = ioutil.WriteFile(filepath.Join(varsDir, "terraform.tfstate"), []byte("some-tf-state"), storage.OS_READ_WRITE_MODE)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = store.DeleteVarsDir()
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = os.Stat(varsDir)
+			Expect(os.IsNotExist(err)).To(BeTrue())
+		})
+	})
+
 	Describe("GetCloudConfigDir", func() {
 		var expectedDir string
 
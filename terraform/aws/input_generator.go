@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/cloudfoundry/bosh-bootloader/aws/ec2"
+	"github.com/cloudfoundry/bosh-bootloader/bosh"
 	"github.com/cloudfoundry/bosh-bootloader/storage"
 )
 
@@ -33,6 +34,16 @@ func (i InputGenerator) Generate(state storage.State) (map[string]string, error)
 		return map[string]string{}, err
 	}
 
+	internalNetwork, err := bosh.ParseInternalNetwork(state.InternalCIDR)
+	if err != nil {
+		return map[string]string{}, err
+	}
+
+	err = internalNetwork.ValidateLayout(len(azs))
+	if err != nil {
+		return map[string]string{}, err
+	}
+
 	shortEnvID := state.EnvID
 	if len(shortEnvID) > terraformNameCharLimit {
 		sha1 := fmt.Sprintf("%x", sha1.Sum([]byte(state.EnvID)))
@@ -44,9 +55,12 @@ func (i InputGenerator) Generate(state storage.State) (map[string]string, error)
 		"short_env_id":           shortEnvID,
 		"access_key":             state.AWS.AccessKeyID,
 		"secret_key":             state.AWS.SecretAccessKey,
+		"session_token":          state.AWS.SessionToken,
 		"region":                 state.AWS.Region,
 		"bosh_availability_zone": "",
 		"availability_zones":     string(zones),
+		"vpc_cidr":               internalNetwork.CIDR().String(),
+		"bosh_subnet_cidr":       internalNetwork.DirectorSubnet().String(),
 	}
 
 	if state.LB.Type == "cf" || state.LB.Type == "concourse" {
@@ -49,6 +49,7 @@ var _ = Describe("InputGenerator", func() {
 				AWS: storage.AWS{
 					AccessKeyID:     "some-access-key-id",
 					SecretAccessKey: "some-secret-access-key",
+					SessionToken:    "some-session-token",
 					Region:          "some-region",
 				},
 			})
@@ -61,13 +62,29 @@ var _ = Describe("InputGenerator", func() {
 				"short_env_id":           "some-env-id",
 				"access_key":             "some-access-key-id",
 				"secret_key":             "some-secret-access-key",
+				"session_token":          "some-session-token",
 				"region":                 "some-region",
 				"bosh_availability_zone": "",
 				"availability_zones":     `["z1","z2","z3"]`,
+				"vpc_cidr":               "10.0.0.0/16",
+				"bosh_subnet_cidr":       "10.0.0.0/24",
 			}))
 		})
 	})
 
+	Context("when an internal cidr is provided", func() {
+		It("derives the vpc and bosh subnet cidrs from it", func() {
+			inputs, err := inputGenerator.Generate(storage.State{
+				EnvID:        "some-env-id",
+				InternalCIDR: "172.16.32.0/19",
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(inputs["vpc_cidr"]).To(Equal("172.16.32.0/19"))
+			Expect(inputs["bosh_subnet_cidr"]).To(Equal("172.16.32.0/27"))
+		})
+	})
+
 	Context("when a cf lb exists", func() {
 		var state storage.State
 
@@ -101,9 +118,12 @@ var _ = Describe("InputGenerator", func() {
 				"short_env_id":                "some-env-id",
 				"access_key":                  "some-access-key-id",
 				"secret_key":                  "some-secret-access-key",
+				"session_token":               "",
 				"region":                      "some-region",
 				"bosh_availability_zone":      "",
 				"availability_zones":          `["z1","z2","z3"]`,
+				"vpc_cidr":                    "10.0.0.0/16",
+				"bosh_subnet_cidr":            "10.0.0.0/24",
 				"ssl_certificate":             "some-cert",
 				"ssl_certificate_chain":       "some-chain",
 				"ssl_certificate_private_key": "some-key",
@@ -126,9 +146,12 @@ var _ = Describe("InputGenerator", func() {
 					"short_env_id":                "some-env-id",
 					"access_key":                  "some-access-key-id",
 					"secret_key":                  "some-secret-access-key",
+					"session_token":               "",
 					"region":                      "some-region",
 					"bosh_availability_zone":      "",
 					"availability_zones":          `["z1","z2","z3"]`,
+					"vpc_cidr":                    "10.0.0.0/16",
+					"bosh_subnet_cidr":            "10.0.0.0/24",
 					"ssl_certificate":             "some-cert",
 					"ssl_certificate_chain":       "some-chain",
 					"ssl_certificate_private_key": "some-key",
@@ -170,9 +193,12 @@ var _ = Describe("InputGenerator", func() {
 				"short_env_id":                "some-env-id",
 				"access_key":                  "some-access-key-id",
 				"secret_key":                  "some-secret-access-key",
+				"session_token":               "",
 				"region":                      "some-region",
 				"bosh_availability_zone":      "",
 				"availability_zones":          `["z1","z2","z3"]`,
+				"vpc_cidr":                    "10.0.0.0/16",
+				"bosh_subnet_cidr":            "10.0.0.0/24",
 				"ssl_certificate":             "some-cert",
 				"ssl_certificate_chain":       "some-chain",
 				"ssl_certificate_private_key": "some-key",
@@ -206,5 +232,23 @@ var _ = Describe("InputGenerator", func() {
 				Expect(err).To(MatchError("failed to marshal"))
 			})
 		})
+
+		Context("when the internal cidr cannot hold every availability zone", func() {
+			BeforeEach(func() {
+				availabilityZoneRetriever.RetrieveAvailabilityZonesCall.Returns.AZs = make([]string, 15)
+			})
+
+			It("returns an error", func() {
+				_, err := inputGenerator.Generate(storage.State{})
+				Expect(err).To(MatchError(ContainSubstring("Internal CIDR 10.0.0.0/16 cannot hold 15 availability zones")))
+			})
+		})
+
+		Context("when the internal cidr is invalid", func() {
+			It("returns an error", func() {
+				_, err := inputGenerator.Generate(storage.State{InternalCIDR: "10.0.0.0/24"})
+				Expect(err).To(MatchError("Internal CIDR 10.0.0.0/24 must be between a /16 and a /19"))
+			})
+		})
 	})
 })
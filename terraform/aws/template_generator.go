@@ -3,6 +3,8 @@ package aws
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
+	"regexp"
 	"strings"
 	"text/template"
 
@@ -108,9 +110,43 @@ func (tg TemplateGenerator) Generate(state storage.State) string {
 		panic(err)
 	}
 
+	if state.ExistingNetwork != "" {
+		return useExistingVPC(finalTemplate.String(), state.ExistingNetwork)
+	}
+
 	return finalTemplate.String()
 }
 
+var (
+	vpcResource             = regexp.MustCompile(`(?s)resource "aws_vpc" "vpc" \{\n.*?\n\}\n`)
+	internetGatewayResource = regexp.MustCompile(`(?s)resource "aws_internet_gateway" "ig" \{\n.*?\n\}\n`)
+	internetGatewayDepends  = regexp.MustCompile(`(?m)^\s*depends_on = \["aws_internet_gateway.ig"\]\n`)
+)
+
+// useExistingVPC replaces the VPC and internet gateway in tmpl with data
+// sources for vpcID, so that they are referenced but never created or
+// destroyed by bbl.
+func useExistingVPC(tmpl, vpcID string) string {
+	tmpl = vpcResource.ReplaceAllLiteralString(tmpl, fmt.Sprintf(`data "aws_vpc" "vpc" {
+  id = %q
+}
+`, vpcID))
+
+	tmpl = internetGatewayResource.ReplaceAllLiteralString(tmpl, fmt.Sprintf(`data "aws_internet_gateway" "ig" {
+  filter {
+    name   = "attachment.vpc-id"
+    values = [%q]
+  }
+}
+`, vpcID))
+
+	tmpl = internetGatewayDepends.ReplaceAllLiteralString(tmpl, "")
+	tmpl = strings.Replace(tmpl, "${aws_vpc.vpc.", "${data.aws_vpc.vpc.", -1)
+	tmpl = strings.Replace(tmpl, "${aws_internet_gateway.ig.", "${data.aws_internet_gateway.ig.", -1)
+
+	return tmpl
+}
+
 func readTemplates() templates {
 	tmpls := templates{}
 	tmpls.base = string(MustAsset("templates/base.tf"))
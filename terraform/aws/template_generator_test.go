@@ -40,5 +40,31 @@ var _ = Describe("TemplateGenerator", func() {
 			Entry("when a cf lb type is provided", "fixtures/template_cf_lb.tf", "cf", ""),
 			Entry("when a cf lb type is provided with a system domain", "fixtures/template_cf_lb_with_domain.tf", "cf", "some-domain"),
 		)
+
+		Context("when an existing network is provided", func() {
+			It("references the vpc and internet gateway instead of creating them", func() {
+				template := templateGenerator.Generate(storage.State{
+					ExistingNetwork: "vpc-12345",
+					LB:              storage.LB{Type: "cf"},
+				})
+
+				Expect(template).To(ContainSubstring(`data "aws_vpc" "vpc" {
+  id = "vpc-12345"
+}`))
+				Expect(template).To(ContainSubstring(`data "aws_internet_gateway" "ig" {
+  filter {
+    name   = "attachment.vpc-id"
+    values = ["vpc-12345"]
+  }
+}`))
+				Expect(template).To(ContainSubstring(`vpc_id      = "${data.aws_vpc.vpc.id}"`))
+				Expect(template).To(ContainSubstring(`gateway_id = "${data.aws_internet_gateway.ig.id}"`))
+
+				Expect(template).NotTo(ContainSubstring(`resource "aws_vpc"`))
+				Expect(template).NotTo(ContainSubstring(`resource "aws_internet_gateway"`))
+				Expect(template).NotTo(ContainSubstring("${aws_vpc.vpc."))
+				Expect(template).NotTo(ContainSubstring("aws_internet_gateway.ig\""))
+			})
+		})
 	})
 })
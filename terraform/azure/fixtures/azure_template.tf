@@ -26,6 +26,10 @@ variable "client_secret" {
 	type = "string"
 }
 
+variable "internal_cidr" {
+	type = "string"
+}
+
 provider "azurerm" {
   subscription_id  = "${var.subscription_id}"
   tenant_id        = "${var.tenant_id}"
@@ -55,14 +59,14 @@ resource "azurerm_public_ip" "bosh" {
 
 resource "azurerm_virtual_network" "bosh" {
   name                = "${var.env_id}-bosh-vn"
-  address_space       = ["10.0.0.0/16"]
+  address_space       = ["${var.internal_cidr}"]
   location            = "${var.location}"
   resource_group_name = "${azurerm_resource_group.bosh.name}"
 }
 
 resource "azurerm_subnet" "bosh" {
   name                 = "${var.env_id}-bosh-sn"
-  address_prefix       = "10.0.0.0/16"
+  address_prefix       = "${var.internal_cidr}"
   resource_group_name  = "${azurerm_resource_group.bosh.name}"
   virtual_network_name = "${azurerm_virtual_network.bosh.name}"
 }
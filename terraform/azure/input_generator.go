@@ -3,6 +3,7 @@ package azure
 import (
 	"strings"
 
+	"github.com/cloudfoundry/bosh-bootloader/bosh"
 	"github.com/cloudfoundry/bosh-bootloader/storage"
 )
 
@@ -14,6 +15,11 @@ func NewInputGenerator() InputGenerator {
 }
 
 func (i InputGenerator) Generate(state storage.State) (map[string]string, error) {
+	internalNetwork, err := bosh.ParseInternalNetwork(state.InternalCIDR)
+	if err != nil {
+		return map[string]string{}, err
+	}
+
 	simpleEnvId := strings.Replace(state.EnvID, "-", "", -1)
 	if len(simpleEnvId) > 20 {
 		simpleEnvId = simpleEnvId[:20]
@@ -26,6 +32,22 @@ func (i InputGenerator) Generate(state storage.State) (map[string]string, error)
 		"tenant_id":       state.Azure.TenantID,
 		"client_id":       state.Azure.ClientID,
 		"client_secret":   state.Azure.ClientSecret,
+		"internal_cidr":   internalNetwork.CIDR().String(),
+	}
+
+	if state.LB.Type == "cf" {
+		applicationGatewaySubnet, err := internalNetwork.ApplicationGatewaySubnet()
+		if err != nil {
+			return map[string]string{}, err
+		}
+
+		input["application_gateway_cidr"] = applicationGatewaySubnet.String()
+		input["pfx_cert_base64"] = state.LB.Cert
+		input["pfx_password"] = state.LB.Key
+
+		if state.LB.Domain != "" {
+			input["system_domain"] = state.LB.Domain
+		}
 	}
 
 	return input, nil
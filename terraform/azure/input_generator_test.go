@@ -42,9 +42,61 @@ var _ = Describe("InputGenerator", func() {
 			"tenant_id":       state.Azure.TenantID,
 			"client_id":       state.Azure.ClientID,
 			"client_secret":   state.Azure.ClientSecret,
+			"internal_cidr":   "10.0.0.0/16",
 		}))
 	})
 
+	Context("when a cf load balancer is requested", func() {
+		BeforeEach(func() {
+			state.InternalCIDR = "10.0.0.0/19"
+			state.LB = storage.LB{
+				Type: "cf",
+				Cert: "some-base64-pfx",
+				Key:  "some-password",
+			}
+		})
+
+		It("adds the application gateway subnet and certificate", func() {
+			inputs, err := inputGenerator.Generate(state)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(inputs).To(HaveKeyWithValue("internal_cidr", "10.0.0.0/19"))
+			Expect(inputs).To(HaveKeyWithValue("application_gateway_cidr", "10.0.32.0/24"))
+			Expect(inputs).To(HaveKeyWithValue("pfx_cert_base64", "some-base64-pfx"))
+			Expect(inputs).To(HaveKeyWithValue("pfx_password", "some-password"))
+			Expect(inputs).NotTo(HaveKey("system_domain"))
+		})
+
+		It("adds the system domain when a domain is given", func() {
+			state.LB.Domain = "some-domain"
+
+			inputs, err := inputGenerator.Generate(state)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(inputs).To(HaveKeyWithValue("system_domain", "some-domain"))
+		})
+
+		Context("when the range after the internal cidr is not private", func() {
+			It("returns an error", func() {
+				state.InternalCIDR = "192.168.0.0/16"
+
+				_, err := inputGenerator.Generate(state)
+				Expect(err).To(MatchError("The application gateway subnet 192.169.0.0/24 after the internal CIDR 192.168.0.0/16 is not a private range, use a lower internal CIDR"))
+			})
+		})
+	})
+
+	Context("when a concourse load balancer is requested", func() {
+		It("does not add any load balancer inputs", func() {
+			state.LB = storage.LB{Type: "concourse"}
+
+			inputs, err := inputGenerator.Generate(state)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(inputs).To(HaveLen(8))
+		})
+	})
+
 	Context("given a long environment id", func() {
 		It("shortens the id for simple_env_id", func() {
 			state.EnvID = "super-long-environment-id-with-999"
@@ -59,6 +111,7 @@ var _ = Describe("InputGenerator", func() {
 				"tenant_id":       state.Azure.TenantID,
 				"client_id":       state.Azure.ClientID,
 				"client_secret":   state.Azure.ClientSecret,
+				"internal_cidr":   "10.0.0.0/16",
 			}))
 		})
 	})
@@ -1,6 +1,8 @@
 package azure
 
 import (
+	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/cloudfoundry/bosh-bootloader/storage"
@@ -14,6 +16,9 @@ type templates struct {
 	networkSecurityGroup string
 	output               string
 	tls                  string
+	cfLB                 string
+	cfDNS                string
+	concourseLB          string
 }
 
 type TemplateGenerator struct{}
@@ -24,7 +29,56 @@ func NewTemplateGenerator() TemplateGenerator {
 
 func (t TemplateGenerator) Generate(state storage.State) string {
 	tmpls := readTemplates()
-	return strings.Join([]string{tmpls.vars, tmpls.resourceGroup, tmpls.network, tmpls.storage, tmpls.networkSecurityGroup, tmpls.output, tmpls.tls}, "\n")
+	template := strings.Join([]string{tmpls.vars, tmpls.resourceGroup, tmpls.network, tmpls.storage, tmpls.networkSecurityGroup, tmpls.output, tmpls.tls}, "\n")
+
+	switch state.LB.Type {
+	case "concourse":
+		template = strings.Join([]string{template, tmpls.concourseLB}, "\n")
+	case "cf":
+		// The application gateway needs a subnet of its own, outside of the
+		// bosh subnet which takes up the whole internal CIDR.
+		template = strings.Replace(template, `address_space       = ["${var.internal_cidr}"]`,
+			`address_space       = ["${var.internal_cidr}", "${var.application_gateway_cidr}"]`, 1)
+		template = strings.Join([]string{template, tmpls.cfLB}, "\n")
+
+		if state.LB.Domain != "" {
+			template = strings.Join([]string{template, tmpls.cfDNS}, "\n")
+		}
+	}
+
+	if state.ExistingNetwork != "" {
+		return useExistingNetwork(template, state.ExistingNetwork)
+	}
+
+	return template
+}
+
+var (
+	resourceGroupResource  = regexp.MustCompile(`(?s)resource "azurerm_resource_group" "bosh" \{\n.*?\n\}\n`)
+	virtualNetworkResource = regexp.MustCompile(`(?s)resource "azurerm_virtual_network" "bosh" \{\n.*?\n\}\n`)
+)
+
+// useExistingNetwork replaces the resource group and virtual network in
+// template with data sources for existingNetwork, given as
+// <resource-group>/<virtual-network>. The rest of the environment is created
+// in that resource group, and the adopted resources are never destroyed.
+func useExistingNetwork(template, existingNetwork string) string {
+	parts := strings.SplitN(existingNetwork, "/", 2)
+	resourceGroup, virtualNetwork := parts[0], parts[len(parts)-1]
+
+	template = resourceGroupResource.ReplaceAllLiteralString(template, fmt.Sprintf(`data "azurerm_resource_group" "bosh" {
+  name = %q
+}
+`, resourceGroup))
+
+	template = virtualNetworkResource.ReplaceAllLiteralString(template, fmt.Sprintf(`data "azurerm_virtual_network" "bosh" {
+  name                = %q
+  resource_group_name = %q
+}
+`, virtualNetwork, resourceGroup))
+
+	template = strings.Replace(template, "${azurerm_resource_group.bosh.", "${data.azurerm_resource_group.bosh.", -1)
+	return strings.Replace(template, "${azurerm_virtual_network.bosh.", "${data.azurerm_virtual_network.bosh.", -1)
 }
 
 func readTemplates() templates {
@@ -36,6 +90,9 @@ func readTemplates() templates {
 	tmpls.networkSecurityGroup = string(MustAsset("templates/network_security_group.tf"))
 	tmpls.output = string(MustAsset("templates/output.tf"))
 	tmpls.tls = string(MustAsset("templates/tls.tf"))
+	tmpls.cfLB = string(MustAsset("templates/cf_lb.tf"))
+	tmpls.cfDNS = string(MustAsset("templates/cf_dns.tf"))
+	tmpls.concourseLB = string(MustAsset("templates/concourse_lb.tf"))
 
 	return tmpls
 }
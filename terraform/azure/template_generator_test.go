@@ -34,5 +34,62 @@ var _ = Describe("TemplateGenerator", func() {
 			})
 			Expect(template).To(Equal(string(expectedTemplate)))
 		})
+
+		Context("when a concourse lb type is provided", func() {
+			It("adds the concourse load balancer", func() {
+				template := templateGenerator.Generate(storage.State{
+					LB: storage.LB{Type: "concourse"},
+				})
+
+				Expect(template).To(ContainSubstring(string(azure.MustAsset("templates/concourse_lb.tf"))))
+				Expect(template).NotTo(ContainSubstring(`resource "azurerm_application_gateway"`))
+				Expect(template).To(ContainSubstring(`address_space       = ["${var.internal_cidr}"]`))
+			})
+		})
+
+		Context("when a cf lb type is provided", func() {
+			It("adds the application gateway and load balancers, and a subnet for the gateway", func() {
+				template := templateGenerator.Generate(storage.State{
+					LB: storage.LB{Type: "cf"},
+				})
+
+				Expect(template).To(ContainSubstring(string(azure.MustAsset("templates/cf_lb.tf"))))
+				Expect(template).To(ContainSubstring(`address_space       = ["${var.internal_cidr}", "${var.application_gateway_cidr}"]`))
+				Expect(template).NotTo(ContainSubstring(`resource "azurerm_lb" "concourse"`))
+				Expect(template).NotTo(ContainSubstring(`resource "azurerm_dns_zone"`))
+			})
+
+			It("adds a dns zone when a domain is given", func() {
+				template := templateGenerator.Generate(storage.State{
+					LB: storage.LB{Type: "cf", Domain: "some-domain"},
+				})
+
+				Expect(template).To(ContainSubstring(string(azure.MustAsset("templates/cf_dns.tf"))))
+			})
+		})
+
+		Context("when an existing network is provided", func() {
+			It("references the resource group and virtual network instead of creating them", func() {
+				template := templateGenerator.Generate(storage.State{
+					EnvID:           "azure-environment",
+					ExistingNetwork: "some-resource-group/some-vnet",
+				})
+
+				Expect(template).To(ContainSubstring(`data "azurerm_resource_group" "bosh" {
+  name = "some-resource-group"
+}`))
+				Expect(template).To(ContainSubstring(`data "azurerm_virtual_network" "bosh" {
+  name                = "some-vnet"
+  resource_group_name = "some-resource-group"
+}`))
+				Expect(template).To(ContainSubstring(`virtual_network_name = "${data.azurerm_virtual_network.bosh.name}"`))
+				Expect(template).To(ContainSubstring(`resource_group_name  = "${data.azurerm_resource_group.bosh.name}"`))
+
+				Expect(template).NotTo(ContainSubstring(`resource "azurerm_resource_group"`))
+				Expect(template).NotTo(ContainSubstring(`resource "azurerm_virtual_network"`))
+				Expect(template).NotTo(ContainSubstring("${azurerm_resource_group.bosh."))
+				Expect(template).NotTo(ContainSubstring("${azurerm_virtual_network.bosh."))
+			})
+		})
 	})
 })
@@ -1,5 +1,8 @@
-// Code generated by go-bindata.
+// Package azure Code generated by go-bindata. (@generated) DO NOT EDIT.
 // sources:
+// templates/cf_dns.tf
+// templates/cf_lb.tf
+// templates/concourse_lb.tf
 // templates/network.tf
 // templates/network_security_group.tf
 // templates/output.tf
@@ -7,8 +10,6 @@
 // templates/storage.tf
 // templates/tls.tf
 // templates/vars.tf
-// DO NOT EDIT!
-
 package azure
 
 import (
@@ -26,7 +27,7 @@ import (
 func bindataRead(data []byte, name string) ([]byte, error) {
 	gz, err := gzip.NewReader(bytes.NewBuffer(data))
 	if err != nil {
-		return nil, fmt.Errorf("Read %q: %v", name, err)
+		return nil, fmt.Errorf("read %q: %v", name, err)
 	}
 
 	var buf bytes.Buffer
@@ -34,7 +35,7 @@ func bindataRead(data []byte, name string) ([]byte, error) {
 	clErr := gz.Close()
 
 	if err != nil {
-		return nil, fmt.Errorf("Read %q: %v", name, err)
+		return nil, fmt.Errorf("read %q: %v", name, err)
 	}
 	if clErr != nil {
 		return nil, err
@@ -55,26 +56,97 @@ type bindataFileInfo struct {
 	modTime time.Time
 }
 
+// Name return file name
 func (fi bindataFileInfo) Name() string {
 	return fi.name
 }
+
+// Size return file size
 func (fi bindataFileInfo) Size() int64 {
 	return fi.size
 }
+
+// Mode return file mode
 func (fi bindataFileInfo) Mode() os.FileMode {
 	return fi.mode
 }
+
+// ModTime return file modify time
 func (fi bindataFileInfo) ModTime() time.Time {
 	return fi.modTime
 }
+
+// IsDir return file whether a directory
 func (fi bindataFileInfo) IsDir() bool {
-	return false
+	return fi.mode&os.ModeDir != 0
 }
+
+// Sys return file is sys mode
 func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
-var _templatesNetworkTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x91\xcb\x4e\xc5\x20\x10\x40\xf7\x7c\xc5\x64\xe2\xb6\x68\x37\xee\xfc\x12\x63\x08\x6d\x47\x25\xb6\xd0\x0c\x0f\x8d\x0d\xff\x6e\x68\x52\xb5\xdc\x36\xf7\xc2\x92\xc3\xe4\x9c\x0c\x93\x77\x91\x7b\x02\xd4\xdf\x91\x89\x27\x95\x0c\x87\xa8\x47\x65\x29\x7c\x3a\xfe\x40\xc0\xce\xf9\x77\x84\x45\x00\x58\x3d\x11\x54\xe7\x09\xf0\x6e\x49\x9a\x25\xd9\xa4\xcc\x90\x9b\x82\x37\xc9\xa2\x00\xd0\xc3\xc0\xe4\xbd\xf2\xb3\xee\xe9\x97\x7f\xc6\xf6\x41\xae\xf7\xbe\x7d\xc4\x17\x01\x30\xba\x5e\x07\xe3\xec\xe1\xdc\xed\x31\x97\x89\x9b\xaf\x7a\x63\x17\x67\xb5\x0a\xad\xe4\xa6\xbf\x07\x64\x91\x91\x85\xca\x28\xb2\x10\x97\xb9\x3e\x76\x96\xc2\xd5\xca\x93\x4c\xbf\xcb\x9c\x99\x5e\xcd\xd7\xdf\x87\xff\x99\x27\xee\x37\xcb\x03\x54\x8b\x39\x68\xaf\x88\x2a\xfe\x27\x00\x00\xff\xff\x03\x56\xce\x9c\xeb\x01\x00\x00")
+var _templatesCf_dnsTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xdd\x91\x41\x6e\x83\x30\x10\x45\xf7\x9c\xc2\xb2\xba\xaa\x84\x15\xa9\xeb\x9e\xa4\xaa\xac\x89\x99\x10\x24\xb0\xad\xb1\x4d\x4a\x22\xee\xde\x01\x04\x4d\x68\x4b\xab\x76\x17\xb6\xfe\xf3\xe7\xcd\xa3\x05\xaa\x60\x5f\xa3\x90\xa1\x0b\x11\x1b\x5d\xb8\x06\x2a\x2b\xc5\x25\x13\x22\x76\x1e\xc5\x33\x3f\x45\xaa\x6c\x29\xb3\x3e\xcb\x08\x83\x4b\x64\x38\x0f\xe7\x44\x48\x3c\x60\x83\x3e\x3b\x8b\x52\x48\x73\x98\xe6\x2c\x34\x28\x56\x1f\xd7\x3c\x5c\x5a\x20\x75\xb3\xa7\x97\x1c\x9f\x3b\x75\x49\x2e\x79\x3d\x4e\x8f\xf1\x79\xc5\x6d\x40\xed\x5d\x38\xaa\x21\xd5\x8f\x48\x2e\x45\x9f\xa2\x90\x68\xdb\x05\x66\x2c\xd1\x01\xa9\x45\x0a\x13\x54\x0b\x75\x5a\xf5\xce\x69\x65\x0e\xea\x7a\xa0\xdf\x38\x15\x98\xc6\x38\x2a\x7e\x3e\xf7\x71\xb8\x6d\x81\x59\xab\xf8\x8e\xe1\xdf\x46\xf8\xb7\xc5\x5a\x7c\xc1\xf3\xb4\xdb\x4d\xdd\x03\x7e\x58\xbd\xbe\x5c\x95\xfb\xb4\xaf\x2b\xa3\x2b\xcf\x4c\x39\x78\x9f\x97\x10\xf1\x04\x9d\xaa\xbc\x86\xa2\xe0\xdd\x6c\xe8\xf5\x97\x8a\xf2\x10\x8e\xb9\x27\xf7\xd6\x6d\xca\xe2\xd4\x5d\xe8\x5a\xae\xfd\x9b\xac\x68\x7c\xce\x88\x11\x69\xd3\x16\xc7\xee\xc2\xd6\xc7\xb9\x9f\x74\xbd\x03\x42\x8a\x49\xf6\x99\x04\x00\x00")
+
+func templatesCf_dnsTfBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesCf_dnsTf,
+		"templates/cf_dns.tf",
+	)
+}
+
+func templatesCf_dnsTf() (*asset, error) {
+	bytes, err := templatesCf_dnsTfBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/cf_dns.tf", size: 1177, mode: os.FileMode(436), modTime: time.Unix(1792206593, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesCf_lbTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xe5\x59\x4d\x73\xdb\x36\x10\xbd\xeb\x57\x70\x38\x39\xa5\x43\x55\xb1\x35\x9d\xf4\x90\x43\x3b\x39\xb4\xe7\xf4\x8e\x01\x49\x48\xc2\x98\x22\x18\x00\xb4\xad\x78\xf4\xdf\xbb\x00\x3f\x44\x80\x00\x09\xb1\x4a\x6a\xb7\x3a\x78\x64\x73\xdf\x62\xf7\xed\x2e\xf0\x08\x3f\x62\x4e\x71\x5a\x90\x28\xc6\x55\x55\xd0\x0c\x4b\xca\x4a\xb4\xc7\x92\x3c\xe1\x13\xca\x68\xce\xe3\xe8\x65\x15\x45\xf2\x54\x91\xe8\x53\x14\x0b\xc9\x69\xb9\x8f\x57\xe7\xd5\xea\xb1\x87\x56\xbb\x67\x94\x11\x2e\x51\x8a\x05\xf9\x65\x1b\x88\xa8\xb0\x10\x4f\x8c\xe7\x5e\x73\x56\xcb\xaa\x96\x51\x9c\xed\x90\x20\x59\xcd\xa9\x3c\xa1\x3d\x67\x75\xd5\x20\x1e\x71\x51\x6b\xc8\xbb\x17\xfc\xad\xe6\x84\x1f\x51\x49\x24\x78\x7c\xb0\xcc\xd7\xd9\x6e\x5d\xe2\x23\x39\xdb\x5e\x21\xe5\x3e\x55\x65\xe0\xf5\xeb\xe0\xc6\xed\x14\x96\x93\x84\xa3\x22\x45\xd4\x1f\x65\x55\xa7\xe0\x0d\x2c\xc0\x47\x02\xae\x93\xce\x25\xad\x10\xce\x73\x4e\x84\x18\x85\x2a\xc4\x01\x55\x9c\x3d\x9f\x94\xef\xc9\x58\x8b\x54\xb9\x05\xfb\x44\xdb\x3b\x82\x34\x7c\x85\xc6\x79\x71\x38\x11\xa5\xcc\x2a\x74\xa1\x20\x20\x4c\x00\x24\x0d\xc0\x11\xa7\xe9\x2d\x34\xd0\x81\x4b\x3b\x52\xf8\xc6\x6a\x9e\xa9\x66\x6f\x91\xa2\x4e\xa1\x67\x62\x15\xfc\xb0\x12\xcd\x4a\x2a\xa2\xc8\xfe\xe8\x85\xa1\x91\xd7\xa4\x7c\x44\x34\x3f\x27\x26\x32\x11\x65\x0c\xd0\x76\x59\xa0\x99\xec\xe8\xb3\x0d\xf5\x8d\xda\x59\x41\xbb\x20\x9b\xde\x45\x4d\x10\x46\xba\xa6\xc5\x3a\x65\xe2\xd0\xb1\x07\xf4\x50\x2e\x6b\x5c\xf4\xa3\xa0\xf1\x06\xdc\xb2\x30\xf0\x4e\x92\x7a\x7a\xaf\xe1\x29\x90\x2f\x15\x72\xc1\x1a\x2e\xa6\xf1\x9d\x95\x9f\x24\x03\x12\x44\x56\x9f\x59\xd7\x27\x08\x17\x7d\x34\xe0\x26\x3f\x81\x29\xcd\xe2\x95\xda\xa0\xf0\x5e\xe8\x74\xa3\x08\x52\xa1\x9c\x95\x47\x52\xca\x51\x7e\xca\xed\xd9\xcd\xa3\xa3\xec\x9a\x51\x3f\x8b\x0b\xc9\x0b\xe7\x2c\x94\x2a\x80\x8b\x87\xba\x4d\xbf\x8f\x14\xd0\x5f\x24\x2e\x73\xcc\x73\xf4\xe5\x08\xd4\xc5\xfa\xb9\xa4\x84\xdb\xcf\x9b\x27\x19\xae\x70\x06\xfb\x32\x3c\xb9\xd3\x3c\xc1\x8f\x6e\x00\xa0\x08\x19\x2b\x77\x74\x5f\xf3\x26\x21\x6b\x31\x27\x19\xdd\xd4\xd1\x2a\x31\xc0\xcd\x72\xcd\x74\x83\xad\x99\x67\xf3\xe7\xd1\xe6\xdb\xd5\x0e\x7e\xec\xa0\xba\x92\x94\x39\xaa\x18\x97\xc3\x40\x5c\x31\x1c\xa4\xac\x9a\xf5\xb4\xf5\xa7\xe8\xe3\x66\xb1\x1f\x61\x38\xda\x6e\xef\x47\x9e\x66\x69\x9a\x69\x9f\xce\x91\x87\xb2\xf1\x44\xd8\xec\xf9\x4f\xaf\x01\x81\x29\xce\x1e\x54\xb4\xfd\x3e\xc8\x58\x31\x97\x7f\x8b\x49\x5a\x4c\xa2\x30\x23\x87\x8a\x24\x38\xde\xa5\x04\x91\x20\xa6\x72\x9f\x5c\x42\x79\x49\x3a\x2f\x6d\x6b\x32\xf6\x40\x89\x56\x30\x10\xf6\x6e\x47\xcb\xa6\x4f\xe3\xcf\x54\x28\xcd\x92\x0f\x2a\xe3\x58\x4b\xd7\x1c\x1e\x73\x26\x59\x06\xb9\xda\xa1\xfc\xd1\x37\x09\x27\x5f\x6b\x22\x24\x92\xf4\x48\xe0\x98\xea\x4d\x7e\xdd\xf4\x6d\x23\x44\xa1\xf5\x14\xdd\xa9\xed\x82\x38\xc6\x6e\x94\x19\x40\x92\x01\xa4\x59\x2a\xc7\x12\x9b\x08\x4b\xa9\x9d\xdb\xa4\x5a\x25\x66\xda\x75\x7f\xbd\x54\x55\x93\x5f\x50\x01\xfd\x03\x13\x3e\x45\xfe\x64\x15\x34\xfb\x9d\x9b\x26\x02\x6f\x7b\x23\x6f\xbb\xcc\x34\xb2\x31\x7a\x68\x14\xe5\xcc\x20\x3b\xcb\xe8\xaa\xe7\xad\x79\x11\xaf\x95\x18\x71\x0d\x33\xad\xb1\xd5\xc7\xf6\x62\x81\x7d\xac\x29\xee\xa6\x46\x29\x3b\x18\x5a\xc4\xeb\x82\xcc\x33\xed\xed\x3e\x05\x6f\xc7\x11\xbe\x21\xfd\xf2\xe1\x42\xff\x8e\x85\x3a\xfe\xd5\x6f\x46\x91\xcd\x54\x02\x9b\xdc\xb5\x29\x0e\xf5\x5d\xe0\x7e\xe8\xd9\x0c\xd1\xec\xc6\x3a\xda\xf5\xbe\x03\xb3\xe2\x07\x51\x2b\x5e\x3f\xb7\x21\x5a\xba\x7f\xab\x5a\xa8\xa4\x2f\xf8\x57\xad\xa3\x85\x84\xaf\xb7\x92\xd1\x45\x1a\xcc\xdd\x22\xca\xbe\x8b\x7a\xbe\x99\x7e\x33\xc2\xbf\x5a\xaf\x0d\xde\xe2\x67\x38\x46\xae\x89\xba\x1d\xf1\xff\x84\x4e\x55\x36\x9c\xa7\xb8\xc0\x25\x1c\x15\x2a\xe1\x51\xb7\xda\x97\x20\x3a\x5b\x5f\xa6\x60\x92\x92\xb7\x9d\x9a\xfb\x60\x06\xdc\x5f\x99\x96\x29\x2e\xd9\x0a\xef\x60\xf0\xf1\xb2\xa2\xf7\xf1\xab\x37\xa9\x85\xfc\x44\x4b\x76\x1d\x17\x55\x3e\x47\x1e\xd6\x16\xc8\x2b\x23\x17\xe7\xb1\x73\x09\xc5\x0a\xc1\x39\x53\xbe\x6a\xa6\xc4\x91\x93\xc7\xaf\x36\x0f\x6e\x0b\x67\x87\x98\xaf\xaa\x91\xa7\x59\x2e\x19\xbb\xcd\x26\x9b\x6a\x74\x3b\xbb\xa8\xc5\x66\x6b\x52\x71\xca\x94\x7b\x37\xf8\x6e\xa3\xde\xa9\x73\xca\x49\xe6\x39\x2b\x61\x81\x3f\xcb\x94\xd5\xa5\x7e\xe5\xc3\x59\x06\x95\xf2\x86\xf2\x1b\x9c\x73\x4f\x73\x44\x5f\x58\x6e\xbb\x59\x4b\x6f\x8e\xcb\xbd\x25\x87\xdf\x2b\x9b\x1c\xd4\x18\x2d\x9b\x2e\xb4\x0d\xc1\x46\xf1\x3b\x70\xe5\xbb\x69\x1c\xb9\xb2\x0d\x3b\x9b\xa9\x71\x0c\x9f\x45\xf7\xcd\xbb\x63\xf3\x0b\xb9\xa2\x9f\x95\x4d\x97\x3b\xde\x85\xba\x69\xe0\xe0\xff\x27\x9c\x42\xd8\x5b\x46\xda\xeb\x96\x4e\x66\xfc\x57\x6b\xa7\xe1\x3f\x16\x96\x8b\xa7\xdb\x90\xff\x43\x34\x86\x9d\x70\x80\x7e\x7a\xc3\xd9\x79\x25\x54\x77\xd5\xe3\xd6\x50\xfa\xe2\xaf\x7b\x87\xae\xb0\x3c\x0c\x90\x3f\x1f\x08\x2e\xe4\x21\x0e\xd0\x58\x36\x71\x19\x9c\x3f\x72\x4a\x64\x7d\xd8\x2c\x93\x62\x97\x85\x92\x77\x2f\x7a\x95\x35\x2d\x73\xf2\x1c\xfd\x04\x2e\xef\xb6\xe7\x7f\x59\xa1\x8d\xab\xb2\x40\xa2\x99\xdd\x74\x23\x8d\xe6\xec\x97\x45\x22\x2d\xac\xf3\x96\xa9\xb4\xd8\x5b\xd4\x59\xe1\xe6\x83\x5e\xab\xe5\xae\x39\x9c\xe7\xab\x37\xa7\xe6\xb6\x6f\x5b\xcd\x29\x8e\x93\x0f\x9b\xfb\xfb\xff\xb6\xa4\xfb\x1b\x9c\xbd\x7d\x8b\x67\x22\x00\x00")
+
+func templatesCf_lbTfBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesCf_lbTf,
+		"templates/cf_lb.tf",
+	)
+}
+
+func templatesCf_lbTf() (*asset, error) {
+	bytes, err := templatesCf_lbTfBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/cf_lb.tf", size: 8807, mode: os.FileMode(436), modTime: time.Unix(1792206593, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesConcourse_lbTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xed\x97\xbb\x8e\x9c\x30\x14\x86\x7b\x9e\xc2\x42\xa9\x22\xed\x68\x32\xd9\x62\x9b\x14\x29\xd3\xa7\x47\xc6\x78\x66\xac\xf5\xd8\xc8\x97\xd9\x24\x2b\xde\x3d\xc7\x10\x58\x2e\x36\x78\x96\x6d\x50\x86\x0a\x89\xdf\xe7\xf2\xfb\xf3\x05\x69\x4d\x69\x0d\x4a\x89\x14\x44\x5a\xa5\x69\xc6\xf3\x4c\xe0\x0b\x4d\xd1\x6b\x82\xd0\x15\x73\x4b\xd1\x37\x94\x7e\x7a\xc5\x7f\xac\xa2\xea\x02\xdf\x77\x9d\x78\xe7\x94\x55\x9a\x54\x49\x22\x27\x81\x34\x25\x56\x31\xf3\x3b\x3b\x29\x69\xcb\x60\x3c\x41\xcd\x8b\x54\xcf\x23\x79\x64\x0e\x28\x96\x85\x43\x97\x36\xe7\x8c\x80\xa2\x17\x8d\x95\x19\x2e\x0a\x45\xb5\x6e\x62\xc2\x1b\x7c\x20\x14\xa5\x93\x51\x69\x2f\x53\x93\xc3\x95\x82\x42\x4f\x9d\xfa\x8a\xd5\x8e\x8a\x6b\xc6\x8a\xea\xe1\x6d\x30\x0c\xe5\x92\x60\xc3\xa4\x98\x1f\xda\xaa\x2a\x37\xa4\x2d\xad\x71\x24\x1b\x26\x1f\x34\x3a\x54\xee\x72\xa9\xcf\xad\x6d\x08\x75\xfd\xb4\x8d\x67\x98\x77\xd5\x40\x18\x6d\xe0\x95\xa4\x09\x48\x0d\x3e\xe9\xba\x51\x84\xa0\x09\xa6\xa4\xb8\x50\x61\x26\x9d\xb9\xa8\x95\xdf\x3c\x9e\xc7\xb9\x76\xbb\x59\xf1\x1e\xc5\x5a\x03\xc3\x8f\xd0\xa2\xa1\xa2\x70\xee\x40\x05\x47\x76\xb2\xaa\xc9\xdc\xb8\xe0\x9d\xf0\xf9\xda\x7d\x86\xb3\x22\x02\xcc\x05\x5f\xb3\x1c\x93\x67\x57\x6a\x1b\xb4\x94\x92\x7f\x84\xd9\x6b\x2c\x74\x53\x85\x8b\x1c\x73\x2c\x08\x55\xae\xcf\x09\x9b\x83\xfd\xa2\x6e\x32\xd4\x60\xa9\x64\x4e\x37\xd9\x11\xcc\xb9\x92\x46\x12\xc9\x47\x45\xfe\x24\x65\xfd\x55\x2a\x33\x6d\xe1\xf1\xf1\x6b\xd0\x0b\x65\xf9\xc0\x8a\x87\xb3\x31\x65\xc4\x26\x34\x67\x4d\x13\x63\x69\x63\x41\x2b\xad\x0a\x05\xf2\xb9\x16\x5c\x7e\xbd\x39\x0b\xce\xb2\x6f\x3d\xf4\x8a\x18\x25\xf7\x2e\x1f\xef\x3c\xe6\xd4\xd3\x4a\x20\x68\x2d\x8f\xa3\xc1\x0b\x46\x67\x80\x8f\x10\x27\x7c\xda\xf7\x3a\xf5\x8b\x1a\xd5\x4d\x20\xe9\x8f\x20\x49\xdf\x51\xda\x14\x4a\x6e\xbb\x89\x60\xe9\xa6\x5d\x49\xeb\xf3\x5a\x94\x5c\x88\x3b\x48\x5b\x02\xe9\x00\x4f\x0c\x49\xb5\xce\x8b\x92\xff\xce\xbf\xa9\x9b\xe3\xfa\xab\xf2\xc4\x84\x77\x9e\xf9\x11\x07\x7e\xa9\x98\x74\x29\xfc\xc3\x0f\x7b\x77\xc8\x14\x4c\x51\x12\xf8\x43\x81\x14\x3f\x44\x2e\xad\x28\x5c\x34\x4c\x08\xc0\x1a\x2c\xe6\x3b\xfc\x5d\xbc\x2c\x11\xf7\x86\xdb\x3f\x93\x1d\x45\x99\xc2\xe2\x44\x87\xaa\xcf\x4e\x53\x50\x6d\x98\x68\x56\xe1\x58\x08\x9a\xa7\x7d\x2f\x50\xb7\x96\x14\x3d\xb2\x5f\x33\x81\xc6\xc2\x56\x33\xb7\x13\xc5\x6f\x43\x7e\xc4\x3d\x98\xdd\xf0\xff\xfb\x5e\x86\xf4\x6a\x88\x74\x0c\x45\x5f\xb6\x4d\x11\x1c\x7c\x77\x8c\x42\x18\x45\x1d\xf4\xcb\xa7\xfc\x12\x42\x87\x6d\x23\xe4\x4e\xbc\xff\x82\xa1\xbf\x0e\x5f\xb7\x8d\x37\x14\x00\x00")
+
+func templatesConcourse_lbTfBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesConcourse_lbTf,
+		"templates/concourse_lb.tf",
+	)
+}
+
+func templatesConcourse_lbTf() (*asset, error) {
+	bytes, err := templatesConcourse_lbTfBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/concourse_lb.tf", size: 5175, mode: os.FileMode(436), modTime: time.Unix(1792206593, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesNetworkTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x91\x4d\x6e\x03\x21\x0c\x85\xf7\x9c\xc2\xb2\xba\xcd\xdc\x20\x27\xa9\x2a\xe4\x80\xdb\xa2\x4e\xcc\xc8\xfc\xb4\x6a\xc4\xdd\x2b\xa2\xb2\x08\xcd\xa8\x61\xcb\xf7\xe0\x7d\xb6\x72\x8a\x45\x1d\x03\xd2\x77\x51\xd6\xb3\xad\x41\x73\xa1\xd5\x0a\xe7\xcf\xa8\x1f\x08\x78\x8a\xe9\x1d\xe1\x62\x00\x84\xce\x0c\xd3\x39\x02\x3e\x5d\x2a\xe9\xc2\x52\x6d\xf0\xed\xd0\xf1\x43\x15\x34\x00\xe4\xbd\x72\x4a\x36\x6d\xe4\x46\xf0\x08\xcf\xbf\x81\x20\x99\x55\x68\xb5\x2e\x78\x6d\xf8\x62\x00\xd6\xe8\x28\x87\x28\x77\x3f\x18\x97\xad\x3f\x3d\x8a\xdb\x37\x8d\x65\xb3\xd7\x66\x57\x72\x78\xdc\x02\x4b\x6f\xb5\x74\xaa\xa1\x69\xc6\xfc\xf5\x4e\xe5\x24\x9c\xff\xd5\xdd\xf1\x4d\x37\xbe\x9b\xf2\x6b\xf8\x9a\x03\x93\xef\x8e\xc4\xc3\x16\x00\xd3\xaa\xee\x0c\x61\x22\xa6\x29\xfc\x0c\x00\xf9\xf9\x9f\x88\xfd\x01\x00\x00")
 
 func templatesNetworkTfBytes() ([]byte, error) {
 	return bindataRead(
@@ -89,12 +161,12 @@ func templatesNetworkTf() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates/network.tf", size: 491, mode: os.FileMode(420), modTime: time.Unix(1506988422, 0)}
+	info := bindataFileInfo{name: "templates/network.tf", size: 509, mode: os.FileMode(436), modTime: time.Unix(1792206593, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _templatesNetwork_security_groupTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x96\xcd\x8e\xda\x30\x10\x80\xef\x7e\x8a\x91\xd5\xd3\x4a\xa0\x6d\x36\x59\x71\xd9\x43\x8f\xbd\xf7\x1e\x19\x7b\x12\xac\x06\x4f\x34\x76\xd8\xb6\xab\xbc\x7b\xe5\x84\xac\x80\x86\xd2\xa0\xb6\x52\x10\x5c\xf9\xe6\xcf\xf3\x69\x14\x46\x4f\x0d\x6b\x04\xa9\x7e\x34\x8c\xbc\xcd\x1d\x86\x57\xe2\xaf\xb9\x47\xdd\xb0\x0d\xdf\xf3\x92\xa9\xa9\x25\xc8\x35\xf9\x8d\x84\x37\x01\xe0\xd4\x16\xe1\xe4\xf7\x02\xf2\xc3\xdb\x4e\xf1\x12\xdd\x2e\xb7\xa6\x5d\x74\xb8\x00\xa8\x48\xab\x60\xc9\x8d\xc2\xc3\x9f\x6d\x24\x87\x5e\xfa\x8a\x79\x57\xa5\x23\x87\xd6\x8e\x81\x65\xac\xb0\x8c\x54\x2b\x85\x00\x08\xaa\xf4\x5d\x7b\x00\xe8\x76\x96\xc9\x6d\xd1\x85\x5f\x1a\x8b\x95\x5a\xd1\x0a\x31\x61\x74\x5d\x4c\x18\x5c\x17\x73\x1f\x9b\x9b\x0a\x25\x48\xff\xbb\x7d\x9f\x9b\xde\xf7\x5b\xaf\xd9\x52\xcc\x35\x1a\x92\x3c\x3e\x0a\x00\x63\x19\xf5\xe9\x13\xbd\x67\xfd\xec\xd6\xd4\x38\x13\x73\x29\xad\xd1\xfb\x73\xe5\x3f\x55\x15\xbd\xf6\x25\x29\x90\xa6\x6a\x1c\xfb\xa2\xeb\x08\xed\x5f\xb2\x26\x0e\x39\x2b\x57\xe2\x11\xf4\x10\x11\x83\x3e\x58\xd7\xed\xe7\x94\x7b\x01\x99\x24\x07\x69\x94\x31\x8c\xde\xe7\x35\x63\x61\xbf\x9d\x4f\x73\xc2\x0d\xc8\xd8\xe6\x8f\x1e\xf6\x0f\x0c\x00\x18\xb7\x76\xc4\xa3\x71\xf0\x28\xdb\x24\x3f\x62\xe0\x42\x95\xe8\xc2\x74\x4d\x0e\x62\x2f\xdb\xf2\x71\xb6\xb6\x3c\xaf\x9e\x57\x77\x5f\x0e\x7d\xe9\xf7\x48\x7c\xa5\x32\xef\xe1\x97\xad\x49\x66\x6b\x4d\x92\x65\x59\x76\xd7\x66\xaf\x8d\x71\x7e\xba\x2c\x31\xe8\xb2\x22\x4f\xff\x5d\x91\x87\xbf\x22\x48\xf6\x74\xb7\x63\x6f\x87\x66\x34\x9b\x66\x3d\xdd\x90\x21\xf0\xb2\x25\xe9\x6c\x0f\xc9\x6a\x95\xa6\x77\x53\x06\x53\x8a\xc5\x26\x84\xfa\x9f\x1d\x93\xf9\x7e\xa5\xa4\xe9\xcd\xdd\x13\x5d\x5c\xeb\x48\x45\xe5\x15\xc7\xa4\x8f\xbb\xe5\x8f\x92\xf4\xc6\x2d\xf9\x19\x00\x00\xff\xff\xc3\x49\xda\x6d\x0a\x11\x00\x00")
+var _templatesNetwork_security_groupTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x96\xcf\x8e\x9b\x30\x10\xc6\xef\x7e\x8a\x91\xd5\xd3\x4a\x89\xb6\x59\x58\xe5\xc2\xa1\xc7\xde\x7b\x47\x8e\x19\x08\x2a\xf1\xa0\xb1\xc9\xb6\x5d\xf1\xee\x95\x49\xbc\x02\x9a\x36\x25\x6a\x57\x22\xc2\x57\x7f\xdf\xfc\xf3\x8f\x11\x8c\x96\x1a\xd6\x08\x52\xfd\x68\x18\xf9\x90\x1a\x74\x2f\xc4\x5f\x53\x8b\xba\xe1\xd2\x7d\x4f\x0b\xa6\xa6\x96\x20\x77\x64\xf7\x12\x5e\x05\x80\x51\x07\x84\xd1\x49\x40\x7e\x78\x3d\x2a\x5e\xa3\x39\xa6\x65\xd6\xae\x3a\xb9\x00\xa8\x48\x2b\x57\x92\xb9\x28\x0e\x97\xad\x14\x00\xa1\x96\x53\xc6\xb4\xcb\xd2\x29\x43\x69\x43\xc1\xda\x67\x58\x7b\x55\x2b\x85\x00\x70\xaa\xb0\x5d\x79\x00\x68\x8e\x25\x93\x39\xa0\x71\xbf\x14\xe6\x33\xb5\xa2\x15\x62\x42\xeb\x3a\x9f\xd0\xb8\xce\xe7\xde\x36\x37\x15\x4a\x90\xf6\x4f\xef\x3d\x6e\x29\x74\xef\x4d\x02\xa0\xe6\x92\xfc\x08\x83\xae\x77\x12\xd8\x3c\x3e\x0a\x80\xac\x64\xd4\xe3\x11\xbd\x45\xfd\x6c\x76\xd4\x98\xcc\x97\xad\xb4\x46\x6b\xc3\xdd\xe0\x24\x20\x3f\x55\x15\xbd\x78\x59\xcd\xe4\x48\x53\x15\xee\x7a\x27\x01\xf9\x45\xd7\x5e\x74\x9e\x64\x4d\xec\x52\x56\xa6\xe8\xf5\x95\x80\x7c\xf0\x92\x0c\xad\x2b\x4d\xf7\x3e\x63\x5d\x02\x72\xb3\xe9\x85\x51\x59\xc6\x68\x6d\x5a\x33\xe6\xe5\xb7\xdf\x87\x19\xe9\x82\x64\xf8\xb0\xe9\x60\xcc\x7f\x4b\x00\xc0\x65\x6a\x2f\x70\x74\x59\x38\x88\x36\x89\x0f\x6f\x5c\xa9\x02\x8d\x9b\x8e\x49\xcf\x7b\x9d\x96\x8f\xb3\xa5\xe5\x79\xfb\xbc\x5d\x78\xe9\xf3\x72\xfa\xea\x89\x6f\x44\xe6\xcd\x7e\x9d\x9a\xcd\x7c\x77\x4c\x1c\xc7\xf1\x82\xcd\x19\x9b\xcc\xd8\xe9\xb0\x78\xd3\x75\x44\x9e\xde\x1d\x91\x87\x7f\x02\x48\xfc\xb4\xd0\x71\xa6\x43\x33\x66\xfb\x66\x37\x9d\x90\x60\xbc\x4e\x49\x34\xdb\x45\xb2\xdd\x46\xd1\x42\x4a\x20\x25\x5f\xed\x9d\xab\xff\xdb\x32\x99\xef\x5f\x4a\x14\xdd\xdd\x3e\xd1\xf9\xad\x8c\x54\x54\xdc\xb0\x4c\x4e\xbe\x7b\xfe\x29\x89\xee\x9c\x92\x9f\x03\x00\xc3\x49\xda\x6d\x0a\x11\x00\x00")
 
 func templatesNetwork_security_groupTfBytes() ([]byte, error) {
 	return bindataRead(
@@ -109,12 +181,12 @@ func templatesNetwork_security_groupTf() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates/network_security_group.tf", size: 4362, mode: os.FileMode(420), modTime: time.Unix(1507153563, 0)}
+	info := bindataFileInfo{name: "templates/network_security_group.tf", size: 4362, mode: os.FileMode(436), modTime: time.Unix(1507834274, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _templatesOutputTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x91\xcf\x6a\xf3\x30\x10\xc4\xcf\x9f\x9f\x42\x98\xef\xec\x40\xc0\x97\x40\x9f\x65\x91\xed\x4d\xa2\x46\x96\xc4\xfe\x71\x93\x86\xbc\x7b\x31\xae\x43\x44\x5a\x3b\xbd\x6a\x67\x7e\x33\x8c\xa2\x4a\x52\x31\x65\x13\xf9\x08\x01\xe5\x23\xd2\x09\x82\xed\xb1\x34\xd7\xc2\x18\x63\x06\xeb\x15\xcd\x9b\x29\xff\x5f\xed\xa7\x12\x52\x0f\x83\x23\x51\xeb\x67\x79\x35\x7a\xab\xd1\x73\x2b\x8b\x5b\x51\x64\x48\xd6\x26\xa0\xac\x11\x27\xd5\x22\x88\x90\xa3\x52\x8b\x70\xa0\xa8\x69\x0d\x98\xab\x97\x1b\x4a\x24\x7b\x40\xb0\x6d\x1b\x35\xac\x57\xcd\xe5\x8b\xe8\x0e\xf7\x56\xbd\x00\x63\xab\xe4\xe4\x32\xb5\x59\x80\xcf\x1f\x90\x1b\x7e\xcb\xc0\xb3\x20\x05\xeb\xc1\x2d\x31\x93\x36\xde\xb5\xe0\xbe\x31\x2e\x81\xed\x3a\x42\xe6\x1c\xd6\x39\xc2\x56\x22\xcd\xd7\x91\xf8\xef\x8e\x3b\x8a\x24\xde\x6d\x36\xaf\x60\x77\xdb\xba\xae\xeb\xe7\x35\x86\x9e\x21\x91\x1b\xac\x20\x9c\xf0\x32\x55\x7e\x28\x2c\x3e\xbb\x57\xb3\xa9\x7a\x78\x84\x84\xfd\xad\x2c\x8c\x61\x0c\xec\xc4\x0d\xa3\x57\x48\xf1\xe7\xb4\xa9\xe3\xdf\xc2\xee\x1e\x88\x09\x03\xf3\xf1\x29\x6f\x6f\x3d\x67\x81\xef\xda\xa7\x26\x9e\x41\xc9\xe7\xb3\xbd\x36\xd7\x76\xdc\xea\x2b\x00\x00\xff\xff\xc4\x27\xe0\xd1\x89\x03\x00\x00")
+var _templatesOutputTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x91\xcf\x6a\xf3\x30\x10\xc4\xcf\x9f\x9e\x42\x98\xef\xec\x40\xc0\x97\x40\x9f\x65\x91\xed\x4d\xa2\x46\x96\xc4\xfe\x71\x93\x06\xbf\x7b\x31\xa9\x43\x45\x5b\x27\xbd\x6a\x67\x7e\x33\xda\x4d\x2a\x59\xc5\x56\x6d\xe2\x23\x44\x94\xb7\x44\x27\x88\x6e\xc0\xca\x5e\x8d\xb5\xd6\x8e\x2e\x28\xda\x17\x5b\xfd\xbf\xba\x77\x25\xa4\x01\x46\x4f\xa2\x2e\x2c\xf2\x7a\xf6\xd6\xb3\x67\xaa\xcc\x64\x4c\x81\x64\x6d\x23\xca\x23\xe2\x4d\xb5\x0a\x22\xe4\xa4\xd4\x21\x1c\x28\x69\x7e\x04\x2c\xd5\xeb\x0d\x25\x91\x3b\x20\xb8\xae\x4b\x1a\x1f\x57\x2d\xe5\xab\xe8\x1e\xf7\x4e\x83\x00\x63\xa7\xe4\xe5\x72\x6b\xb3\x02\x5f\x0e\x50\x1a\x7e\xcb\xc0\xb3\x20\x45\x17\xc0\xaf\x31\xb3\xb6\xc1\x77\xe0\x3f\x31\x3e\x83\xeb\x7b\x42\xe6\x12\xd6\x7b\xc2\x4e\x12\x2d\xd3\x99\xf8\xef\x8e\x3b\x8a\x64\xde\x6d\x36\xcf\x60\x77\xdb\xa6\x69\x9a\x02\x3e\x4b\x60\x1c\x18\x32\xf9\xd1\x09\xc2\x09\x2f\x73\x40\x51\x58\x42\x31\xaf\x17\x53\xfd\xe5\x11\x32\x0e\x53\x65\xac\x65\x8c\xec\xc5\x8f\xb3\x57\x48\xf1\xe7\xb4\xdb\xd7\xff\x16\x76\xf7\x40\xca\x18\x99\x8f\xdf\xf2\xf6\x2e\x70\x11\xf8\xaa\x43\x6e\xd3\x19\x94\x42\xb9\xb6\xe7\xd6\xb5\xad\xcc\x64\x3e\x06\x00\xc4\x27\xe0\xd1\x89\x03\x00\x00")
 
 func templatesOutputTfBytes() ([]byte, error) {
 	return bindataRead(
@@ -129,12 +201,12 @@ func templatesOutputTf() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates/output.tf", size: 905, mode: os.FileMode(420), modTime: time.Unix(1506988422, 0)}
+	info := bindataFileInfo{name: "templates/output.tf", size: 905, mode: os.FileMode(436), modTime: time.Unix(1507834274, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _templatesResource_groupTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x90\x41\x6a\xc5\x30\x0c\x44\xf7\x3e\xc5\x60\xba\x6d\x6e\xd0\xb3\x18\xc5\x11\xa9\x21\xb1\x83\x6c\x67\xd1\xe0\xbb\x17\x1b\xd2\x26\x24\xff\x7f\xf8\x5a\x8a\xd1\x68\xe6\x09\xc7\x90\xc5\x32\x34\xfd\x64\x61\x99\xcd\xbe\x31\xa3\x84\xbc\x68\xe8\x3e\xc4\x6f\x8d\x4d\x01\x9e\x66\x46\x9d\x2f\xe8\x8f\x6d\x25\xe9\xd8\xaf\xc6\x0d\xe5\xb3\x69\x14\x30\x05\x4b\xc9\x05\xff\xaf\xd8\x37\x45\x2b\x05\x24\x1a\x63\xb3\x02\xd8\xaf\x4e\x82\x9f\xd9\xa7\x8b\x5f\xb5\x2a\xaa\x28\x75\x8d\xb7\xe4\x7e\x72\xd6\xb8\x07\xc9\xee\xe6\x75\xda\xa7\x57\x87\x06\xc0\x99\x8e\x39\xff\x6d\x27\xf7\x1c\xbb\xfa\xb3\xab\xf2\x66\xf3\xd7\xc2\xd0\x30\x08\xc7\x68\x68\x3a\xb2\x8b\x89\x92\xb3\xef\x20\xfb\x0d\x00\x00\xff\xff\xe9\x3c\x7f\x17\xd1\x01\x00\x00")
+var _templatesResource_groupTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x90\x51\x6a\xc4\x30\x0c\x44\xff\x75\x8a\x41\xf4\xb7\xb9\xc1\x9e\xc5\x68\x1d\xb1\x35\x24\xf6\x22\xdb\xf9\xe8\xe2\xbb\x97\x18\x36\x6d\x48\xda\xc2\xda\x7f\x62\x34\x7a\x33\xa6\x39\x55\xf3\x0a\x96\xcf\x6a\x6a\xb3\x7b\x4e\xdc\xcd\x52\xbd\x33\xf8\x9a\xf2\x07\xe3\x41\x40\x94\x59\xb1\xbe\x0b\xf8\xed\xb1\x88\x0d\x1a\x17\x17\xc6\xf6\xde\x35\x04\x4c\xc9\x4b\x09\x29\x7e\x2b\x9e\x93\xc6\x44\x40\x91\x5b\xee\x56\x80\xc6\x25\x58\x8a\xb3\xc6\x72\xf0\x63\x02\x1a\x35\xa2\x23\xde\xbd\x5e\xa7\xe0\x5d\xf8\x85\xec\xec\xff\x4f\xfb\xe7\xd6\x8f\x04\xc0\xbe\x1d\xb7\xbf\xdb\x57\xce\x7b\x1c\x56\xd6\x61\x95\x77\x9b\x2d\x85\x93\x71\x34\xcd\xd9\xc9\xb4\xd1\x5c\xc0\xb9\x48\x09\xfe\x95\xca\xbe\x06\x00\xe9\x3c\x7f\x17\xd1\x01\x00\x00")
 
 func templatesResource_groupTfBytes() ([]byte, error) {
 	return bindataRead(
@@ -149,12 +221,12 @@ func templatesResource_groupTf() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates/resource_group.tf", size: 465, mode: os.FileMode(420), modTime: time.Unix(1506988422, 0)}
+	info := bindataFileInfo{name: "templates/resource_group.tf", size: 465, mode: os.FileMode(436), modTime: time.Unix(1507834274, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _templatesStorageTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xcc\x91\x41\x6e\x83\x40\x0c\x45\xf7\x73\x0a\x6b\xd4\x75\x6e\xd0\x75\xf7\xcd\x01\x46\x66\xb0\xe8\x48\x60\x23\xdb\x50\xb5\x11\x77\xaf\x18\x05\xda\x20\x45\xc9\xb2\x6c\xf9\xfe\x7e\xcf\xa3\x64\x32\x69\x26\x88\xf8\x3d\x29\xe9\x90\xcc\x45\xb1\xa3\x84\x39\xcb\xc4\x1e\x21\x36\x62\x1f\x11\x2e\x01\x80\x71\x20\x38\x7c\xaf\x10\x5f\x2e\x33\xea\xc9\xca\x30\xf6\x94\x88\xe7\x54\xda\x25\x06\x80\xad\x3c\x75\x2a\xd3\x98\xea\x74\x8d\x6f\xbb\x6e\x03\xa7\x75\xd1\x69\x4d\x2d\x31\x04\x80\x5e\x32\x7a\x11\xde\xd6\x7c\x92\xf9\x64\x6b\xf1\x95\x2d\xf9\xd7\x58\x1b\xcf\x8e\xdc\xa2\xb6\xe9\xed\xfd\x5c\x47\x1d\x3b\xab\xc4\x00\xc4\x73\x51\xe1\x81\xd8\x7f\x59\xff\x40\x2e\x61\x09\xe1\xfe\x19\xb2\xb0\x63\x61\xd2\x87\x87\xa8\x8c\x35\x72\x47\x1d\x9e\x96\x07\x38\xbc\xc2\xb5\xe0\x66\xfe\x10\x39\x14\xec\xdc\xeb\x7f\x32\xdb\x6f\x35\x6a\x99\xd1\x29\x3e\xaf\x6d\x4e\x43\xa6\xbe\x7f\xa0\xbe\xc7\xfe\xb5\x7e\xd3\x4b\xb3\xba\xff\x04\x00\x00\xff\xff\xcf\x0a\x89\xf7\xf9\x02\x00\x00")
+var _templatesStorageTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xcc\x91\x41\x6a\xc3\x40\x0c\x45\xf7\x73\x0a\x31\x74\x9d\x1b\x74\xdd\x7d\x73\x80\x41\x1e\x0b\x77\xc0\x96\x8c\x24\xbb\xb4\xc1\x77\x2f\x63\x62\xb7\x31\x84\x64\xd9\xd9\xce\xd7\xd7\x7f\x5f\x4a\x26\x93\x66\x82\x88\xdf\x93\x92\x0e\xc9\x5c\x14\x3b\x4a\x98\xb3\x4c\xec\x11\x62\x23\xf6\x11\xe1\x12\x00\x18\x07\x82\xc3\x7b\x85\xf8\x72\x99\x51\x4f\x56\x86\xb1\xa7\x44\x3c\xa7\xd2\x2e\x31\x00\x6c\xe6\xa9\x53\x99\xc6\xb4\x4e\xaf\xf2\x6d\xd7\xad\xe0\x54\x17\x9d\xaa\x6a\x89\x21\x00\xf4\x92\xd1\x8b\xf0\xb6\xe6\x93\xcc\x27\xab\xc6\xd7\x6c\xc9\xbf\x46\xaa\x3f\x67\x47\x6e\x51\xdb\xf4\xf6\x7e\x5e\x47\x1d\x3b\x5b\x13\x03\x10\xcf\x45\x85\x07\x62\xff\xcd\xfa\x27\xe4\x12\x96\x10\xee\xd7\x90\x85\x1d\x0b\x93\x3e\x2c\x02\xaa\x7d\x45\xb8\x87\x0e\x4f\xc3\x03\x1c\xae\x70\x35\xb8\x99\x3f\x48\x0e\x06\x7b\xee\x7a\x48\x32\xdb\xbb\x1a\xb5\xcc\xe8\x14\x9f\xc7\x36\xa7\x21\x53\xdf\x3f\x40\xdf\x65\xff\x1a\xbf\xe9\xa5\x89\x61\x09\x3f\x03\x00\xcf\x0a\x89\xf7\xf9\x02\x00\x00")
 
 func templatesStorageTfBytes() ([]byte, error) {
 	return bindataRead(
@@ -169,12 +241,12 @@ func templatesStorageTf() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates/storage.tf", size: 761, mode: os.FileMode(420), modTime: time.Unix(1506988422, 0)}
+	info := bindataFileInfo{name: "templates/storage.tf", size: 761, mode: os.FileMode(436), modTime: time.Unix(1507834274, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _templatesTlsTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x2a\x4a\x2d\xce\x2f\x2d\x4a\x4e\x55\x50\x2a\xc9\x29\x8e\x2f\x28\xca\x2c\x4b\x2c\x49\x8d\xcf\x4e\xad\x54\x52\x50\x4a\xca\x2f\xce\x88\x2f\xcb\x2d\x56\x52\xa8\xe6\x52\x50\x48\xcc\x49\xcf\x2f\xca\x2c\xc9\xc8\x55\xb0\x55\x50\x0a\x0a\x76\x54\xe2\x52\x50\x28\x2a\x4e\x8c\x4f\xca\x2c\x29\x56\xb0\x55\x30\x31\xb0\x34\xe3\xaa\xe5\x02\x04\x00\x00\xff\xff\x2c\x7a\x83\xa0\x50\x00\x00\x00")
+var _templatesTlsTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x50\x00\xaf\xff\x72\x65\x73\x6f\x75\x72\x63\x65\x20\x22\x74\x6c\x73\x5f\x70\x72\x69\x76\x61\x74\x65\x5f\x6b\x65\x79\x22\x20\x22\x62\x6f\x73\x68\x5f\x76\x6d\x73\x22\x20\x7b\x0a\x20\x20\x61\x6c\x67\x6f\x72\x69\x74\x68\x6d\x20\x3d\x20\x22\x52\x53\x41\x22\x0a\x20\x20\x72\x73\x61\x5f\x62\x69\x74\x73\x20\x3d\x20\x34\x30\x39\x36\x0a\x7d\x0a\x03\x00\x2c\x7a\x83\xa0\x50\x00\x00\x00")
 
 func templatesTlsTfBytes() ([]byte, error) {
 	return bindataRead(
@@ -189,12 +261,12 @@ func templatesTlsTf() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates/tls.tf", size: 80, mode: os.FileMode(420), modTime: time.Unix(1506988422, 0)}
+	info := bindataFileInfo{name: "templates/tls.tf", size: 80, mode: os.FileMode(436), modTime: time.Unix(1507834274, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _templatesVarsTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\xcf\xd1\xaa\xc2\x30\x0c\x06\xe0\xeb\xd3\xa7\x08\xe5\x5c\xfb\x06\x3e\xcb\xc8\xba\x20\x81\xae\x2b\x69\x56\xd0\xb1\x77\x97\x3a\xa9\x32\x15\x6b\x6e\xff\x2f\xe1\x4f\x46\x61\xec\x3d\x81\xa5\x90\x3b\x1e\x2c\x2c\xe6\x4f\xcf\x91\xe0\x08\x36\xa9\x70\x38\x59\xb3\x1a\xf3\x70\x7e\x72\xa8\x3c\x85\xef\x32\xf1\x18\x3d\x75\xad\x87\xd3\xdc\x27\x27\x1c\xcb\xf1\xa6\x05\xa5\x80\x41\x9b\xa8\xf3\x4c\xbf\xd1\x44\x4e\x48\x3f\xf1\x28\x53\xe6\x81\x04\x2c\x5e\x66\x21\x19\x0b\x04\xd8\xbd\x00\x65\xeb\x7f\xc9\x28\x87\x5d\xb2\x5a\x03\x50\xfb\xc3\x7d\xaa\xae\xc9\xcd\xd5\xf2\x2f\xae\x26\xcf\x6e\x6b\xfe\xce\x6d\xc9\x5a\x3e\xb8\x06\x00\x00\xff\xff\xb7\xcd\x6b\x24\xf8\x01\x00\x00")
+var _templatesVarsTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\xcf\x41\x8a\xc3\x30\x0c\x05\xd0\xf5\xf8\x14\xc2\xcc\x7a\x6e\x30\x67\x09\x8a\x23\x06\x81\xa3\x18\x59\x31\x4c\x83\xef\x5e\xd2\x16\xb7\xa4\x2d\x49\xbd\xfd\xcf\x1f\xfd\x82\xca\xd8\x47\x02\x4f\x52\x3a\x1e\x3c\x2c\xee\xcb\xfe\x13\xc1\x2f\xf8\x6c\xca\xf2\xe7\x5d\x75\xee\xee\xe2\x14\xd0\x78\x92\x7d\x99\x79\x4c\x91\xba\xa3\xc5\x79\xee\x73\x50\x4e\x6b\xf9\xa1\x0f\x46\x82\x62\x87\x68\x88\x4c\x9f\xd1\x4c\x41\xc9\xf6\x39\x8b\x91\x0a\xc6\x2e\xf0\xa0\xef\x78\xd2\xa9\xf0\x40\x0a\x1e\x4f\xb3\x92\x8e\x2b\x04\xd8\x2c\x86\xf5\xa6\xef\xa5\xa0\xfe\x6c\x92\xea\x1d\x40\x9b\x0b\xb7\xd7\x74\x4b\x2e\xae\x6d\x7d\x72\x2d\x79\x74\xd7\xa1\xaf\x5c\xa6\xa0\x64\xd5\xbb\xea\xce\x03\x00\xf2\xc2\xc1\xd1\x27\x02\x00\x00")
 
 func templatesVarsTfBytes() ([]byte, error) {
 	return bindataRead(
@@ -209,7 +281,7 @@ func templatesVarsTf() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates/vars.tf", size: 504, mode: os.FileMode(420), modTime: time.Unix(1506988422, 0)}
+	info := bindataFileInfo{name: "templates/vars.tf", size: 551, mode: os.FileMode(436), modTime: time.Unix(1792206593, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -266,24 +338,29 @@ func AssetNames() []string {
 
 // _bindata is a table, holding each asset generator, mapped to its name.
 var _bindata = map[string]func() (*asset, error){
-	"templates/network.tf": templatesNetworkTf,
+	"templates/cf_dns.tf":                 templatesCf_dnsTf,
+	"templates/cf_lb.tf":                  templatesCf_lbTf,
+	"templates/concourse_lb.tf":           templatesConcourse_lbTf,
+	"templates/network.tf":                templatesNetworkTf,
 	"templates/network_security_group.tf": templatesNetwork_security_groupTf,
-	"templates/output.tf": templatesOutputTf,
-	"templates/resource_group.tf": templatesResource_groupTf,
-	"templates/storage.tf": templatesStorageTf,
-	"templates/tls.tf": templatesTlsTf,
-	"templates/vars.tf": templatesVarsTf,
+	"templates/output.tf":                 templatesOutputTf,
+	"templates/resource_group.tf":         templatesResource_groupTf,
+	"templates/storage.tf":                templatesStorageTf,
+	"templates/tls.tf":                    templatesTlsTf,
+	"templates/vars.tf":                   templatesVarsTf,
 }
 
 // AssetDir returns the file names below a certain
 // directory embedded in the file by go-bindata.
 // For example if you run go-bindata on data/... and data contains the
 // following hierarchy:
-//     data/
-//       foo.txt
-//       img/
-//         a.png
-//         b.png
+//
+//	data/
+//	  foo.txt
+//	  img/
+//	    a.png
+//	    b.png
+//
 // then AssetDir("data") would return []string{"foo.txt", "img"}
 // AssetDir("data/img") would return []string{"a.png", "b.png"}
 // AssetDir("foo.txt") and AssetDir("notexist") would return an error
@@ -314,15 +391,19 @@ type bintree struct {
 	Func     func() (*asset, error)
 	Children map[string]*bintree
 }
+
 var _bintree = &bintree{nil, map[string]*bintree{
 	"templates": &bintree{nil, map[string]*bintree{
-		"network.tf": &bintree{templatesNetworkTf, map[string]*bintree{}},
+		"cf_dns.tf":                 &bintree{templatesCf_dnsTf, map[string]*bintree{}},
+		"cf_lb.tf":                  &bintree{templatesCf_lbTf, map[string]*bintree{}},
+		"concourse_lb.tf":           &bintree{templatesConcourse_lbTf, map[string]*bintree{}},
+		"network.tf":                &bintree{templatesNetworkTf, map[string]*bintree{}},
 		"network_security_group.tf": &bintree{templatesNetwork_security_groupTf, map[string]*bintree{}},
-		"output.tf": &bintree{templatesOutputTf, map[string]*bintree{}},
-		"resource_group.tf": &bintree{templatesResource_groupTf, map[string]*bintree{}},
-		"storage.tf": &bintree{templatesStorageTf, map[string]*bintree{}},
-		"tls.tf": &bintree{templatesTlsTf, map[string]*bintree{}},
-		"vars.tf": &bintree{templatesVarsTf, map[string]*bintree{}},
+		"output.tf":                 &bintree{templatesOutputTf, map[string]*bintree{}},
+		"resource_group.tf":         &bintree{templatesResource_groupTf, map[string]*bintree{}},
+		"storage.tf":                &bintree{templatesStorageTf, map[string]*bintree{}},
+		"tls.tf":                    &bintree{templatesTlsTf, map[string]*bintree{}},
+		"vars.tf":                   &bintree{templatesVarsTf, map[string]*bintree{}},
 	}},
 }}
 
@@ -372,4 +453,3 @@ func _filePath(dir, name string) string {
 	cannonicalName := strings.Replace(name, "\\", "/", -1)
 	return filepath.Join(append([]string{dir}, strings.Split(cannonicalName, "/")...)...)
 }
-
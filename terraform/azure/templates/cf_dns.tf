@@ -0,0 +1,36 @@
+variable "system_domain" {
+  type = "string"
+}
+
+resource "azurerm_dns_zone" "cf" {
+  name                = "${var.system_domain}"
+  resource_group_name = "${azurerm_resource_group.bosh.name}"
+}
+
+output "env_dns_zone_name_servers" {
+  value = "${azurerm_dns_zone.cf.name_servers}"
+}
+
+resource "azurerm_dns_a_record" "cf" {
+  name                = "*"
+  zone_name           = "${azurerm_dns_zone.cf.name}"
+  resource_group_name = "${azurerm_resource_group.bosh.name}"
+  ttl                 = "300"
+  records             = ["${azurerm_public_ip.cf-app-gateway.ip_address}"]
+}
+
+resource "azurerm_dns_a_record" "cf-ssh-proxy" {
+  name                = "ssh"
+  zone_name           = "${azurerm_dns_zone.cf.name}"
+  resource_group_name = "${azurerm_resource_group.bosh.name}"
+  ttl                 = "300"
+  records             = ["${azurerm_public_ip.cf-ssh-proxy.ip_address}"]
+}
+
+resource "azurerm_dns_a_record" "cf-tcp-router" {
+  name                = "tcp"
+  zone_name           = "${azurerm_dns_zone.cf.name}"
+  resource_group_name = "${azurerm_resource_group.bosh.name}"
+  ttl                 = "300"
+  records             = ["${azurerm_public_ip.cf-tcp-router.ip_address}"]
+}
@@ -0,0 +1,264 @@
+variable "application_gateway_cidr" {
+  type = "string"
+}
+
+variable "pfx_cert_base64" {
+  type = "string"
+}
+
+variable "pfx_password" {
+  type = "string"
+}
+
+output "cf_security_group" {
+  value = "${azurerm_network_security_group.cf.name}"
+}
+
+output "cf_app_gateway_name" {
+  value = "${azurerm_application_gateway.cf.name}"
+}
+
+output "router_lb_ip" {
+  value = "${azurerm_public_ip.cf-app-gateway.ip_address}"
+}
+
+output "cf_ssh_proxy_lb_name" {
+  value = "${azurerm_lb.cf-ssh-proxy.name}"
+}
+
+output "ssh_proxy_lb_ip" {
+  value = "${azurerm_public_ip.cf-ssh-proxy.ip_address}"
+}
+
+output "cf_tcp_router_lb_name" {
+  value = "${azurerm_lb.cf-tcp-router.name}"
+}
+
+output "tcp_router_lb_ip" {
+  value = "${azurerm_public_ip.cf-tcp-router.ip_address}"
+}
+
+resource "azurerm_subnet" "cf-app-gateway" {
+  name                 = "${var.env_id}-cf-app-gateway-sn"
+  address_prefix       = "${var.application_gateway_cidr}"
+  resource_group_name  = "${azurerm_resource_group.bosh.name}"
+  virtual_network_name = "${azurerm_virtual_network.bosh.name}"
+}
+
+resource "azurerm_public_ip" "cf-app-gateway" {
+  name                         = "${var.env_id}-cf-app-gateway"
+  location                     = "${var.location}"
+  resource_group_name          = "${azurerm_resource_group.bosh.name}"
+  public_ip_address_allocation = "dynamic"
+
+  tags {
+    environment = "${var.env_id}"
+  }
+}
+
+resource "azurerm_application_gateway" "cf" {
+  name                = "${var.env_id}-cf-app-gateway"
+  location            = "${var.location}"
+  resource_group_name = "${azurerm_resource_group.bosh.name}"
+
+  sku {
+    name     = "Standard_Small"
+    tier     = "Standard"
+    capacity = 2
+  }
+
+  gateway_ip_configuration {
+    name      = "${var.env_id}-cf-gateway-ip-configuration"
+    subnet_id = "${azurerm_subnet.cf-app-gateway.id}"
+  }
+
+  frontend_port {
+    name = "${var.env_id}-cf-http"
+    port = 80
+  }
+
+  frontend_port {
+    name = "${var.env_id}-cf-https"
+    port = 443
+  }
+
+  frontend_ip_configuration {
+    name                 = "${var.env_id}-cf-frontend-ip-configuration"
+    public_ip_address_id = "${azurerm_public_ip.cf-app-gateway.id}"
+  }
+
+  backend_address_pool {
+    name = "${var.env_id}-cf-backend-address-pool"
+  }
+
+  backend_http_settings {
+    name                  = "${var.env_id}-cf-backend-http-settings"
+    cookie_based_affinity = "Disabled"
+    port                  = 80
+    protocol              = "Http"
+    request_timeout       = 900
+  }
+
+  ssl_certificate {
+    name     = "${var.env_id}-cf-ssl-certificate"
+    data     = "${var.pfx_cert_base64}"
+    password = "${var.pfx_password}"
+  }
+
+  http_listener {
+    name                           = "${var.env_id}-cf-http-listener"
+    frontend_ip_configuration_name = "${var.env_id}-cf-frontend-ip-configuration"
+    frontend_port_name             = "${var.env_id}-cf-http"
+    protocol                       = "Http"
+  }
+
+  http_listener {
+    name                           = "${var.env_id}-cf-https-listener"
+    frontend_ip_configuration_name = "${var.env_id}-cf-frontend-ip-configuration"
+    frontend_port_name             = "${var.env_id}-cf-https"
+    protocol                       = "Https"
+    ssl_certificate_name           = "${var.env_id}-cf-ssl-certificate"
+  }
+
+  request_routing_rule {
+    name                       = "${var.env_id}-cf-http-rule"
+    rule_type                  = "Basic"
+    http_listener_name         = "${var.env_id}-cf-http-listener"
+    backend_address_pool_name  = "${var.env_id}-cf-backend-address-pool"
+    backend_http_settings_name = "${var.env_id}-cf-backend-http-settings"
+  }
+
+  request_routing_rule {
+    name                       = "${var.env_id}-cf-https-rule"
+    rule_type                  = "Basic"
+    http_listener_name         = "${var.env_id}-cf-https-listener"
+    backend_address_pool_name  = "${var.env_id}-cf-backend-address-pool"
+    backend_http_settings_name = "${var.env_id}-cf-backend-http-settings"
+  }
+}
+
+resource "azurerm_public_ip" "cf-ssh-proxy" {
+  name                         = "${var.env_id}-cf-ssh-proxy"
+  location                     = "${var.location}"
+  resource_group_name          = "${azurerm_resource_group.bosh.name}"
+  public_ip_address_allocation = "static"
+
+  tags {
+    environment = "${var.env_id}"
+  }
+}
+
+resource "azurerm_lb" "cf-ssh-proxy" {
+  name                = "${var.env_id}-cf-ssh-proxy"
+  location            = "${var.location}"
+  resource_group_name = "${azurerm_resource_group.bosh.name}"
+
+  frontend_ip_configuration {
+    name                 = "${var.env_id}-cf-ssh-proxy"
+    public_ip_address_id = "${azurerm_public_ip.cf-ssh-proxy.id}"
+  }
+}
+
+resource "azurerm_lb_backend_address_pool" "cf-ssh-proxy" {
+  name                = "${var.env_id}-cf-ssh-proxy"
+  resource_group_name = "${azurerm_resource_group.bosh.name}"
+  loadbalancer_id     = "${azurerm_lb.cf-ssh-proxy.id}"
+}
+
+resource "azurerm_lb_probe" "cf-ssh-proxy" {
+  name                = "${var.env_id}-cf-ssh-proxy"
+  resource_group_name = "${azurerm_resource_group.bosh.name}"
+  loadbalancer_id     = "${azurerm_lb.cf-ssh-proxy.id}"
+  protocol            = "Tcp"
+  port                = 2222
+}
+
+resource "azurerm_lb_rule" "cf-ssh-proxy" {
+  name                           = "${var.env_id}-cf-ssh-proxy"
+  resource_group_name            = "${azurerm_resource_group.bosh.name}"
+  loadbalancer_id                = "${azurerm_lb.cf-ssh-proxy.id}"
+  frontend_ip_configuration_name = "${var.env_id}-cf-ssh-proxy"
+  backend_address_pool_id        = "${azurerm_lb_backend_address_pool.cf-ssh-proxy.id}"
+  probe_id                       = "${azurerm_lb_probe.cf-ssh-proxy.id}"
+  protocol                       = "Tcp"
+  frontend_port                  = 2222
+  backend_port                   = 2222
+}
+
+resource "azurerm_network_security_rule" "cf-ssh-proxy" {
+  name                        = "${var.env_id}-cf-ssh-proxy"
+  priority                    = 203
+  direction                   = "Inbound"
+  access                      = "Allow"
+  protocol                    = "Tcp"
+  source_port_range           = "*"
+  destination_port_range      = "2222"
+  source_address_prefix       = "*"
+  destination_address_prefix  = "*"
+  resource_group_name         = "${azurerm_resource_group.bosh.name}"
+  network_security_group_name = "${azurerm_network_security_group.cf.name}"
+}
+
+resource "azurerm_public_ip" "cf-tcp-router" {
+  name                         = "${var.env_id}-cf-tcp-router"
+  location                     = "${var.location}"
+  resource_group_name          = "${azurerm_resource_group.bosh.name}"
+  public_ip_address_allocation = "static"
+
+  tags {
+    environment = "${var.env_id}"
+  }
+}
+
+resource "azurerm_lb" "cf-tcp-router" {
+  name                = "${var.env_id}-cf-tcp-router"
+  location            = "${var.location}"
+  resource_group_name = "${azurerm_resource_group.bosh.name}"
+
+  frontend_ip_configuration {
+    name                 = "${var.env_id}-cf-tcp-router"
+    public_ip_address_id = "${azurerm_public_ip.cf-tcp-router.id}"
+  }
+}
+
+resource "azurerm_lb_backend_address_pool" "cf-tcp-router" {
+  name                = "${var.env_id}-cf-tcp-router"
+  resource_group_name = "${azurerm_resource_group.bosh.name}"
+  loadbalancer_id     = "${azurerm_lb.cf-tcp-router.id}"
+}
+
+resource "azurerm_lb_probe" "cf-tcp-router" {
+  name                = "${var.env_id}-cf-tcp-router"
+  resource_group_name = "${azurerm_resource_group.bosh.name}"
+  loadbalancer_id     = "${azurerm_lb.cf-tcp-router.id}"
+  protocol            = "Http"
+  port                = 80
+  request_path        = "/health"
+}
+
+resource "azurerm_lb_rule" "cf-tcp-router" {
+  count                          = 10
+  name                           = "${var.env_id}-cf-tcp-router-${count.index + 1024}"
+  resource_group_name            = "${azurerm_resource_group.bosh.name}"
+  loadbalancer_id                = "${azurerm_lb.cf-tcp-router.id}"
+  frontend_ip_configuration_name = "${var.env_id}-cf-tcp-router"
+  backend_address_pool_id        = "${azurerm_lb_backend_address_pool.cf-tcp-router.id}"
+  probe_id                       = "${azurerm_lb_probe.cf-tcp-router.id}"
+  protocol                       = "Tcp"
+  frontend_port                  = "${count.index + 1024}"
+  backend_port                   = "${count.index + 1024}"
+}
+
+resource "azurerm_network_security_rule" "cf-tcp-router" {
+  name                        = "${var.env_id}-cf-tcp-router"
+  priority                    = 204
+  direction                   = "Inbound"
+  access                      = "Allow"
+  protocol                    = "Tcp"
+  source_port_range           = "*"
+  destination_port_range      = "1024-1033"
+  source_address_prefix       = "*"
+  destination_address_prefix  = "*"
+  resource_group_name         = "${azurerm_resource_group.bosh.name}"
+  network_security_group_name = "${azurerm_network_security_group.cf.name}"
+}
@@ -26,6 +26,10 @@ variable "client_secret" {
 	type = "string"
 }
 
+variable "internal_cidr" {
+	type = "string"
+}
+
 provider "azurerm" {
   subscription_id  = "${var.subscription_id}"
   tenant_id        = "${var.tenant_id}"
@@ -54,15 +54,15 @@ func NewExecutor(cmd terraformCmd, stateStore stateStore, debug bool) Executor {
 	}
 }
 
-func (e Executor) Apply(input map[string]string, template, prevTFState string) (string, error) {
+func (e Executor) Apply(input map[string]string, template, overridesDir, prevTFState string) (string, error) {
 	terraformDir, err := e.stateStore.GetTerraformDir()
 	if err != nil {
 		return "", fmt.Errorf("Get terraform dir: %s", err)
 	}
 
-	err = writeFile(filepath.Join(terraformDir, "template.tf"), []byte(template), os.ModePerm)
+	err = writeTemplate(terraformDir, template, overridesDir)
 	if err != nil {
-		return "", fmt.Errorf("Write terraform template: %s", err)
+		return "", err
 	}
 
 	varsDir, err := e.stateStore.GetVarsDir()
@@ -104,15 +104,65 @@ func (e Executor) Apply(input map[string]string, template, prevTFState string) (
 	return string(tfState), nil
 }
 
-func (e Executor) Destroy(input map[string]string, template, prevTFState string) (string, error) {
+func (e Executor) Plan(input map[string]string, template, overridesDir, prevTFState string) (string, error) {
 	terraformDir, err := e.stateStore.GetTerraformDir()
 	if err != nil {
 		return "", fmt.Errorf("Get terraform dir: %s", err)
 	}
 
-	err = writeFile(filepath.Join(terraformDir, "template.tf"), []byte(template), os.ModePerm)
+	err = writeTemplate(terraformDir, template, overridesDir)
 	if err != nil {
-		return "", fmt.Errorf("Write terraform template: %s", err)
+		return "", err
+	}
+
+	varsDir, err := e.stateStore.GetVarsDir()
+	if err != nil {
+		return "", fmt.Errorf("Get vars dir: %s", err)
+	}
+
+	tfStatePath := filepath.Join(varsDir, "terraform.tfstate")
+
+	if prevTFState != "" {
+		err = writeFile(tfStatePath, []byte(prevTFState), os.ModePerm)
+		if err != nil {
+			return "", fmt.Errorf("Write previous terraform state: %s", err)
+		}
+	}
+
+	err = e.cmd.Run(os.Stdout, terraformDir, []string{"init"}, e.debug)
+	if err != nil {
+		return "", fmt.Errorf("Run terraform init: %s", err)
+	}
+
+	args := []string{
+		"plan",
+		"-state", tfStatePath,
+		"-input=false",
+		"-no-color",
+		"-detailed-exitcode",
+	}
+	for k, v := range input {
+		args = append(args, makeVar(k, v)...)
+	}
+	buffer := bytes.NewBuffer([]byte{})
+	err = e.cmd.Run(buffer, terraformDir, args, true)
+	// -detailed-exitcode makes terraform exit 2 when the plan has changes.
+	if err != nil && exitCode(err) != 2 {
+		return "", fmt.Errorf("Run terraform plan: %s", err)
+	}
+
+	return buffer.String(), nil
+}
+
+func (e Executor) Destroy(input map[string]string, template, overridesDir, prevTFState string) (string, error) {
+	terraformDir, err := e.stateStore.GetTerraformDir()
+	if err != nil {
+		return "", fmt.Errorf("Get terraform dir: %s", err)
+	}
+
+	err = writeTemplate(terraformDir, template, overridesDir)
+	if err != nil {
+		return "", err
 	}
 
 	varsDir, err := e.stateStore.GetVarsDir()
@@ -155,6 +205,48 @@ func (e Executor) Destroy(input map[string]string, template, prevTFState string)
 	return string(tfState), nil
 }
 
+// Taint marks resource in prevTFState so that the next apply replaces it.
+func (e Executor) Taint(template, overridesDir, resource, prevTFState string) (string, error) {
+	terraformDir, err := e.stateStore.GetTerraformDir()
+	if err != nil {
+		return "", fmt.Errorf("Get terraform dir: %s", err)
+	}
+
+	err = writeTemplate(terraformDir, template, overridesDir)
+	if err != nil {
+		return "", err
+	}
+
+	varsDir, err := e.stateStore.GetVarsDir()
+	if err != nil {
+		return "", fmt.Errorf("Get vars dir: %s", err)
+	}
+
+	tfStatePath := filepath.Join(varsDir, "terraform.tfstate")
+
+	err = writeFile(tfStatePath, []byte(prevTFState), os.ModePerm)
+	if err != nil {
+		return "", fmt.Errorf("Write previous terraform state: %s", err)
+	}
+
+	err = e.cmd.Run(os.Stdout, terraformDir, []string{"init"}, e.debug)
+	if err != nil {
+		return "", fmt.Errorf("Run terraform init: %s", err)
+	}
+
+	err = e.cmd.Run(os.Stdout, terraformDir, []string{"taint", "-state", tfStatePath, resource}, e.debug)
+	if err != nil {
+		return "", fmt.Errorf("Run terraform taint: %s", err)
+	}
+
+	tfState, err := readFile(tfStatePath)
+	if err != nil {
+		return "", fmt.Errorf("Read terraform state: %s", err) //not tested
+	}
+
+	return string(tfState), nil
+}
+
 func (e Executor) Import(input ImportInput) (string, error) {
 	terraformDir, err := e.stateStore.GetTerraformDir()
 	if err != nil {
@@ -170,12 +262,13 @@ provider "aws" {
 	region     = %q
 	access_key = %q
 	secret_key = %q
+	token      = %q
 }
 
 resource %q %q {
-}`, input.Creds.Region, input.Creds.AccessKeyID, input.Creds.SecretAccessKey, resourceType, resourceName)
+}`, input.Creds.Region, input.Creds.AccessKeyID, input.Creds.SecretAccessKey, input.Creds.SessionToken, resourceType, resourceName)
 
-	err = writeFile(filepath.Join(terraformDir, "template.tf"), []byte(template), os.ModePerm)
+	err = writeTemplate(terraformDir, template, "")
 	if err != nil {
 		return "", err
 	}
@@ -258,42 +351,98 @@ func (e Executor) Output(tfState, outputName string) (string, error) {
 	return strings.TrimSuffix(buffer.String(), "\n"), nil
 }
 
+// Outputs reads the root module outputs straight from tfState, so it does not
+// need the terraform binary. Both the version 3 state written by terraform 0.11
+// and earlier and the version 4 state written since 0.12 are supported.
 func (e Executor) Outputs(tfState string) (map[string]interface{}, error) {
-	varsDir, err := e.stateStore.GetVarsDir()
-	if err != nil {
-		return map[string]interface{}{}, err
+	outputs := map[string]interface{}{}
+	if tfState == "" {
+		return outputs, nil
 	}
 
-	err = writeFile(filepath.Join(varsDir, "terraform.tfstate"), []byte(tfState), os.ModePerm)
+	var state struct {
+		Outputs map[string]tfOutput `json:"outputs"`
+		Modules []struct {
+			Path    []string            `json:"path"`
+			Outputs map[string]tfOutput `json:"outputs"`
+		} `json:"modules"`
+	}
+	err := json.Unmarshal([]byte(tfState), &state)
 	if err != nil {
-		return map[string]interface{}{}, err
+		return map[string]interface{}{}, fmt.Errorf("Parse terraform state: %s", err)
+	}
+
+	tfOutputs := state.Outputs
+	for _, module := range state.Modules {
+		if len(module.Path) == 1 && module.Path[0] == "root" {
+			tfOutputs = module.Outputs
+		}
 	}
 
-	err = e.cmd.Run(os.Stdout, varsDir, []string{"init"}, false)
+	for tfKey, tfValue := range tfOutputs {
+		outputs[tfKey] = tfValue.Value
+	}
+
+	return outputs, nil
+}
+
+// writeTemplate writes the generated template to terraformDir along with a
+// copy of every .tf file in overridesDir. Overrides copied by earlier runs are
+// removed first, so deleting a file from overridesDir takes effect.
+func writeTemplate(terraformDir, template, overridesDir string) error {
+	previousOverrides, err := filepath.Glob(filepath.Join(terraformDir, "*.tf"))
 	if err != nil {
-		return map[string]interface{}{}, err
+		return err //not tested
+	}
+	for _, path := range previousOverrides {
+		err = os.Remove(path)
+		if err != nil {
+			return fmt.Errorf("Remove previous terraform override: %s", err) //not tested
+		}
 	}
 
-	args := []string{"output", "--json"}
-	buffer := bytes.NewBuffer([]byte{})
-	err = e.cmd.Run(buffer, varsDir, args, true)
+	err = writeFile(filepath.Join(terraformDir, "template.tf"), []byte(template), os.ModePerm)
 	if err != nil {
-		return map[string]interface{}{}, err
+		return fmt.Errorf("Write terraform template: %s", err)
+	}
+
+	if overridesDir == "" {
+		return nil
 	}
 
-	var tfOutputs map[string]tfOutput
-	err = json.Unmarshal(buffer.Bytes(), &tfOutputs)
+	overrides, err := filepath.Glob(filepath.Join(overridesDir, "*.tf"))
 	if err != nil {
-		return map[string]interface{}{}, err
+		return err //not tested
 	}
+	for _, path := range overrides {
+		name := filepath.Base(path)
+		if name == "template.tf" {
+			return fmt.Errorf("Terraform override %s conflicts with the template generated by bbl", path)
+		}
 
-	outputs := map[string]interface{}{}
+		contents, err := ioutil.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("Read terraform override: %s", err)
+		}
 
-	for tfKey, tfValue := range tfOutputs {
-		outputs[tfKey] = tfValue.Value
+		err = writeFile(filepath.Join(terraformDir, name), contents, os.ModePerm)
+		if err != nil {
+			return fmt.Errorf("Write terraform override: %s", err)
+		}
 	}
 
-	return outputs, nil
+	return nil
+}
+
+func exitCode(err error) int {
+	exitErr, ok := err.(interface {
+		ExitCode() int
+	})
+	if !ok {
+		return -1
+	}
+
+	return exitErr.ExitCode()
 }
 
 func makeVar(name string, value string) []string {
@@ -74,7 +74,7 @@ var _ = Describe("Executor", func() {
 
 	Describe("Apply", func() {
 		It("writes the terraform template to a file", func() {
-			_, err := executor.Apply(input, "some-template", "")
+			_, err := executor.Apply(input, "some-template", "", "")
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(stateStore.GetTerraformDirCall.CallCount).To(Equal(1))
@@ -85,8 +85,65 @@ var _ = Describe("Executor", func() {
 			Expect(string(fileContents)).To(Equal("some-template"))
 		})
 
+		Context("when an overrides dir is given", func() {
+			var overridesDir string
+
+			BeforeEach(func() {
+				var err error
+				overridesDir, err = ioutil.TempDir("", "overrides")
+				Expect(err).NotTo(HaveOccurred())
+
+				err = ioutil.WriteFile(filepath.Join(overridesDir, "peering.tf"), []byte("some-peering"), os.ModePerm)
+				Expect(err).NotTo(HaveOccurred())
+				err = ioutil.WriteFile(filepath.Join(overridesDir, "base_override.tf"), []byte("some-override"), os.ModePerm)
+				Expect(err).NotTo(HaveOccurred())
+				err = ioutil.WriteFile(filepath.Join(overridesDir, "README.md"), []byte("not terraform"), os.ModePerm)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = ioutil.WriteFile(filepath.Join(terraformDir, "removed.tf"), []byte("some-removed-override"), os.ModePerm)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("copies the .tf files next to the template and removes old ones", func() {
+				_, err := executor.Apply(input, "some-template", overridesDir, "")
+				Expect(err).NotTo(HaveOccurred())
+
+				files, err := filepath.Glob(filepath.Join(terraformDir, "*"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(files).To(ConsistOf(
+					filepath.Join(terraformDir, "template.tf"),
+					filepath.Join(terraformDir, "peering.tf"),
+					filepath.Join(terraformDir, "base_override.tf"),
+				))
+
+				contents, err := ioutil.ReadFile(filepath.Join(terraformDir, "peering.tf"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(contents)).To(Equal("some-peering"))
+			})
+
+			It("returns an error when an override would replace the template", func() {
+				err := ioutil.WriteFile(filepath.Join(overridesDir, "template.tf"), []byte("some-template"), os.ModePerm)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = executor.Apply(input, "some-template", overridesDir, "")
+				Expect(err).To(MatchError(fmt.Sprintf("Terraform override %s conflicts with the template generated by bbl", filepath.Join(overridesDir, "template.tf"))))
+			})
+
+			It("returns an error when an override cannot be written", func() {
+				terraform.SetWriteFile(func(file string, data []byte, perm os.FileMode) error {
+					if strings.HasSuffix(file, "peering.tf") {
+						return errors.New("failed to write override")
+					}
+					return nil
+				})
+
+				_, err := executor.Apply(input, "some-template", overridesDir, "")
+				Expect(err).To(MatchError("Write terraform override: failed to write override"))
+			})
+		})
+
 		It("passes the correct args and dir to run command", func() {
-			_, err := executor.Apply(input, "some-template", "")
+			_, err := executor.Apply(input, "some-template", "", "")
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(cmd.RunCall.Receives.WorkingDirectory).To(Equal(terraformDir))
@@ -112,7 +169,7 @@ var _ = Describe("Executor", func() {
 				return []byte("some-terraform-state"), nil
 			})
 
-			terraformState, err := executor.Apply(input, "some-template", "")
+			terraformState, err := executor.Apply(input, "some-template", "", "")
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(actualFilename).To(ContainSubstring("terraform.tfstate"))
@@ -141,7 +198,7 @@ var _ = Describe("Executor", func() {
 			})
 
 			It("does not write the previous tf state file", func() {
-				_, err := executor.Apply(input, "some-template", "")
+				_, err := executor.Apply(input, "some-template", "", "")
 				Expect(err).NotTo(HaveOccurred())
 
 				Expect(writeTFStateFileCallCount).To(Equal(0))
@@ -150,7 +207,7 @@ var _ = Describe("Executor", func() {
 
 		Context("when previous tf state is not blank", func() {
 			It("writes the tf state to a file", func() {
-				_, err := executor.Apply(input, "some-template", "some-tf-state")
+				_, err := executor.Apply(input, "some-template", "", "some-tf-state")
 				Expect(err).NotTo(HaveOccurred())
 
 				fileContents, err := ioutil.ReadFile(tfStatePath)
@@ -167,7 +224,7 @@ var _ = Describe("Executor", func() {
 				})
 
 				It("returns an error", func() {
-					_, err := executor.Apply(input, "some-template", "")
+					_, err := executor.Apply(input, "some-template", "", "")
 					Expect(err).To(MatchError("Get terraform dir: canteloupe"))
 				})
 			})
@@ -183,7 +240,7 @@ var _ = Describe("Executor", func() {
 				})
 
 				It("returns an error", func() {
-					_, err := executor.Apply(input, "some-template", "")
+					_, err := executor.Apply(input, "some-template", "", "")
 					Expect(err).To(MatchError("Write terraform template: pear"))
 				})
 			})
@@ -194,7 +251,7 @@ var _ = Describe("Executor", func() {
 				})
 
 				It("returns an error", func() {
-					_, err := executor.Apply(input, "", "")
+					_, err := executor.Apply(input, "", "", "")
 					Expect(err).To(MatchError("Get vars dir: coconut"))
 				})
 			})
@@ -210,7 +267,7 @@ var _ = Describe("Executor", func() {
 				})
 
 				It("returns an error", func() {
-					_, err := executor.Apply(input, "some-template", "some-tf-state")
+					_, err := executor.Apply(input, "some-template", "", "some-tf-state")
 					Expect(err).To(MatchError("Write previous terraform state: peach"))
 				})
 			})
@@ -221,7 +278,7 @@ var _ = Describe("Executor", func() {
 				})
 
 				It("returns an error", func() {
-					_, err := executor.Apply(input, "some-template", "")
+					_, err := executor.Apply(input, "some-template", "", "")
 					Expect(err).To(MatchError("Run terraform init: guava"))
 				})
 			})
@@ -235,7 +292,7 @@ var _ = Describe("Executor", func() {
 				})
 
 				It("returns an error and the current tf state", func() {
-					_, err := executor.Apply(input, "some-template", "")
+					_, err := executor.Apply(input, "some-template", "", "")
 					taErr := err.(terraform.ExecutorError)
 					Expect(taErr).To(MatchError("the-executor-error"))
 
@@ -253,7 +310,7 @@ var _ = Describe("Executor", func() {
 				})
 
 				It("returns an error", func() {
-					_, err := executor.Apply(input, "some-template", "")
+					_, err := executor.Apply(input, "some-template", "", "")
 					Expect(err).To(MatchError("Read terraform state: lychee"))
 				})
 			})
@@ -272,7 +329,7 @@ var _ = Describe("Executor", func() {
 					})
 
 					It("returns an error and the current tf state", func() {
-						_, err := executor.Apply(input, "some-template", "")
+						_, err := executor.Apply(input, "some-template", "", "")
 						taErr := err.(terraform.ExecutorError)
 
 						tfState, err := taErr.TFState()
@@ -284,9 +341,149 @@ var _ = Describe("Executor", func() {
 		})
 	})
 
+	Describe("Taint", func() {
+		BeforeEach(func() {
+			terraform.SetReadFile(func(filename string) ([]byte, error) {
+				return []byte("some-tainted-tf-state"), nil
+			})
+		})
+
+		It("taints the resource in the previous tf state and returns the new tf state", func() {
+			tfState, err := executor.Taint("some-template", "", "tls_private_key.bosh_vms", "some-tf-state")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tfState).To(Equal("some-tainted-tf-state"))
+
+			template, err := ioutil.ReadFile(filepath.Join(terraformDir, "template.tf"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(template)).To(Equal("some-template"))
+
+			prevTFState, err := ioutil.ReadFile(tfStatePath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(prevTFState)).To(Equal("some-tf-state"))
+
+			Expect(cmd.RunCall.Receives.WorkingDirectory).To(Equal(terraformDir))
+			Expect(cmd.RunCall.Receives.Args).To(Equal([]string{"taint", "-state", tfStatePath, "tls_private_key.bosh_vms"}))
+		})
+
+		Context("when an error occurs", func() {
+			It("returns an error when getting the terraform dir fails", func() {
+				stateStore.GetTerraformDirCall.Returns.Error = errors.New("canteloupe")
+
+				_, err := executor.Taint("some-template", "", "tls_private_key.bosh_vms", "some-tf-state")
+				Expect(err).To(MatchError("Get terraform dir: canteloupe"))
+			})
+
+			It("returns an error when terraform init fails", func() {
+				cmd.RunCall.Returns.Errors = []error{errors.New("guava")}
+
+				_, err := executor.Taint("some-template", "", "tls_private_key.bosh_vms", "some-tf-state")
+				Expect(err).To(MatchError("Run terraform init: guava"))
+			})
+
+			It("returns an error when terraform taint fails", func() {
+				cmd.RunCall.Returns.Errors = []error{nil, errors.New("lychee")}
+
+				_, err := executor.Taint("some-template", "", "tls_private_key.bosh_vms", "some-tf-state")
+				Expect(err).To(MatchError("Run terraform taint: lychee"))
+			})
+		})
+	})
+
+	Describe("Plan", func() {
+		BeforeEach(func() {
+			cmd.RunCall.Stub = func(stdout io.Writer) {
+				stdout.Write([]byte("Plan: 1 to add, 0 to change, 0 to destroy."))
+			}
+		})
+
+		It("writes the template and previous tf state and returns the plan output", func() {
+			output, err := executor.Plan(input, "some-template", "", "some-tf-state")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(output).To(Equal("Plan: 1 to add, 0 to change, 0 to destroy."))
+
+			template, err := ioutil.ReadFile(filepath.Join(terraformDir, "template.tf"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(template)).To(Equal("some-template"))
+
+			tfState, err := ioutil.ReadFile(tfStatePath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(tfState)).To(Equal("some-tf-state"))
+		})
+
+		It("passes the correct args and dir to run command", func() {
+			_, err := executor.Plan(input, "some-template", "", "")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(cmd.RunCall.Receives.WorkingDirectory).To(Equal(terraformDir))
+			Expect(cmd.RunCall.Receives.Args).To(ConsistOf([]string{
+				"plan",
+				"-state", tfStatePath,
+				"-input=false",
+				"-no-color",
+				"-detailed-exitcode",
+				"-var", "project_id=some-project-id",
+				"-var", "env_id=some-env-id",
+				"-var", "region=some-region",
+				"-var", "zone=some-zone",
+				"-var", "ssl_certificate=some/certificate/path",
+				"-var", "ssl_certificate_private_key=some/key/path",
+				"-var", "credentials=some/credentials/path",
+				"-var", "system_domain=some-domain",
+			}))
+		})
+
+		Context("when an error occurs", func() {
+			Context("when getting terraform dir fails", func() {
+				BeforeEach(func() {
+					stateStore.GetTerraformDirCall.Returns.Error = errors.New("canteloupe")
+				})
+
+				It("returns an error", func() {
+					_, err := executor.Plan(input, "some-template", "", "")
+					Expect(err).To(MatchError("Get terraform dir: canteloupe"))
+				})
+			})
+
+			Context("when terraform init fails", func() {
+				BeforeEach(func() {
+					cmd.RunCall.Returns.Errors = []error{errors.New("guava")}
+				})
+
+				It("returns an error", func() {
+					_, err := executor.Plan(input, "some-template", "", "")
+					Expect(err).To(MatchError("Run terraform init: guava"))
+				})
+			})
+
+			Context("when terraform plan exits 2 because there are changes", func() {
+				BeforeEach(func() {
+					cmd.RunCall.Returns.Errors = []error{nil, exitError{code: 2}}
+				})
+
+				It("returns the plan output", func() {
+					output, err := executor.Plan(input, "some-template", "", "")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(output).To(Equal("Plan: 1 to add, 0 to change, 0 to destroy."))
+				})
+			})
+
+			Context("when terraform plan fails", func() {
+				BeforeEach(func() {
+					cmd.RunCall.Returns.Errors = []error{nil, errors.New("papaya")}
+				})
+
+				It("returns an error", func() {
+					_, err := executor.Plan(input, "some-template", "", "")
+					Expect(err).To(MatchError("Run terraform plan: papaya"))
+				})
+			})
+		})
+	})
+
 	Describe("Destroy", func() {
 		It("writes the template and tf state to a temp dir", func() {
-			_, err := executor.Destroy(input, "some-template", "some-tf-state")
+			_, err := executor.Destroy(input, "some-template", "", "some-tf-state")
 			Expect(err).NotTo(HaveOccurred())
 
 			templateContents, err := ioutil.ReadFile(filepath.Join(terraformDir, "template.tf"))
@@ -298,8 +495,22 @@ var _ = Describe("Executor", func() {
 			Expect(string(tfStateContents)).To(Equal("some-tf-state"))
 		})
 
+		It("copies the terraform overrides next to the template", func() {
+			overridesDir, err := ioutil.TempDir("", "overrides")
+			Expect(err).NotTo(HaveOccurred())
+			err = ioutil.WriteFile(filepath.Join(overridesDir, "peering.tf"), []byte("some-peering"), os.ModePerm)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = executor.Destroy(input, "some-template", overridesDir, "some-tf-state")
+			Expect(err).NotTo(HaveOccurred())
+
+			contents, err := ioutil.ReadFile(filepath.Join(terraformDir, "peering.tf"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(contents)).To(Equal("some-peering"))
+		})
+
 		It("passes the correct args and dir to run command", func() {
-			_, err := executor.Destroy(input, "some-template", "some-tf-state")
+			_, err := executor.Destroy(input, "some-template", "", "some-tf-state")
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(cmd.RunCall.Receives.WorkingDirectory).To(Equal(terraformDir))
@@ -324,7 +535,7 @@ var _ = Describe("Executor", func() {
 				return []byte{}, nil
 			})
 
-			tfState, err := executor.Destroy(input, "some-template", "some-tf-state")
+			tfState, err := executor.Destroy(input, "some-template", "", "some-tf-state")
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(tfState).To(Equal(""))
@@ -337,7 +548,7 @@ var _ = Describe("Executor", func() {
 				})
 
 				It("returns an error", func() {
-					_, err := executor.Destroy(input, "some-template", "")
+					_, err := executor.Destroy(input, "some-template", "", "")
 					Expect(err).To(MatchError("Get terraform dir: kiwi"))
 				})
 			})
@@ -348,7 +559,7 @@ var _ = Describe("Executor", func() {
 				})
 
 				It("returns an error", func() {
-					_, err := executor.Destroy(input, "some-template", "")
+					_, err := executor.Destroy(input, "some-template", "", "")
 					Expect(err).To(MatchError("Get vars dir: banana"))
 				})
 			})
@@ -364,7 +575,7 @@ var _ = Describe("Executor", func() {
 				})
 
 				It("returns an error", func() {
-					_, err := executor.Destroy(input, "some-template", "")
+					_, err := executor.Destroy(input, "some-template", "", "")
 					Expect(err).To(MatchError("Write terraform template: nectarine"))
 				})
 			})
@@ -380,7 +591,7 @@ var _ = Describe("Executor", func() {
 				})
 
 				It("returns an error", func() {
-					_, err := executor.Destroy(input, "some-template", "some-tf-state")
+					_, err := executor.Destroy(input, "some-template", "", "some-tf-state")
 					Expect(err).To(MatchError("Write previous terraform state: grape"))
 				})
 			})
@@ -391,7 +602,7 @@ var _ = Describe("Executor", func() {
 				})
 
 				It("returns an error", func() {
-					_, err := executor.Destroy(input, "some-template", "")
+					_, err := executor.Destroy(input, "some-template", "", "")
 					Expect(err).To(MatchError("Run terraform init: coconut"))
 				})
 			})
@@ -404,7 +615,7 @@ var _ = Describe("Executor", func() {
 				})
 
 				It("returns an error and the current tf state", func() {
-					_, err := executor.Destroy(input, "some-template", "")
+					_, err := executor.Destroy(input, "some-template", "", "")
 					tdErr := err.(terraform.ExecutorError)
 					Expect(tdErr).To(MatchError("the-executor-error"))
 
@@ -422,7 +633,7 @@ var _ = Describe("Executor", func() {
 				})
 
 				It("returns an error", func() {
-					_, err := executor.Destroy(input, "some-template", "")
+					_, err := executor.Destroy(input, "some-template", "", "")
 					Expect(err).To(MatchError("Read terraform state: blueberry"))
 				})
 			})
@@ -441,7 +652,7 @@ var _ = Describe("Executor", func() {
 					})
 
 					It("returns an error and the current tf state", func() {
-						_, err := executor.Destroy(input, "some-template", "")
+						_, err := executor.Destroy(input, "some-template", "", "")
 						tdErr := err.(terraform.ExecutorError)
 
 						tfState, err := tdErr.TFState()
@@ -496,6 +707,7 @@ var _ = Describe("Executor", func() {
 					Region:          "some-region",
 					AccessKeyID:     "some-access-key",
 					SecretAccessKey: "some-secret",
+					SessionToken:    "some-session-token",
 				},
 			})
 			Expect(err).NotTo(HaveOccurred())
@@ -505,6 +717,7 @@ provider "aws" {
 	region     = "some-region"
 	access_key = "some-access-key"
 	secret_key = "some-secret"
+	token      = "some-session-token"
 }
 
 resource "some-resource-type" "some-addr" {
@@ -758,97 +971,94 @@ resource "some-resource-type" "some-addr" {
 	})
 
 	Describe("Outputs", func() {
-		It("returns all outputs from the terraform state", func() {
-			cmd.RunCall.Stub = func(stdout io.Writer) {
-				fmt.Fprintf(stdout, `{
-					"director_address": {
-						"sensitive": false,
-						"type": "string",
-						"value": "some-director-address"
+		It("returns the root module outputs from a version 3 terraform state", func() {
+			outputs, err := executor.Outputs(`{
+				"version": 3,
+				"modules": [
+					{
+						"path": ["root"],
+						"outputs": {
+							"director_address": {
+								"sensitive": false,
+								"type": "string",
+								"value": "some-director-address"
+							},
+							"network_names": {
+								"sensitive": false,
+								"type": "list",
+								"value": ["some-network", "some-other-network"]
+							}
+						}
 					},
-					"external_ip": {
-						"sensitive": false,
-						"type": "string",
-						"value": "some-external-ip"
+					{
+						"path": ["root", "some-module"],
+						"outputs": {
+							"module_output": {
+								"sensitive": false,
+								"type": "string",
+								"value": "some-module-output"
+							}
+						}
 					}
-				}`)
-			}
-			outputs, err := executor.Outputs("some-tf-state")
+				]
+			}`)
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(outputs).To(Equal(map[string]interface{}{
 				"director_address": "some-director-address",
-				"external_ip":      "some-external-ip",
+				"network_names":    []interface{}{"some-network", "some-other-network"},
 			}))
-
-			Expect(cmd.RunCall.Receives.WorkingDirectory).To(Equal(varsDir))
-			Expect(cmd.RunCall.Receives.Args).To(Equal([]string{"output", "--json"}))
-			Expect(cmd.RunCall.Receives.Debug).To(BeTrue())
 		})
 
-		Context("when an error occurs", func() {
-			Context("when it fails to get vars dir", func() {
-				BeforeEach(func() {
-					stateStore.GetVarsDirCall.Returns.Error = errors.New("failed to get vars dir")
-				})
-
-				It("returns an error", func() {
-					_, err := executor.Outputs("some-tf-state")
-					Expect(err).To(MatchError("failed to get vars dir"))
-				})
-			})
-
-			Context("when it fails to write the tfstate file", func() {
-				BeforeEach(func() {
-					terraform.SetWriteFile(func(file string, data []byte, perm os.FileMode) error {
-						if strings.Contains(file, "terraform.tfstate") {
-							return errors.New("failed to write tf state file")
-						}
-
-						return nil
-					})
-				})
-
-				It("returns an error", func() {
-					_, err := executor.Outputs("some-tf-state")
-					Expect(err).To(MatchError("failed to write tf state file"))
-				})
-			})
+		It("returns the outputs from a version 4 terraform state", func() {
+			outputs, err := executor.Outputs(`{
+				"version": 4,
+				"outputs": {
+					"external_ip": {
+						"value": "some-external-ip",
+						"type": "string",
+						"sensitive": true
+					}
+				}
+			}`)
+			Expect(err).NotTo(HaveOccurred())
 
-			Context("when terraform init fails", func() {
-				BeforeEach(func() {
-					cmd.RunCall.Returns.Errors = []error{errors.New("failed to initialize terraform")}
-				})
+			Expect(outputs).To(Equal(map[string]interface{}{
+				"external_ip": "some-external-ip",
+			}))
+		})
 
-				It("returns an error", func() {
-					_, err := executor.Outputs("some-tf-state")
-					Expect(err).To(MatchError("failed to initialize terraform"))
-				})
-			})
+		It("does not run terraform", func() {
+			_, err := executor.Outputs(`{"version": 4, "outputs": {}}`)
+			Expect(err).NotTo(HaveOccurred())
 
-			Context("when it fails to call terraform command run", func() {
-				BeforeEach(func() {
-					cmd.RunCall.Returns.Errors = []error{nil, errors.New("failed to run terraform command")}
-				})
+			Expect(cmd.RunCall.CallCount).To(Equal(0))
+		})
 
-				It("returns an error", func() {
-					_, err := executor.Outputs("some-tf-state")
-					Expect(err).To(MatchError("failed to run terraform command"))
-				})
-			})
+		It("returns no outputs when there is no terraform state", func() {
+			outputs, err := executor.Outputs("")
+			Expect(err).NotTo(HaveOccurred())
 
-			Context("when it fails to unmarshal the terraform outputs", func() {
-				BeforeEach(func() {
-					cmd.RunCall.Stub = func(stdout io.Writer) {
-						fmt.Fprintf(stdout, "%%%")
-					}
-				})
+			Expect(outputs).To(BeEmpty())
+		})
 
-				It("returns an error", func() {
-					_, err := executor.Outputs("some-tf-state")
-					Expect(err).To(MatchError("invalid character '%' looking for beginning of value"))
-				})
+		Context("when the terraform state cannot be parsed", func() {
+			It("returns an error", func() {
+				_, err := executor.Outputs("%%%")
+				Expect(err).To(MatchError("Parse terraform state: invalid character '%' looking for beginning of value"))
 			})
 		})
 	})
 })
+
+type exitError struct {
+	code int
+}
+
+func (e exitError) Error() string {
+	return fmt.Sprintf("exit status %d", e.code)
+}
+
+func (e exitError) ExitCode() int {
+	return e.code
+}
@@ -18,6 +18,10 @@ variable "credentials" {
 	type = "string"
 }
 
+variable "internal_cidr" {
+	type = "string"
+}
+
 provider "google" {
 	credentials = "${file("${var.credentials}")}"
 	project = "${var.project_id}"
@@ -55,7 +59,7 @@ resource "google_compute_network" "bbl-network" {
 
 resource "google_compute_subnetwork" "bbl-subnet" {
   name			= "${var.env_id}-subnet"
-  ip_cidr_range = "10.0.0.0/16"
+  ip_cidr_range = "${var.internal_cidr}"
   network		= "${google_compute_network.bbl-network.self_link}"
 }
 
@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/cloudfoundry/bosh-bootloader/bosh"
 	"github.com/cloudfoundry/bosh-bootloader/storage"
 )
 
@@ -19,6 +20,11 @@ func NewInputGenerator() InputGenerator {
 }
 
 func (i InputGenerator) Generate(state storage.State) (map[string]string, error) {
+	internalNetwork, err := bosh.ParseInternalNetwork(state.InternalCIDR)
+	if err != nil {
+		return map[string]string{}, err
+	}
+
 	dir, err := tempDir("", "")
 	if err != nil {
 		return map[string]string{}, err
@@ -37,6 +43,7 @@ func (i InputGenerator) Generate(state storage.State) (map[string]string, error)
 		"zone":          state.GCP.Zone,
 		"credentials":   credentialsPath,
 		"system_domain": state.LB.Domain,
+		"internal_cidr": internalNetwork.CIDR().String(),
 	}
 
 	if state.LB.Cert != "" && state.LB.Key != "" {
@@ -65,6 +65,7 @@ var _ = Describe("InputGenerator", func() {
 			"zone":          state.GCP.Zone,
 			"credentials":   filepath.Join(tempDir, "credentials.json"),
 			"system_domain": state.LB.Domain,
+			"internal_cidr": "10.0.0.0/16",
 		}))
 
 		credentials, err := ioutil.ReadFile(inputs["credentials"])
@@ -91,6 +92,7 @@ var _ = Describe("InputGenerator", func() {
 				"ssl_certificate":             filepath.Join(tempDir, "cert"),
 				"ssl_certificate_private_key": filepath.Join(tempDir, "key"),
 				"system_domain":               state.LB.Domain,
+				"internal_cidr":               "10.0.0.0/16",
 			}))
 
 			sslCertificate, err := ioutil.ReadFile(inputs["ssl_certificate"])
@@ -2,6 +2,7 @@ package gcp
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/cloudfoundry/bosh-bootloader/storage"
@@ -41,9 +42,30 @@ func (t TemplateGenerator) Generate(state storage.State) string {
 		}
 	}
 
+	if state.ExistingNetwork != "" {
+		return useExistingNetwork(template, state.ExistingNetwork)
+	}
+
 	return template
 }
 
+var (
+	networkResource = regexp.MustCompile(`(?s)resource "google_compute_network" "bbl-network" \{\n.*?\n\}\n`)
+	networkDepends  = regexp.MustCompile(`(?m)^\s*depends_on = \["google_compute_network.bbl-network"\]\n`)
+)
+
+// useExistingNetwork replaces the network in template with a data source for
+// networkName, so that it is referenced but never created or destroyed by bbl.
+func useExistingNetwork(template, networkName string) string {
+	template = networkResource.ReplaceAllLiteralString(template, fmt.Sprintf(`data "google_compute_network" "bbl-network" {
+  name = %q
+}
+`, networkName))
+
+	template = networkDepends.ReplaceAllLiteralString(template, "")
+	return strings.Replace(template, "${google_compute_network.bbl-network.", "${data.google_compute_network.bbl-network.", -1)
+}
+
 func (t TemplateGenerator) GenerateBackendService(zoneList []string) string {
 	backendBase := `resource "google_compute_backend_service" "router-lb-backend-service" {
   name        = "${var.env_id}-router-lb"
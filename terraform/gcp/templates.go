@@ -1,4 +1,4 @@
-// Code generated by go-bindata.
+// Package gcp Code generated by go-bindata. (@generated) DO NOT EDIT.
 // sources:
 // templates/bosh_director.tf
 // templates/cf_dns.tf
@@ -6,8 +6,6 @@
 // templates/concourse_lb.tf
 // templates/jumpbox.tf
 // templates/vars.tf
-// DO NOT EDIT!
-
 package gcp
 
 import (
@@ -25,7 +23,7 @@ import (
 func bindataRead(data []byte, name string) ([]byte, error) {
 	gz, err := gzip.NewReader(bytes.NewBuffer(data))
 	if err != nil {
-		return nil, fmt.Errorf("Read %q: %v", name, err)
+		return nil, fmt.Errorf("read %q: %v", name, err)
 	}
 
 	var buf bytes.Buffer
@@ -33,7 +31,7 @@ func bindataRead(data []byte, name string) ([]byte, error) {
 	clErr := gz.Close()
 
 	if err != nil {
-		return nil, fmt.Errorf("Read %q: %v", name, err)
+		return nil, fmt.Errorf("read %q: %v", name, err)
 	}
 	if clErr != nil {
 		return nil, err
@@ -54,26 +52,37 @@ type bindataFileInfo struct {
 	modTime time.Time
 }
 
+// Name return file name
 func (fi bindataFileInfo) Name() string {
 	return fi.name
 }
+
+// Size return file size
 func (fi bindataFileInfo) Size() int64 {
 	return fi.size
 }
+
+// Mode return file mode
 func (fi bindataFileInfo) Mode() os.FileMode {
 	return fi.mode
 }
+
+// ModTime return file modify time
 func (fi bindataFileInfo) ModTime() time.Time {
 	return fi.modTime
 }
+
+// IsDir return file whether a directory
 func (fi bindataFileInfo) IsDir() bool {
-	return false
+	return fi.mode&os.ModeDir != 0
 }
+
+// Sys return file is sys mode
 func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
-var _templatesBosh_directorTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xc4\x95\xd1\x72\xa2\x30\x14\x86\xaf\xe5\x29\xce\x64\xf6\xb2\xba\x96\x05\xf5\xa6\x4f\xb2\xe3\x64\x02\x1e\x59\xb6\x91\xc3\x84\xa0\x9d\xe9\xf8\xee\x3b\x21\x04\xb0\x2a\x1b\x6d\x9d\x4e\x2f\xa0\xf8\xff\x7f\x4e\xbe\x9c\x03\x54\xeb\xb2\xd6\xc0\x0a\xd4\x07\x52\xaf\xbc\x10\x3b\x64\xf0\x1e\x00\x00\xec\x85\xac\x11\x5e\x80\xfd\x78\xcf\x88\x32\x89\x3c\xa5\x5d\x59\x6b\xe4\xad\x7a\x96\x24\x72\xea\xee\x8d\xf3\xc8\x82\x63\x10\xb8\xcc\xaa\x4e\x6e\x8b\xed\x0d\x4d\xb2\xfd\xf7\x42\x70\x42\xd5\x1f\x4e\x25\x16\x5c\x8b\xcc\x33\x7b\x9b\x2b\x3c\x08\x29\x67\xc6\x3c\x35\xe6\x6b\xc1\x9b\x5c\x61\xaa\x49\x9d\x84\x4f\x7c\x93\x9d\xfb\x42\xfa\xdf\x7a\x57\x26\xf4\x76\x35\x77\x2f\xd4\x0c\x8b\x3d\xcf\x37\xc7\x69\xab\x3d\xf1\xe7\x85\x46\x55\x08\x79\xcf\xae\x9d\x77\x50\x96\xc2\x8a\x6a\x95\x22\xb0\xcb\xa7\xcb\x80\x0d\xce\xd7\xae\x65\xdc\x93\xc9\x79\xb9\x4e\x14\x00\x88\x5a\x13\x4f\x15\x8a\x93\x03\xad\xe0\x05\xb6\x42\x56\x38\xba\x72\xaf\x6f\x17\xb7\x0f\x86\x6b\x4f\xce\xd6\x6e\x35\x01\x40\x5e\xf2\x34\xdf\x28\xae\x44\x91\x35\x3c\x9e\xe7\xb3\xe6\xef\xe7\xf3\xc2\xfc\xde\x66\xb7\x19\x1e\x3d\x5d\xa1\xdc\x72\x99\x17\xaf\xff\x21\xe6\x30\x33\x60\xf8\x66\x41\xf7\x35\x9b\x13\x3a\x2b\xba\x93\xf5\x65\xdd\x3a\x69\x01\x80\x2d\xc7\xee\xd7\x10\xfe\xcd\xdc\x86\xe7\x6c\x6d\x04\x42\x4a\x3a\xb4\x5d\x52\x92\xd2\x56\x14\x86\xec\x09\xd8\x62\xb5\x58\x99\x6b\x18\xc7\x71\xcc\xd6\x56\xa3\x48\x53\x4a\xd2\xd4\xa2\xd3\xd2\x54\x77\x34\x39\x5a\xa8\x0c\xb5\x69\x3c\x9b\x70\xba\x99\x6e\xa4\xd8\xda\x17\x53\x6f\x19\xe7\xd4\xeb\xbe\x02\x94\x47\xfd\x9e\xd0\x56\x51\xf4\xab\xb9\xae\xa2\xe8\x0b\x21\xba\xb7\xc7\x8d\x20\x3b\x9b\x07\xcc\x4e\xfb\x68\xa0\x83\xbd\x7c\x84\x7a\x17\x20\xf7\x0a\xf3\x67\xe3\x1c\x53\x4d\xbe\x88\x2e\x5a\x1e\x48\x6a\xb0\xa9\xab\x9d\x17\x85\xb6\xf7\xc2\x38\x8c\xe7\xf6\x66\xb9\x5c\x7e\x47\xb3\xb5\x5f\x25\x03\xa7\x79\x30\x8a\xf2\x83\xf8\x81\x10\xdd\xc7\x72\x7c\x7a\x3f\xc3\xab\x3b\xa6\xa7\xf1\x99\xba\xb9\x35\x3d\xdb\xf1\x9b\x5a\x70\xc0\x2a\x4f\x77\x3d\x2c\x9f\x61\xbe\xa6\xa9\x37\x77\x0d\xfc\xbf\x00\x00\x00\xff\xff\x79\x21\x66\x2d\xa9\x0a\x00\x00")
+var _templatesBosh_directorTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xc4\x95\xd1\x8e\xa2\x30\x14\x86\xaf\xed\x53\x9c\x34\x7b\x39\xba\x86\x85\x91\x9b\x79\x92\x8d\x69\x0a\x56\x96\x9d\x4a\x49\x69\x75\x92\x09\xef\xbe\xa9\x6d\x01\x47\xc4\xea\x6a\x26\x73\x31\x88\xff\xff\x9f\xc3\x77\x4e\x51\x68\x55\x6b\x05\xb8\x62\xea\x20\xe4\x3b\xa9\xe8\x8e\x61\xf8\x44\x00\x00\x7b\xca\x35\x83\x37\xc0\x3f\x3e\x0b\x21\x0a\xce\x48\x2e\x76\xb5\x56\x8c\x38\xf5\x22\xcb\xf8\xdc\x5f\x1b\x67\x8b\x51\x8b\x90\xcf\x6c\x74\x76\x5b\x6c\x6f\x38\x26\xdb\x8f\x23\xc1\x99\x68\xfe\x10\x51\xb3\x8a\x28\x5a\x04\x66\x6f\x4b\xc9\x0e\x94\xf3\x85\x31\xcf\x8d\xf9\x52\xf0\xa6\x94\x2c\x57\x42\x9e\x84\xcf\x42\x93\xbd\x7b\x24\xfd\xaf\xde\xd5\x99\xf8\xb8\x98\xbb\xa7\x72\xc1\xaa\x3d\x29\x37\xed\xdc\x69\x4f\xfc\x65\xa5\x98\xac\x28\xbf\xe7\xa9\xbd\x77\xd0\x96\x64\x8d\xd0\x32\x67\x80\xbf\x98\xdc\x0c\x30\xe0\xc1\x7c\xed\xf4\x8c\x7b\x36\x3b\x6f\xd7\x8b\x10\x00\xd5\x4a\x90\x5c\x32\x7a\x32\xd0\x06\xde\x60\x4b\x79\xc3\x26\x2b\xf7\x7a\x57\xdc\xde\x18\xd6\x9e\x9d\xd5\x76\x1a\x04\x50\xd6\x24\x2f\x37\x92\x48\x5a\x15\x03\xa6\x1d\x37\xf3\x65\x8b\x11\x80\x2b\xe2\xc2\x02\x96\xbb\x61\x7c\x4b\x78\x59\xbd\x5f\x41\xe7\x79\x63\xc0\xec\xc3\x56\xed\x9b\x37\xcb\x7f\xd6\x7d\x27\xeb\xdb\x1a\x9d\xe4\x58\x57\x6e\x96\x08\xc0\xb6\x63\x1f\xdc\xa0\xfe\x8d\x97\x8b\xe3\xdf\xcf\x25\x5e\x23\x33\x15\xce\xc5\xc1\xad\x4b\x2d\xa4\xb2\xa2\x28\xc2\x2f\x80\x5f\xd3\xd7\xd4\xfc\x8f\x92\x24\x49\xf0\xda\x6a\xa4\x50\x22\x17\xdc\xf4\xa2\xf2\xda\x40\x6b\x4d\x8e\xa2\xb2\x60\xca\x6c\xa0\x4d\x38\x7d\x98\xee\x6c\xe1\x75\x28\xa6\xde\x32\xcd\xa9\xd7\x3d\x02\x54\x40\xff\x81\xd0\xd2\x38\xfe\x65\xe0\xa5\x69\x1c\x3f\x10\xa2\x7f\x8d\xdc\x08\xb2\xb3\x05\xc0\xec\xb4\xcf\x06\xda\x15\x3a\x87\x7a\x17\x20\x7f\x9e\xc3\xd9\x78\xc7\x5c\x89\x50\x44\xa3\x96\x27\x92\xf2\xf5\xa6\x36\x2f\x8e\xec\x81\x8d\x92\x28\x59\xda\x8b\xd5\x6a\xf5\x1d\xcb\xe6\x7e\x9e\x0c\x9c\xe3\x8d\x49\x94\x5f\xc4\x4f\x84\xe8\x2a\x5d\x39\xbd\xff\xc3\xab\x1b\xd3\x0b\x3c\x86\x64\x17\x18\xb6\x8e\xcf\x7c\xfb\x4d\xac\xe0\x80\x55\x99\xef\x7a\x58\x97\x44\x2a\xbf\xae\xd1\x9b\x9b\xa1\xaf\x51\x8b\xfe\x0d\x00\x5f\xb1\xde\xf5\xb2\x0a\x00\x00")
 
 func templatesBosh_directorTfBytes() ([]byte, error) {
 	return bindataRead(
@@ -88,12 +97,12 @@ func templatesBosh_directorTf() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates/bosh_director.tf", size: 2729, mode: os.FileMode(420), modTime: time.Unix(1507150150, 0)}
+	info := bindataFileInfo{name: "templates/bosh_director.tf", size: 2738, mode: os.FileMode(436), modTime: time.Unix(1792206593, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _templatesCf_dnsTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x96\x41\x8f\x9b\x30\x10\x85\xef\xfc\x8a\x91\xd5\x53\xa5\xa0\x95\x7a\xce\xa1\x52\xcf\xbd\xf4\x58\x45\xc8\xb1\x27\x04\xc9\xd8\xd6\xcc\x40\x9a\xae\xf8\xef\x95\x09\x10\xd2\x2e\x6a\x38\x64\x95\xc3\x72\x01\xcc\xf3\xbc\x37\x9f\x11\xa6\xd5\x54\xe9\xbd\x43\x50\x7c\x66\xc1\xba\xb0\xa1\xd6\x95\x57\xf0\x9a\x01\xc8\x39\x22\x6c\x41\xb1\x50\xe5\x4b\x95\x75\x59\x46\xc8\xa1\x21\x83\xa0\xca\x10\x4a\x87\x85\xf5\x5c\xd4\xda\xeb\x12\x6d\xf1\x3b\x78\x54\xa0\xd0\xb7\xfd\xf0\xe5\x36\x15\xf2\xba\x46\x18\x8e\x2d\xa8\x4f\xaf\xad\xa6\x3c\xc9\x2a\xdb\x6d\x7a\x59\x06\x90\xa6\x8c\xc2\x49\x74\x93\xaa\xcb\x7b\x1d\xb2\xa1\x2a\x4a\x15\x7c\xd2\x7d\xfb\xfe\x03\x52\x09\x38\x04\x02\x39\x22\xdc\x54\x07\xf4\x6d\x45\xc1\xd7\xe8\xa5\x6f\x20\x34\x12\x1b\xf9\xab\xdd\x3e\x2e\x23\xb5\x48\x7c\x49\xdc\x6a\xd7\xe0\x25\xc6\x42\xa3\xf9\xbc\xcd\x3c\x05\x1f\x2b\x74\xcb\xa4\x08\x4d\x20\x5b\x30\x8a\x02\x75\xaa\x9c\x35\x9a\xec\xc6\x7a\xfe\x87\xd3\x16\xd4\xe7\xfc\x4e\xf3\x91\x5c\x77\xc1\x13\xd1\x5b\x2e\x7a\x3a\x3f\x47\x73\x13\xea\xd8\x08\x16\xa5\x0b\x7b\xed\x0a\x6d\x2d\x21\x73\x6e\x0e\x9b\xe1\x52\xed\xc6\x05\x9f\xfc\xbf\xa6\x72\x22\xee\xba\x72\x5f\x5e\x5e\xb2\x0c\x60\x9e\x64\x25\xa3\x4e\xa5\x02\x44\x56\x8b\xe6\x3e\xe0\x34\xf9\xbf\x11\xf3\xe1\xdc\xa9\xdd\x7d\x80\xcd\x61\xc3\x7c\xdc\x44\x0a\xbf\xce\x6f\x01\x66\x3e\x3e\x00\xf1\x2c\xf8\xd5\xfd\x69\xe8\xbe\x95\x6e\x35\x58\x31\x71\xe9\xa5\x15\x13\x1f\xcb\x34\x79\x53\x68\x04\xe9\x29\xa1\x5e\xe3\xad\xa6\x6a\x43\x8c\x0e\x69\x89\xec\xf0\xf8\xb1\x74\x4f\x4f\xf4\x21\xb8\x89\xb5\x9a\xa6\x0b\x65\x49\x58\x6a\x09\x8b\x44\x67\x92\x0f\xaa\x2b\xf7\xac\x13\x2f\x6f\x5b\x27\xfe\xc0\x79\xe7\x0e\x45\x68\x8f\xcd\x7e\x86\x71\x3b\x0d\x3e\x92\xe1\x60\xbb\x9b\xfd\xe4\x4d\xfc\xde\x1b\xdc\xd0\xed\x0d\xba\x3f\x01\x00\x00\xff\xff\xea\xd9\xbe\x05\x97\x0a\x00\x00")
+var _templatesCf_dnsTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\xd5\xc1\xca\xda\x40\x10\x07\xf0\x7b\x9e\x62\x58\x3c\x7d\x90\xf0\x41\xcf\x39\x14\x7a\xee\xa5\xc7\x22\x61\xdd\x1d\x63\x20\xd9\x5d\x66\x36\x49\xad\xec\xbb\x97\x8d\x49\x88\xad\x81\x78\xb0\x78\xd0\x8b\x66\x1d\x67\xfe\xf3\x33\x62\x27\xa9\x92\x87\x1a\x41\xf0\x99\x3d\x36\x85\xb6\x8d\xac\x8c\x80\x4b\x02\xe0\xcf\x0e\x21\x07\xc1\x9e\x2a\x53\x8a\x24\x24\x09\x21\xdb\x96\x14\x82\x28\xad\x2d\x6b\x2c\xb4\xe1\xa2\x91\x46\x96\xa8\x8b\xdf\xd6\xa0\x00\x81\xa6\x1b\x8e\xaf\x97\xb1\x91\x91\x0d\xc2\xf8\xc8\x41\xec\x2e\x9d\xa4\x2c\x96\x55\x3a\xa4\x43\x59\x02\x10\x3f\x32\x15\xce\x45\x37\xa9\x42\x36\xd4\x21\x2b\xaa\x9c\xaf\xac\x89\xe1\xbe\x7d\xff\x01\xb1\x05\x1c\x2d\x81\x3f\x21\xdc\x74\x07\x34\x5d\x45\xd6\x34\x68\xfc\xb0\x80\x6d\xbd\x6b\xfd\x5f\xeb\x0e\x71\x19\xa9\x43\xe2\x6b\xe2\x4e\xd6\xed\xb0\xfb\xee\xb2\xb2\x68\xb6\x5c\x33\x8b\xc1\xa7\x0e\x61\x5d\x8a\x50\x59\xd2\x05\xa3\x17\x20\xfa\xaa\xd6\x4a\x92\x4e\xb5\xe1\x7f\x9c\x72\x10\x1f\xd9\xc6\xe1\x93\x5c\xb8\xf2\x38\x34\x9a\x8b\x41\xe7\xe7\x34\x5c\xd9\xc6\xb5\x1e\x8b\xb2\xb6\x07\x59\x17\x52\x6b\x42\xe6\x4c\x1d\xd3\xf1\xa5\xd8\x4f\x5f\xf8\x3c\xff\x6b\x6c\xe7\x7d\x3d\x9e\x40\x0e\x5f\x3e\x3f\x93\x04\x60\x99\xe4\x41\xa3\x20\x62\x03\x22\x2d\xbd\xe4\x21\xe0\xee\xb2\x35\x62\x36\x3e\x07\xb1\xdf\x06\xac\x8e\x29\xf3\x29\x75\x64\x7f\x9d\xef\x01\x33\x9f\x9e\x40\x3c\xa5\xbd\x99\xfe\x32\xba\xf7\xd2\x3d\x0c\xeb\x95\x5b\xbb\x69\xbd\x72\xcf\x35\x8d\xb3\xc9\xb6\x1e\xe9\x75\x6e\xd9\xbb\xf1\x1e\x56\xd5\xd6\xb9\x1a\x69\x4d\x76\x7c\xfb\xb9\xba\x3d\xbf\xa4\x6a\xff\xf8\x8f\xbf\xb6\x65\x49\x58\x4a\x6f\x57\x45\x17\x25\x6f\xd5\x6d\xaa\xf3\x7f\x56\xcf\x6b\xaa\x1f\x59\xcf\x6f\xce\x6d\x9c\x8a\x50\x9f\xda\xc3\x82\x31\x9f\x0f\x9f\x69\x38\x8e\x9d\x15\x97\x7e\xff\x1b\x6e\xdc\x76\xbc\x0e\x62\x9f\x84\xe4\xcf\x00\xea\xd9\xbe\x05\x97\x0a\x00\x00")
 
 func templatesCf_dnsTfBytes() ([]byte, error) {
 	return bindataRead(
@@ -108,12 +117,12 @@ func templatesCf_dnsTf() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates/cf_dns.tf", size: 2711, mode: os.FileMode(420), modTime: time.Unix(1507151140, 0)}
+	info := bindataFileInfo{name: "templates/cf_dns.tf", size: 2711, mode: os.FileMode(436), modTime: time.Unix(1507834274, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _templatesCf_lbTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xd4\x59\x4b\x8f\xdb\x36\x17\x5d\x7f\xfe\x15\x84\xf0\x2d\x5a\x20\xf2\xd8\x1e\x37\x75\x17\x59\x15\xdd\xa6\x5d\x74\x17\x0c\x04\x8a\xba\xb2\x09\x73\x44\x96\xa4\xec\x18\x83\xf9\xef\x05\x49\xc9\xd6\x93\x7a\xd8\x41\x31\xc9\xc2\x1a\xf1\xde\x73\xa9\x73\x1f\x3a\x92\x4e\x58\x52\x1c\x33\x40\x81\x52\x2c\x22\x20\x35\x4d\x29\xc1\x1a\x02\xf4\xb6\x40\x48\x5f\x04\xa0\x2f\x28\x50\x5a\xd2\x6c\x1f\x2c\xde\x17\x8b\x5e\x8f\x48\x48\x7a\x32\xbf\x47\xb8\xf4\x7a\xf3\x5c\x8b\x5c\xa3\x40\xf2\x5c\x83\x8c\x62\x4c\x8e\x90\x25\x91\x02\x79\xa2\xa4\x08\x7a\xc2\x2c\xb7\x7e\xff\x7f\xdb\x73\xbe\x67\x10\x11\xfe\x2a\x72\x0d\x4d\xf3\xa5\x43\x09\x59\x1c\x16\x2b\x61\xb9\x92\xe1\x57\x78\xef\x8a\xc8\xe2\x88\x0a\x17\xc7\x17\x69\xcf\x78\x8c\x59\x84\x93\x44\x82\x52\x4b\x92\x86\xe5\x61\xf1\x5b\x07\x57\xea\x10\x09\xc9\xbf\x5f\xc6\xe2\x57\x80\x95\x3a\x84\xd6\xb7\x1b\x5a\x13\x11\x4d\xdb\x7b\x05\x5b\x13\x11\x3a\xe7\x6e\xf0\xb3\x9a\x01\x7a\xee\x21\x81\x48\x48\x0e\x79\x3c\x19\xd1\xb9\xd5\x31\x25\x28\x9e\x4b\x02\x28\x68\x78\xa5\x54\xc2\x19\x33\x16\xa0\xa0\x3c\x0c\x49\xea\xa2\x99\xa4\x23\xf7\xcf\x06\x3c\x61\xb9\x84\xec\x14\xd1\xe4\x3d\x24\x69\xc8\x05\x64\xc1\x02\xa1\x04\x04\x64\x89\x8a\x78\x86\xbe\xa0\x6f\xcd\x00\x19\xe8\x33\x97\xc7\x65\x1c\xb3\xb0\x38\x0e\x5e\x0c\xb8\x3b\xbe\x82\x0f\xbb\x95\x45\xb8\x40\x08\x33\xc6\xcf\x05\x23\x42\x72\xcd\x09\x67\x06\x46\x13\x11\xb8\x93\x5c\x6a\xe5\xb0\xbf\x05\xbb\x55\xf0\x09\x05\xdb\xed\xb3\x0d\xfc\x6e\x00\x1c\x1b\x91\xc4\xd9\x1e\x94\x35\x5a\x2d\xed\xff\xa7\x55\xf0\x62\x0c\x34\x96\x7b\xd0\x91\xc6\x7b\xb7\x7c\x77\xef\xbc\x78\xd3\x50\xef\x8f\x00\x05\xb7\x0e\xa9\xe4\xa2\x23\x0b\xfe\xec\x16\xb0\x29\x97\x67\x2c\x13\x9a\xed\x23\x99\x33\x70\xf0\x07\xad\x45\x78\x5b\x09\xdd\xca\x88\xbc\x1b\x47\xc3\x32\x15\xe5\x7e\x67\xb7\x7c\xc9\x33\xea\x2b\x83\x22\x0d\x26\xa4\x1b\x08\xcb\x72\xe7\x2c\x2e\xba\x5c\x01\x4b\x23\x46\xb3\xa3\xc5\x33\x89\x77\x69\x35\x78\xbb\xd5\x7d\xfc\xa8\xd9\x04\xa9\xff\x80\x21\x55\xa7\x48\x8d\xe3\xc8\xf4\x85\x97\xa4\x56\x0e\x2a\xf5\x53\x46\x68\xf1\xd2\x26\xc6\xda\x3b\x63\x3b\x34\x14\x91\x54\x68\x6a\xa7\x46\x20\x01\x33\x76\x41\x18\x31\x8e\x13\x14\x63\x86\x33\x02\x12\xc5\xb9\x46\x8c\x2a\x0d\x09\xc2\x0a\xe1\x0c\x19\x10\x74\x05\xc9\x25\x8b\x5e\xb1\xe8\xe5\xa6\x58\xaf\x11\x92\x4b\x16\x9a\x73\x55\x4a\x46\x5e\xbd\x6a\x5e\xbe\xf2\x5c\x7f\x3f\x09\xaa\x9b\x85\xd2\x61\x0a\x15\xaa\x9b\x8b\xbb\x09\x41\xa8\x21\x46\x7a\x86\x60\xc3\xca\xe0\x9a\x3f\xab\x58\xfe\xb9\xd7\x52\x49\x41\x01\x71\x23\x34\x12\x12\x52\xfa\xbd\xc5\x65\x47\x15\xe5\x0a\xa4\x61\xe4\x44\x13\x48\xcc\x25\xa0\x42\x43\xa1\x23\x5c\xd0\x93\x3d\x53\x89\x86\x04\xa6\xd2\x36\xc4\x4d\x69\xb9\x30\x29\x65\xf0\x93\x89\xe5\xd1\x64\x3f\xdb\x1d\x54\xe1\xbc\xae\xce\x9c\xd1\x14\xc8\x85\x30\x40\x6f\x8b\xff\x11\x09\x06\x2b\x86\x94\x4b\x88\x12\x50\x5a\x72\xb3\x01\x2d\x73\xb0\x37\x2a\x1f\x73\x45\x2a\x1b\xc5\x58\x24\xd3\x7f\xcf\x28\x26\xb8\xe5\x2f\xc5\x39\xd3\xe5\x4d\xec\x4e\x91\x38\xb6\xa5\x0e\x80\x99\x3e\x44\xe4\x00\xe4\xe8\xf6\x2f\xf2\x98\x51\x12\xba\x85\xb0\x58\xe8\xec\xa8\x9e\x91\xeb\x00\xec\x35\xd9\x39\x55\x0d\x61\xa8\x76\x43\xaf\x8d\xb4\x5b\xed\x56\x66\x55\xc2\x3f\x39\x28\x1d\x09\xac\x0f\x95\x38\x4f\x0e\x27\x18\xcc\x46\x2b\xe8\x63\xae\xab\x9c\xd6\x3d\x1b\x1f\xde\xf7\x48\xe9\x67\x6a\xc2\xb7\xc7\xce\x22\xaa\x3a\x7c\x0c\x19\xe8\x84\xe0\x6e\xe5\xd3\x81\xeb\xe7\xd5\x72\xb3\x5e\x5b\x2d\xb8\xd9\x18\xfb\xe7\x5f\x96\xeb\xdf\xdc\x89\xf5\x67\xeb\x5a\x15\x87\xe8\x81\xf2\xb0\xfd\xf8\x53\x44\x12\x9c\xb3\xa1\x87\xb9\x8a\x69\xfd\x31\xe8\xf6\xec\xd6\x5b\x0a\x35\xdd\x79\xf5\x1c\x98\x22\x37\xbb\x09\x65\xd6\x05\xde\x5f\x63\x57\xeb\x8f\xf3\xb0\xb1\xd9\x6c\x36\xb7\xfa\x1a\x7c\x8c\x18\xc8\x9a\xff\xee\x59\xab\x8e\x99\xa9\x33\x4d\x00\x4a\x51\x9e\x45\x38\x4d\x69\x46\xb5\xbd\x07\x7e\xfd\xf3\xeb\x1f\x03\x79\xed\x12\xcd\xfd\xe9\x1d\xda\x47\x4d\xe8\x4e\x2b\xf0\x5e\x75\x6b\x60\x6c\x3e\x9c\x16\xaf\x26\xef\xef\xdf\xff\x6a\x28\xf4\xc7\xbd\x57\x98\xdf\xb4\x95\xf7\x0b\x23\xba\xb6\xde\x59\x37\xdf\x51\xad\x55\x31\xff\x08\x6d\xb5\x5e\x6d\xb6\xe1\xf3\xe6\xd7\xcf\xbb\xf9\xcd\xd5\x62\xd7\xdf\x5d\xb5\xa1\xd8\xc9\xee\x10\xaf\x33\x14\x83\x27\x8b\x5e\x01\x54\x4f\x67\x9f\x66\xb8\x53\x31\xb4\xe6\xcd\x2c\x56\xbc\x13\xc7\x08\xb8\x0a\x29\x36\xb1\xb6\x1a\xda\xd9\x6d\x31\xd8\x99\xe3\x4f\x0b\x84\xfc\x79\xee\x1c\x64\xbe\x3c\x0c\xf3\x3f\x71\x94\x55\x36\xed\x9d\x65\x95\x26\x78\xc4\x44\x1b\xf1\x36\x73\xfe\x28\x3b\xab\xc9\xc2\xe3\x3c\xf0\xae\xcb\x18\x4c\xab\xcf\x51\x88\x93\xeb\x71\x64\x29\x76\x88\xff\x51\x73\xa7\xb3\x1e\xcf\xaa\x78\xad\x34\xaa\x1a\xaf\xd6\xd3\x6b\xf1\xac\xfc\x35\x68\x5f\x17\x3d\xa0\xf8\x9a\x6f\xbd\x67\xd1\x31\x89\x8d\x1f\x40\xc6\x6e\xf5\x43\xb8\x68\x7e\x01\x98\xdb\x85\xc5\x97\x80\xf6\xc7\x9b\x06\xb0\xb9\x83\x0e\x01\x97\x62\xe3\x8a\x5a\xf1\x9d\xa0\x53\x9c\x73\x3d\x6f\xed\x9c\x39\xab\xab\xe8\x2f\x75\xc6\x44\x91\x31\xf9\x01\x71\xb7\xdd\x16\xea\x62\xaa\xb8\xa8\x31\x3d\x5a\x56\xb4\xc8\xe8\x63\x62\xd2\xd8\x9b\x80\xfa\x40\xed\xdf\x95\x58\x5f\x72\x27\x77\x63\x41\xb1\xbf\x1f\x4d\x06\xef\xef\xc8\x8e\x2f\x68\xff\x06\x00\x00\xff\xff\x43\xa8\x44\x67\xd9\x1d\x00\x00")
+var _templatesCf_lbTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xd4\x59\x41\x8f\xa3\x36\x14\x3e\x97\x5f\xf1\x84\x7a\x68\xa5\x25\x9b\xc9\x4c\xb7\xe9\x61\x4e\x55\xaf\xdb\x1e\x7a\xab\x56\xc8\x18\x93\x58\xf1\x62\x6a\x9b\xb0\xd1\x2a\xff\xbd\x32\x36\xc1\x10\xe3\x40\x32\xab\x6a\x67\x0e\xc3\xc4\x7e\xdf\x7b\xfe\xbe\xf7\x1e\x0f\x72\x44\x82\xa2\x8c\x11\x88\xa5\x64\x29\x26\x42\xd1\x82\x62\xa4\x48\x0c\x5f\x23\x00\x75\xaa\x08\xbc\x42\x2c\x95\xa0\xe5\x2e\x8e\xce\x51\x34\x69\x91\x56\x82\x1e\xf5\xdf\x03\x39\x4d\x5a\xf3\x5a\x55\xb5\x82\x58\xf0\x5a\x11\x91\x66\x08\x1f\x48\x99\xa7\x92\x88\x23\xc5\xd6\xe9\x11\xb1\xba\xf5\xfa\xe3\xd7\x1d\xe7\x3b\x46\x52\xcc\x3f\x57\xb5\x22\xe3\xed\x2b\x83\x92\xb0\x2c\xb1\x2b\x49\xb7\x52\xa2\xcf\xe4\xec\xf3\xc8\xb2\x94\x56\xc6\x4f\xc8\xd3\x8e\xf1\x0c\xb1\x14\xe5\xb9\x20\x52\xae\x70\x91\x74\x97\xf6\xef\x10\x5c\xca\x7d\x5a\x09\xfe\xe5\x34\x17\xbf\x43\xc3\x45\x22\xe5\x3e\x69\x6d\xfd\xd0\x0a\x57\xe9\xb2\xd8\x1d\x6c\x85\xab\xc4\x18\xfb\xc1\x1b\x79\x07\x68\x33\x41\x02\x16\x24\xdf\xd7\xd9\x62\x44\x63\x36\xc4\x14\x44\xf2\x5a\x60\x02\xf1\xc8\xaa\xa0\x82\x34\x88\xb1\x18\xe2\xee\x32\xc1\x85\xf1\xa6\x45\xd7\x3e\x01\x4c\xf6\x1c\x91\x58\x91\xf2\x98\xd2\xfc\x9c\xe0\x22\xe1\x15\x29\xe3\x08\x20\x27\x15\x29\x73\x99\xf2\x12\x5e\xe1\x9f\xb1\x83\x92\xa8\x86\x8b\xc3\x2a\xcb\x58\x62\xaf\xe3\x4f\x11\x80\xbd\xbe\x80\xdf\x36\xeb\x92\x30\x02\x40\x8c\xf1\xc6\x32\x52\x09\xae\x38\xe6\x4c\xc7\xa8\x70\xa5\x23\x02\xa8\xb8\x50\x52\x5f\xe8\x88\xb6\xeb\xf8\x1d\xc4\x2f\x2f\xcf\xad\xe3\x73\x14\x01\x18\x36\x52\x81\xca\x1d\x91\x6d\xd8\xeb\x55\xfb\xfb\x7e\x1d\x7f\xd2\x1b\x14\x12\x3b\xa2\x52\x85\x76\x66\xf9\xe1\xda\xf9\x14\x94\x61\x58\x1f\x31\xc4\x7d\x85\x38\x5a\x78\x54\x88\xe7\xc0\x16\x5c\x34\x48\xe4\xb4\xdc\xa5\xa2\x66\xc4\xc0\xef\x95\xaa\x92\x7e\x25\x31\x2b\x33\x74\xd7\x86\x9a\x65\x5a\x75\xf1\x7a\x15\x9c\x53\xf2\x1d\xcf\xbd\xaf\x11\x88\x95\x41\xbb\x34\x0d\x61\xd5\x45\xce\x32\x5b\xe5\x92\xb0\x22\x65\xb4\x3c\xb4\x78\x5a\x78\x23\xab\xc6\xdb\xae\x1f\xe3\x47\xde\x4d\x90\xfc\x1f\x18\x92\x43\x8a\xe4\x3c\x8e\x74\x5d\x04\x49\x72\x3c\x18\x07\x4e\xfe\x74\x1e\xae\x78\xb9\x26\xa6\xdd\x6f\xec\xdb\xa6\x21\xb1\xa0\x95\xa2\x6d\xd7\x88\x05\x41\x8c\x9d\x00\x01\xe3\x28\x87\x0c\x31\x54\x62\x22\x20\xab\x15\x30\x2a\x15\xc9\x01\x49\x40\x25\x68\x10\xb8\x80\xd4\x82\xa5\x9f\x51\x35\xc9\x8d\x5d\x1f\x10\x52\x0b\x96\xe8\xcf\x5c\x4a\x66\x9e\x5e\x8e\x8f\x2f\x03\xe7\x9f\x26\x41\xfa\x59\xe8\x0c\x96\x50\x21\xfd\x5c\x3c\x4c\x08\xc0\x68\x18\x99\x68\x82\xa3\x5d\x1a\x57\xff\xeb\x62\x85\xfb\xde\x08\xc0\x64\x96\xfe\xa0\x27\x34\xad\x04\x29\xe8\x97\x2b\x2e\x3d\x59\x54\x4b\x22\x34\x23\x47\x9a\x93\x5c\x1f\x01\xec\x0c\x05\x07\x72\x82\xf7\xed\x27\x8e\x37\xa8\x10\x15\x1a\xc6\x99\xb4\x8c\x9b\x82\x32\xf2\x93\xf6\x15\x98\xc9\x7e\x6e\x79\x72\xe1\x82\xa6\x66\x3b\xa3\x05\xc1\x27\xcc\x08\x7c\x8d\x7e\xc0\x82\x68\xac\x8c\x14\x5c\x90\x34\x27\x52\x09\x7e\x82\x57\x50\xa2\x26\xed\x8d\x2a\xc4\x9c\x95\x72\x94\x8c\x56\x4c\x27\x1d\xaf\x52\xd0\x6e\x6f\xef\xa3\x39\x29\x50\xcd\x54\x77\x13\xf3\xe6\xcc\xfc\x1b\x9d\x9b\x41\xa1\xd0\xf7\x04\x31\xb5\x4f\xf1\x9e\xe0\x83\x89\xbf\xaa\x33\x46\x71\x62\x16\x12\xbb\x70\x39\x42\x97\xd1\xf6\xc7\x77\x22\x03\xd0\x9e\x49\x73\x31\x70\xa1\xa9\x36\x4d\xaf\x43\xe8\x7e\x5e\x61\xbb\xde\xae\xf5\xaa\x20\xff\xd6\x44\xaa\xb4\x42\x6a\xdf\xaf\xc6\xef\x0d\x4e\x7c\x53\x8d\x2b\xa7\x6f\x73\xae\xae\x5b\x77\x5b\x46\x81\xdf\x8e\x7b\xe6\xe8\x87\x8b\x70\x8c\x3e\xca\x07\x06\xdf\xc7\x18\x68\x06\xc1\xed\x3a\x34\x07\x3e\x3d\xaf\x57\x9b\xa7\xa7\x76\x16\xdc\x6c\xf4\xfe\xe7\x5f\x56\x4f\xbf\x99\x0f\x9e\x3e\xb4\xa6\xee\x70\x08\x6f\x38\x1e\x5e\x3f\xfe\x58\x4f\x15\xe7\xec\xd6\xc3\x9c\xb3\x75\xf8\x18\x64\xf9\x0a\xa5\x82\x9d\x37\x4c\x26\x5c\x2c\x9d\x34\xf0\x25\x40\xbf\x6f\x41\x9a\xf9\xc0\xa7\x73\xec\xb2\xfb\xfb\x79\xd8\xd8\x6c\x36\x9b\x3e\xbf\x6e\x3e\x46\xdc\x50\x2d\x7c\xf7\x74\x8c\xef\x96\x4e\x17\x01\x91\x92\xf2\x32\x45\x45\x41\x4b\xaa\xf4\x2d\x28\xfe\xf8\xe7\xc7\x3f\x6e\xe8\xea\x1b\x9a\x7d\x01\xcc\xd1\x77\x34\xe8\x2e\x4b\xf0\xc9\xe9\x56\xc3\xb4\x7a\x98\x59\xdc\x15\xef\xef\xdf\xff\x1a\x4d\xe8\x5e\x9f\x76\x71\xe8\xcf\xfb\xb4\xee\xbc\x57\xb8\xbf\x68\x9d\xf7\x0b\x33\xaa\x76\x58\x59\xbd\xed\x15\xf7\x3e\xea\x9d\xed\xdf\x43\x59\x3d\xad\x37\x2f\xc9\xf3\xe6\xd7\x0f\xdb\xfb\x8b\xab\x3f\xf2\xac\xea\xb2\x32\x07\xd8\xbd\xc5\xeb\x1d\x13\x83\xd7\x8f\x95\x11\x66\xcb\x39\x35\x33\x3c\x38\x31\x38\x7c\x3e\xc0\x4a\xb0\xe3\xe8\x01\xce\x21\xa5\x15\xb6\xcd\x86\x6b\x75\xaf\x18\xf4\x6a\xfc\x2e\x02\x08\xeb\xec\x7d\xfa\xf7\x9e\x6c\x36\xff\x0b\x5b\x59\x6f\x1c\xee\x65\x4e\x11\xbc\x45\x47\x73\xdc\x7a\x5b\x5a\x23\x1f\x68\x65\x8d\xb4\x02\x04\xb9\xb7\x7e\x4d\x36\x35\x37\xde\x75\x25\x8d\x5c\x98\x9f\xb3\x10\x17\xe7\xe3\xcc\x54\xf4\x0c\xff\xb3\xfa\x8e\x37\x1f\x1b\x69\x5f\x2b\xcd\xca\xc6\xcb\xee\xe5\xb9\xd8\xc8\x70\x0e\xb6\xaf\x8b\xde\x20\xf9\xc6\x6f\xbd\xef\xa2\x63\x11\x1b\xdf\x80\x8c\xed\xfa\x9b\x70\x31\xfe\x06\xe0\xde\x2a\xb4\xdf\x04\xf4\x75\x38\x01\xac\xdf\x72\xdf\x02\xee\x86\x8d\x0b\xaa\x63\x7b\x4b\x41\x6b\x1a\x5f\x1c\x0f\x75\xbb\xd6\xcc\xec\xba\x0c\xfd\x76\x78\xf0\xc6\x15\x18\x32\x96\xce\x18\xdb\xed\xcb\x8b\x9d\x2e\x96\x0e\x17\x03\xa6\xc3\xe5\x6d\x95\xf6\x92\x31\xc5\x44\x98\x5f\x27\x92\x85\xa8\xa1\xce\xb7\xb0\x26\xaf\xbc\x4e\x16\x64\xef\x7c\x51\x35\x5a\x8a\xc3\xf5\xa8\x15\x7c\xbc\x22\xad\x2b\xfb\xff\x39\x8e\xce\xd1\x7f\x03\x00\x43\xa8\x44\x67\xd9\x1d\x00\x00")
 
 func templatesCf_lbTfBytes() ([]byte, error) {
 	return bindataRead(
@@ -128,12 +137,12 @@ func templatesCf_lbTf() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates/cf_lb.tf", size: 7641, mode: os.FileMode(420), modTime: time.Unix(1507150150, 0)}
+	info := bindataFileInfo{name: "templates/cf_lb.tf", size: 7641, mode: os.FileMode(436), modTime: time.Unix(1507834274, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _templatesConcourse_lbTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xb4\x93\x3f\x8f\xdc\x20\x10\xc5\xeb\xf0\x29\x46\xa3\x94\xc1\x85\x73\x6d\xaa\x28\xed\x25\x45\xba\xe8\x84\x58\x7b\xec\x45\xc7\x31\x08\xf0\x5a\xd1\xc9\xdf\x3d\xc2\x66\xbd\xbe\xdc\x9f\x5d\x69\xb5\x95\x47\xe3\xc7\x63\xf8\x3d\xe0\x21\xf9\x21\x01\x36\xec\x1a\x1e\x42\x24\x95\x74\xe8\x29\x29\xcf\x6c\x11\x9e\xc5\xa7\x83\xb6\x03\xc1\x37\xc0\xcf\xcf\x3d\x73\x6f\x49\x35\xfc\xe4\x87\xf4\x42\x59\x2d\xb5\x9c\x6b\xa7\x9f\x68\x42\x31\x09\xf1\xda\xdd\xee\x94\xf1\xd9\x17\x00\xe0\x7d\x6b\xdd\xb6\x81\x62\xac\xd6\x85\xf2\xd8\x29\xdf\xc5\x3f\x50\xe4\x21\x34\x04\xf8\xdf\xfa\xce\x04\x1a\xb5\xb5\x08\x78\x2c\xe5\xea\xb5\x6c\x9f\xa7\xcc\x43\xcc\xdb\x1f\x74\xa8\xc8\x1d\x94\x69\xa7\x93\x4e\xb2\x27\x87\x59\x4a\x69\xe4\xf0\xf8\xe6\xa4\xe5\x5f\xb5\xdb\x59\x79\xac\x0b\x00\x01\xa0\xad\xe5\xb1\x9c\xd6\x07\x4e\xdc\xb0\xcd\x36\xa9\xf1\xb8\x34\x39\xa4\xb8\x8c\xf1\x07\xef\xee\xbe\xe2\x17\xc0\xba\xae\x6b\x7c\x10\x00\x53\xb6\x28\x94\x93\xee\xe3\x2c\x3a\x1d\xe3\xe1\x43\x04\x05\x14\x6e\xe8\xcb\xb5\xb7\x02\x78\xff\xf4\x1f\x03\x7e\x71\x4b\x70\x93\xfe\x85\xde\x02\x20\x52\x8c\x86\x9d\xd2\x5d\x67\x9c\x49\x7f\xb3\xfe\xfe\xe7\xfd\x8f\x33\xc9\x72\x18\x75\x68\x8d\xeb\x55\x18\x2c\x21\x60\x8c\x7b\x79\xea\xca\xa5\xbb\x4d\xf8\x4c\xca\x31\xee\x71\xe5\xbc\x51\x5f\x78\xdb\x23\xd9\x4e\x59\xe3\x1e\xa7\xec\x92\xf3\x54\x41\xbb\x9e\x66\x97\x39\x4a\x01\x60\xbc\xda\xc6\xff\xfb\xfb\xaf\xd2\x2d\x89\xbc\xbd\xe5\xd5\xaf\xe0\x15\xab\x7d\x4a\x3e\x5e\x45\x6b\x76\xb8\x19\xaf\xfc\x02\x6e\x8c\xeb\x5f\x00\x00\x00\xff\xff\x5c\xc2\x73\xd4\xf2\x04\x00\x00")
+var _templatesConcourse_lbTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xb4\x93\xbd\x8e\xdb\x30\x10\x84\xeb\xf0\x29\x16\x8b\x94\x91\x0a\xe5\xda\xab\x82\xb4\x97\x14\xe9\x82\x03\x41\x4b\x2b\x99\x30\xcd\x25\x48\xca\x42\x60\xe8\xdd\x03\xea\xcf\x4a\xfc\x73\x06\x0c\x57\x5a\x50\xc3\xe1\xee\x37\x24\xb7\xd1\xb5\x11\xb0\x64\x5b\x72\xeb\x03\xc9\xa8\x7c\x43\x51\x3a\x66\x83\x70\x14\x9f\x0e\xca\xb4\x04\xaf\x80\x9f\x8f\x0d\x73\x63\x48\x96\xbc\x77\x6d\xfc\x47\x99\x8f\x75\x96\x76\xe5\x56\xed\xa9\x47\xd1\x0b\x71\xee\x6e\x36\x52\xbb\xe4\x0b\x00\x70\xdd\x5a\x55\x95\xa7\x10\xf2\x65\x63\x36\xaf\x4c\xdf\xd1\xdf\x53\xe0\xd6\x97\x04\xf8\xdf\xfe\x5a\x7b\xea\x94\x31\x08\x38\x97\xd9\xe2\x35\x1e\x9f\xba\x4c\x4d\x0c\xc7\x1f\x94\xcf\xc9\x1e\xa4\xae\xfa\x93\x2e\x63\x47\x16\x05\x80\xa5\xd8\xb1\xdf\x5d\xec\x74\xfa\x97\x6f\x36\x26\x9b\xeb\x09\x80\x00\x50\xc6\x70\x37\x4d\xeb\x3c\x47\x2e\xd9\x24\x9b\x58\xba\x64\x0c\xe0\xd8\xc7\x90\x8a\x57\xf8\x8d\x2f\x2f\x5f\xf1\x0b\x60\x51\x14\x05\xbe\x0b\x80\x5e\x08\x80\x89\x72\x54\x4d\x18\x44\xa7\x31\xde\x6f\x22\x98\x40\x21\xe0\x19\xc4\x15\x80\xeb\xd3\xdf\x06\xbc\xca\x1e\x01\x57\xe9\xdf\xe9\x2d\x00\x02\x85\xa0\xd9\x4a\x55\xd7\xda\xea\xf8\x27\xe9\xdf\x7e\xbc\x7d\xff\x20\x59\xf6\x9d\xf2\x95\xb6\x8d\xf4\xad\x21\x04\x0c\x61\x9b\x9d\x56\xb3\x71\x75\x69\x22\xb1\xbd\x9d\x72\x08\x5b\x5c\x38\xaf\xd4\x77\xde\xf6\x40\xa6\x96\x46\xdb\x5d\x9f\x5c\x52\x9e\xd2\x2b\xdb\xd0\xe0\x32\x44\x29\x00\xb4\x93\xeb\xf8\x7f\x7d\xfb\x99\xc4\xda\xcd\x17\xfd\xf2\x91\x0f\xbf\x82\x33\x56\xdb\x18\x5d\x78\x88\xd6\xe0\xf0\x34\x5e\xe9\x05\x3c\x19\xd7\xdf\x01\x00\x5c\xc2\x73\xd4\xf2\x04\x00\x00")
 
 func templatesConcourse_lbTfBytes() ([]byte, error) {
 	return bindataRead(
@@ -148,12 +157,12 @@ func templatesConcourse_lbTf() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates/concourse_lb.tf", size: 1266, mode: os.FileMode(420), modTime: time.Unix(1507150150, 0)}
+	info := bindataFileInfo{name: "templates/concourse_lb.tf", size: 1266, mode: os.FileMode(436), modTime: time.Unix(1507834274, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _templatesJumpboxTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\xcf\xc1\x8a\x83\x30\x10\x06\xe0\xf3\xe6\x29\x86\xb0\x57\x15\x84\x5c\x84\x7d\x96\x90\x35\x83\xb5\x44\x13\x26\x33\x22\x88\xef\x5e\x4a\xad\x56\xe8\xa5\xbd\x86\xfc\xdf\xff\x0f\x61\x8e\x42\x2d\x82\xee\x62\xec\x02\xda\x36\x0e\x49\x18\xad\xf3\x9e\x30\x67\x0d\xfa\x2a\x43\xfa\x8f\x73\xd1\x27\x0d\x8b\x02\x18\xdd\x80\xf0\x07\xfa\x77\x99\x1c\x95\x38\x4e\xb6\xf7\x6b\xf1\xf2\x4b\xad\x4a\x45\xe1\x24\xbc\x87\xad\x50\x78\xa4\x01\x26\x17\x64\x03\xde\x77\x96\x87\x55\x6e\x4f\x6b\x53\xd7\x27\x17\x67\x46\x1a\x5d\xb0\xcf\x55\x5f\xba\x27\xd4\xf7\x84\x2d\x47\x3a\x8e\x5f\xd4\xcf\xce\x5e\x98\x53\x6e\xaa\xea\xb3\xd9\xc6\x18\x73\x2f\xb9\x05\x00\x00\xff\xff\xb2\xf6\x55\xa8\x69\x01\x00\x00")
+var _templatesJumpboxTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\xce\xc1\xca\x83\x30\x0c\xc0\xf1\xf3\xd7\xa7\x08\xe5\xbb\xaa\x20\xf4\x22\xec\x59\x4a\x67\x83\xeb\xa8\xb6\xa4\x8d\x08\xd2\x77\x1f\x63\xce\x4d\xd8\x65\xec\x1a\xf2\xff\x25\x84\x29\x30\xf5\x08\x72\x08\x61\xf0\xa8\xfb\x30\x46\xce\xa8\x8d\xb5\x84\x29\x49\x90\x57\x1e\xe3\x39\x2c\x95\x8b\x12\x56\x01\x30\x99\x11\xe1\x04\xf2\x7f\x9d\x0d\xd5\x38\xcd\xda\xd9\x52\xbd\x6d\x89\x22\x44\xe0\x1c\x39\xef\xb1\x66\xf2\x8f\x1a\x60\x36\x9e\x37\xe0\xf3\xcd\xfa\x65\xd5\xdb\xa8\x74\x6d\x7b\x70\x71\xc9\x48\x93\xf1\xda\xc5\x9f\xdc\x03\x6a\x1d\x61\x9f\x03\x3d\x83\xbb\xfc\xb7\xb3\x97\x9c\x63\xea\x9a\xe6\xbb\xb7\x95\x52\x4a\x8a\x22\x6e\x03\x00\xb2\xf6\x55\xa8\x69\x01\x00\x00")
 
 func templatesJumpboxTfBytes() ([]byte, error) {
 	return bindataRead(
@@ -168,12 +177,12 @@ func templatesJumpboxTf() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates/jumpbox.tf", size: 361, mode: os.FileMode(420), modTime: time.Unix(1507150150, 0)}
+	info := bindataFileInfo{name: "templates/jumpbox.tf", size: 361, mode: os.FileMode(436), modTime: time.Unix(1507834274, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _templatesVarsTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\xd0\x41\x0a\xc3\x20\x10\x05\xd0\x75\x3c\x85\x0c\x59\xb4\x9b\xde\xa0\x67\x29\x36\x99\xca\x14\x99\x91\x89\x08\xad\x78\xf7\x22\x16\xcc\xa6\xa4\x4b\xf9\x4f\x3e\xf3\xb3\x53\x72\xf7\x80\x16\xa2\xca\x13\x97\x74\xa3\x15\x6c\x31\x53\x7a\x45\xb4\x57\x0b\x5b\x52\x62\x0f\xa6\x1a\x33\xac\xa2\x27\xe1\x63\xf7\x16\xc6\x63\x85\x9c\xff\x6a\x5d\x14\x57\xe4\x44\x2e\x6c\xbf\x70\x54\xc9\xb4\xa2\x5a\xf0\x22\x3e\xf4\xf2\xdd\xbf\xc6\xe7\xf2\xa0\x80\x27\x98\x4b\x76\x7a\xd9\x85\x15\xce\x15\xcc\xf4\x1d\xa2\xd3\x46\xc6\x32\x2d\xee\xb7\x8f\xb4\xbf\x6b\xab\xff\x04\x00\x00\xff\xff\x39\xda\x2a\x22\x4d\x01\x00\x00")
+var _templatesVarsTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\xd0\x41\xaa\xc3\x30\x0c\x04\xd0\x75\x74\x0a\x23\xb2\xf8\x7f\xd3\x1b\xf4\x2c\xc1\x8d\x55\xa3\x62\xa4\xa0\x18\x43\x1b\x7c\xf7\x12\x52\x70\x36\xc5\x5d\xcf\x83\x19\xa6\x78\x63\x7f\x4b\xe4\x70\x31\x7d\xd0\x9c\x27\x0e\xe8\x36\x18\xf2\x73\x21\x77\x75\xb8\x66\x63\x89\x08\x15\xa0\x59\xa3\xc8\x2a\x7d\xf7\x52\xa1\xbe\x22\x29\x3f\xb5\xce\x46\x81\x24\xb3\x4f\x6b\x1f\xb3\x64\x32\xf1\x69\x9a\x39\xd8\x37\xbe\x98\x16\x0e\x64\x0e\xa3\x6a\x4c\xc7\xd6\x53\xcd\x3e\x65\xdc\xee\x9c\xe8\x0f\xc7\xad\x78\xbb\x9c\xc2\x8a\xff\x15\x61\xf8\xfc\x76\xd0\x9d\xb4\x23\xf7\xf8\xb8\xaa\xa5\x46\x91\x55\x2a\x42\x85\xf7\x00\xd2\x3d\x04\x96\x7c\x01\x00\x00")
 
 func templatesVarsTfBytes() ([]byte, error) {
 	return bindataRead(
@@ -188,7 +197,7 @@ func templatesVarsTf() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates/vars.tf", size: 333, mode: os.FileMode(420), modTime: time.Unix(1507150150, 0)}
+	info := bindataFileInfo{name: "templates/vars.tf", size: 380, mode: os.FileMode(436), modTime: time.Unix(1792206593, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -246,11 +255,11 @@ func AssetNames() []string {
 // _bindata is a table, holding each asset generator, mapped to its name.
 var _bindata = map[string]func() (*asset, error){
 	"templates/bosh_director.tf": templatesBosh_directorTf,
-	"templates/cf_dns.tf": templatesCf_dnsTf,
-	"templates/cf_lb.tf": templatesCf_lbTf,
-	"templates/concourse_lb.tf": templatesConcourse_lbTf,
-	"templates/jumpbox.tf": templatesJumpboxTf,
-	"templates/vars.tf": templatesVarsTf,
+	"templates/cf_dns.tf":        templatesCf_dnsTf,
+	"templates/cf_lb.tf":         templatesCf_lbTf,
+	"templates/concourse_lb.tf":  templatesConcourse_lbTf,
+	"templates/jumpbox.tf":       templatesJumpboxTf,
+	"templates/vars.tf":          templatesVarsTf,
 }
 
 // AssetDir returns the file names below a certain
@@ -292,14 +301,15 @@ type bintree struct {
 	Func     func() (*asset, error)
 	Children map[string]*bintree
 }
+
 var _bintree = &bintree{nil, map[string]*bintree{
 	"templates": &bintree{nil, map[string]*bintree{
 		"bosh_director.tf": &bintree{templatesBosh_directorTf, map[string]*bintree{}},
-		"cf_dns.tf": &bintree{templatesCf_dnsTf, map[string]*bintree{}},
-		"cf_lb.tf": &bintree{templatesCf_lbTf, map[string]*bintree{}},
-		"concourse_lb.tf": &bintree{templatesConcourse_lbTf, map[string]*bintree{}},
-		"jumpbox.tf": &bintree{templatesJumpboxTf, map[string]*bintree{}},
-		"vars.tf": &bintree{templatesVarsTf, map[string]*bintree{}},
+		"cf_dns.tf":        &bintree{templatesCf_dnsTf, map[string]*bintree{}},
+		"cf_lb.tf":         &bintree{templatesCf_lbTf, map[string]*bintree{}},
+		"concourse_lb.tf":  &bintree{templatesConcourse_lbTf, map[string]*bintree{}},
+		"jumpbox.tf":       &bintree{templatesJumpboxTf, map[string]*bintree{}},
+		"vars.tf":          &bintree{templatesVarsTf, map[string]*bintree{}},
 	}},
 }}
 
@@ -349,4 +359,3 @@ func _filePath(dir, name string) string {
 	cannonicalName := strings.Replace(name, "\\", "/", -1)
 	return filepath.Join(append([]string{dir}, strings.Split(cannonicalName, "/")...)...)
 }
-
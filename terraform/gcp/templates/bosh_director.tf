@@ -29,7 +29,7 @@ resource "google_compute_network" "bbl-network" {
 
 resource "google_compute_subnetwork" "bbl-subnet" {
   name			= "${var.env_id}-subnet"
-  ip_cidr_range = "10.0.0.0/16"
+  ip_cidr_range = "${var.internal_cidr}"
   network		= "${google_compute_network.bbl-network.self_link}"
 }
 
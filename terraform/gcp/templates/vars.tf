@@ -18,6 +18,10 @@ variable "credentials" {
 	type = "string"
 }
 
+variable "internal_cidr" {
+	type = "string"
+}
+
 provider "google" {
 	credentials = "${file("${var.credentials}")}"
 	project = "${var.project_id}"
@@ -19,8 +19,10 @@ type Manager struct {
 
 type executor interface {
 	Version() (string, error)
-	Destroy(inputs map[string]string, terraformTemplate, tfState string) (string, error)
-	Apply(inputs map[string]string, terraformTemplate, tfState string) (string, error)
+	Destroy(inputs map[string]string, terraformTemplate, overridesDir, tfState string) (string, error)
+	Apply(inputs map[string]string, terraformTemplate, overridesDir, tfState string) (string, error)
+	Plan(inputs map[string]string, terraformTemplate, overridesDir, tfState string) (string, error)
+	Taint(terraformTemplate, overridesDir, resource, tfState string) (string, error)
 }
 
 type InputGenerator interface {
@@ -100,6 +102,7 @@ func (m Manager) Apply(bblState storage.State) (storage.State, error) {
 	tfState, err := m.executor.Apply(
 		input,
 		template,
+		bblState.TerraformOverrides,
 		bblState.TFState,
 	)
 
@@ -116,6 +119,43 @@ func (m Manager) Apply(bblState storage.State) (storage.State, error) {
 	return bblState, nil
 }
 
+// Plan runs terraform plan for bblState without changing the terraform
+// state, so it reports what the next Apply would do.
+func (m Manager) Plan(bblState storage.State) (Plan, error) {
+	m.logger.Step("generating terraform template")
+	template := m.templateGenerator.Generate(bblState)
+
+	m.logger.Step("generating terraform variables")
+	input, err := m.inputGenerator.Generate(bblState)
+	if err != nil {
+		return Plan{}, err
+	}
+
+	m.logger.Step("planning terraform changes")
+	output, err := m.executor.Plan(input, template, bblState.TerraformOverrides, bblState.TFState)
+	readAndReset(m.terraformOutputBuffer)
+	if err != nil {
+		return Plan{}, err
+	}
+
+	return NewPlan(output), nil
+}
+
+// Taint marks resource so that the next Apply replaces it.
+func (m Manager) Taint(bblState storage.State, resource string) (storage.State, error) {
+	m.logger.Step("tainting %s", resource)
+	template := m.templateGenerator.Generate(bblState)
+
+	tfState, err := m.executor.Taint(template, bblState.TerraformOverrides, resource, bblState.TFState)
+	readAndReset(m.terraformOutputBuffer)
+	if err != nil {
+		return storage.State{}, err
+	}
+
+	bblState.TFState = tfState
+	return bblState, nil
+}
+
 func (m Manager) Destroy(bblState storage.State) (storage.State, error) {
 	m.logger.Step("destroying infrastructure")
 	if bblState.TFState == "" {
@@ -132,6 +172,7 @@ func (m Manager) Destroy(bblState storage.State) (storage.State, error) {
 	tfState, err := m.executor.Destroy(
 		input,
 		template,
+		bblState.TerraformOverrides,
 		bblState.TFState)
 
 	bblState.LatestTFOutput = readAndReset(m.terraformOutputBuffer)
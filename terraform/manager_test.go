@@ -166,6 +166,15 @@ var _ = Describe("Manager", func() {
 			Expect(state).To(Equal(expectedState))
 		})
 
+		It("passes the terraform overrides dir from the state to the executor", func() {
+			incomingState.TerraformOverrides = "/some/overrides"
+
+			_, err := manager.Apply(incomingState)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(executor.ApplyCall.Receives.OverridesDir).To(Equal("/some/overrides"))
+		})
+
 		Context("when an error occurs", func() {
 			Context("when InputGenerator.Generate returns an error", func() {
 				BeforeEach(func() {
@@ -215,6 +224,80 @@ var _ = Describe("Manager", func() {
 		})
 	})
 
+	Describe("Taint", func() {
+		It("taints the resource and returns the state with the new tf state", func() {
+			templateGenerator.GenerateCall.Returns.Template = "some-template"
+			executor.TaintCall.Returns.TFState = "some-tainted-tf-state"
+			terraformOutputBuffer.Write([]byte("some terraform output"))
+
+			state, err := manager.Taint(storage.State{EnvID: "some-env-id", TFState: "some-tf-state", TerraformOverrides: "/some/overrides"}, "tls_private_key.bosh_vms")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(executor.TaintCall.Receives.Template).To(Equal("some-template"))
+			Expect(executor.TaintCall.Receives.OverridesDir).To(Equal("/some/overrides"))
+			Expect(executor.TaintCall.Receives.Resource).To(Equal("tls_private_key.bosh_vms"))
+			Expect(executor.TaintCall.Receives.TFState).To(Equal("some-tf-state"))
+
+			Expect(state).To(Equal(storage.State{EnvID: "some-env-id", TFState: "some-tainted-tf-state", TerraformOverrides: "/some/overrides"}))
+			Expect(terraformOutputBuffer.Len()).To(Equal(0))
+			Expect(logger.StepCall.Messages).To(ContainElement("tainting tls_private_key.bosh_vms"))
+		})
+
+		It("returns an error when terraform taint fails", func() {
+			executor.TaintCall.Returns.Error = errors.New("failed to taint")
+
+			_, err := manager.Taint(storage.State{}, "tls_private_key.bosh_vms")
+			Expect(err).To(MatchError("failed to taint"))
+		})
+	})
+
+	Describe("Plan", func() {
+		It("plans the generated template and returns the parsed plan", func() {
+			templateGenerator.GenerateCall.Returns.Template = "some-template"
+			inputGenerator.GenerateCall.Returns.Inputs = map[string]string{"env_id": "some-env-id"}
+			executor.PlanCall.Returns.Output = "Plan: 2 to add, 1 to change, 0 to destroy."
+			terraformOutputBuffer.Write([]byte("some terraform output"))
+
+			plan, err := manager.Plan(storage.State{EnvID: "some-env-id", TFState: "some-tf-state", TerraformOverrides: "/some/overrides"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(executor.PlanCall.Receives.Template).To(Equal("some-template"))
+			Expect(executor.PlanCall.Receives.OverridesDir).To(Equal("/some/overrides"))
+			Expect(executor.PlanCall.Receives.Inputs).To(Equal(map[string]string{"env_id": "some-env-id"}))
+			Expect(executor.PlanCall.Receives.TFState).To(Equal("some-tf-state"))
+			Expect(executor.ApplyCall.CallCount).To(Equal(0))
+
+			Expect(plan).To(Equal(terraform.Plan{
+				Add:    2,
+				Change: 1,
+				Output: "Plan: 2 to add, 1 to change, 0 to destroy.",
+			}))
+			Expect(terraformOutputBuffer.Len()).To(Equal(0))
+
+			Expect(logger.StepCall.Messages).To(gomegamatchers.ContainSequence([]string{
+				"generating terraform template",
+				"generating terraform variables",
+				"planning terraform changes",
+			}))
+		})
+
+		Context("failure cases", func() {
+			It("returns an error when the input generator fails", func() {
+				inputGenerator.GenerateCall.Returns.Error = errors.New("failed to generate inputs")
+
+				_, err := manager.Plan(storage.State{})
+				Expect(err).To(MatchError("failed to generate inputs"))
+			})
+
+			It("returns an error when terraform plan fails", func() {
+				executor.PlanCall.Returns.Error = errors.New("failed to plan")
+
+				_, err := manager.Plan(storage.State{})
+				Expect(err).To(MatchError("failed to plan"))
+			})
+		})
+	})
+
 	Describe("Destroy", func() {
 		Context("when the bbl state contains a non-empty TFState", func() {
 			var (
@@ -235,7 +318,8 @@ var _ = Describe("Manager", func() {
 						Type:   "cf",
 						Domain: "some-domain",
 					},
-					TFState: "some-tf-state",
+					TFState:            "some-tf-state",
+					TerraformOverrides: "/some/overrides",
 				}
 				executor.DestroyCall.Returns.TFState = expectedTFState
 
@@ -281,6 +365,7 @@ var _ = Describe("Manager", func() {
 				}))
 				Expect(executor.DestroyCall.Receives.Template).To(Equal(templateGenerator.GenerateCall.Returns.Template))
 				Expect(executor.DestroyCall.Receives.TFState).To(Equal(incomingState.TFState))
+				Expect(executor.DestroyCall.Receives.OverridesDir).To(Equal("/some/overrides"))
 			})
 
 			It("returns the bbl state updated with the TFState and output from executor destroy", func() {
@@ -0,0 +1,36 @@
+package terraform
+
+import (
+	"regexp"
+	"strconv"
+)
+
+var planSummary = regexp.MustCompile(`Plan: (\d+) to add, (\d+) to change, (\d+) to destroy`)
+
+type Plan struct {
+	Add     int    `json:"add"`
+	Change  int    `json:"change"`
+	Destroy int    `json:"destroy"`
+	Output  string `json:"output"`
+}
+
+// NewPlan reads the resource counts from the summary line of terraform plan
+// output. Output without a summary, such as "No changes.", has no changes.
+func NewPlan(output string) Plan {
+	plan := Plan{Output: output}
+
+	matches := planSummary.FindStringSubmatch(output)
+	if matches == nil {
+		return plan
+	}
+
+	plan.Add, _ = strconv.Atoi(matches[1])
+	plan.Change, _ = strconv.Atoi(matches[2])
+	plan.Destroy, _ = strconv.Atoi(matches[3])
+
+	return plan
+}
+
+func (p Plan) HasChanges() bool {
+	return p.Add+p.Change+p.Destroy > 0
+}
@@ -0,0 +1,28 @@
+package terraform_test
+
+import (
+	"github.com/cloudfoundry/bosh-bootloader/terraform"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Plan", func() {
+	Describe("NewPlan", func() {
+		It("reads the resource counts from the plan summary", func() {
+			plan := terraform.NewPlan("+ aws_elb.cf_router_lb\n\nPlan: 3 to add, 2 to change, 1 to destroy.\n")
+
+			Expect(plan.Add).To(Equal(3))
+			Expect(plan.Change).To(Equal(2))
+			Expect(plan.Destroy).To(Equal(1))
+			Expect(plan.HasChanges()).To(BeTrue())
+		})
+
+		It("has no changes when there is no plan summary", func() {
+			plan := terraform.NewPlan("No changes. Infrastructure is up-to-date.\n")
+
+			Expect(plan.HasChanges()).To(BeFalse())
+			Expect(plan.Output).To(Equal("No changes. Infrastructure is up-to-date.\n"))
+		})
+	})
+})